@@ -55,6 +55,7 @@ func main() {
 		retries       = flag.Int("retries", 3, "Number of retry attempts")
 		quiet         = flag.Bool("quiet", false, "Suppress progress output")
 		pretty        = flag.Bool("pretty", false, "Pretty-print JSON output")
+		format        = flag.String("format", "raw", "Output format: raw (the API response) or json (a result envelope)")
 		help          = flag.Bool("help", false, "Show usage information")
 	)
 	flag.Parse()
@@ -64,29 +65,39 @@ func main() {
 		return
 	}
 
+	if *format != "raw" && *format != "json" {
+		log.Fatal("Error: -format must be 'raw' or 'json'")
+	}
+	if *format == "json" {
+		jsonOutputPath = *outputPath
+		jsonOutput = true
+	}
+
 	if *keyID == "" {
-		log.Fatal("Error: -key-id is required")
+		fatalf("Error: -key-id is required")
 	}
 	if *hashAlgorithm == "" {
-		log.Fatal("Error: -hash-algorithm is required")
+		fatalf("Error: -hash-algorithm is required")
 	}
 	if *digest == "" {
-		log.Fatal("Error: -digest is required")
+		fatalf("Error: -digest is required")
 	}
 
 	client, err := createClient(*apiKey, *baseURL, *timeout, *retries)
 	if err != nil {
-		log.Fatalf("Error creating SDK client: %v", err)
+		fatalf("Error creating SDK client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout+10*time.Second)
+	// Allow for the health check and the signing call, each bounded, retries
+	// included, by the client's timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 2*client.Timeout())
 	defer cancel()
 
 	if !*quiet {
 		fmt.Fprintf(os.Stderr, "Connecting to SecureSBOM API...\n")
 	}
 	if err := client.HealthCheck(ctx); err != nil {
-		log.Fatalf("Error connecting to API: %v", err)
+		fatalf("Error connecting to API: %w", err)
 	}
 
 	if !*quiet {
@@ -99,10 +110,15 @@ func main() {
 		KeyID:         *keyID,
 	})
 	if err != nil {
-		log.Fatalf("Error signing digest: %v", err)
+		fatalf("Error signing digest: %w", err)
 	}
 
-	if err := outputSignedDigest(result, *outputPath, *pretty); err != nil {
+	if jsonOutput {
+		err = writeResult(securesbom.NewCLIResult("digest", result), *outputPath)
+	} else {
+		err = outputSignedDigest(result, *outputPath, *pretty)
+	}
+	if err != nil {
 		log.Fatalf("Error outputting digest signature: %v", err)
 	}
 
@@ -146,6 +162,43 @@ func createClient(apiKey, baseURL string, timeout time.Duration, retries int) (s
 	return baseClient, nil
 }
 
+// jsonOutput is set with -format json, so that failures are written to the
+// output in the same envelope as results
+var (
+	jsonOutput     bool
+	jsonOutputPath string
+)
+
+// fatalf exits with status 1, writing the error as a JSON envelope with
+// -format json and logging it otherwise
+func fatalf(format string, args ...interface{}) {
+	err := fmt.Errorf(format, args...)
+	if jsonOutput {
+		if writeErr := writeResult(securesbom.NewCLIErrorResult("digest", err, 1), jsonOutputPath); writeErr != nil {
+			log.Print(writeErr)
+		}
+		os.Exit(1)
+	}
+	log.Fatal(err)
+}
+
+// writeResult writes the result envelope to outputPath, or stdout if empty
+func writeResult(result *securesbom.CLIResult, outputPath string) error {
+	if outputPath == "" || outputPath == "-" {
+		return result.Write(os.Stdout)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	if err := result.Write(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write to file %s: %w", outputPath, err)
+	}
+	return file.Close()
+}
+
 func outputSignedDigest(result *securesbom.SignDigestResponse, outputPath string, pretty bool) error {
 	var (
 		jsonData []byte
@@ -190,6 +243,8 @@ REQUIRED:
 OPTIONS:
   -pretty bool      Pretty-print the response JSON
   -output string    Output file path (default: stdout)
+  -format string    Output format: raw, the API response (default), or json,
+                    a result envelope with status, data and error fields
   -api-key string   API key (or set SECURE_SBOM_API_KEY)
   -base-url string  API base URL (or set SECURE_SBOM_BASE_URL)
   -timeout duration Request timeout (default: 30s)
@@ -207,6 +262,9 @@ EXAMPLES:
   # Sign with a custom API endpoint
   %s -key-id my-key-123 -hash-algorithm sha256 -digest Zm9vYmFy -base-url https://custom.api.com
 
+  # Emit a JSON result envelope for scripts; failures use the same envelope
+  %s -key-id my-key-123 -hash-algorithm sha256 -digest Zm9vYmFy -format json
+
 ENVIRONMENT VARIABLES:
   SECURE_SBOM_API_KEY    Your SecureSBOM API key
   SECURE_SBOM_BASE_URL   Custom API endpoint URL
@@ -214,5 +272,5 @@ ENVIRONMENT VARIABLES:
 API KEY:
   You can obtain an API key from: https://shiftleftcyber.io/contactus
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
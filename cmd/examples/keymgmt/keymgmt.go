@@ -18,13 +18,18 @@
 //
 // This example shows:
 // - Listing available signing keys
-// - Generating new signing keys
-// - Retrieving public keys
+// - Generating new signing keys, one at a time or in bulk
+// - Retrieving key metadata and public keys
+// - Importing externally generated public keys
+// - Deleting keys
 //
 // Usage:
 //   go run main.go list
 //   go run main.go generate
+//   go run main.go info <key-id>
 //   go run main.go public <key-id>
+//   go run main.go import <key-id> <pem-file>
+//   go run main.go delete <key-id>
 //
 // Environment variables:
 //   SECURE_SBOM_API_KEY - Your API key (required)
@@ -33,12 +38,16 @@
 package main
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -58,8 +67,14 @@ func main() {
 		runListCommand(os.Args[2:])
 	case "generate":
 		runGenerateCommand(os.Args[2:])
+	case "info":
+		runInfoCommand(os.Args[2:])
 	case "public":
 		runPublicCommand(os.Args[2:])
+	case "import":
+		runImportCommand(os.Args[2:])
+	case "delete":
+		runDeleteCommand(os.Args[2:])
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -74,26 +89,44 @@ func runListCommand(args []string) {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
 	apiKey := fs.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
 	baseURL := fs.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
-	output := fs.String("output", "table", "Output format: table, json")
+	output := fs.String("output", "table", "Output format: table, json, yaml")
+	status := fs.String("status", "", "Only list keys with this status: active, revoked, expired")
+	algorithm := fs.String("algorithm", "", "Only list keys with this algorithm or family, e.g. rsa-2048 or rsa")
+	createdBefore := fs.String("created-before", "", "Only list keys created before this date (RFC 3339 or YYYY-MM-DD)")
+	createdAfter := fs.String("created-after", "", "Only list keys created after this date (RFC 3339 or YYYY-MM-DD)")
+	labelSelector := fs.String("label-selector", "", "Only list keys whose labels match, e.g. team=payments,env!=dev")
+	showLabels := fs.Bool("show-labels", false, "Show each key's labels in table output")
 	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
 	quiet := fs.Bool("quiet", false, "Suppress progress output")
 	err := fs.Parse(args)
 	if err != nil {
-		log.Fatalf("failed to runListCommand: %v", err)
+		fatalf("failed to runListCommand: %w", err)
+	}
+	if *output == "json" {
+		jsonCommand = "list"
 	}
 
 	// Validate output format
-	if *output != "table" && *output != "json" {
-		log.Fatal("Error: output must be 'table' or 'json'")
+	if *output != "table" && *output != "json" && *output != "yaml" {
+		fatalf("Error: output must be 'table', 'json' or 'yaml'")
+	}
+
+	// Filters compose: a key is listed only if it passes all of them
+	opts := securesbom.ListKeysOptions{Status: *status, Algorithm: *algorithm, LabelSelector: *labelSelector}
+	if opts.CreatedBefore, err = parseDate(*createdBefore); err != nil {
+		fatalf("Error: invalid -created-before: %w", err)
+	}
+	if opts.CreatedAfter, err = parseDate(*createdAfter); err != nil {
+		fatalf("Error: invalid -created-after: %w", err)
 	}
 
 	// Create client
 	client, err := createClient(*apiKey, *baseURL, *timeout)
 	if err != nil {
-		log.Fatalf("Error creating client: %v", err)
+		fatalf("Error creating client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
 	defer cancel()
 
 	// List keys
@@ -101,17 +134,35 @@ func runListCommand(args []string) {
 		fmt.Fprintf(os.Stderr, "Retrieving keys from SecureSBOM...\n")
 	}
 
-	result, err := client.ListKeys(ctx)
-	if err != nil {
-		log.Fatalf("Error listing keys: %v", err)
+	result := &securesbom.KeyListResponse{}
+	for key, err := range securesbom.IterateKeys(ctx, client, opts) {
+		if err != nil {
+			fatalf("Error listing keys: %w", err)
+		}
+		result.Keys = append(result.Keys, key)
 	}
 
 	// Output results
-	if *output == "json" {
-		outputJSON(result)
-	} else {
-		outputKeysTable(result)
+	switch *output {
+	case "json":
+		outputJSON(securesbom.NewCLIResult("list", result))
+	case "yaml":
+		outputYAML(result)
+	default:
+		outputKeysTable(result, *showLabels)
+	}
+}
+
+// parseDate parses an RFC 3339 timestamp or a YYYY-MM-DD date, taken as
+// midnight UTC; an empty value yields the zero time, which applies no filter
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
 	}
+	return time.Parse(time.RFC3339, value)
 }
 
 // runGenerateCommand generates a new signing key
@@ -119,32 +170,56 @@ func runGenerateCommand(args []string) {
 	fs := flag.NewFlagSet("generate", flag.ExitOnError)
 	apiKey := fs.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
 	baseURL := fs.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
-	output := fs.String("output", "table", "Output format: table, json")
-	savePublic := fs.String("save-public", "", "Save public key to file")
+	output := fs.String("output", "table", "Output format: table, json, yaml")
+	savePublic := fs.String("save-public", "", "Save public key to file (with -count, a directory for <key-id>.pub.pem files)")
 	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
 	quiet := fs.Bool("quiet", false, "Suppress progress output")
 	filesystemKey := fs.Bool("filesystemKey", false, "Generate filesystem-backed key (NOT FOR PRODUCTION USE)")
+	algorithm := fs.String("algorithm", "", "Key algorithm: ed25519, ecdsa-p256, ecdsa-p384, rsa-2048, rsa-4096 (default: server default)")
+	count := fs.Int("count", 1, "Number of keys to generate")
+	labels := map[string]string{}
+	fs.Func("label", "Label the key with key=value; repeat for several labels", func(value string) error {
+		k, v, ok := strings.Cut(value, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("label must be key=value, got %q", value)
+		}
+		labels[k] = v
+		return nil
+	})
 	err := fs.Parse(args)
 	if err != nil {
-		log.Fatalf("failed to runGenerateCommand: %v", err)
+		fatalf("failed to runGenerateCommand: %w", err)
+	}
+	if *output == "json" {
+		jsonCommand = "generate"
+	}
+	if *count < 1 {
+		fatalf("Error: count must be at least 1")
 	}
 
 	// Validate output format
-	if *output != "table" && *output != "json" {
-		log.Fatal("Error: output must be 'table' or 'json'")
+	if *output != "table" && *output != "json" && *output != "yaml" {
+		fatalf("Error: output must be 'table', 'json' or 'yaml'")
 	}
 
 	// Create client
 	client, err := createClient(*apiKey, *baseURL, *timeout)
 	if err != nil {
-		log.Fatalf("Error creating client: %v", err)
+		fatalf("Error creating client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	// Keys are created DefaultBatchConcurrency at a time, each within the
+	// client's timeout
+	rounds := (*count + securesbom.DefaultBatchConcurrency - 1) / securesbom.DefaultBatchConcurrency
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(rounds)*client.Timeout())
 	defer cancel()
 
 	if !*quiet {
-		fmt.Fprintf(os.Stderr, "Generating new signing key...\n")
+		if *count > 1 {
+			fmt.Fprintf(os.Stderr, "Generating %d new signing keys...\n", *count)
+		} else {
+			fmt.Fprintf(os.Stderr, "Generating new signing key...\n")
+		}
 	}
 
 	var backend string
@@ -156,20 +231,33 @@ func runGenerateCommand(args []string) {
 
 	if !*quiet {
 		fmt.Fprintf(os.Stderr, "Using backend: %q\n", backend)
+		if *algorithm != "" {
+			fmt.Fprintf(os.Stderr, "Using algorithm: %q\n", *algorithm)
+		}
+	}
+
+	opts := securesbom.GenerateKeyOptions{
+		Backend:   backend,
+		Algorithm: *algorithm,
+		Labels:    labels,
+	}
+	if *count > 1 {
+		generateKeys(ctx, client, *count, opts, *savePublic, *output, *quiet)
+		return
 	}
 
 	var key *securesbom.GenerateKeyCMDResponse
 
-	key, err = client.GenerateKeyWithBackend(ctx, backend)
+	key, err = client.GenerateKeyWithOptions(ctx, opts)
 
 	if err != nil {
-		log.Fatalf("Error generating key: %v", err)
+		fatalf("Error generating key: %w", err)
 	}
 
 	// Save public key if requested
 	if *savePublic != "" {
 		if err := os.WriteFile(*savePublic, []byte(key.PublicKey), 0644); err != nil {
-			log.Fatalf("Error saving public key: %v", err)
+			fatalf("Error saving public key: %w", err)
 		}
 		if !*quiet {
 			fmt.Fprintf(os.Stderr, "Public key saved to: %s\n", *savePublic)
@@ -177,13 +265,72 @@ func runGenerateCommand(args []string) {
 	}
 
 	// Output results
-	if *output == "json" {
-		outputJSON(key)
-	} else {
+	switch *output {
+	case "json":
+		outputJSON(securesbom.NewCLIResult("generate", key))
+	case "yaml":
+		outputYAML(key)
+	default:
 		outputGeneratedKeyTable(key)
 	}
 }
 
+// runInfoCommand shows the metadata for a single key
+func runInfoCommand(args []string) {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	apiKey := fs.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
+	baseURL := fs.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
+	output := fs.String("output", "table", "Output format: table, json, yaml")
+	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
+	quiet := fs.Bool("quiet", false, "Suppress progress output")
+	err := fs.Parse(args)
+	if err != nil {
+		fatalf("failed to runInfoCommand: %w", err)
+	}
+	if *output == "json" {
+		jsonCommand = "info"
+	}
+
+	if fs.NArg() < 1 {
+		fatalf("Error: key-id is required\n\nUsage: keymgmt info <key-id> [options]")
+	}
+
+	// Validate output format
+	if *output != "table" && *output != "json" && *output != "yaml" {
+		fatalf("Error: output must be 'table', 'json' or 'yaml'")
+	}
+
+	keyID := fs.Arg(0)
+
+	// Create client
+	client, err := createClient(*apiKey, *baseURL, *timeout)
+	if err != nil {
+		fatalf("Error creating client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
+	defer cancel()
+
+	if !*quiet {
+		fmt.Fprintf(os.Stderr, "Retrieving key %s...\n", keyID)
+	}
+
+	key, err := client.GetKey(ctx, keyID)
+	if err != nil {
+		fatalf("Error getting key: %w", err)
+	}
+
+	// Output results
+	switch *output {
+	case "json":
+		outputJSON(securesbom.NewCLIResult("info", key))
+	case "yaml":
+		outputYAML(key)
+	default:
+		outputKeyInfo(key)
+	}
+}
+
 // runPublicCommand retrieves the public key for a specific key ID
 func runPublicCommand(args []string) {
 	fs := flag.NewFlagSet("public", flag.ExitOnError)
@@ -194,11 +341,11 @@ func runPublicCommand(args []string) {
 	quiet := fs.Bool("quiet", false, "Suppress progress output")
 	err := fs.Parse(args)
 	if err != nil {
-		log.Fatalf("failed to runPublicCommand: %v", err)
+		fatalf("failed to runPublicCommand: %w", err)
 	}
 
 	if fs.NArg() < 1 {
-		log.Fatal("Error: key-id is required\n\nUsage: keymgmt public <key-id> [options]")
+		fatalf("Error: key-id is required\n\nUsage: keymgmt public <key-id> [options]")
 	}
 
 	keyID := fs.Arg(0)
@@ -206,10 +353,10 @@ func runPublicCommand(args []string) {
 	// Create client
 	client, err := createClient(*apiKey, *baseURL, *timeout)
 	if err != nil {
-		log.Fatalf("Error creating client: %v", err)
+		fatalf("Error creating client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
 	defer cancel()
 
 	// Get public key
@@ -219,7 +366,7 @@ func runPublicCommand(args []string) {
 
 	publicKey, err := client.GetPublicKey(ctx, keyID)
 	if err != nil {
-		log.Fatalf("Error getting public key: %v", err)
+		fatalf("Error getting public key: %w", err)
 	}
 
 	// Output public key
@@ -227,7 +374,7 @@ func runPublicCommand(args []string) {
 		fmt.Print(publicKey)
 	} else {
 		if err := os.WriteFile(*outputFile, []byte(publicKey), 0644); err != nil {
-			log.Fatalf("Error writing public key to file: %v", err)
+			fatalf("Error writing public key to file: %w", err)
 		}
 		if !*quiet {
 			fmt.Fprintf(os.Stderr, "Public key saved to: %s\n", *outputFile)
@@ -235,6 +382,133 @@ func runPublicCommand(args []string) {
 	}
 }
 
+// runImportCommand registers the public half of a key generated outside
+// SecureSBOM so signatures made with it can be verified by key ID
+func runImportCommand(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	apiKey := fs.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
+	baseURL := fs.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
+	output := fs.String("output", "table", "Output format: table, json, yaml")
+	algorithm := fs.String("algorithm", "", "Key algorithm: ed25519, ecdsa-p256, ecdsa-p384, rsa-2048, rsa-4096 (default: inferred from the key)")
+	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
+	quiet := fs.Bool("quiet", false, "Suppress progress output")
+	err := fs.Parse(args)
+	if err != nil {
+		fatalf("failed to runImportCommand: %w", err)
+	}
+	if *output == "json" {
+		jsonCommand = "import"
+	}
+
+	if fs.NArg() < 2 {
+		fatalf("Error: key-id and pem-file are required\n\nUsage: keymgmt import <key-id> <pem-file> [options]")
+	}
+
+	// Validate output format
+	if *output != "table" && *output != "json" && *output != "yaml" {
+		fatalf("Error: output must be 'table', 'json' or 'yaml'")
+	}
+
+	keyID := fs.Arg(0)
+	publicKeyPEM, err := os.ReadFile(fs.Arg(1))
+	if err != nil {
+		fatalf("Error reading public key: %w", err)
+	}
+
+	// Create client
+	client, err := createClient(*apiKey, *baseURL, *timeout)
+	if err != nil {
+		fatalf("Error creating client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
+	defer cancel()
+
+	if !*quiet {
+		fmt.Fprintf(os.Stderr, "Importing public key %s...\n", keyID)
+	}
+
+	key, err := client.ImportPublicKey(ctx, keyID, string(publicKeyPEM), *algorithm)
+	if errors.Is(err, securesbom.ErrKeyExists) {
+		fatalf("Error: key %s already exists; choose another key ID or delete the existing key first", keyID)
+	}
+	if err != nil {
+		fatalf("Error importing key: %w", err)
+	}
+
+	// Output results
+	switch *output {
+	case "json":
+		outputJSON(securesbom.NewCLIResult("import", key))
+	case "yaml":
+		outputYAML(key)
+	default:
+		outputKeyInfo(key)
+	}
+}
+
+// runDeleteCommand deletes a specific key
+func runDeleteCommand(args []string) {
+	fs := flag.NewFlagSet("delete", flag.ExitOnError)
+	apiKey := fs.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
+	baseURL := fs.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
+	force := fs.Bool("force", false, "Skip the confirmation prompt and ignore keys that do not exist")
+	timeout := fs.Duration("timeout", 30*time.Second, "Request timeout")
+	quiet := fs.Bool("quiet", false, "Suppress progress output")
+	err := fs.Parse(args)
+	if err != nil {
+		fatalf("failed to runDeleteCommand: %w", err)
+	}
+
+	if fs.NArg() < 1 {
+		fatalf("Error: key-id is required\n\nUsage: keymgmt delete <key-id> [options]")
+	}
+
+	keyID := fs.Arg(0)
+
+	if !*force && !confirm(fmt.Sprintf("Delete key %s? This cannot be undone. [y/N]: ", keyID)) {
+		fmt.Fprintf(os.Stderr, "Aborted\n")
+		os.Exit(1)
+	}
+
+	// Create client
+	client, err := createClient(*apiKey, *baseURL, *timeout)
+	if err != nil {
+		fatalf("Error creating client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.Timeout())
+	defer cancel()
+
+	if !*quiet {
+		fmt.Fprintf(os.Stderr, "Deleting key %s...\n", keyID)
+	}
+
+	err = client.DeleteKey(ctx, keyID)
+	if err != nil {
+		if *force && errors.Is(err, securesbom.ErrKeyNotFound) {
+			if !*quiet {
+				fmt.Fprintf(os.Stderr, "Key %s does not exist, nothing to delete\n", keyID)
+			}
+			return
+		}
+		fatalf("Error deleting key: %w", err)
+	}
+
+	fmt.Printf("✓ Key %s deleted\n", keyID)
+}
+
+// confirm prompts the user on stderr and reads a yes/no answer from stdin
+func confirm(prompt string) bool {
+	fmt.Fprint(os.Stderr, prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 // createClient builds and configures the SDK client
 func createClient(apiKey, baseURL string, timeout time.Duration) (securesbom.ClientInterface, error) {
 	configBuilder := securesbom.NewConfigBuilder().
@@ -252,14 +526,20 @@ func createClient(apiKey, baseURL string, timeout time.Duration) (securesbom.Cli
 }
 
 // outputKeysTable displays keys in a formatted table
-func outputKeysTable(result *securesbom.KeyListResponse) {
+func outputKeysTable(result *securesbom.KeyListResponse, showLabels bool) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	defer func() {
 		_ = w.Flush()
 	}()
 
-	_, _ = fmt.Fprintf(w, "KEY ID\tCREATED\tALGORITHM\tBACKEND\tPROTECTION LEVEL\tPURPOSE\n")
-	_, _ = fmt.Fprintf(w, "------\t-------\t---------\t---------\t---------\t---------\n")
+	header := "KEY ID\tSTATUS\tCREATED\tEXPIRES\tALGORITHM\tBACKEND\tPROTECTION LEVEL\tPURPOSE"
+	rule := "------\t------\t-------\t-------\t---------\t---------\t---------\t---------"
+	if showLabels {
+		header += "\tLABELS"
+		rule += "\t------"
+	}
+	_, _ = fmt.Fprintln(w, header)
+	_, _ = fmt.Fprintln(w, rule)
 
 	for _, key := range result.Keys {
 		createdAt := key.CreatedAt.Format("2006-01-02 15:04")
@@ -267,8 +547,12 @@ func outputKeysTable(result *securesbom.KeyListResponse) {
 		if algorithm == "" {
 			algorithm = "default"
 		}
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key.ID, createdAt, algorithm, key.Backend, key.ProtectionLevel,
-			key.Purpose)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", key.ID, keyStatusLabel(key), createdAt, keyExpiryLabel(key),
+			algorithm, key.Backend, key.ProtectionLevel, key.Purpose)
+		if showLabels {
+			_, _ = fmt.Fprintf(w, "\t%s", formatLabels(key.Labels))
+		}
+		_, _ = fmt.Fprintln(w)
 	}
 
 	if len(result.Keys) == 0 {
@@ -276,6 +560,107 @@ func outputKeysTable(result *securesbom.KeyListResponse) {
 	}
 }
 
+// formatLabels renders labels as a sorted, comma separated list that can be
+// pasted back into -label-selector
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// keyStatusLabel shouts unusable keys so nobody picks them for signing
+func keyStatusLabel(key securesbom.GenerateKeyCMDResponse) string {
+	switch {
+	case key.IsExpired():
+		return "EXPIRED"
+	case key.Status == securesbom.KeyStatusRevoked:
+		return "REVOKED"
+	case key.Status == "":
+		return "-"
+	default:
+		return key.Status
+	}
+}
+
+func keyExpiryLabel(key securesbom.GenerateKeyCMDResponse) string {
+	if key.ExpiresAt.IsZero() {
+		return "never"
+	}
+	return key.ExpiresAt.Format("2006-01-02 15:04")
+}
+
+// generateKeys creates count keys at once, writing each public key to
+// <key-id>.pub.pem in the savePublic directory if set. Keys that were created
+// are output even if others failed; any failure exits with status 1.
+func generateKeys(ctx context.Context, client securesbom.ClientInterface, count int, opts securesbom.GenerateKeyOptions, savePublic, output string, quiet bool) {
+	batch := securesbom.BatchOptions{}
+	if !quiet {
+		batch.OnProgress = func(completed, total int) {
+			fmt.Fprintf(os.Stderr, "\r  %d/%d done", completed, total)
+			if completed == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+	keys, err := securesbom.GenerateKeys(ctx, client, count, opts, batch)
+
+	var batchErr *securesbom.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		fatalf("Error generating keys: %w", err)
+	}
+
+	generated := &securesbom.KeyListResponse{Keys: []securesbom.GenerateKeyCMDResponse{}}
+	for _, key := range keys {
+		if key != nil {
+			generated.Keys = append(generated.Keys, *key)
+		}
+	}
+
+	if savePublic != "" && len(generated.Keys) > 0 {
+		if err := os.MkdirAll(savePublic, 0755); err != nil {
+			fatalf("Error creating public key directory: %w", err)
+		}
+		for _, key := range generated.Keys {
+			path := filepath.Join(savePublic, key.ID+".pub.pem")
+			if err := os.WriteFile(path, []byte(key.PublicKey), 0644); err != nil {
+				fatalf("Error saving public key: %w", err)
+			}
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Public keys saved to: %s\n", savePublic)
+		}
+	}
+
+	switch output {
+	case "json":
+		if batchErr != nil {
+			outputJSON(securesbom.NewCLIFailedResult("generate", generated, batchErr, 1))
+		} else {
+			outputJSON(securesbom.NewCLIResult("generate", generated))
+		}
+	case "yaml":
+		outputYAML(generated)
+	default:
+		fmt.Printf("✓ Generated %d of %d keys\n\n", len(generated.Keys), count)
+		outputKeysTable(generated, len(opts.Labels) > 0)
+	}
+
+	if batchErr != nil {
+		for i, itemErr := range batchErr.Errors {
+			if itemErr != nil {
+				fmt.Fprintf(os.Stderr, "Error generating key %d: %v\n", i+1, itemErr)
+			}
+		}
+		os.Exit(1)
+	}
+}
+
 // outputGeneratedKeyTable displays a newly generated key in a formatted way
 func outputGeneratedKeyTable(key *securesbom.GenerateKeyCMDResponse) {
 	fmt.Printf("✓ New key generated successfully\n\n")
@@ -295,20 +680,78 @@ func outputGeneratedKeyTable(key *securesbom.GenerateKeyCMDResponse) {
 	fmt.Printf("Backend: %s\n", key.Backend)
 	fmt.Printf("Protection Level: %s\n", key.ProtectionLevel)
 	fmt.Printf("Purpose: %s\n", key.Purpose)
+	if len(key.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", formatLabels(key.Labels))
+	}
 
 	fmt.Printf("\nYou can now use this key ID for signing:\n")
 	fmt.Printf("  sign -key-id %s -sbom your-sbom.json\n", key.ID)
 }
 
-// outputJSON outputs data in JSON format
-func outputJSON(data interface{}) {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(data); err != nil {
+// outputKeyInfo displays the metadata for a single key
+func outputKeyInfo(key *securesbom.GenerateKeyCMDResponse) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer func() {
+		_ = w.Flush()
+	}()
+
+	algorithm := key.Algorithm
+	if algorithm == "" {
+		algorithm = "default"
+	}
+
+	_, _ = fmt.Fprintf(w, "Key ID:\t%s\n", key.ID)
+	_, _ = fmt.Fprintf(w, "Status:\t%s\n", keyStatusLabel(*key))
+	_, _ = fmt.Fprintf(w, "Created:\t%s\n", key.CreatedAt.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(w, "Expires:\t%s\n", keyExpiryLabel(*key))
+	_, _ = fmt.Fprintf(w, "Algorithm:\t%s\n", algorithm)
+	_, _ = fmt.Fprintf(w, "Backend:\t%s\n", key.Backend)
+	if key.KMSPath != "" {
+		_, _ = fmt.Fprintf(w, "KMS Path:\t%s\n", key.KMSPath)
+	}
+	_, _ = fmt.Fprintf(w, "Protection Level:\t%s\n", key.ProtectionLevel)
+	_, _ = fmt.Fprintf(w, "Purpose:\t%s\n", key.Purpose)
+	if len(key.Usage) > 0 {
+		_, _ = fmt.Fprintf(w, "Usage:\t%s\n", strings.Join(key.Usage, ", "))
+	}
+	if len(key.Labels) > 0 {
+		_, _ = fmt.Fprintf(w, "Labels:\t%s\n", formatLabels(key.Labels))
+	}
+}
+
+// outputJSON outputs the result envelope in JSON format
+func outputJSON(result *securesbom.CLIResult) {
+	if err := result.Write(os.Stdout); err != nil {
 		log.Fatalf("Error encoding JSON: %v", err)
 	}
 }
 
+// jsonCommand names the running command once it is known to be writing JSON,
+// so that failures are reported in the same envelope as results
+var jsonCommand string
+
+// fatalf exits with status 1, reporting the error as a JSON envelope on stdout
+// when the command writes JSON and logging it otherwise
+func fatalf(format string, args ...interface{}) {
+	err := fmt.Errorf(format, args...)
+	if jsonCommand != "" {
+		outputJSON(securesbom.NewCLIErrorResult(jsonCommand, err, 1))
+		os.Exit(1)
+	}
+	log.Fatal(err)
+}
+
+// outputYAML outputs data as YAML
+func outputYAML(data interface{}) {
+	out, err := securesbom.MarshalYAML(data)
+	if err != nil {
+		fatalf("Error encoding YAML: %w", err)
+	}
+	if _, err := os.Stdout.Write(out); err != nil {
+		fatalf("Error writing YAML: %w", err)
+	}
+}
+
 // printUsage displays usage information
 func printUsage() {
 	fmt.Fprintf(os.Stderr, `SecureSBOM SDK Key Management Example
@@ -321,20 +764,47 @@ USAGE:
 COMMANDS:
   list                List all available signing keys
   generate            Generate a new signing key
+  info <key-id>       Show metadata for a specific key ID
   public <key-id>     Get the public key for a specific key ID
+  import <key-id> <pem-file>
+                      Register an externally generated public key
+  delete <key-id>     Delete a key
   help                Show this help message
 
 LIST OPTIONS:
-  -output string      Output format: table, json (default: table)
+  -output string      Output format: table, json, yaml (default: table)
+  -status string      Only list keys with this status: active, revoked, expired
+  -algorithm string   Only list keys with this algorithm or family (e.g. rsa)
+  -created-before string
+                      Only list keys created before this date (RFC 3339 or YYYY-MM-DD)
+  -created-after string
+                      Only list keys created after this date (RFC 3339 or YYYY-MM-DD)
+  -label-selector string
+                      Only list keys whose labels match every comma separated
+                      requirement: k=v, k!=v, k or !k
+  -show-labels        Add a LABELS column to table output
   -api-key string     API key (or set SECURE_SBOM_API_KEY)
   -base-url string    API base URL (or set SECURE_SBOM_BASE_URL)
   -timeout duration   Request timeout (default: 30s)
   -quiet              Suppress progress output
 
 GENERATE OPTIONS:
-  -output string      Output format: table, json (default: table)
+  -output string      Output format: table, json, yaml (default: table)
   -filesystemKey      Generate filesystem-backed key (NOT FOR PRODUCTION USE)
-  -save-public string Save public key to file
+  -algorithm string   Key algorithm: ed25519, ecdsa-p256, ecdsa-p384, rsa-2048,
+                      rsa-4096 (default: server default)
+  -count int          Number of keys to generate concurrently (default: 1);
+                      stops early if the key quota is exhausted
+  -save-public string Save public key to file, or with -count, to
+                      <key-id>.pub.pem files in this directory
+  -label key=value    Label the key; repeat for several labels
+  -api-key string     API key (or set SECURE_SBOM_API_KEY)
+  -base-url string    API base URL (or set SECURE_SBOM_BASE_URL)
+  -timeout duration   Request timeout (default: 30s)
+  -quiet              Suppress progress output
+
+INFO OPTIONS:
+  -output string      Output format: table, json, yaml (default: table)
   -api-key string     API key (or set SECURE_SBOM_API_KEY)
   -base-url string    API base URL (or set SECURE_SBOM_BASE_URL)
   -timeout duration   Request timeout (default: 30s)
@@ -347,6 +817,22 @@ PUBLIC OPTIONS:
   -timeout duration   Request timeout (default: 30s)
   -quiet              Suppress progress output
 
+IMPORT OPTIONS:
+  -output string      Output format: table, json, yaml (default: table)
+  -algorithm string   Key algorithm: ed25519, ecdsa-p256, ecdsa-p384, rsa-2048,
+                      rsa-4096 (default: inferred from the key)
+  -api-key string     API key (or set SECURE_SBOM_API_KEY)
+  -base-url string    API base URL (or set SECURE_SBOM_BASE_URL)
+  -timeout duration   Request timeout (default: 30s)
+  -quiet              Suppress progress output
+
+DELETE OPTIONS:
+  -force              Skip confirmation; succeed if the key does not exist
+  -api-key string     API key (or set SECURE_SBOM_API_KEY)
+  -base-url string    API base URL (or set SECURE_SBOM_BASE_URL)
+  -timeout duration   Request timeout (default: 30s)
+  -quiet              Suppress progress output
+
 EXAMPLES:
   # List all keys
   keymgmt list
@@ -354,18 +840,51 @@ EXAMPLES:
   # List keys in JSON format
   keymgmt list -output json
 
+  # Show a key in YAML format for review
+  keymgmt info my-key-123 -output yaml
+
+  # List only keys that can sign
+  keymgmt list -status active
+
+  # List active RSA keys created before 2025 that are due for rotation
+  keymgmt list -status active -algorithm rsa -created-before 2025-01-01
+
+  # List the payments team's production keys with their labels
+  keymgmt list -label-selector team=payments,env=prod -show-labels
+
   # Generate a new key
   keymgmt generate
 
+  # Generate an Ed25519 key
+  keymgmt generate -algorithm ed25519
+
+  # Generate a key labelled for the payments team in production
+  keymgmt generate -label team=payments -label env=prod
+
   # Generate a new key and save public key to file
   keymgmt generate -save-public public.pem
 
+  # Provision 20 keys and save their public keys to ./public-keys
+  keymgmt generate -count 20 -save-public public-keys
+
+  # Show metadata for a specific key
+  keymgmt info my-key-123
+
   # Get public key for a specific key
   keymgmt public my-key-123
 
   # Save public key to file
   keymgmt public my-key-123 -output public.pem
 
+  # Register the public half of a key generated in your own HSM
+  keymgmt import hsm-key-1 hsm-key-1.pub.pem
+
+  # Import a key, checking it is the expected algorithm
+  keymgmt import -algorithm ecdsa-p256 hsm-key-1 hsm-key-1.pub.pem
+
+  # Delete a key without prompting
+  keymgmt delete my-key-123 -force
+
 ENVIRONMENT VARIABLES:
   SECURE_SBOM_API_KEY    Your SecureSBOM API key
   SECURE_SBOM_BASE_URL   Custom API endpoint URL
@@ -0,0 +1,208 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main demonstrates how to export SecureSBOM SDK metrics to Prometheus.
+//
+// This example shows:
+// - Adapting prometheus/client_golang to the securesbom.Collector interface
+// - Counting retries and circuit breaker trips
+// - Serving the metrics on /metrics
+//
+// It lives in its own Go module so the SDK does not depend on client_golang.
+//
+// Usage:
+//   go run . -listen :9090 -interval 30s
+//
+// Environment variables:
+//   SECURE_SBOM_API_KEY - Your API key (required)
+//   SECURE_SBOM_BASE_URL - Custom API endpoint (optional)
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+)
+
+// prometheusCollector implements securesbom.Collector and
+// securesbom.CircuitBreakerCollector using client_golang metrics
+type prometheusCollector struct {
+	requests *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	trips    prometheus.Counter
+}
+
+func newPrometheusCollector(registerer prometheus.Registerer) *prometheusCollector {
+	c := &prometheusCollector{
+		requests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "securesbom",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of SecureSBOM API requests by SDK method and HTTP status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "securesbom",
+			Name:      "retries_total",
+			Help:      "Number of retried SecureSBOM API operations by SDK method.",
+		}, []string{"method"}),
+		trips: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "securesbom",
+			Name:      "circuit_breaker_trips_total",
+			Help:      "Number of times the SecureSBOM circuit breaker opened.",
+		}),
+	}
+	registerer.MustRegister(c.requests, c.retries, c.trips)
+	return c
+}
+
+func (c *prometheusCollector) ObserveRequest(method string, statusCode int, duration time.Duration) {
+	code := "error"
+	if statusCode > 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	c.requests.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+func (c *prometheusCollector) IncRetry(method string) {
+	c.retries.WithLabelValues(method).Inc()
+}
+
+func (c *prometheusCollector) IncCircuitBreakerTrip() {
+	c.trips.Inc()
+}
+
+func main() {
+	var (
+		listen   = flag.String("listen", ":9090", "Address to serve /metrics on")
+		interval = flag.Duration("interval", 30*time.Second, "How often to probe the API")
+		apiKey   = flag.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
+		baseURL  = flag.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
+		timeout  = flag.Duration("timeout", 30*time.Second, "Request timeout")
+		retries  = flag.Int("retries", 3, "Number of retry attempts")
+		help     = flag.Bool("help", false, "Show usage information")
+	)
+	flag.Parse()
+
+	if *help {
+		printUsage()
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	collector := newPrometheusCollector(registry)
+
+	client, err := createClient(*apiKey, *baseURL, *timeout, *retries, collector)
+	if err != nil {
+		log.Fatalf("Error creating SDK client: %v", err)
+	}
+
+	go probe(client, *interval)
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	fmt.Fprintf(os.Stderr, "Serving metrics on %s/metrics\n", *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
+
+func createClient(apiKey, baseURL string, timeout time.Duration, retries int, collector *prometheusCollector) (securesbom.ClientInterface, error) {
+	configBuilder := securesbom.NewConfigBuilder().
+		WithTimeout(timeout).
+		WithMetrics(collector).
+		FromEnv()
+
+	if apiKey != "" {
+		configBuilder = configBuilder.WithAPIKey(apiKey)
+	}
+	if baseURL != "" {
+		configBuilder = configBuilder.WithBaseURL(baseURL)
+	}
+
+	baseClient, err := configBuilder.BuildClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base client: %w", err)
+	}
+
+	// Both wrappers pick up the collector from the base client
+	var client securesbom.ClientInterface = securesbom.WithCircuitBreakerClient(baseClient, securesbom.CircuitBreakerConfig{})
+	if retries > 0 {
+		retryConfig := securesbom.RetryConfig{
+			MaxAttempts: retries,
+			InitialWait: 1 * time.Second,
+			MaxWait:     10 * time.Second,
+			Multiplier:  2.0,
+		}
+		client = securesbom.WithRetryingClient(client, retryConfig)
+	}
+
+	return client, nil
+}
+
+// probe calls the API periodically so there is something to scrape
+func probe(client securesbom.ClientInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		// Each probe makes two calls, each bounded by the client's timeout
+		ctx, cancel := context.WithTimeout(context.Background(), 2*client.Timeout())
+		if err := client.HealthCheck(ctx); err != nil {
+			log.Printf("Health check failed: %v", err)
+		}
+		if _, err := client.ListKeys(ctx); err != nil {
+			log.Printf("Listing keys failed: %v", err)
+		}
+		cancel()
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, `SecureSBOM SDK Prometheus Metrics Example
+
+Periodically call the SecureSBOM API and export SDK metrics to Prometheus.
+
+USAGE:
+  %s [options]
+
+OPTIONS:
+  -listen string       Address to serve /metrics on (default: :9090)
+  -interval duration   How often to probe the API (default: 30s)
+  -api-key string      API key (or set SECURE_SBOM_API_KEY)
+  -base-url string     API base URL (or set SECURE_SBOM_BASE_URL)
+  -timeout duration    Request timeout (default: 30s)
+  -retries int         Number of retry attempts (default: 3)
+  -help                Show this help message
+
+METRICS:
+  securesbom_request_duration_seconds{method,code}
+  securesbom_retries_total{method}
+  securesbom_circuit_breaker_trips_total
+
+ENVIRONMENT VARIABLES:
+  SECURE_SBOM_API_KEY    Your SecureSBOM API key
+  SECURE_SBOM_BASE_URL   Custom API endpoint URL
+
+`, os.Args[0])
+}
@@ -25,6 +25,9 @@
 // Usage:
 //   go run main.go -key-id my-key-123 -sbom sbom.json -output signed-sbom.json
 //   cat sbom.json | go run main.go -key-id my-key-123 > signed-sbom.json
+//   go run main.go -key-id my-key-123 -sbom sbom.json -dry-run
+//   go run main.go -key-id my-key-123 -sbom sbom.spdx.json -detached
+//   go run main.go -key-id my-key-123 -sbom sbom.json -stream -output signed-sbom.json
 //
 // Environment variables:
 //   SECURE_SBOM_API_KEY - Your API key (required)
@@ -39,6 +42,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
@@ -55,8 +59,12 @@ func main() {
 		timeout    = flag.Duration("timeout", 30*time.Second, "Request timeout")
 		retries    = flag.Int("retries", 3, "Number of retry attempts")
 		quiet      = flag.Bool("quiet", false, "Suppress progress output")
-		detached   = flag.Bool("detached", false, "Return detached signature instead of embedding it in the SBOM")
+		detached   = flag.Bool("detached", false, "Write a detached .sig file instead of embedding the signature in the SBOM")
 		pretty     = flag.Bool("pretty", false, "Pretty-print JSON output (where supported)")
+		dryRun     = flag.Bool("dry-run", false, "Validate the SBOM and key without signing")
+		stream     = flag.Bool("stream", false, "Stream the signed SBOM to the output instead of the full API response")
+		timestamp  = flag.Bool("timestamp", false, "Require an RFC 3161 timestamp token with the signature")
+		format     = flag.String("format", "raw", "Output format: raw (the API response) or json (a result envelope)")
 		help       = flag.Bool("help", false, "Show usage information")
 	)
 	flag.Parse()
@@ -66,19 +74,32 @@ func main() {
 		return
 	}
 
+	// Validate output format
+	if *format != "raw" && *format != "json" {
+		log.Fatal("Error: -format must be 'raw' or 'json'")
+	}
+	if *format == "json" {
+		if *detached || *stream {
+			log.Fatal("Error: -format json cannot be combined with -detached or -stream")
+		}
+		jsonOutputPath = *outputPath
+		jsonOutput = true
+	}
+
 	// Validate required parameters
 	if *keyID == "" {
-		log.Fatal("Error: -key-id is required")
+		fatalf("Error: -key-id is required")
 	}
 
 	// Create SDK client with configuration
-	client, err := createClient(*apiKey, *baseURL, *timeout, *retries)
+	client, err := createClient(*apiKey, *baseURL, *timeout, *retries, *timestamp)
 	if err != nil {
-		log.Fatalf("Error creating SDK client: %v", err)
+		fatalf("Error creating SDK client: %w", err)
 	}
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout+10*time.Second)
+	// Allow for the health check and the signing call, each bounded, retries
+	// included, by the client's timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 2*client.Timeout())
 	defer cancel()
 
 	// Load SBOM
@@ -87,7 +108,7 @@ func main() {
 	}
 	sbom, err := loadSBOM(*sbomPath)
 	if err != nil {
-		log.Fatalf("Error loading SBOM: %v", err)
+		fatalf("Error loading SBOM: %w", err)
 	}
 
 	// Verify API connectivity
@@ -95,7 +116,25 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Connecting to SecureSBOM API...\n")
 	}
 	if err := client.HealthCheck(ctx); err != nil {
-		log.Fatalf("Error connecting to API: %v", err)
+		fatalf("Error connecting to API: %w", err)
+	}
+
+	// Validate without signing when requested
+	if *dryRun {
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "Validating sign request for key %s...\n", *keyID)
+		}
+		if err := securesbom.ValidateSignRequest(ctx, client, *keyID, sbom); err != nil {
+			fatalf("Validation failed:\n%w", err)
+		}
+		fmt.Fprintf(os.Stderr, "✓ SBOM and key are valid; nothing was signed\n")
+		if jsonOutput {
+			result := securesbom.NewCLIResult("sign", map[string]interface{}{"dry_run": true, "key_id": *keyID})
+			if err := writeResult(result, *outputPath); err != nil {
+				log.Fatalf("Error outputting result: %v", err)
+			}
+		}
+		return
 	}
 
 	// Sign the SBOM
@@ -103,24 +142,75 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Signing SBOM with key %s...\n", *keyID)
 	}
 
+	// Detached mode leaves the SBOM untouched and writes the signature beside it
+	if *detached {
+		signature, err := securesbom.SignSBOMDetached(ctx, client, *keyID, sbom)
+		if err != nil {
+			fatalf("Error signing SBOM: %w", err)
+		}
+
+		sigPath := detachedSignaturePath(*outputPath, *sbomPath)
+		if err := outputDetachedSignature(signature, sigPath); err != nil {
+			fatalf("Error writing detached signature: %w", err)
+		}
+
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "✓ SBOM successfully signed (%s)\n", signature.Algorithm)
+			if sigPath != "" {
+				fmt.Fprintf(os.Stderr, "  Signature written to: %s\n", sigPath)
+			}
+		}
+		return
+	}
+
+	// Stream mode writes the signed SBOM as it arrives rather than holding it in memory
+	if *stream {
+		sbomBytes, err := sbom.Bytes()
+		if err != nil {
+			fatalf("Error encoding SBOM: %w", err)
+		}
+
+		result, err := streamSignedSBOM(ctx, client, *keyID, sbomBytes, *outputPath)
+		if err != nil {
+			fatalf("Error signing SBOM: %w", err)
+		}
+
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "✓ SBOM successfully signed (%s)\n", result.Algorithm)
+			if *outputPath != "" && *outputPath != "-" {
+				fmt.Fprintf(os.Stderr, "  Signed SBOM written to: %s\n", *outputPath)
+			}
+		}
+		return
+	}
+
 	opts := securesbom.SignOptions{
-		Detached: *detached,
-		Pretty:   *pretty,
+		Pretty: *pretty,
 	}
 
-	result, err := client.SignSBOMWithOptions(ctx, *keyID, sbom.Data(), opts)
+	result, err := client.SignSBOMWithOptions(ctx, *keyID, sbom, opts)
 	if err != nil {
-		log.Fatalf("Error signing SBOM: %v", err)
+		fatalf("Error signing SBOM: %w", err)
 	}
 
 	// Output the signed SBOM
-	if err := outputSignedSBOM(result, *outputPath); err != nil {
+	if jsonOutput {
+		err = writeResult(securesbom.NewCLIResult("sign", result), *outputPath)
+	} else {
+		err = outputSignedSBOM(result, *outputPath)
+	}
+	if err != nil {
 		log.Fatalf("Error outputting signed SBOM: %v", err)
 	}
 
 	// Success message
 	if !*quiet {
 		fmt.Fprintf(os.Stderr, "✓ SBOM successfully signed\n")
+		fmt.Fprintf(os.Stderr, "  Key ID:    %s\n", result.GetKeyID())
+		fmt.Fprintf(os.Stderr, "  Algorithm: %s\n", result.GetSignatureAlgorithm())
+		if signature := result.GetSignatureValue(); signature != "" {
+			fmt.Fprintf(os.Stderr, "  Signature: %s\n", truncate(signature, 32))
+		}
 		if *outputPath != "" && *outputPath != "-" {
 			fmt.Fprintf(os.Stderr, "  Output written to: %s\n", *outputPath)
 		} else {
@@ -130,10 +220,11 @@ func main() {
 }
 
 // createClient builds and configures the SDK client
-func createClient(apiKey, baseURL string, timeout time.Duration, retries int) (securesbom.ClientInterface, error) {
+func createClient(apiKey, baseURL string, timeout time.Duration, retries int, timestamp bool) (securesbom.ClientInterface, error) {
 	// Build configuration using the SDK's builder pattern
 	configBuilder := securesbom.NewConfigBuilder().
 		WithTimeout(timeout).
+		WithTimestamping(timestamp).
 		FromEnv() // Load from environment variables first
 
 	// Override with command line parameters if provided
@@ -175,6 +266,14 @@ func loadSBOM(path string) (*securesbom.SBOM, error) {
 	return securesbom.LoadSBOMFromFile(path)
 }
 
+// truncate shortens s to at most n characters for display
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
 // outputSignedSBOM writes the signed SBOM to the specified output
 func outputSignedSBOM(result *securesbom.SignResultAPIResponseV2, outputPath string) error {
 	// Pretty-print the JSON
@@ -198,6 +297,99 @@ func outputSignedSBOM(result *securesbom.SignResultAPIResponseV2, outputPath str
 	return nil
 }
 
+// streamSignedSBOM signs sbom and streams the signed document to outputPath, or
+// stdout if empty. A partially written output file is removed on failure.
+func streamSignedSBOM(ctx context.Context, client securesbom.ClientInterface, keyID string, sbom []byte, outputPath string) (*securesbom.SignResultAPIResponseV2, error) {
+	if outputPath == "" || outputPath == "-" {
+		return client.SignSBOMToWriter(ctx, keyID, sbom, os.Stdout)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+
+	result, err := client.SignSBOMToWriter(ctx, keyID, sbom, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write to file %s: %w", outputPath, closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(outputPath)
+		return nil, err
+	}
+	return result, nil
+}
+
+// jsonOutput is set with -format json, so that failures are written to the
+// output in the same envelope as results
+var (
+	jsonOutput     bool
+	jsonOutputPath string
+)
+
+// fatalf exits with status 1, writing the error as a JSON envelope with
+// -format json and logging it otherwise
+func fatalf(format string, args ...interface{}) {
+	err := fmt.Errorf(format, args...)
+	if jsonOutput {
+		if writeErr := writeResult(securesbom.NewCLIErrorResult("sign", err, 1), jsonOutputPath); writeErr != nil {
+			log.Print(writeErr)
+		}
+		os.Exit(1)
+	}
+	log.Fatal(err)
+}
+
+// writeResult writes the result envelope to outputPath, or stdout if empty
+func writeResult(result *securesbom.CLIResult, outputPath string) error {
+	if outputPath == "" || outputPath == "-" {
+		return result.Write(os.Stdout)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	if err := result.Write(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write to file %s: %w", outputPath, err)
+	}
+	return file.Close()
+}
+
+// detachedSignaturePath returns where to write a detached signature: next to the
+// output file if one was given, otherwise next to the SBOM file. An empty path
+// means stdout.
+func detachedSignaturePath(outputPath, sbomPath string) string {
+	base := outputPath
+	if base == "" || base == "-" {
+		base = sbomPath
+	}
+	if base == "" || base == "-" {
+		return ""
+	}
+	if strings.HasSuffix(base, ".sig") {
+		return base
+	}
+	return base + ".sig"
+}
+
+// outputDetachedSignature writes the base64 signature to sigPath, or stdout if empty
+func outputDetachedSignature(signature *securesbom.DetachedSignature, sigPath string) error {
+	encoded := signature.Base64() + "\n"
+
+	if sigPath == "" {
+		fmt.Print(encoded)
+		return nil
+	}
+
+	if err := os.WriteFile(sigPath, []byte(encoded), 0644); err != nil {
+		return fmt.Errorf("failed to write to file %s: %w", sigPath, err)
+	}
+
+	return nil
+}
+
 // printUsage displays usage information
 func printUsage() {
 	fmt.Fprintf(os.Stderr, `SecureSBOM SDK Sign Example
@@ -212,9 +404,15 @@ REQUIRED:
   -sbom string      Path to SBOM file (default: stdin)
 
 OPTIONS:
-  -detached bool    Return a detached signature - leave the orgional SBOM intac
+  -detached bool    Write a detached signature (.sig) and leave the original SBOM intact
   -pretty   bool    Pretty Print the response
+  -dry-run          Validate the SBOM and key without signing
+  -stream           Stream the signed SBOM itself to the output (lower memory use)
+  -timestamp        Require an RFC 3161 timestamp token (timestamp_token in the output)
   -output string    Output file path (default: stdout)
+  -format string    Output format: raw, the API response (default), or json,
+                    a result envelope with status, data and error fields;
+                    not with -detached or -stream
   -api-key string   API key (or set SECURE_SBOM_API_KEY)
   -base-url string  API base URL (or set SECURE_SBOM_BASE_URL)
   -timeout duration Request timeout (default: 30s)
@@ -235,6 +433,21 @@ EXAMPLES:
   # Sign with retry disabled
   %s -key-id my-key-123 -sbom sbom.json -retries 0
 
+  # Write a detached signature to sbom.spdx.json.sig
+  %s -key-id my-key-123 -sbom sbom.spdx.json -detached
+
+  # Check the SBOM and key without signing
+  %s -key-id my-key-123 -sbom sbom.json -dry-run
+
+  # Stream a large signed SBOM straight to a file
+  %s -key-id my-key-123 -sbom big-sbom.json -stream -output big-sbom.signed.json
+
+  # Sign with a trusted timestamp for long-term archival
+  %s -key-id my-key-123 -sbom sbom.json -timestamp -output signed.json
+
+  # Emit a JSON result envelope for scripts; failures use the same envelope
+  %s -key-id my-key-123 -sbom sbom.json -format json
+
 ENVIRONMENT VARIABLES:
   SECURE_SBOM_API_KEY    Your SecureSBOM API key
   SECURE_SBOM_BASE_URL   Custom API endpoint URL
@@ -242,5 +455,5 @@ ENVIRONMENT VARIABLES:
 API KEY:
   You can obtain an API key from: https://shiftleftcyber.io/contactus
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
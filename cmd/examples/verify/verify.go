@@ -21,10 +21,18 @@
 // - Loading a signed SBOM from file or stdin
 // - Verifying signatures with proper error handling
 // - Outputting verification results
+// - Verifying every SBOM in a directory with a summary table
+// - Streaming directory results as NDJSON while the batch runs
+// - Verifying cosign keyless signatures against a signer identity
 //
 // Usage:
 //   go run main.go -key-id my-key-123 -sbom signed-sbom.json
 //   cat signed-sbom.json | go run main.go -key-id my-key-123
+//   go run main.go -dir release/sboms -pattern '*.json' -key-id my-key-123
+//   go run main.go -dir release/sboms -key-id my-key-123 -output ndjson
+//   go run main.go -sbom sbom.json -bundle sbom.json.bundle \
+//     -certificate-identity dev@example.com -certificate-oidc-issuer https://accounts.google.com \
+//     -fulcio-root fulcio.crt.pem -rekor-key rekor.pub -rekor-origin 'rekor.example.com - 1'
 //
 // Environment variables:
 //   SECURE_SBOM_API_KEY - Your API key (required)
@@ -35,10 +43,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"text/tabwriter"
 	"time"
 
 	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
@@ -49,49 +60,97 @@ func main() {
 	var (
 		keyID     = flag.String("key-id", "", "Key ID used to sign the SBOM (required)")
 		sbomPath  = flag.String("sbom", "", "Path to signed SBOM file (use '-' or omit for stdin)")
-		signature = flag.String("signature", "", "signature to verify (used for SPDX)")
+		dir       = flag.String("dir", "", "Verify every SBOM under this directory instead of -sbom")
+		pattern   = flag.String("pattern", "", "With -dir, only verify files matching this glob, e.g. '*.cdx.json'")
+		keyMap    = flag.String("key-map", "", "With -dir, JSON file mapping relative paths to key IDs")
+		workers   = flag.Int("concurrency", securesbom.DefaultBatchConcurrency, "With -dir, number of SBOMs verified at once")
+		signature = flag.String("signature", "", "Signature to verify (default: read from <sbom>.sig, or embedded)")
+		bundle    = flag.String("bundle", "", "Sigstore or cosign bundle of a keyless signature, verified instead of a key")
+		identity  = flag.String("certificate-identity", "", "With -bundle, the signer's email or URI identity")
+		identityR = flag.String("certificate-identity-regexp", "", "With -bundle, a regexp the signer's identity must match")
+		issuer    = flag.String("certificate-oidc-issuer", "", "With -bundle, the OIDC issuer that authenticated the signer")
+		fulcio    = flag.String("fulcio-root", "", "With -bundle, PEM file of the trusted Fulcio certificates")
+		rekor     = flag.String("rekor-key", "", "With -bundle, PEM file of the trusted Rekor public keys")
+		origin    = flag.String("rekor-origin", "", "With -bundle, origin the Rekor log's checkpoints name, to check inclusion proofs")
 		apiKey    = flag.String("api-key", "", "API key (or set SECURE_SBOM_API_KEY)")
 		baseURL   = flag.String("base-url", "", "API base URL (or set SECURE_SBOM_BASE_URL)")
-		output    = flag.String("output", "text", "Output format: text, json")
+		output    = flag.String("output", "text", "Output format: text, json, yaml, or ndjson with -dir")
 		timeout   = flag.Duration("timeout", 30*time.Second, "Request timeout")
 		retries   = flag.Int("retries", 3, "Number of retry attempts")
 		quiet     = flag.Bool("quiet", false, "Suppress progress output (only show result)")
 		help      = flag.Bool("help", false, "Show usage information")
 	)
-	flag.Parse()
+	// The flag package exits with 2 on a bad flag, which scripts would read as
+	// an invalid signature
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(securesbom.ExitBadInput)
+	}
+	jsonOutput = *output == "json"
 
 	if *help {
 		printUsage()
 		return
 	}
 
-	// Validate required parameters
-	if *keyID == "" {
-		log.Fatal("Error: -key-id is required")
+	// Validate required parameters; in directory mode keys can also come
+	// from the SBOMs or -key-map, and keyless signatures need none
+	if *keyID == "" && *dir == "" && *bundle == "" {
+		fail(securesbom.ExitBadInput, "Error: -key-id is required")
+	}
+	if *dir != "" && (*sbomPath != "" || *signature != "" || *bundle != "") {
+		fail(securesbom.ExitBadInput, "Error: -dir cannot be combined with -sbom, -signature or -bundle")
+	}
+	if *bundle != "" && (*keyID != "" || *signature != "") {
+		fail(securesbom.ExitBadInput, "Error: -bundle cannot be combined with -key-id or -signature")
+	}
+	var trustRoot *securesbom.KeylessTrustRoot
+	if *bundle != "" {
+		if *fulcio == "" || *rekor == "" {
+			fail(securesbom.ExitBadInput, "Error: -bundle requires -fulcio-root and -rekor-key")
+		}
+		var err error
+		if trustRoot, err = loadTrustRoot(*fulcio, *rekor, *origin); err != nil {
+			fail(securesbom.ExitBadInput, "Error loading keyless trust root: %w", err)
+		}
 	}
 
 	// Validate output format
-	if *output != "text" && *output != "json" {
-		log.Fatal("Error: -output must be 'text' or 'json'")
+	if *output == "ndjson" && *dir == "" {
+		fail(securesbom.ExitBadInput, "Error: -output ndjson requires -dir")
+	}
+	if *output != "text" && *output != "json" && *output != "yaml" && *output != "ndjson" {
+		fail(securesbom.ExitBadInput, "Error: -output must be 'text', 'json', 'yaml' or 'ndjson'")
 	}
 
 	// Create SDK client with configuration
-	client, err := createClient(*apiKey, *baseURL, *timeout, *retries)
+	client, err := createClient(*apiKey, *baseURL, *timeout, *retries, trustRoot)
 	if err != nil {
-		log.Fatalf("Error creating SDK client: %v", err)
+		fail(securesbom.ExitBadInput, "Error creating SDK client: %w", err)
 	}
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout+10*time.Second)
+	// Allow for the health check and the verification call, each bounded,
+	// retries included, by the client's timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 2*client.Timeout())
 	defer cancel()
 
-	// Load signed SBOM
-	if !*quiet {
-		fmt.Fprintf(os.Stderr, "Loading SBOM...\n")
-	}
-	sbom, err := loadSignedSBOM(*sbomPath)
-	if err != nil {
-		log.Fatalf("Error loading signed SBOM: %v", err)
+	// Keyless signatures are checked locally, so the API isn't needed
+	if *bundle != "" {
+		if !*quiet {
+			fmt.Fprintf(os.Stderr, "Verifying keyless SBOM signature...\n")
+		}
+		keyless := securesbom.KeylessIdentity{Issuer: *issuer, Subject: *identity, SubjectRegexp: *identityR}
+		result, err := verifyKeyless(ctx, client, *sbomPath, *bundle, keyless)
+		if err != nil {
+			fail(securesbom.ExitCode(result, err), "Error verifying SBOM: %w", err)
+		}
+		if err := outputVerificationResult(result, nil, *output); err != nil {
+			log.Fatalf("Error outputting verification result: %v", err)
+		}
+		os.Exit(securesbom.ExitCode(result, nil))
 	}
 
 	// Verify API connectivity
@@ -99,7 +158,16 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Connecting to SecureSBOM API...\n")
 	}
 	if err := client.HealthCheck(ctx); err != nil {
-		log.Fatalf("Error connecting to API: %v", err)
+		fail(securesbom.ExitAPIError, "Error connecting to API: %w", err)
+	}
+
+	if *dir != "" {
+		opts := securesbom.VerifyDirectoryOptions{
+			Pattern: *pattern,
+			KeyID:   *keyID,
+			Batch:   securesbom.BatchOptions{Concurrency: *workers},
+		}
+		os.Exit(verifyDirectory(client, *dir, *keyMap, opts, *output, *quiet))
 	}
 
 	// Verify the SBOM signature
@@ -107,37 +175,182 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Verifying SBOM signature with key %s...\n", *keyID)
 	}
 
-	cliVerifyReq := securesbom.VerifyCMDRequest{
-		KeyID: *keyID,
-		SBOM:  sbom.Data(),
+	var result *securesbom.VerifyResultCMDResponse
+	if *signature != "" {
+		result, err = verifyWithSignature(ctx, client, *keyID, *sbomPath, *signature)
+	} else {
+		// Detects the format and uses a detached .sig file next to the SBOM, or
+		// the signature embedded in the SBOM, extracting it from an SPDX annotation
+		result, err = client.VerifySBOMFromFile(ctx, *keyID, stdinIfEmpty(*sbomPath))
+	}
+	// An invalid signature can come back as an error, e.g. for a disallowed
+	// algorithm; ExitCode still classifies it as an invalid signature. A
+	// failure the server explained comes with a result naming the reason,
+	// which is reported like any other result.
+	if err != nil && (result == nil || result.Reason == "") {
+		fail(securesbom.ExitCode(result, err), "Error verifying SBOM: %w", err)
 	}
 
-	if signature != nil {
-		cliVerifyReq.SignatureB64 = *signature
+	// Output verification result
+	if err := outputVerificationResult(result, err, *output); err != nil {
+		log.Fatalf("Error outputting verification result: %v", err)
 	}
 
-	var result *securesbom.VerifyResultCMDResponse
-	result, err = client.VerifySBOM(ctx, cliVerifyReq)
+	// Exit with appropriate code
+	os.Exit(securesbom.ExitCode(result, err))
+}
+
+// verifyDirectory verifies the SBOMs under dir, prints a summary, or with
+// ndjson output one record per file as it completes, and returns the exit
+// code: invalid signatures take precedence over API errors, and API errors
+// over bad input
+func verifyDirectory(client securesbom.ClientInterface, dir, keyMapPath string, opts securesbom.VerifyDirectoryOptions, output string, quiet bool) int {
+	if keyMapPath != "" {
+		data, err := os.ReadFile(keyMapPath)
+		if err != nil {
+			fail(securesbom.ExitBadInput, "Error reading key map: %w", err)
+		}
+		if err := json.Unmarshal(data, &opts.KeyIDs); err != nil {
+			fail(securesbom.ExitBadInput, "Error parsing key map %s: %w", keyMapPath, err)
+		}
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Verifying SBOMs in %s...\n", dir)
+		opts.Batch.OnProgress = func(completed, total int) {
+			fmt.Fprintf(os.Stderr, "\r  %d/%d verified", completed, total)
+			if completed == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+
+	// Records are written as results arrive, so a consumer can act on them
+	// before the whole directory is done
+	if output == "ndjson" {
+		encoder := json.NewEncoder(os.Stdout)
+		opts.OnResult = func(index int, result securesbom.FileVerifyResult) {
+			if err := encoder.Encode(fileRecord{Index: index, fileSummary: summarize(result)}); err != nil {
+				log.Fatalf("Error outputting verification result: %v", err)
+			}
+		}
+	}
+
+	// Each request has its own timeout, so the walk as a whole has none
+	results, err := securesbom.VerifyDirectory(context.Background(), client, dir, opts)
+	if results == nil {
+		fail(securesbom.ExitCode(nil, err), "Error verifying directory: %w", err)
+	}
+
+	code := securesbom.ExitValid
 	if err != nil {
-		log.Fatalf("Error verifying SBOM: %v", err)
+		code = securesbom.ExitCode(nil, err)
 	}
+	if output != "ndjson" {
+		if err := outputDirectorySummary(results, output, err, code); err != nil {
+			log.Fatalf("Error outputting verification summary: %v", err)
+		}
+	}
+	return code
+}
 
-	// Output verification result
-	if err := outputVerificationResult(result, *output); err != nil {
-		log.Fatalf("Error outputting verification result: %v", err)
+// fileSummary is one row of the directory summary
+type fileSummary struct {
+	Path   string `json:"path"`
+	Format string `json:"format,omitempty"`
+	KeyID  string `json:"key_id,omitempty"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// fileRecord is one NDJSON line; Index is the file's position in lexical
+// order, since records arrive in completion order
+type fileRecord struct {
+	Index int `json:"index"`
+	fileSummary
+}
+
+// summarize converts a file's result to a summary row
+func summarize(r securesbom.FileVerifyResult) fileSummary {
+	row := fileSummary{Path: r.Path, Format: r.Format, KeyID: r.KeyID, Valid: r.Valid()}
+	if r.Err != nil {
+		row.Reason = string(securesbom.VerifyReasonFromError(r.Err))
+		row.Detail = r.Err.Error()
+	} else {
+		row.Reason = string(r.Result.Reason)
+		row.Detail = r.Result.Message
 	}
+	return row
+}
 
-	// Exit with appropriate code
-	if !result.Valid {
-		os.Exit(1)
+// outputDirectorySummary prints one row per file in the specified format;
+// verifyErr and code describe the directory as a whole for JSON output
+func outputDirectorySummary(results []securesbom.FileVerifyResult, format string, verifyErr error, code int) error {
+	summary := make([]fileSummary, len(results))
+	valid := 0
+	for i, r := range results {
+		summary[i] = summarize(r)
+		if r.Valid() {
+			valid++
+		}
+	}
+
+	switch format {
+	case "json":
+		if code != securesbom.ExitValid {
+			return securesbom.NewCLIFailedResult("verify", summary, verifyErr, code).Write(os.Stdout)
+		}
+		return securesbom.NewCLIResult("verify", summary).Write(os.Stdout)
+	case "yaml":
+		data, err := securesbom.MarshalYAML(summary)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tFILE\tFORMAT\tKEY ID\tREASON\tDETAIL")
+	for _, row := range summary {
+		status := "✓ VALID"
+		if !row.Valid {
+			status = "✗ FAILED"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", status, row.Path, row.Format, row.KeyID, row.Reason, row.Detail)
 	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d of %d SBOMs verified\n", valid, len(summary))
+	return nil
+}
+
+// jsonOutput is set when -output json is given, so that failures are reported
+// in the same envelope as results
+var jsonOutput bool
+
+// fail reports the error and exits with code, one of the securesbom.Exit
+// codes. With JSON output the error is written to stdout as a
+// securesbom.CLIResult; otherwise it is logged.
+func fail(code int, format string, args ...interface{}) {
+	err := fmt.Errorf(format, args...)
+	if jsonOutput {
+		if writeErr := securesbom.NewCLIErrorResult("verify", err, code).Write(os.Stdout); writeErr != nil {
+			log.Print(writeErr)
+		}
+	} else {
+		log.Print(err)
+	}
+	os.Exit(code)
 }
 
 // createClient builds and configures the SDK client
-func createClient(apiKey, baseURL string, timeout time.Duration, retries int) (securesbom.ClientInterface, error) {
+func createClient(apiKey, baseURL string, timeout time.Duration, retries int, trustRoot *securesbom.KeylessTrustRoot) (securesbom.ClientInterface, error) {
 	// Build configuration using the SDK's builder pattern
 	configBuilder := securesbom.NewConfigBuilder().
 		WithTimeout(timeout).
+		WithKeylessTrustRoot(trustRoot).
 		FromEnv() // Load from environment variables first
 
 	// Override with command line parameters if provided
@@ -168,22 +381,85 @@ func createClient(apiKey, baseURL string, timeout time.Duration, retries int) (s
 	return baseClient, nil
 }
 
-// loadSignedSBOM loads a signed SBOM from file or stdin
-func loadSignedSBOM(path string) (*securesbom.SBOM, error) {
-	if path == "" || path == "-" {
-		// Read from stdin
-		return securesbom.LoadSBOMFromReader(os.Stdin)
+// stdinIfEmpty maps an omitted -sbom flag to stdin
+func stdinIfEmpty(path string) string {
+	if path == "" {
+		return securesbom.StdinPath
+	}
+	return path
+}
+
+// verifyWithSignature verifies the SBOM against a signature given on the
+// command line rather than one embedded in, or stored beside, the SBOM
+func verifyWithSignature(ctx context.Context, client securesbom.ClientInterface, keyID, path, signature string) (*securesbom.VerifyResultCMDResponse, error) {
+	var sbom *securesbom.SBOM
+	var err error
+	if path = stdinIfEmpty(path); path == securesbom.StdinPath {
+		sbom, err = securesbom.LoadSBOMFromReader(os.Stdin)
+	} else {
+		sbom, err = securesbom.LoadSBOMFromFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signed SBOM: %w", err)
+	}
+
+	return client.VerifySBOM(ctx, securesbom.VerifyCMDRequest{
+		KeyID:        keyID,
+		SBOM:         sbom,
+		SignatureB64: signature,
+	})
+}
+
+// loadTrustRoot reads the Fulcio certificates and Rekor keys keyless
+// signatures are checked against, and the origin of the Rekor log's
+// checkpoints if given
+func loadTrustRoot(fulcioPath, rekorPath, origin string) (*securesbom.KeylessTrustRoot, error) {
+	fulcioPEM, err := os.ReadFile(fulcioPath)
+	if err != nil {
+		return nil, err
+	}
+	rekorPEM, err := os.ReadFile(rekorPath)
+	if err != nil {
+		return nil, err
+	}
+	root, err := securesbom.LoadKeylessTrustRoot(fulcioPEM, rekorPEM)
+	if err != nil {
+		return nil, err
+	}
+	if origin != "" {
+		root.SetRekorOrigin(origin)
 	}
+	return root, nil
+}
 
-	// Read from file
-	return securesbom.LoadSBOMFromFile(path)
+// verifyKeyless verifies a cosign keyless signature over the SBOM bytes
+// exactly as they were signed
+func verifyKeyless(ctx context.Context, client securesbom.ClientInterface, sbomPath, bundlePath string, identity securesbom.KeylessIdentity) (*securesbom.VerifyResultCMDResponse, error) {
+	var sbom []byte
+	var err error
+	if path := stdinIfEmpty(sbomPath); path == securesbom.StdinPath {
+		sbom, err = io.ReadAll(os.Stdin)
+	} else {
+		sbom, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+	bundle, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	return client.VerifyKeyless(ctx, sbom, bundle, identity)
 }
 
-// outputVerificationResult outputs the verification result in the specified format
-func outputVerificationResult(result *securesbom.VerifyResultCMDResponse, format string) error {
+// outputVerificationResult outputs the verification result in the specified
+// format; verifyErr is the error returned with an invalid result, if any
+func outputVerificationResult(result *securesbom.VerifyResultCMDResponse, verifyErr error, format string) error {
 	switch format {
 	case "json":
-		return outputVerificationJSON(result)
+		return outputVerificationJSON(result, verifyErr)
+	case "yaml":
+		return outputVerificationYAML(result)
 	case "text":
 		return outputVerificationText(result)
 	default:
@@ -192,7 +468,7 @@ func outputVerificationResult(result *securesbom.VerifyResultCMDResponse, format
 }
 
 // outputVerificationJSON outputs the result in JSON format
-func outputVerificationJSON(result *securesbom.VerifyResultCMDResponse) error {
+func outputVerificationJSON(result *securesbom.VerifyResultCMDResponse, verifyErr error) error {
 	output := map[string]interface{}{
 		"valid":     result.Valid,
 		"message":   result.Message,
@@ -204,6 +480,9 @@ func outputVerificationJSON(result *securesbom.VerifyResultCMDResponse) error {
 	} else {
 		output["status"] = "INVALID"
 	}
+	if result.Reason != "" {
+		output["reason"] = result.Reason
+	}
 
 	if result.KeyID != "" {
 		output["key_id"] = result.KeyID
@@ -211,10 +490,33 @@ func outputVerificationJSON(result *securesbom.VerifyResultCMDResponse) error {
 	if result.Algorithm != "" {
 		output["algorithm"] = result.Algorithm
 	}
+	if result.PublicKeyFingerprint != "" {
+		output["public_key_fingerprint"] = result.PublicKeyFingerprint
+	}
+	if len(result.CertificateChain) > 0 {
+		output["certificate_chain"] = result.CertificateChain
+	}
+	if len(result.Warnings) > 0 {
+		output["warnings"] = result.Warnings
+	}
+	if result.SBOMMetadata != nil {
+		output["sbom_metadata"] = result.SBOMMetadata
+	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	if !result.Valid {
+		return securesbom.NewCLIFailedResult("verify", output, verifyErr, securesbom.ExitCode(result, verifyErr)).Write(os.Stdout)
+	}
+	return securesbom.NewCLIResult("verify", output).Write(os.Stdout)
+}
+
+// outputVerificationYAML outputs the result in YAML format
+func outputVerificationYAML(result *securesbom.VerifyResultCMDResponse) error {
+	data, err := securesbom.MarshalYAML(result)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
 }
 
 // outputVerificationText outputs the result in human-readable text format
@@ -229,6 +531,10 @@ func outputVerificationText(result *securesbom.VerifyResultCMDResponse) error {
 		fmt.Printf("Message:    %s\n", result.Message)
 	}
 
+	if result.Reason != "" {
+		fmt.Printf("Reason:     %s\n", result.Reason)
+	}
+
 	if result.KeyID != "" {
 		fmt.Printf("Key ID:     %s\n", result.KeyID)
 	}
@@ -237,10 +543,30 @@ func outputVerificationText(result *securesbom.VerifyResultCMDResponse) error {
 		fmt.Printf("Algorithm:  %s\n", result.Algorithm)
 	}
 
+	if result.PublicKeyFingerprint != "" {
+		fmt.Printf("Key FP:     %s\n", result.PublicKeyFingerprint)
+	}
+
+	if len(result.CertificateChain) > 0 {
+		fmt.Printf("Cert Chain: %d certificate(s)\n", len(result.CertificateChain))
+	}
+
+	if m := result.SBOMMetadata; m != nil {
+		fmt.Printf("SBOM:       %s %s (%s %s, %d components)\n", m.Name, m.Version, m.Format, m.SpecVersion, m.ComponentCount)
+		if m.SerialNumber != "" {
+			fmt.Printf("Serial:     %s\n", m.SerialNumber)
+		}
+	}
+
 	if !result.Timestamp.IsZero() {
 		fmt.Printf("Verified:   %s\n", result.Timestamp.Format(time.RFC3339))
 	}
 
+	// Warnings don't change the exit code but shouldn't go unnoticed
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
 	return nil
 }
 
@@ -252,14 +578,38 @@ Verify the authenticity and integrity of a signed SBOM document.
 
 USAGE:
   %s -key-id KEY_ID [options]
+  %s -dir DIR [-key-id KEY_ID] [options]
+  %s -bundle BUNDLE -certificate-identity ID -certificate-oidc-issuer URL
+     -fulcio-root FILE -rekor-key FILE [options]
 
 REQUIRED:
-  -key-id string    Key ID used to sign the SBOM
+  -key-id string    Key ID used to sign the SBOM (with -dir, the key for SBOMs
+                    that don't name one and aren't in -key-map)
 
 OPTIONS:
   -sbom string      Path to signed SBOM file (default: stdin)
-  -signature string Signature to verify (required for SPDX SBOMs)
-  -output string    Output format: text, json (default: text)
+  -signature string Signature to verify (default: read from <sbom>.sig, or embedded)
+  -dir string       Verify every SBOM under this directory and print a summary
+  -bundle string    Sigstore or cosign bundle of a keyless signature (cosign
+                    sign-blob --bundle); verified locally instead of with a key
+  -certificate-identity string
+                    With -bundle, the signer's email or URI identity
+  -certificate-identity-regexp string
+                    With -bundle, a regexp the signer's identity must match
+  -certificate-oidc-issuer string
+                    With -bundle, the OIDC issuer that authenticated the signer
+  -fulcio-root string
+                    With -bundle, PEM file of the trusted Fulcio certificates
+  -rekor-key string With -bundle, PEM file of the trusted Rekor public keys
+  -rekor-origin string
+                    With -bundle, origin the Rekor log's checkpoints name, in
+                    the form '<host> - <tree ID>'; required for bundles with
+                    an inclusion proof
+  -pattern string   With -dir, only verify files matching this glob, e.g. '*.cdx.json'
+  -key-map string   With -dir, JSON file mapping relative paths to key IDs
+  -concurrency int  With -dir, number of SBOMs verified at once (default: 8)
+  -output string    Output format: text, json, yaml (default: text); with -dir
+                    also ndjson, one JSON record per file as it completes
   -api-key string   API key (or set SECURE_SBOM_API_KEY)
   -base-url string  API base URL (or set SECURE_SBOM_BASE_URL)
   -timeout duration Request timeout (default: 30s)
@@ -269,13 +619,19 @@ OPTIONS:
 
 EXIT CODES:
   0  Signature is valid
-  1  Signature is invalid or verification failed
+  1  Unexpected failure, e.g. writing the output
+  2  Signature is invalid, or the SBOM is not signed
+  3  The API could not be reached or returned an error
+  4  Bad input: missing file, malformed SBOM, unknown key or invalid flags
 
 EXAMPLES:
   # Verify signed CycloneDX SBOM from file (signature embedded)
   %s -key-id my-key-123 -sbom signed-sbom.json
 
-  # Verify SPDX SBOM with separate signature
+  # Verify SPDX SBOM with its detached signature in sbom.spdx.json.sig
+  %s -key-id my-key-123 -sbom sbom.spdx.json
+
+  # Verify SPDX SBOM with a signature given on the command line
   %s -key-id my-key-123 -sbom sbom.spdx.json -signature "base64signature..."
 
   # Verify from stdin with text output
@@ -284,6 +640,26 @@ EXAMPLES:
   # Verify with JSON output for automation
   %s -key-id my-key-123 -sbom signed.json -output json
 
+  # Verify with YAML output for review
+  %s -key-id my-key-123 -sbom signed.json -output yaml
+
+  # Verify every CycloneDX SBOM in a release bundle; keys come from the
+  # embedded signatures, falling back to my-key-123
+  %s -dir release/sboms -pattern '*.cdx.json' -key-id my-key-123
+
+  # Verify a bundle whose SPDX files use different keys
+  %s -dir release/sboms -key-map keys.json   # {"app.spdx.json": "app-key", ...}
+
+  # Stream one JSON record per file as it completes, for a large bundle
+  %s -dir release/sboms -key-id my-key-123 -output ndjson -quiet | jq -c 'select(.valid | not)'
+
+  # Verify an SBOM signed by cosign keyless in a GitHub Actions workflow
+  %s -sbom sbom.json -bundle sbom.json.bundle \
+    -certificate-identity-regexp '^https://github.com/my-org/' \
+    -certificate-oidc-issuer https://token.actions.githubusercontent.com \
+    -fulcio-root fulcio.crt.pem -rekor-key rekor.pub \
+    -rekor-origin "$(cat rekor-origin.txt)"
+
   # Verify with custom API endpoint
   %s -key-id my-key-123 -sbom signed.json -base-url https://custom.api.com
 
@@ -291,11 +667,13 @@ EXAMPLES:
   %s -key-id my-key-123 -sbom signed.json -quiet
 
   # Use in shell scripts (check exit code)
-  if %s -key-id my-key-123 -sbom signed.json -quiet; then
-    echo "Valid signature"
-  else
-    echo "Invalid signature"
-  fi
+  %s -key-id my-key-123 -sbom signed.json -quiet
+  case $? in
+    0) echo "Valid signature" ;;
+    2) echo "Invalid signature" ;;
+    3) echo "API unavailable, try again later" ;;
+    *) echo "Check the command line" ;;
+  esac
 
 ENVIRONMENT VARIABLES:
   SECURE_SBOM_API_KEY    Your SecureSBOM API key
@@ -303,10 +681,11 @@ ENVIRONMENT VARIABLES:
 
 SBOM FORMATS:
   - CycloneDX: Signature is embedded in the SBOM (no -signature flag needed)
-  - SPDX: Signature must be provided separately via -signature flag
+  - SPDX: Signature is read from <sbom>.sig, extracted from the annotation
+    added by securesbom.EmbedSPDXSignature, or given with the -signature flag
 
 API KEY:
   You can obtain an API key from: https://shiftleftcyber.io/contactus
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
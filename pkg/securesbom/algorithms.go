@@ -0,0 +1,85 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Signing algorithms that can be requested when generating keys or signing
+const (
+	AlgorithmEd25519   = "ed25519"
+	AlgorithmECDSAP256 = "ecdsa-p256"
+	AlgorithmECDSAP384 = "ecdsa-p384"
+	AlgorithmRSA2048   = "rsa-2048"
+	AlgorithmRSA4096   = "rsa-4096"
+)
+
+// ErrUnsupportedAlgorithm is returned when a requested algorithm is not one the SDK knows
+var ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+
+// supportedAlgorithms lists the algorithms accepted by validateAlgorithm, in
+// the order they are reported in errors
+var supportedAlgorithms = []string{
+	AlgorithmEd25519,
+	AlgorithmECDSAP256,
+	AlgorithmECDSAP384,
+	AlgorithmRSA2048,
+	AlgorithmRSA4096,
+}
+
+// VerifyCodeDisallowedAlgorithm is the result Code set when a signature is
+// valid but its algorithm is not in Config.AllowedAlgorithms
+const VerifyCodeDisallowedAlgorithm = "DISALLOWED_ALGORITHM"
+
+// checkAllowedAlgorithm fails a valid result whose algorithm is not in allowed.
+// The result keeps the rejected algorithm so callers can report it.
+func checkAllowedAlgorithm(result *VerifyResultCMDResponse, allowed []string) error {
+	if len(allowed) == 0 || !result.Valid || slices.Contains(allowed, result.Algorithm) {
+		return nil
+	}
+
+	if result.Algorithm == "" {
+		result.Message = "signature algorithm was not reported and allowed algorithms are restricted"
+	} else {
+		result.Message = fmt.Sprintf("signature algorithm %q is not allowed (allowed: %s)", result.Algorithm, strings.Join(allowed, ", "))
+	}
+	result.Valid = false
+	result.Code = VerifyCodeDisallowedAlgorithm
+	return fmt.Errorf("%s: %w", result.Message, ErrDisallowedAlgorithm)
+}
+
+// IsKnownAlgorithm reports whether algorithm is one of the Algorithm constants
+// this SDK version knows. Keys and verification results report algorithms as
+// plain strings, and values added by newer servers are passed through
+// unchanged, so use this to decide how to treat algorithms you don't
+// recognize. Matching is exact, as for GenerateKeyOptions.Algorithm.
+func IsKnownAlgorithm(algorithm string) bool {
+	return slices.Contains(supportedAlgorithms, algorithm)
+}
+
+// validateAlgorithm accepts an empty algorithm (server default) or one of the
+// supported algorithms
+func validateAlgorithm(algorithm string) error {
+	if algorithm == "" || IsKnownAlgorithm(algorithm) {
+		return nil
+	}
+	return fmt.Errorf("%w %q (supported: %s)", ErrUnsupportedAlgorithm, algorithm, strings.Join(supportedAlgorithms, ", "))
+}
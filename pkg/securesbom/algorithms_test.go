@@ -0,0 +1,256 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateAlgorithm(t *testing.T) {
+	tests := []struct {
+		algorithm   string
+		expectError bool
+	}{
+		{algorithm: ""},
+		{algorithm: AlgorithmEd25519},
+		{algorithm: AlgorithmECDSAP256},
+		{algorithm: AlgorithmECDSAP384},
+		{algorithm: AlgorithmRSA2048},
+		{algorithm: AlgorithmRSA4096},
+		{algorithm: "ED25519", expectError: true},
+		{algorithm: "rsa-1024", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			err := validateAlgorithm(tt.algorithm)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if tt.expectError && !errors.Is(err, ErrUnsupportedAlgorithm) {
+				t.Errorf("expected ErrUnsupportedAlgorithm, got %v", err)
+			}
+			if tt.expectError && !strings.Contains(err.Error(), AlgorithmEd25519) {
+				t.Errorf("expected error to list supported algorithms, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsKnownAlgorithm(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		expected  bool
+	}{
+		{algorithm: AlgorithmEd25519, expected: true},
+		{algorithm: AlgorithmRSA4096, expected: true},
+		{algorithm: ""},
+		{algorithm: "ED25519"},
+		{algorithm: "ml-dsa-65"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			if got := IsKnownAlgorithm(tt.algorithm); got != tt.expected {
+				t.Errorf("IsKnownAlgorithm(%q) = %v, want %v", tt.algorithm, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestClient_UnknownAlgorithm checks that an algorithm introduced by a newer
+// server passes through key listings unchanged instead of failing to decode
+func TestClient_UnknownAlgorithm(t *testing.T) {
+	const algorithm = "ml-dsa-65"
+	key := map[string]interface{}{"id": "key-pq", "algorithm": algorithm, "public_key": "pem", "status": "active"}
+
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodPost:
+			return createMockResponse(http.StatusCreated, key), nil
+		case strings.HasSuffix(req.URL.Path, "/key-pq"):
+			return createMockResponse(http.StatusOK, key), nil
+		default:
+			return createMockResponse(http.StatusOK, map[string]interface{}{"keys": []interface{}{key}}), nil
+		}
+	}, nil)
+	ctx := context.Background()
+
+	page, err := client.ListKeysPaged(ctx, ListKeysOptions{Algorithm: "ml-dsa"})
+	if err != nil {
+		t.Fatalf("ListKeysPaged() error = %v", err)
+	}
+	if len(page.Keys) != 1 {
+		t.Fatalf("ListKeysPaged() returned %d keys, want 1", len(page.Keys))
+	}
+	got, err := client.GetKey(ctx, "key-pq")
+	if err != nil {
+		t.Fatalf("GetKey() error = %v", err)
+	}
+	generated, err := client.GenerateKey(ctx)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	for name, k := range map[string]GenerateKeyCMDResponse{"ListKeysPaged": page.Keys[0], "GetKey": *got, "GenerateKey": *generated} {
+		if k.Algorithm != algorithm {
+			t.Errorf("%s algorithm = %q, want %q", name, k.Algorithm, algorithm)
+		}
+		if IsKnownAlgorithm(k.Algorithm) {
+			t.Errorf("%s: IsKnownAlgorithm(%q) = true", name, k.Algorithm)
+		}
+
+		encoded, err := json.Marshal(k)
+		if err != nil {
+			t.Fatalf("%s: Marshal() error = %v", name, err)
+		}
+		var decoded GenerateKeyCMDResponse
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("%s: Unmarshal() error = %v", name, err)
+		}
+		if decoded.Algorithm != algorithm {
+			t.Errorf("%s: round-tripped algorithm = %q, want %q", name, decoded.Algorithm, algorithm)
+		}
+	}
+}
+
+func TestClient_SignSBOMWithOptions_Algorithm(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		bodyBytes, _ := io.ReadAll(req.Body)
+		body = string(bodyBytes)
+		return createMockResponse(200, SignResultAPIResponseV2{Algorithm: AlgorithmECDSAP384}), nil
+	}, nil)
+
+	sbom := map[string]string{"bomFormat": "CycloneDX"}
+
+	if _, err := client.SignSBOMWithOptions(context.Background(), "key-123", sbom, SignOptions{Algorithm: AlgorithmECDSAP384}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(body, `"algorithm":"ecdsa-p384"`) {
+		t.Errorf("expected algorithm in request body, got %s", body)
+	}
+
+	_, err := client.SignSBOMWithOptions(context.Background(), "key-123", sbom, SignOptions{Algorithm: "md5"})
+	if !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("expected ErrUnsupportedAlgorithm, got %v", err)
+	}
+}
+
+func TestClient_AllowedAlgorithms(t *testing.T) {
+	allowed := []string{AlgorithmEd25519, AlgorithmECDSAP256}
+
+	tests := []struct {
+		name         string
+		allowed      []string
+		mockResponse *http.Response
+		expectValid  bool
+		expectCode   string
+		expectError  error
+	}{
+		{
+			name:         "allowed algorithm",
+			allowed:      allowed,
+			mockResponse: createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Algorithm: AlgorithmEd25519}),
+			expectValid:  true,
+			expectCode:   "VALID",
+		},
+		{
+			name:         "disallowed algorithm",
+			allowed:      allowed,
+			mockResponse: createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Algorithm: "rsa-sha1"}),
+			expectCode:   VerifyCodeDisallowedAlgorithm,
+			expectError:  ErrDisallowedAlgorithm,
+		},
+		{
+			name:         "algorithm not reported",
+			allowed:      allowed,
+			mockResponse: createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID"}),
+			expectCode:   VerifyCodeDisallowedAlgorithm,
+			expectError:  ErrDisallowedAlgorithm,
+		},
+		{
+			name:         "no restriction",
+			mockResponse: createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Algorithm: "rsa-sha1"}),
+			expectValid:  true,
+			expectCode:   "VALID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := WithRetryingClient(newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				calls++
+				return tt.mockResponse, nil
+			}, func(config *Config) { config.AllowedAlgorithms = tt.allowed }), DefaultRetryConfig())
+
+			result, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{
+				KeyID: "key-1",
+				SBOM:  map[string]interface{}{"bomFormat": "CycloneDX"},
+			})
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("expected %v, got %v", tt.expectError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls != 1 {
+				t.Errorf("expected 1 request, got %d", calls)
+			}
+			if result == nil {
+				t.Fatal("expected a result")
+			}
+			if result.Valid != tt.expectValid || result.Code != tt.expectCode {
+				t.Errorf("result = valid %v code %q, want valid %v code %q", result.Valid, result.Code, tt.expectValid, tt.expectCode)
+			}
+			if tt.expectError != nil && result.Algorithm != "" && !strings.Contains(result.Message, result.Algorithm) {
+				t.Errorf("expected message to name the rejected algorithm, got %q", result.Message)
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_WithAllowedAlgorithms(t *testing.T) {
+	_, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithAllowedAlgorithms([]string{AlgorithmEd25519, " "}).
+		BuildClient()
+	if err == nil || !strings.Contains(err.Error(), "allowed algorithms cannot be empty") {
+		t.Errorf("expected an error for an empty algorithm, got %v", err)
+	}
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithAllowedAlgorithms([]string{AlgorithmEd25519}).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.config.AllowedAlgorithms) != 1 || client.config.AllowedAlgorithms[0] != AlgorithmEd25519 {
+		t.Errorf("AllowedAlgorithms = %v", client.config.AllowedAlgorithms)
+	}
+}
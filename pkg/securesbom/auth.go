@@ -0,0 +1,154 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed, so it
+// doesn't expire in flight
+const tokenExpiryDelta = 30 * time.Second
+
+// Token is a bearer token obtained from a TokenSource
+type Token struct {
+	AccessToken string
+	// Expiry is when the token expires. Zero means unknown, in which case the
+	// token is used until the API rejects it.
+	Expiry time.Time
+}
+
+// TokenSource supplies bearer tokens, e.g. from an OAuth client credentials
+// flow. The client calls Token only when it has no valid token, never
+// concurrently, so implementations need not cache.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// tokenCache holds the current token and serializes refreshes so concurrent
+// requests share a single call to the TokenSource
+type tokenCache struct {
+	source TokenSource
+	clock  clock
+	// refreshing is a one-slot semaphore held while calling source
+	refreshing chan struct{}
+
+	mu      sync.Mutex
+	current *Token
+}
+
+func newTokenCache(source TokenSource, clk clock) *tokenCache {
+	return &tokenCache{
+		source:     source,
+		clock:      clk,
+		refreshing: make(chan struct{}, 1),
+	}
+}
+
+// token returns a valid access token, fetching a new one if needed. rejected
+// is a token the API refused, which is never returned again; pass "" otherwise.
+func (c *tokenCache) token(ctx context.Context, rejected string) (string, error) {
+	if token, ok := c.cached(rejected); ok {
+		return token, nil
+	}
+
+	select {
+	case c.refreshing <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-c.refreshing }()
+
+	// Another request may have refreshed the token while this one waited
+	if token, ok := c.cached(rejected); ok {
+		return token, nil
+	}
+
+	token, err := c.source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == nil || token.AccessToken == "" {
+		return "", fmt.Errorf("token source returned an empty token")
+	}
+
+	c.mu.Lock()
+	c.current = token
+	c.mu.Unlock()
+	return token.AccessToken, nil
+}
+
+func (c *tokenCache) cached(rejected string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil || c.current.AccessToken == rejected {
+		return "", false
+	}
+	if !c.current.Expiry.IsZero() && !c.clock.Now().Add(tokenExpiryDelta).Before(c.current.Expiry) {
+		return "", false
+	}
+	return c.current.AccessToken, true
+}
+
+// authenticate sets the credentials on req and returns the bearer token it
+// used, or "" for API key authentication
+func (c *Client) authenticate(ctx context.Context, req *http.Request, rejected string) (string, error) {
+	if c.tokens == nil {
+		req.Header.Set("x-api-key", c.config.APIKey)
+		return "", nil
+	}
+
+	token, err := c.tokens.token(ctx, rejected)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return token, nil
+}
+
+// reauthenticate copies req with a fresh token after the API rejected token,
+// or returns nil if the request body can't be sent again
+func (c *Client) reauthenticate(ctx context.Context, req *http.Request, token string) (*http.Request, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return nil, nil
+	}
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		retry.Body = body
+	}
+	if _, err := c.authenticate(ctx, retry, token); err != nil {
+		return nil, err
+	}
+	return retry, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTokenSource issues "token-1", "token-2", ... with the given lifetime
+type countingTokenSource struct {
+	calls    atomic.Int32
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (*Token, error) {
+	n := s.calls.Add(1)
+	token := &Token{AccessToken: fmt.Sprintf("token-%d", n)}
+	if s.lifetime > 0 {
+		token.Expiry = s.now().Add(s.lifetime)
+	}
+	return token, nil
+}
+
+// withTestTokenSource configures a newTestClient to authenticate with source
+// in place of an API key
+func withTestTokenSource(source TokenSource) func(config *Config) {
+	return func(config *Config) {
+		config.APIKey = ""
+		config.TokenSource = source
+	}
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	source := &countingTokenSource{lifetime: time.Hour, now: time.Now}
+	var authHeaders []string
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("x-api-key") != "" {
+			t.Error("expected no API key header with a token source")
+		}
+		authHeaders = append(authHeaders, req.Header.Get("Authorization"))
+		return createMockResponse(200, map[string]string{"status": "ok"}), nil
+	}, withTestTokenSource(source))
+
+	for range 3 {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls := source.calls.Load(); calls != 1 {
+		t.Errorf("expected the token to be fetched once, got %d", calls)
+	}
+	for _, header := range authHeaders {
+		if header != "Bearer token-1" {
+			t.Errorf("expected Authorization %q, got %q", "Bearer token-1", header)
+		}
+	}
+}
+
+func TestClient_TokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	clk := newFakeClock()
+	source := &countingTokenSource{lifetime: time.Minute, now: clk.Now}
+	var lastAuth string
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		lastAuth = req.Header.Get("Authorization")
+		return createMockResponse(200, map[string]string{"status": "ok"}), nil
+	}, withTestTokenSource(source))
+	client.tokens.clock = clk
+
+	_ = client.HealthCheck(context.Background())
+	clk.Advance(time.Minute - tokenExpiryDelta)
+	_ = client.HealthCheck(context.Background())
+
+	if lastAuth != "Bearer token-2" {
+		t.Errorf("expected a refreshed token close to expiry, got %q", lastAuth)
+	}
+}
+
+func TestClient_TokenSourceUnauthorized(t *testing.T) {
+	tests := []struct {
+		name             string
+		rejectTokens     map[string]bool
+		body             func(c *Client) error
+		expectError      bool
+		expectRequests   int
+		expectTokenCalls int32
+	}{
+		{
+			name:         "refreshes and retries once",
+			rejectTokens: map[string]bool{"token-1": true},
+			body: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", map[string]string{"bomFormat": "CycloneDX"})
+				return err
+			},
+			expectRequests:   2,
+			expectTokenCalls: 2,
+		},
+		{
+			name:         "second rejection is returned",
+			rejectTokens: map[string]bool{"token-1": true, "token-2": true},
+			body: func(c *Client) error {
+				return c.HealthCheck(context.Background())
+			},
+			expectError:      true,
+			expectRequests:   2,
+			expectTokenCalls: 2,
+		},
+		{
+			name:         "streamed body is not retried",
+			rejectTokens: map[string]bool{"token-1": true},
+			body: func(c *Client) error {
+				r := io.MultiReader(strings.NewReader(`{"bomFormat":"CycloneDX"}`))
+				_, err := c.SignSBOMFromReader(context.Background(), "key-123", r, -1)
+				return err
+			},
+			expectError:      true,
+			expectRequests:   1,
+			expectTokenCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &countingTokenSource{now: time.Now}
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if req.Body != nil {
+					body, _ := io.ReadAll(req.Body)
+					if !strings.Contains(string(body), "CycloneDX") {
+						t.Errorf("expected the request body to be resent, got %q", body)
+					}
+				}
+				token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+				if tt.rejectTokens[token] {
+					return createMockResponse(401, map[string]string{"error": "token expired"}), nil
+				}
+				return createMockResponse(200, SignResultAPIResponseV2{Signature: "c2ln"}), nil
+			}, withTestTokenSource(source))
+
+			err := tt.body(client)
+			if tt.expectError {
+				if !IsUnauthorized(err) {
+					t.Errorf("expected unauthorized error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if requests != tt.expectRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectRequests, requests)
+			}
+			if calls := source.calls.Load(); calls != tt.expectTokenCalls {
+				t.Errorf("expected %d token fetches, got %d", tt.expectTokenCalls, calls)
+			}
+		})
+	}
+}
+
+func TestClient_TokenSourceConcurrentRefresh(t *testing.T) {
+	var calls atomic.Int32
+	source := TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &Token{AccessToken: "shared", Expiry: time.Now().Add(time.Hour)}, nil
+	})
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(200, map[string]string{"status": "ok"}), nil
+	}, withTestTokenSource(source))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.HealthCheck(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected concurrent requests to share one token fetch, got %d", n)
+	}
+}
+
+func TestClient_TokenSourceError(t *testing.T) {
+	sourceErr := errors.New("identity provider unavailable")
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		t.Error("expected no request without a token")
+		return nil, nil
+	}, withTestTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		return nil, sourceErr
+	})))
+
+	if err := client.HealthCheck(context.Background()); !errors.Is(err, sourceErr) {
+		t.Errorf("expected token source error, got %v", err)
+	}
+}
+
+func TestConfigBuilder_WithTokenSource(t *testing.T) {
+	client, err := NewConfigBuilder().
+		WithBaseURL("https://api.example.com").
+		WithTokenSource(&countingTokenSource{now: time.Now}).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("expected a token source to replace the API key, got %v", err)
+	}
+	if client.tokens == nil {
+		t.Error("expected the client to use the token source")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// BackoffStrategy decides how long WithRetry waits before the next attempt.
+// attempt is the zero-based number of the attempt that just failed, lastErr
+// its error, and resp its response for API errors, with the body already read
+// and closed, or nil when no response arrived. MaxAttempts, MaxElapsedTime and
+// the context deadline still bound the retries whatever the strategy returns.
+//
+// A strategy is shared by every call made through a client, so NextDelay must
+// be safe for concurrent use.
+type BackoffStrategy interface {
+	NextDelay(attempt int, lastErr error, resp *http.Response) time.Duration
+}
+
+// backoffStarter is implemented by strategies that keep state between the
+// attempts of one call, such as DecorrelatedJitter; WithRetry asks for a fresh
+// strategy per call so concurrent calls don't share it
+type backoffStarter interface {
+	start() BackoffStrategy
+}
+
+// ExponentialBackoff waits InitialWait after the first failure and
+// Multiplier times longer after each one after that, up to MaxWait.
+// JitterFraction randomizes each wait by reducing it by up to this fraction (0
+// to 1), using Rand, which defaults to math/rand/v2. A longer Retry-After delay
+// requested by the server is honored, also capped by MaxWait. It is the
+// strategy a RetryConfig without a Backoff uses.
+type ExponentialBackoff struct {
+	InitialWait    time.Duration
+	MaxWait        time.Duration
+	Multiplier     float64
+	JitterFraction float64
+	Rand           func() float64
+}
+
+func (b ExponentialBackoff) NextDelay(attempt int, lastErr error, _ *http.Response) time.Duration {
+	waitTime := time.Duration(float64(b.InitialWait) * math.Pow(b.Multiplier, float64(attempt)))
+	if waitTime > b.MaxWait {
+		waitTime = b.MaxWait
+	}
+
+	if b.JitterFraction > 0 {
+		fraction := math.Min(b.JitterFraction, 1)
+		waitTime -= time.Duration(float64(waitTime) * fraction * orRand(b.Rand)())
+	}
+
+	waitTime = max(waitTime, retryAfter(lastErr))
+	if waitTime > b.MaxWait {
+		waitTime = b.MaxWait
+	}
+	return waitTime
+}
+
+// ConstantBackoff waits the same Wait between every attempt, or longer when
+// the server asks for it with Retry-After, up to MaxWait. A zero MaxWait caps
+// Retry-After at 10 seconds, the MaxWait of DefaultRetryConfig; Wait itself is
+// never shortened.
+type ConstantBackoff struct {
+	Wait    time.Duration
+	MaxWait time.Duration
+}
+
+func (b ConstantBackoff) NextDelay(_ int, lastErr error, _ *http.Response) time.Duration {
+	return max(b.Wait, min(retryAfter(lastErr), orDefaultMaxWait(b.MaxWait)))
+}
+
+// DecorrelatedJitter picks each wait at random between InitialWait and three
+// times the previous wait, capped by MaxWait, which spreads out clients that
+// fail together better than jittered exponential backoff. Rand defaults to
+// math/rand/v2. The previous wait is tracked per call, so one value can be
+// shared by concurrent calls. A longer Retry-After delay requested by the
+// server is honored, also capped by MaxWait. A zero MaxWait means 10 seconds,
+// the MaxWait of DefaultRetryConfig.
+type DecorrelatedJitter struct {
+	InitialWait time.Duration
+	MaxWait     time.Duration
+	Rand        func() float64
+}
+
+// NextDelay without a per-call history bases the wait on InitialWait alone;
+// WithRetry tracks the previous wait for each call
+func (b DecorrelatedJitter) NextDelay(_ int, lastErr error, _ *http.Response) time.Duration {
+	return b.next(b.InitialWait, lastErr)
+}
+
+func (b DecorrelatedJitter) start() BackoffStrategy {
+	return &decorrelatedJitterCall{backoff: b, previous: b.InitialWait}
+}
+
+// next returns a wait in [InitialWait, 3*previous), capped by MaxWait
+func (b DecorrelatedJitter) next(previous time.Duration, lastErr error) time.Duration {
+	upper := max(3*previous, b.InitialWait)
+	waitTime := b.InitialWait + time.Duration(float64(upper-b.InitialWait)*orRand(b.Rand)())
+	return min(max(waitTime, retryAfter(lastErr)), orDefaultMaxWait(b.MaxWait))
+}
+
+// decorrelatedJitterCall is the state of DecorrelatedJitter for one call,
+// whose attempts run one after another
+type decorrelatedJitterCall struct {
+	backoff  DecorrelatedJitter
+	previous time.Duration
+}
+
+func (c *decorrelatedJitterCall) NextDelay(_ int, lastErr error, _ *http.Response) time.Duration {
+	c.previous = c.backoff.next(c.previous, lastErr)
+	return c.previous
+}
+
+// retryAfter returns the delay the server asked for in err, if any
+func retryAfter(err error) time.Duration {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// defaultMaxWait is the MaxWait of DefaultRetryConfig
+const defaultMaxWait = 10 * time.Second
+
+func orDefaultMaxWait(maxWait time.Duration) time.Duration {
+	if maxWait <= 0 {
+		return defaultMaxWait
+	}
+	return maxWait
+}
+
+func orRand(random func() float64) func() float64 {
+	if random == nil {
+		return rand.Float64
+	}
+	return random
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	backoff := ConstantBackoff{Wait: 200 * time.Millisecond, MaxWait: 5 * time.Second}
+
+	tests := []struct {
+		name     string
+		backoff  ConstantBackoff
+		attempt  int
+		err      error
+		expected time.Duration
+	}{
+		{name: "first attempt", backoff: backoff, attempt: 0, expected: 200 * time.Millisecond},
+		{name: "later attempt", backoff: backoff, attempt: 5, expected: 200 * time.Millisecond},
+		{
+			name:     "shorter retry-after",
+			backoff:  backoff,
+			err:      &APIError{StatusCode: 429, RetryAfter: 50 * time.Millisecond},
+			expected: 200 * time.Millisecond,
+		},
+		{name: "longer retry-after", backoff: backoff, err: &APIError{StatusCode: 429, RetryAfter: 3 * time.Second}, expected: 3 * time.Second},
+		{name: "retry-after capped by max wait", backoff: backoff, err: &APIError{StatusCode: 429, RetryAfter: time.Hour}, expected: 5 * time.Second},
+		{
+			name:     "zero max wait caps retry-after at the default",
+			backoff:  ConstantBackoff{Wait: 200 * time.Millisecond},
+			err:      &APIError{StatusCode: 503, RetryAfter: time.Hour},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "wait longer than max wait",
+			backoff:  ConstantBackoff{Wait: 20 * time.Second, MaxWait: 5 * time.Second},
+			err:      &APIError{StatusCode: 503, RetryAfter: time.Hour},
+			expected: 20 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.NextDelay(tt.attempt, tt.err, nil); got != tt.expected {
+				t.Errorf("NextDelay() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  DecorrelatedJitter
+		random   float64
+		err      error
+		expected []time.Duration
+	}{
+		{
+			name:     "upper bound grows with the previous wait",
+			backoff:  DecorrelatedJitter{InitialWait: 100 * time.Millisecond, MaxWait: 10 * time.Second},
+			random:   0.5,
+			expected: []time.Duration{200 * time.Millisecond, 350 * time.Millisecond, 575 * time.Millisecond},
+		},
+		{
+			name:     "capped by max wait",
+			backoff:  DecorrelatedJitter{InitialWait: 100 * time.Millisecond, MaxWait: 250 * time.Millisecond},
+			random:   0.99,
+			expected: []time.Duration{250 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name:     "zero random stays at the initial wait",
+			backoff:  DecorrelatedJitter{InitialWait: 100 * time.Millisecond, MaxWait: time.Second},
+			expected: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+		},
+		{
+			name:     "retry-after wins, capped by max wait",
+			backoff:  DecorrelatedJitter{InitialWait: 100 * time.Millisecond, MaxWait: time.Second},
+			err:      &APIError{StatusCode: 503, RetryAfter: 5 * time.Second},
+			expected: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:     "zero max wait caps retry-after at the default",
+			backoff:  DecorrelatedJitter{InitialWait: 100 * time.Millisecond},
+			err:      &APIError{StatusCode: 503, RetryAfter: time.Hour},
+			expected: []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.backoff.Rand = func() float64 { return tt.random }
+
+			// Each call starts again from InitialWait
+			for range 2 {
+				strategy := RetryConfig{Backoff: tt.backoff}.backoffStrategy()
+				var got []time.Duration
+				for attempt := range len(tt.expected) {
+					got = append(got, strategy.NextDelay(attempt, tt.err, nil))
+				}
+				if !slices.Equal(got, tt.expected) {
+					t.Fatalf("waits = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// recordingBackoff records what WithRetry passes to NextDelay
+type recordingBackoff struct {
+	attempts []int
+	statuses []int
+}
+
+func (b *recordingBackoff) NextDelay(attempt int, lastErr error, resp *http.Response) time.Duration {
+	b.attempts = append(b.attempts, attempt)
+	if resp != nil {
+		b.statuses = append(b.statuses, resp.StatusCode)
+	}
+	return time.Duration(attempt+1) * time.Second
+}
+
+func TestRetryingClient_BackoffStrategy(t *testing.T) {
+	clk := newFakeClock()
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(http.StatusServiceUnavailable, map[string]string{"error": "unavailable"}), nil
+	}, func(config *Config) { config.clock = clk })
+
+	backoff := &recordingBackoff{}
+	retryConfig := RetryConfig{MaxAttempts: 4, InitialWait: time.Hour, MaxWait: time.Hour, Multiplier: 2, Backoff: backoff}
+	if _, err := WithRetryingClient(client, retryConfig).ListKeys(context.Background()); err == nil {
+		t.Fatal("expected error but got none")
+	}
+
+	if want := []int{0, 1, 2}; !slices.Equal(backoff.attempts, want) {
+		t.Errorf("NextDelay attempts = %v, want %v", backoff.attempts, want)
+	}
+	if want := []int{503, 503, 503}; !slices.Equal(backoff.statuses, want) {
+		t.Errorf("NextDelay responses = %v, want %v", backoff.statuses, want)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}; !slices.Equal(clk.Sleeps(), want) {
+		t.Errorf("sleeps = %v, want %v", clk.Sleeps(), want)
+	}
+}
+
+func TestConfigBuilder_WithBackoffStrategy(t *testing.T) {
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithBackoffStrategy(ConstantBackoff{Wait: time.Second}).
+		BuildRetryingClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.retryConfig.Backoff != (ConstantBackoff{Wait: time.Second}) {
+		t.Errorf("Backoff = %#v, want the constant backoff", client.retryConfig.Backoff)
+	}
+
+	if _, err := NewConfigBuilder().WithBackoffStrategy(nil).BuildRetryingClient(); err == nil {
+		t.Error("expected error for a nil backoff strategy")
+	}
+}
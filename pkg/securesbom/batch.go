@@ -0,0 +1,234 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of concurrent requests used by batch
+// operations when BatchOptions.Concurrency is not set
+const DefaultBatchConcurrency = 8
+
+// BatchOptions configures batch operations
+type BatchOptions struct {
+	// Concurrency limits the number of in-flight requests
+	Concurrency int
+	// OnProgress, if set, is called after each item finishes, successfully or
+	// not, with the number of finished items and the batch size. Calls are
+	// serialized, so it need not be safe for concurrent use, and completed
+	// increases by one each time until it reaches total. Keep it quick: it
+	// holds up the worker that finished the item.
+	OnProgress func(completed, total int)
+}
+
+// concurrency returns Concurrency, or DefaultBatchConcurrency when it is not set
+func (o BatchOptions) concurrency() int {
+	if o.Concurrency <= 0 {
+		return DefaultBatchConcurrency
+	}
+	return o.Concurrency
+}
+
+// BatchError reports the items of a batch operation that failed. Errors is
+// positional: Errors[i] belongs to input i and is nil for successful items.
+type BatchError struct {
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d batch items failed, first error: %v", failed, len(e.Errors), first)
+}
+
+// Unwrap returns the non-nil item errors so errors.Is and errors.As can inspect them
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// VerifyBatch verifies many SBOMs concurrently. Results are positional and nil
+// for items that failed, except failures the server explained, whose invalid
+// result carries the Reason; a *BatchError describes the failures so that one
+// malformed SBOM doesn't fail the whole batch. Items not started before ctx is
+// cancelled fail with the context error.
+//
+// Pass a RetryingClient to retry transient failures. Each item is retried on
+// its own, with its own attempts and MaxElapsedTime budget, so a flaky item
+// neither holds up nor uses up the retries of the others.
+func VerifyBatch(ctx context.Context, client ClientInterface, requests []VerifyCMDRequest, opts BatchOptions) ([]*VerifyResultCMDResponse, error) {
+	results := make([]*VerifyResultCMDResponse, len(requests))
+	errs := runBatch(ctx, len(requests), opts, func(ctx context.Context, i int) error {
+		result, err := client.VerifySBOM(ctx, requests[i])
+		results[i] = result
+		return err
+	})
+	return results, errs
+}
+
+// BatchVerifyResult is the outcome of one request of VerifyBatchStream
+type BatchVerifyResult struct {
+	// Index is the position of the request in the input slice
+	Index int
+	// Result is nil when Err is set, except for failures the server
+	// explained, whose invalid result carries the Reason
+	Result *VerifyResultCMDResponse
+	Err    error
+}
+
+// VerifyBatchStream is VerifyBatch that sends each result on the returned
+// channel as soon as it completes, in completion order, rather than returning
+// them all at the end, so callers can process results while the batch runs.
+// Every request yields exactly one result, including those not started before
+// ctx is cancelled, and the channel is closed after the last one.
+//
+// The caller must receive until the channel is closed. Workers wait for their
+// result to be taken, which keeps verification from running far ahead of a
+// slow consumer.
+func VerifyBatchStream(ctx context.Context, client ClientInterface, requests []VerifyCMDRequest, opts BatchOptions) <-chan BatchVerifyResult {
+	out := make(chan BatchVerifyResult, opts.concurrency())
+	go func() {
+		defer close(out)
+
+		sent := make([]bool, len(requests))
+		err := runBatch(ctx, len(requests), opts, func(ctx context.Context, i int) error {
+			result, err := client.VerifySBOM(ctx, requests[i])
+			out <- BatchVerifyResult{Index: i, Result: result, Err: err}
+			sent[i] = true
+			return err
+		})
+
+		// Requests never started because ctx ended still get a result
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			for i, itemErr := range batchErr.Errors {
+				if itemErr != nil && !sent[i] {
+					out <- BatchVerifyResult{Index: i, Err: itemErr}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// BatchSignSBOM signs many SBOMs with the same key concurrently. Like
+// VerifyBatch, results are positional and nil for items that failed, with a
+// *BatchError describing the failures. With a RetryingClient each item is
+// retried on its own and has its own idempotency key.
+func BatchSignSBOM(ctx context.Context, client ClientInterface, keyID string, sboms []interface{}, opts BatchOptions) ([]*SignResultAPIResponseV2, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+
+	results := make([]*SignResultAPIResponseV2, len(sboms))
+	errs := runBatch(ctx, len(sboms), opts, func(ctx context.Context, i int) error {
+		result, err := client.SignSBOM(ctx, keyID, sboms[i])
+		results[i] = result
+		return err
+	})
+	return results, errs
+}
+
+// ParallelWithRetry calls fn for each index in [0, n) with the concurrency of
+// opts, retrying each index independently as WithRetry does with config. It
+// composes batch operations not covered by VerifyBatch and BatchSignSBOM, e.g.
+//
+//	err := securesbom.ParallelWithRetry(ctx, len(requests), securesbom.BatchOptions{}, securesbom.DefaultRetryConfig(),
+//		func(ctx context.Context, i int) error {
+//			result, err := client.SignDigest(ctx, requests[i])
+//			results[i] = result
+//			return err
+//		})
+//
+// fn must be safe for concurrent use. As with the batch operations, a
+// *BatchError describes the indexes that still failed after their retries.
+func ParallelWithRetry(ctx context.Context, n int, opts BatchOptions, config RetryConfig, fn func(ctx context.Context, i int) error) error {
+	return runBatch(ctx, n, opts, func(ctx context.Context, i int) error {
+		return WithRetry(ctx, config, func() error {
+			return fn(ctx, i)
+		})
+	})
+}
+
+// runBatch calls fn for each index in [0, n) with bounded concurrency and
+// returns a *BatchError if any call failed
+func runBatch(ctx context.Context, n int, opts BatchOptions, fn func(ctx context.Context, i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, opts.concurrency())
+	var wg sync.WaitGroup
+
+	var progressMu sync.Mutex
+	completed := 0
+	finished := func() {
+		if opts.OnProgress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		completed++
+		opts.OnProgress(completed, n)
+	}
+
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			finished()
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			finished()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(ctx, i)
+			finished()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return &BatchError{Errors: errs}
+		}
+	}
+	return nil
+}
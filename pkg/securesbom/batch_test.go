@@ -0,0 +1,397 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBatchTestClient returns a client whose verify endpoint rejects documents
+// named "malformed" with a 400 and tracks request concurrency
+func newBatchTestClient(t *testing.T, inFlight, maxInFlight *int32) *Client {
+	t.Helper()
+
+	return newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		bodyBytes, _ := io.ReadAll(req.Body)
+		var body struct {
+			SBOM map[string]string `json:"sbom"`
+		}
+		_ = json.Unmarshal(bodyBytes, &body)
+
+		switch body.SBOM["name"] {
+		case "malformed":
+			return createMockResponse(400, map[string]string{"error": "malformed SBOM"}), nil
+		default:
+			return createMockResponse(200, map[string]string{"code": "VALID", "message": "ok"}), nil
+		}
+	}, nil)
+}
+
+func TestVerifyBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newBatchTestClient(t, &inFlight, &maxInFlight)
+
+	names := []string{"good-1", "malformed", "good-2", "good-3", "good-4", "good-5"}
+	requests := make([]VerifyCMDRequest, len(names))
+	for i, name := range names {
+		requests[i] = VerifyCMDRequest{KeyID: "key-123", SBOM: map[string]string{"name": name}}
+	}
+
+	results, err := VerifyBatch(context.Background(), client, requests, BatchOptions{Concurrency: 2})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if len(results) != len(requests) || len(batchErr.Errors) != len(requests) {
+		t.Fatalf("expected positional results and errors, got %d results and %d errors", len(results), len(batchErr.Errors))
+	}
+
+	for i, name := range names {
+		if name == "malformed" {
+			if batchErr.Errors[i] == nil || results[i] != nil {
+				t.Errorf("item %d: expected error and nil result", i)
+			}
+			continue
+		}
+		if batchErr.Errors[i] != nil {
+			t.Errorf("item %d: unexpected error %v", i, batchErr.Errors[i])
+		}
+		if results[i] == nil || !results[i].Valid {
+			t.Errorf("item %d: expected valid result, got %+v", i, results[i])
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestVerifyBatch_ContextCancelled(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newBatchTestClient(t, &inFlight, &maxInFlight)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	requests := []VerifyCMDRequest{
+		{KeyID: "key-123", SBOM: map[string]string{"name": "good-1"}},
+		{KeyID: "key-123", SBOM: map[string]string{"name": "good-2"}},
+	}
+
+	_, err := VerifyBatch(ctx, client, requests, BatchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestVerifyBatchStream(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newBatchTestClient(t, &inFlight, &maxInFlight)
+
+	names := []string{"good-1", "malformed", "good-2", "good-3", "good-4"}
+	requests := make([]VerifyCMDRequest, len(names))
+	for i, name := range names {
+		requests[i] = VerifyCMDRequest{KeyID: "key-123", SBOM: map[string]string{"name": name}}
+	}
+
+	seen := make(map[int]bool)
+	for r := range VerifyBatchStream(context.Background(), client, requests, BatchOptions{Concurrency: 2}) {
+		if seen[r.Index] {
+			t.Errorf("item %d: sent more than once", r.Index)
+		}
+		seen[r.Index] = true
+
+		if names[r.Index] == "malformed" {
+			if r.Err == nil || r.Result != nil {
+				t.Errorf("item %d: expected error and nil result", r.Index)
+			}
+			continue
+		}
+		if r.Err != nil || r.Result == nil || !r.Result.Valid {
+			t.Errorf("item %d: expected valid result, got %+v, %v", r.Index, r.Result, r.Err)
+		}
+	}
+	if len(seen) != len(requests) {
+		t.Errorf("expected %d results, got %d", len(requests), len(seen))
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		count := 0
+		for r := range VerifyBatchStream(ctx, client, requests, BatchOptions{}) {
+			count++
+			if !errors.Is(r.Err, context.Canceled) {
+				t.Errorf("item %d: expected context.Canceled, got %v", r.Index, r.Err)
+			}
+		}
+		if count != len(requests) {
+			t.Errorf("expected a result for each of %d requests, got %d", len(requests), count)
+		}
+	})
+}
+
+func TestBatchOptions_OnProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel bool
+	}{
+		{name: "every item reported"},
+		{name: "cancelled items still reported", cancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			client := newBatchTestClient(t, &inFlight, &maxInFlight)
+
+			requests := make([]VerifyCMDRequest, 20)
+			for i := range requests {
+				requests[i] = VerifyCMDRequest{KeyID: "key-123", SBOM: map[string]string{"name": "good"}}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			// Appending without a lock relies on OnProgress calls being serialized
+			var completed []int
+			opts := BatchOptions{
+				Concurrency: 4,
+				OnProgress: func(done, total int) {
+					if total != len(requests) {
+						t.Errorf("total = %d, want %d", total, len(requests))
+					}
+					completed = append(completed, done)
+				},
+			}
+			_, _ = VerifyBatch(ctx, client, requests, opts)
+
+			if len(completed) != len(requests) {
+				t.Fatalf("OnProgress called %d times, want %d", len(completed), len(requests))
+			}
+			for i, done := range completed {
+				if done != i+1 {
+					t.Fatalf("OnProgress completed values = %v, want 1 through %d in order", completed, len(requests))
+				}
+			}
+		})
+	}
+}
+
+func TestBatchSignSBOM(t *testing.T) {
+	var signed int32
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			KeyID string            `json:"key_id"`
+			SBOM  map[string]string `json:"sbom"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		if body.KeyID != "key-123" {
+			t.Errorf("expected key-123, got %q", body.KeyID)
+		}
+		if body.SBOM["name"] == "malformed" {
+			return createMockResponse(400, map[string]string{"error": "malformed SBOM"}), nil
+		}
+		atomic.AddInt32(&signed, 1)
+		return createMockResponse(200, SignResultAPIResponseV2{Algorithm: AlgorithmEd25519, SignatureB64: "c2ln"}), nil
+	}, nil)
+
+	sboms := []interface{}{
+		map[string]string{"name": "good-1"},
+		map[string]string{"name": "malformed"},
+		map[string]string{"name": "good-2"},
+	}
+
+	var progress int32
+	results, err := BatchSignSBOM(context.Background(), client, "key-123", sboms, BatchOptions{
+		OnProgress: func(completed, total int) { atomic.StoreInt32(&progress, int32(completed)) },
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if batchErr.Errors[1] == nil || results[1] != nil {
+		t.Error("expected the malformed item to fail")
+	}
+	for _, i := range []int{0, 2} {
+		if batchErr.Errors[i] != nil || results[i] == nil || results[i].Algorithm != AlgorithmEd25519 {
+			t.Errorf("item %d: expected a signed result, got %+v, %v", i, results[i], batchErr.Errors[i])
+		}
+	}
+	if signed != 2 || progress != 3 {
+		t.Errorf("signed %d items with final progress %d, want 2 and 3", signed, progress)
+	}
+
+	if _, err := BatchSignSBOM(context.Background(), client, "", sboms, BatchOptions{}); err == nil {
+		t.Error("expected error for empty key ID")
+	}
+}
+
+func TestBatch_RetryingClientRetriesEachItem(t *testing.T) {
+	sboms := []interface{}{
+		map[string]string{"name": "good-1"},
+		map[string]string{"name": "flaky"},
+		map[string]string{"name": "good-2"},
+		map[string]string{"name": "good-3"},
+	}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, client ClientInterface) (succeeded []bool, err error)
+	}{
+		{
+			name: "BatchSignSBOM",
+			run: func(ctx context.Context, client ClientInterface) ([]bool, error) {
+				results, err := BatchSignSBOM(ctx, client, "key-123", sboms, BatchOptions{Concurrency: 2})
+				succeeded := make([]bool, len(results))
+				for i, result := range results {
+					succeeded[i] = result != nil
+				}
+				return succeeded, err
+			},
+		},
+		{
+			name: "VerifyBatch",
+			run: func(ctx context.Context, client ClientInterface) ([]bool, error) {
+				requests := make([]VerifyCMDRequest, len(sboms))
+				for i, sbom := range sboms {
+					requests[i] = VerifyCMDRequest{KeyID: "key-123", SBOM: sbom, SignatureB64: "c2ln"}
+				}
+				results, err := VerifyBatch(ctx, client, requests, BatchOptions{Concurrency: 2})
+				succeeded := make([]bool, len(results))
+				for i, result := range results {
+					succeeded[i] = result != nil && result.Valid
+				}
+				return succeeded, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := map[string]int{}
+			idempotencyKeys := map[string]bool{}
+			base := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				var body struct {
+					SBOM map[string]string `json:"sbom"`
+				}
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				name := body.SBOM["name"]
+
+				mu.Lock()
+				attempts[name]++
+				attempt := attempts[name]
+				if key := req.Header.Get(IdempotencyKeyHeader); key != "" {
+					idempotencyKeys[key] = true
+				}
+				mu.Unlock()
+
+				if name == "flaky" && attempt == 1 {
+					return createMockResponse(http.StatusServiceUnavailable, map[string]string{"error": "try again"}), nil
+				}
+				return createMockResponse(http.StatusOK, map[string]interface{}{
+					"code": "VALID", "message": "ok", "algorithm": AlgorithmEd25519, "signature_b64": "c2ln",
+				}), nil
+			}, nil)
+			// Two attempts per item: the flaky item needs both, so a budget
+			// shared across the batch would have run out
+			client := WithRetryingClient(base, RetryConfig{MaxAttempts: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1})
+
+			succeeded, err := tt.run(context.Background(), client)
+			if err != nil {
+				t.Fatalf("batch error = %v", err)
+			}
+			for i, ok := range succeeded {
+				if !ok {
+					t.Errorf("item %d did not succeed", i)
+				}
+			}
+			want := map[string]int{"good-1": 1, "flaky": 2, "good-2": 1, "good-3": 1}
+			for name, n := range want {
+				if attempts[name] != n {
+					t.Errorf("%s made %d attempts, want %d", name, attempts[name], n)
+				}
+			}
+			if tt.name == "BatchSignSBOM" && len(idempotencyKeys) != len(sboms) {
+				t.Errorf("got %d idempotency keys, want one per item (%d)", len(idempotencyKeys), len(sboms))
+			}
+		})
+	}
+}
+
+func TestParallelWithRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make([]int, 4)
+	config := RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1}
+
+	err := ParallelWithRetry(context.Background(), len(attempts), BatchOptions{Concurrency: 2}, config, func(ctx context.Context, i int) error {
+		mu.Lock()
+		attempts[i]++
+		attempt := attempts[i]
+		mu.Unlock()
+
+		switch {
+		case i == 1 && attempt < 3:
+			return &APIError{StatusCode: http.StatusServiceUnavailable, Message: "try again"}
+		case i == 3:
+			return &APIError{StatusCode: http.StatusBadRequest, Message: "malformed"}
+		}
+		return nil
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	for i, itemErr := range batchErr.Errors {
+		if (itemErr != nil) != (i == 3) {
+			t.Errorf("item %d error = %v", i, itemErr)
+		}
+	}
+	if want := []int{1, 3, 1, 1}; !slices.Equal(attempts, want) {
+		t.Errorf("attempts = %v, want %v", attempts, want)
+	}
+}
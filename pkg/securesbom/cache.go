@@ -0,0 +1,199 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// publicKeyCache is an in-memory, TTL-bounded cache of PEM public keys by key
+// ID. A nil *publicKeyCache is valid and caches nothing.
+type publicKeyCache struct {
+	ttl   time.Duration
+	clock clock
+
+	mu      sync.RWMutex
+	entries map[string]publicKeyCacheEntry
+}
+
+type publicKeyCacheEntry struct {
+	publicKey string
+	expiresAt time.Time
+}
+
+func newPublicKeyCache(ttl time.Duration, clk clock) *publicKeyCache {
+	return &publicKeyCache{
+		ttl:     ttl,
+		clock:   clk,
+		entries: make(map[string]publicKeyCacheEntry),
+	}
+}
+
+func (c *publicKeyCache) get(keyID string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[keyID]
+	c.mu.RUnlock()
+
+	if !ok {
+		return "", false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.mu.Lock()
+		// Only drop the entry if it wasn't refreshed in the meantime
+		if current, ok := c.entries[keyID]; ok && current.expiresAt == entry.expiresAt {
+			delete(c.entries, keyID)
+		}
+		c.mu.Unlock()
+		return "", false
+	}
+	return entry.publicKey, true
+}
+
+func (c *publicKeyCache) set(keyID, publicKey string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[keyID] = publicKeyCacheEntry{
+		publicKey: publicKey,
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+}
+
+func (c *publicKeyCache) invalidate(keyID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, keyID)
+}
+
+// verifyCache is an in-memory, TTL-bounded cache of successful verification
+// results by key ID, SBOM digest and detached signature. The digest covers any
+// embedded signature, so a changed document or signature is always a miss. A
+// nil *verifyCache is valid and caches nothing.
+type verifyCache struct {
+	ttl   time.Duration
+	clock clock
+
+	mu      sync.RWMutex
+	entries map[verifyCacheKey]verifyCacheEntry
+}
+
+type verifyCacheKey struct {
+	keyID        string
+	digest       string
+	signatureB64 string
+}
+
+type verifyCacheEntry struct {
+	result    VerifyResultCMDResponse
+	expiresAt time.Time
+}
+
+func newVerifyCache(ttl time.Duration, clk clock) *verifyCache {
+	return &verifyCache{
+		ttl:     ttl,
+		clock:   clk,
+		entries: make(map[verifyCacheKey]verifyCacheEntry),
+	}
+}
+
+// get returns a copy of the cached result, so callers may modify it freely
+func (c *verifyCache) get(key verifyCacheKey) (*VerifyResultCMDResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.mu.Lock()
+		// Only drop the entry if it wasn't refreshed in the meantime
+		if current, ok := c.entries[key]; ok && current.expiresAt == entry.expiresAt {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		return nil, false
+	}
+	return entry.result.clone(), true
+}
+
+func (c *verifyCache) set(key verifyCacheKey, result *VerifyResultCMDResponse) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = verifyCacheEntry{
+		result:    *result.clone(),
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+}
+
+// invalidateKey drops every cached result for keyID
+func (c *verifyCache) invalidateKey(keyID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.keyID == keyID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *verifyCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// clone copies r, including the slices and metadata it points to
+func (r *VerifyResultCMDResponse) clone() *VerifyResultCMDResponse {
+	clone := *r
+	clone.CertificateChain = slices.Clone(r.CertificateChain)
+	clone.Warnings = slices.Clone(r.Warnings)
+	if r.SBOMMetadata != nil {
+		metadata := *r.SBOMMetadata
+		clone.SBOMMetadata = &metadata
+	}
+	return &clone
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublicKeyCache(t *testing.T) {
+	clk := newFakeClock()
+	cache := newPublicKeyCache(time.Minute, clk)
+
+	cache.set("key-1", "pem-1")
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		keyID    string
+		expectOK bool
+	}{
+		{name: "hit before expiry", advance: 30 * time.Second, keyID: "key-1", expectOK: true},
+		{name: "miss for unknown key", keyID: "key-2", expectOK: false},
+		{name: "miss after expiry", advance: 31 * time.Second, keyID: "key-1", expectOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk.Advance(tt.advance)
+			publicKey, ok := cache.get(tt.keyID)
+			if ok != tt.expectOK {
+				t.Fatalf("expected hit %v, got %v", tt.expectOK, ok)
+			}
+			if ok && publicKey != "pem-1" {
+				t.Errorf("expected %q, got %q", "pem-1", publicKey)
+			}
+		})
+	}
+}
+
+func TestClient_GetPublicKey_Cache(t *testing.T) {
+	var requests atomic.Int32
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return createMockResponse(200, "-----BEGIN PUBLIC KEY-----"), nil
+		},
+	}
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithHTTPClient(mockClient).
+		WithPublicKeyCache(time.Hour).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPublicKey(ctx, "key-1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Concurrent misses may race to the server, but later calls must not
+	afterWarmup := requests.Load()
+	for range 5 {
+		if _, err := client.GetPublicKey(ctx, "key-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != afterWarmup {
+		t.Errorf("expected cached lookups to skip the API, got %d extra requests", got-afterWarmup)
+	}
+
+	client.InvalidatePublicKey("key-1")
+	if _, err := client.GetPublicKey(ctx, "key-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != afterWarmup+1 {
+		t.Errorf("expected invalidation to force a refetch, got %d requests", got)
+	}
+}
+
+func TestConfigBuilder_WithPublicKeyCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		expectError bool
+		expectCache bool
+	}{
+		{name: "enabled", ttl: time.Minute, expectCache: true},
+		{name: "disabled", ttl: 0, expectCache: false},
+		{name: "negative", ttl: -time.Second, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithPublicKeyCache(tt.ttl).
+				BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (client.publicKeys != nil) != tt.expectCache {
+				t.Errorf("expected cache enabled %v, got %v", tt.expectCache, client.publicKeys != nil)
+			}
+		})
+	}
+}
+
+func TestVerifyCache(t *testing.T) {
+	clk := newFakeClock()
+	cache := newVerifyCache(time.Minute, clk)
+
+	key := verifyCacheKey{keyID: "key-1", digest: "abc"}
+	stored := &VerifyResultCMDResponse{Valid: true, KeyID: "key-1", Warnings: []string{"w"},
+		SBOMMetadata: &SBOMMetadata{Format: "cyclonedx", Name: "app"}}
+	cache.set(key, stored)
+	// The cache keeps its own copy of what it was given
+	stored.Warnings[0] = "changed"
+	stored.SBOMMetadata.Name = "changed"
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		key      verifyCacheKey
+		expectOK bool
+	}{
+		{name: "hit before expiry", advance: 30 * time.Second, key: key, expectOK: true},
+		{name: "miss for other digest", key: verifyCacheKey{keyID: "key-1", digest: "def"}, expectOK: false},
+		{name: "miss for other key", key: verifyCacheKey{keyID: "key-2", digest: "abc"}, expectOK: false},
+		{name: "miss for other signature", key: verifyCacheKey{keyID: "key-1", digest: "abc", signatureB64: "c2ln"}, expectOK: false},
+		{name: "miss after expiry", advance: 31 * time.Second, key: key, expectOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk.Advance(tt.advance)
+			result, ok := cache.get(tt.key)
+			if ok != tt.expectOK {
+				t.Fatalf("expected hit %v, got %v", tt.expectOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if !result.Valid || result.KeyID != "key-1" {
+				t.Errorf("unexpected cached result %+v", result)
+			}
+			if result.Warnings[0] != "w" || result.SBOMMetadata.Name != "app" {
+				t.Errorf("modifying the stored result changed the cache: %+v", result)
+			}
+			// Callers get their own copy
+			result.Warnings[0] = "changed"
+			result.SBOMMetadata.Name = "changed"
+			if again, _ := cache.get(tt.key); again.Warnings[0] != "w" || again.SBOMMetadata.Name != "app" {
+				t.Error("modifying a cached result changed the cache")
+			}
+		})
+	}
+}
+
+func TestClient_VerifySBOM_Cache(t *testing.T) {
+	var requests atomic.Int32
+	valid := atomic.Bool{}
+	valid.Store(true)
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			if !valid.Load() {
+				return createMockResponse(http.StatusBadRequest, map[string]string{"code": "INVALID_SIGNATURE", "message": "signature verification failed"}), nil
+			}
+			return createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Message: "signature is valid"}), nil
+		},
+	}
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithHTTPClient(mockClient).
+		WithVerifyCache(time.Hour).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "signature": map[string]string{"value": "c2ln"}}
+	verify := func(req VerifyCMDRequest) (*VerifyResultCMDResponse, error) {
+		t.Helper()
+		return client.VerifySBOM(ctx, req)
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := verify(VerifyCMDRequest{KeyID: "key-1", SBOM: sbom}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Concurrent misses may race to the server, but later calls must not,
+	// including for an equivalent document with different key order
+	afterWarmup := requests.Load()
+	reordered := json.RawMessage(`{"signature":{"value":"c2ln"},"bomFormat":"CycloneDX"}`)
+	for _, doc := range []interface{}{sbom, reordered} {
+		result, err := verify(VerifyCMDRequest{KeyID: "key-1", SBOM: doc})
+		if err != nil || !result.Valid {
+			t.Fatalf("expected cached valid result, got %+v, %v", result, err)
+		}
+	}
+	if got := requests.Load(); got != afterWarmup {
+		t.Errorf("expected cached verifications to skip the API, got %d extra requests", got-afterWarmup)
+	}
+
+	// A different key or detached signature is verified by the server
+	valid.Store(false)
+	if _, err := verify(VerifyCMDRequest{KeyID: "key-2", SBOM: sbom}); err == nil {
+		t.Error("expected a different key to miss the cache")
+	}
+	if _, err := verify(VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "b3RoZXI="}); err == nil {
+		t.Error("expected a different signature to miss the cache")
+	}
+
+	// Failures are not cached, and invalidation forces the API to be asked again
+	if _, err := verify(VerifyCMDRequest{KeyID: "key-2", SBOM: sbom}); err == nil {
+		t.Error("expected a failed verification not to be cached")
+	}
+	client.InvalidateVerifyCache()
+	if _, err := verify(VerifyCMDRequest{KeyID: "key-1", SBOM: sbom}); err == nil {
+		t.Error("expected invalidation to force the API to verify again")
+	}
+}
+
+func TestConfigBuilder_WithVerifyCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		expectError bool
+		expectCache bool
+	}{
+		{name: "enabled", ttl: time.Minute, expectCache: true},
+		{name: "disabled", ttl: 0, expectCache: false},
+		{name: "negative", ttl: -time.Second, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithVerifyCache(tt.ttl).
+				BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (client.verified != nil) != tt.expectCache {
+				t.Errorf("expected cache enabled %v, got %v", tt.expectCache, client.verified != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallOption overrides client defaults for a single call, e.g.
+//
+//	client.SignSBOM(ctx, keyID, sbom, securesbom.WithTimeout(2*time.Minute), securesbom.WithRetries(5))
+//
+// Options pass through the retrying and circuit breaker wrappers to the
+// client that makes the request, so they can be given at any layer.
+type CallOption interface {
+	apply(*callOptions)
+}
+
+// callOptions holds the per-call overrides; zero values keep the client defaults
+type callOptions struct {
+	timeout         time.Duration
+	maxAttempts     int
+	idempotencyKey  string
+	signatureFormat SignatureFormat
+}
+
+type callOptionFunc func(*callOptions)
+
+func (f callOptionFunc) apply(o *callOptions) { f(o) }
+
+// WithTimeout bounds each attempt of this call instead of Config.Timeout.
+// Non-positive values keep the client default.
+func WithTimeout(timeout time.Duration) CallOption {
+	return callOptionFunc(func(o *callOptions) {
+		if timeout > 0 {
+			o.timeout = timeout
+		}
+	})
+}
+
+// WithRetries sets the maximum number of attempts for this call, overriding
+// RetryConfig.MaxAttempts. It only has an effect through a RetryingClient.
+// Non-positive values keep the client default.
+func WithRetries(maxAttempts int) CallOption {
+	return callOptionFunc(func(o *callOptions) {
+		if maxAttempts > 0 {
+			o.maxAttempts = maxAttempts
+		}
+	})
+}
+
+// WithSignatureFormat asks for the signature in format for this call instead
+// of Config.SignatureFormat. It applies to SignSBOM, SignSBOMWithOptions and
+// the other SBOM signing calls.
+func WithSignatureFormat(format SignatureFormat) CallOption {
+	return callOptionFunc(func(o *callOptions) {
+		if format != "" {
+			o.signatureFormat = format
+		}
+	})
+}
+
+// IdempotencyKeyHeader carries the key the server uses to deduplicate signing requests
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sets the key sent with a signing call instead of a
+// generated one. Every attempt of the call sends the same key, so the server
+// can return the original result rather than signing twice when a retry
+// follows a request that succeeded but whose response was lost.
+//
+// Deduplication only holds while the server remembers the key. The SDK assumes
+// that window outlasts a call and all of its retries; reuse a key across
+// process restarts only if the server's window covers the gap. A key must not
+// be reused for a different request.
+func WithIdempotencyKey(key string) CallOption {
+	return callOptionFunc(func(o *callOptions) {
+		if key != "" {
+			o.idempotencyKey = key
+		}
+	})
+}
+
+// withIdempotencyKey gives a signing call a key unless it already has one, so
+// a key generated by a retrying wrapper is reused by every attempt beneath it
+func withIdempotencyKey(ctx context.Context) context.Context {
+	if callOptionsFromContext(ctx).idempotencyKey != "" {
+		return ctx
+	}
+	return withCallOptions(ctx, []CallOption{WithIdempotencyKey(uuid.NewString())})
+}
+
+type callOptionsKey struct{}
+
+// withCallOptions layers opts on top of any options already carried by ctx
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	merged := callOptionsFromContext(ctx)
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&merged)
+		}
+	}
+	return context.WithValue(ctx, callOptionsKey{}, merged)
+}
+
+func callOptionsFromContext(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
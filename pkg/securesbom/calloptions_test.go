@@ -0,0 +1,160 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallOptions_Timeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []CallOption
+		expected time.Duration
+	}{
+		{name: "client default", expected: 30 * time.Second},
+		{name: "per-call override", opts: []CallOption{WithTimeout(5 * time.Minute)}, expected: 5 * time.Minute},
+		{name: "non-positive keeps default", opts: []CallOption{WithTimeout(0)}, expected: 30 * time.Second},
+		{name: "last option wins", opts: []CallOption{WithTimeout(time.Minute), WithTimeout(2 * time.Minute)}, expected: 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				deadline, ok := req.Context().Deadline()
+				if !ok {
+					t.Fatal("request has no deadline")
+				}
+				remaining = time.Until(deadline)
+				return createMockResponse(http.StatusOK, `{"signature":"c2ln"}`), nil
+			}, func(config *Config) { config.Timeout = 30 * time.Second })
+
+			req := SignDigestRequest{Digest: "ZGlnZXN0", HashAlgorithm: "sha256", KeyID: "key-1"}
+			if _, err := client.SignDigest(context.Background(), req, tt.opts...); err != nil {
+				t.Fatalf("SignDigest() error = %v", err)
+			}
+			if remaining > tt.expected || remaining < tt.expected-5*time.Second {
+				t.Errorf("request deadline in %v, want about %v", remaining, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCallOptions_Retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []CallOption
+		expected int32
+	}{
+		{name: "client default", expected: 3},
+		{name: "more retries", opts: []CallOption{WithRetries(5)}, expected: 5},
+		{name: "single attempt", opts: []CallOption{WithRetries(1)}, expected: 1},
+		{name: "non-positive keeps default", opts: []CallOption{WithRetries(-1)}, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			base := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+				return createMockResponse(http.StatusServiceUnavailable, `{"error":"unavailable"}`), nil
+			}, func(config *Config) { config.clock = newFakeClock() })
+			// Options given to the outer wrapper reach the retrying layer beneath it
+			client := WithCircuitBreakerClient(
+				WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1}),
+				CircuitBreakerConfig{},
+			)
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			if _, err := client.SignSBOM(context.Background(), "key-1", sbom, tt.opts...); err == nil {
+				t.Fatal("SignSBOM() expected an error")
+			}
+			if got := attempts.Load(); got != tt.expected {
+				t.Errorf("attempts = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCallOptions_IdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []CallOption
+		want string
+	}{
+		{name: "generated"},
+		{name: "caller supplied", opts: []CallOption{WithIdempotencyKey("sign-build-42")}, want: "sign-build-42"},
+		{name: "empty key is generated", opts: []CallOption{WithIdempotencyKey("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			base := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+				if len(keys)%2 == 1 {
+					return createMockResponse(http.StatusBadGateway, `{"error":"bad gateway"}`), nil
+				}
+				return createMockResponse(http.StatusOK, `{"signature":"c2ln"}`), nil
+			}, func(config *Config) { config.clock = newFakeClock() })
+			client := WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1})
+
+			req := SignDigestRequest{Digest: "ZGlnZXN0", HashAlgorithm: "sha256", KeyID: "key-1"}
+			for i := 0; i < 2; i++ {
+				if _, err := client.SignDigest(context.Background(), req, tt.opts...); err != nil {
+					t.Fatalf("SignDigest() error = %v", err)
+				}
+			}
+			if len(keys) != 4 {
+				t.Fatalf("requests = %d, want 4", len(keys))
+			}
+
+			// Both attempts of a call share a key
+			if keys[0] == "" || keys[0] != keys[1] || keys[2] != keys[3] {
+				t.Errorf("keys = %q, want the same key across attempts of a call", keys)
+			}
+			if tt.want != "" {
+				if keys[0] != tt.want || keys[2] != tt.want {
+					t.Errorf("keys = %q, want %q", keys, tt.want)
+				}
+			} else if keys[0] == keys[2] {
+				t.Errorf("keys = %q, want a new key per call", keys)
+			}
+		})
+	}
+}
+
+func TestCallOptions_IdempotencyKeyOnlyForSigning(t *testing.T) {
+	var key string
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		key = req.Header.Get(IdempotencyKeyHeader)
+		return createMockResponse(http.StatusOK, `{"code":"VALID","message":"ok"}`), nil
+	}, nil)
+
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+	if _, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"}); err != nil {
+		t.Fatalf("VerifySBOM() error = %v", err)
+	}
+	if key != "" {
+		t.Errorf("%s = %q, want none for verification", IdempotencyKeyHeader, key)
+	}
+}
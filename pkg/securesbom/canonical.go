@@ -0,0 +1,248 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256" // register SHA-224/256 for crypto.Hash
+	_ "crypto/sha512" // register SHA-384/512 for crypto.Hash
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Canonical returns the RFC 8785 JSON Canonicalization Scheme (JCS) form of the
+// SBOM: object keys sorted by UTF-16 code units, no insignificant whitespace,
+// minimal string escaping, and numbers serialized as IEEE 754 doubles in their
+// shortest ECMAScript form. Two documents that differ only in formatting have
+// the same canonical bytes. SPDX tag-value documents have no canonical form and
+// fail with ErrUnsupportedFormat.
+func (s *SBOM) Canonical() ([]byte, error) {
+	if s != nil && s.tagValue {
+		return nil, fmt.Errorf("SPDX tag-value documents have no canonical JSON form: %w", ErrUnsupportedFormat)
+	}
+	data := s.Data()
+	if data == nil {
+		return nil, fmt.Errorf("SBOM has no data")
+	}
+	return canonicalJSON(data)
+}
+
+// Digest returns the hex-encoded hash of the SBOM's canonical bytes, so
+// documents that differ only in formatting have the same digest. An SPDX
+// tag-value document is hashed as it was loaded. A zero algo uses SHA-256.
+func (s *SBOM) Digest(algo crypto.Hash) (string, error) {
+	if s != nil && s.tagValue {
+		return sbomDigest(s, algo)
+	}
+	data := s.Data()
+	if data == nil {
+		return "", fmt.Errorf("SBOM has no data")
+	}
+	return sbomDigest(data, algo)
+}
+
+// sbomDigest hashes the canonical form of sbom, which may be anything accepted
+// by SignSBOM, or the bytes of an SPDX tag-value document
+func sbomDigest(sbom interface{}, algo crypto.Hash) (string, error) {
+	if algo == 0 {
+		algo = crypto.SHA256
+	}
+	if !algo.Available() {
+		return "", fmt.Errorf("hash algorithm %v is not available", algo)
+	}
+	if payload, ok := tagValuePayload(sbom); ok {
+		h := algo.New()
+		h.Write(payload)
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	_, doc, err := marshalSBOM(sbom)
+	if err != nil {
+		return "", err
+	}
+	return documentDigest(doc, algo)
+}
+
+// documentDigest hashes the canonical form of an already parsed document with
+// an available algo
+func documentDigest(doc interface{}, algo crypto.Hash) (string, error) {
+	canonical, err := canonicalDocument(doc)
+	if err != nil {
+		return "", err
+	}
+	h := algo.New()
+	h.Write(canonical)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// canonicalJSON re-encodes v, which may be anything accepted by SignSBOM, in
+// RFC 8785 canonical form
+func canonicalJSON(v interface{}) ([]byte, error) {
+	_, value, err := marshalSBOM(v)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalDocument(value)
+}
+
+// canonicalDocument encodes an already parsed document in RFC 8785 canonical
+// form
+func canonicalDocument(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, fmt.Errorf("failed to canonicalize SBOM: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case float64:
+		number, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, compareUTF16)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+// compareUTF16 orders strings by their UTF-16 code units, as RFC 8785 requires
+func compareUTF16(a, b string) int {
+	return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+}
+
+// writeCanonicalString escapes only the characters JSON requires, using the
+// short escapes where they exist and lowercase \u00xx otherwise
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// canonicalNumber formats f like ECMAScript's Number.prototype.toString
+func canonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("number %v cannot be represented in JSON", f)
+	}
+	if f == 0 {
+		return "0", nil // also covers negative zero
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	// Shortest round-trip digits and exponent, e.g. "1.2345e+02"
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exponent, err := strconv.Atoi(exp)
+	if err != nil {
+		return "", fmt.Errorf("failed to format number %v: %w", f, err)
+	}
+
+	// n is the position of the decimal point relative to the digits
+	n := exponent + 1
+	k := len(digits)
+
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k), nil
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:], nil
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits, nil
+	}
+
+	result := digits[:1]
+	if k > 1 {
+		result += "." + digits[1:]
+	}
+	if n-1 < 0 {
+		return sign + result + "e-" + strconv.Itoa(1-n), nil
+	}
+	return sign + result + "e+" + strconv.Itoa(n-1), nil
+}
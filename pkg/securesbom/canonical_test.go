@@ -0,0 +1,241 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalNumber(t *testing.T) {
+	// Test vectors from RFC 8785 Appendix B
+	tests := []struct {
+		bits     uint64
+		expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got, err := canonicalNumber(math.Float64frombits(tt.bits))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := canonicalNumber(math.NaN()); err == nil {
+		t.Error("expected error for NaN")
+	}
+	if _, err := canonicalNumber(math.Inf(1)); err == nil {
+		t.Error("expected error for Infinity")
+	}
+}
+
+func TestSBOM_Canonical(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name: "RFC 8785 sample",
+			input: `{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name: "keys sorted by UTF-16 code units",
+			input: `{
+				"\u20ac": "Euro Sign",
+				"\r": "Carriage Return",
+				"\ufb33": "Hebrew Letter Dalet With Dagesh",
+				"1": "One",
+				"\ud83d\ude00": "Emoji: Grinning Face",
+				"\u0080": "Control",
+				"\u00f6": "Latin Small Letter O With Diaeresis"
+			}`,
+			expected: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			name:     "HTML characters are not escaped",
+			input:    `{"name": "<a&b>", "nested": {"z": 1, "a": [2, {"y": 0, "x": -0}]}}`,
+			expected: `{"name":"<a&b>","nested":{"a":[2,{"x":0,"y":0}],"z":1}}`,
+		},
+		{
+			name:        "no data",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbom := &SBOM{}
+			if tt.input != "" {
+				var err error
+				sbom, err = LoadSBOMFromReader(strings.NewReader(tt.input))
+				if err != nil {
+					t.Fatalf("failed to load SBOM: %v", err)
+				}
+			}
+
+			got, err := sbom.Canonical()
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSBOM_Digest(t *testing.T) {
+	canonical := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`)
+	sha256Sum := sha256.Sum256(canonical)
+	sha512Sum := sha512.Sum512(canonical)
+
+	tests := []struct {
+		name        string
+		input       string
+		algo        crypto.Hash
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "defaults to SHA-256",
+			input:    `{"specVersion": "1.5", "bomFormat": "CycloneDX"}`,
+			expected: hex.EncodeToString(sha256Sum[:]),
+		},
+		{
+			name:     "formatting does not change the digest",
+			input:    "{\n  \"bomFormat\": \"CycloneDX\",\n  \"specVersion\": \"1.5\"\n}\n",
+			algo:     crypto.SHA256,
+			expected: hex.EncodeToString(sha256Sum[:]),
+		},
+		{
+			name:     "SHA-512",
+			input:    `{"bomFormat": "CycloneDX", "specVersion": "1.5"}`,
+			algo:     crypto.SHA512,
+			expected: hex.EncodeToString(sha512Sum[:]),
+		},
+		{
+			name:        "unavailable hash",
+			input:       `{"bomFormat": "CycloneDX"}`,
+			algo:        crypto.MD4,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbom, err := LoadSBOMFromReader(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("failed to load SBOM: %v", err)
+			}
+
+			got, err := sbom.Digest(tt.algo)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected digest %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_ResultDigest(t *testing.T) {
+	sbom := map[string]interface{}{"specVersion": "1.5", "bomFormat": "CycloneDX"}
+	expected, err := NewSBOM(sbom).Digest(crypto.SHA256)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/verify") {
+			return createMockResponse(200, VerifyResultAPIResponseV2{Message: "ok"}), nil
+		}
+		return createMockResponse(200, SignResultAPIResponseV2{Algorithm: "ES256", SignatureB64: "c2ln"}), nil
+	}, nil)
+
+	signResult, err := client.SignSBOM(context.Background(), "key-123", sbom)
+	if err != nil {
+		t.Fatalf("unexpected sign error: %v", err)
+	}
+	if signResult.SBOMDigest != expected {
+		t.Errorf("expected sign result digest %s, got %s", expected, signResult.SBOMDigest)
+	}
+
+	verifyResult, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-123", SBOM: sbom})
+	if err != nil {
+		t.Fatalf("unexpected verify error: %v", err)
+	}
+	if verifyResult.SBOMDigest != expected {
+		t.Errorf("expected verify result digest %s, got %s", expected, verifyResult.SBOMDigest)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Capabilities describes what the SecureSBOM API deployment accepts
+type Capabilities struct {
+	// SBOMFormats lists the SBOM formats that can be signed, e.g. "cyclonedx"
+	SBOMFormats []string `json:"sbom_formats"`
+	// Algorithms lists the signing algorithms keys can be generated with
+	Algorithms []string `json:"algorithms"`
+	// MaxPayloadSize is the largest request body the API accepts, in bytes.
+	// Zero means the server doesn't advertise a limit.
+	MaxPayloadSize int64 `json:"max_payload_size,omitempty"`
+	// Timestamping reports whether signatures can carry an RFC 3161
+	// timestamp token, see WithTimestamping
+	Timestamping bool `json:"timestamping,omitempty"`
+	// SignatureFormats lists the formats a signature can be requested in, see
+	// WithSignatureFormat. Empty means the server doesn't advertise them.
+	SignatureFormats []string `json:"signature_formats,omitempty"`
+}
+
+// SupportsFormat reports whether format, e.g. "spdx", is in SBOMFormats
+func (c *Capabilities) SupportsFormat(format string) bool {
+	return slices.ContainsFunc(c.SBOMFormats, func(f string) bool { return strings.EqualFold(f, format) })
+}
+
+// SupportsAlgorithm reports whether algorithm is in Algorithms
+func (c *Capabilities) SupportsAlgorithm(algorithm string) bool {
+	return slices.ContainsFunc(c.Algorithms, func(a string) bool { return strings.EqualFold(a, algorithm) })
+}
+
+// SupportsSignatureFormat reports whether format, e.g. "jws", is in
+// SignatureFormats
+func (c *Capabilities) SupportsSignatureFormat(format string) bool {
+	return slices.ContainsFunc(c.SignatureFormats, func(f string) bool { return strings.EqualFold(f, format) })
+}
+
+func (c *Capabilities) clone() *Capabilities {
+	clone := *c
+	clone.SBOMFormats = slices.Clone(c.SBOMFormats)
+	clone.Algorithms = slices.Clone(c.Algorithms)
+	clone.SignatureFormats = slices.Clone(c.SignatureFormats)
+	return &clone
+}
+
+// Capabilities returns the SBOM formats, signing algorithms and payload size
+// limit of the API. The first successful result is cached for the lifetime of
+// the client, and once known, MaxPayloadSize is checked before sending any
+// request body so oversized SBOMs fail with ErrPayloadTooLarge without a
+// round trip.
+func (c *Client) Capabilities(ctx context.Context) (_ *Capabilities, err error) {
+	ctx, span := c.startSpan(ctx, "Capabilities")
+	defer func() { span.end(err) }()
+
+	if capabilities := c.cachedCapabilities(); capabilities != nil {
+		return capabilities.clone(), nil
+	}
+
+	resp, err := c.doRequest(ctx, HTTP_METHOD_GET, API_VERSION+API_ENDPOINT_CAPABILITIES, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var capabilities Capabilities
+	if err := decodeJSON(resp.Body, &capabilities); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.capabilitiesMu.Lock()
+	c.capabilities = &capabilities
+	c.capabilitiesMu.Unlock()
+	return capabilities.clone(), nil
+}
+
+func (c *Client) cachedCapabilities() *Capabilities {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	return c.capabilities
+}
+
+// checkPayloadSize rejects a request body larger than the server's advertised
+// limit. It only applies once Capabilities has been called, and to bodies
+// whose size is known up front.
+func (c *Client) checkPayloadSize(body io.Reader, size int64) error {
+	capabilities := c.cachedCapabilities()
+	if capabilities == nil || capabilities.MaxPayloadSize <= 0 || body == nil {
+		return nil
+	}
+	if size < 0 {
+		sized, ok := body.(interface{ Len() int })
+		if !ok {
+			return nil
+		}
+		size = int64(sized.Len())
+	}
+	if size > capabilities.MaxPayloadSize {
+		return fmt.Errorf("%w: %d bytes exceeds the server's %d byte limit", ErrPayloadTooLarge, size, capabilities.MaxPayloadSize)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockResponse func() *http.Response
+		expectError  bool
+		expected     *Capabilities
+	}{
+		{
+			name: "successful request",
+			mockResponse: func() *http.Response {
+				return createMockResponse(http.StatusOK, map[string]interface{}{
+					"sbom_formats":     []string{"cyclonedx", "spdx"},
+					"algorithms":       []string{AlgorithmEd25519, AlgorithmECDSAP256},
+					"max_payload_size": 1 << 20,
+				})
+			},
+			expected: &Capabilities{
+				SBOMFormats:    []string{"cyclonedx", "spdx"},
+				Algorithms:     []string{AlgorithmEd25519, AlgorithmECDSAP256},
+				MaxPayloadSize: 1 << 20,
+			},
+		},
+		{
+			name:         "invalid JSON response",
+			mockResponse: func() *http.Response { return createMockResponse(http.StatusOK, "invalid json") },
+			expectError:  true,
+		},
+		{
+			name: "API error response",
+			mockResponse: func() *http.Response {
+				return createMockResponse(http.StatusInternalServerError, map[string]string{"error": "unavailable"})
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if want := "https://api.example.com/api/v1/capabilities"; req.URL.String() != want {
+					t.Errorf("URL = %q, want %q", req.URL.String(), want)
+				}
+				return tt.mockResponse(), nil
+			}, nil)
+
+			for range 2 {
+				capabilities, err := client.Capabilities(context.Background())
+				if tt.expectError {
+					if err == nil {
+						t.Fatal("expected error but got none")
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(capabilities, tt.expected) {
+					t.Errorf("expected %+v, got %+v", tt.expected, capabilities)
+				}
+				// Callers get a copy, so changing it leaves the cache intact
+				capabilities.SBOMFormats[0] = "changed"
+			}
+
+			// Failures are not cached; a success is cached for the client's lifetime
+			want := 1
+			if tt.expectError {
+				want = 2
+			}
+			if requests != want {
+				t.Errorf("requests = %d, want %d", requests, want)
+			}
+		})
+	}
+}
+
+func TestCapabilities_Supports(t *testing.T) {
+	capabilities := &Capabilities{SBOMFormats: []string{"cyclonedx"}, Algorithms: []string{AlgorithmECDSAP256}}
+
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{name: "supported format", got: capabilities.SupportsFormat("cyclonedx"), expected: true},
+		{name: "format ignores case", got: capabilities.SupportsFormat("CycloneDX"), expected: true},
+		{name: "unsupported format", got: capabilities.SupportsFormat("spdx"), expected: false},
+		{name: "supported algorithm", got: capabilities.SupportsAlgorithm(AlgorithmECDSAP256), expected: true},
+		{name: "unsupported algorithm", got: capabilities.SupportsAlgorithm(AlgorithmEd25519), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_MaxPayloadSize(t *testing.T) {
+	small := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+	large := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "padding": strings.Repeat("x", 2048)}
+
+	tests := []struct {
+		name           string
+		maxPayloadSize int64
+		skipLookup     bool
+		sbom           interface{}
+		expectError    bool
+	}{
+		{name: "under the limit", maxPayloadSize: 1024, sbom: small},
+		{name: "over the limit", maxPayloadSize: 1024, sbom: large, expectError: true},
+		{name: "no advertised limit", sbom: large},
+		{name: "limit unknown until capabilities are fetched", maxPayloadSize: 1024, skipLookup: true, sbom: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signRequests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/capabilities") {
+					return createMockResponse(http.StatusOK, Capabilities{MaxPayloadSize: tt.maxPayloadSize}), nil
+				}
+				signRequests++
+				return createMockResponse(http.StatusOK, `{"signed_sbom":{}}`), nil
+			}, nil)
+
+			if !tt.skipLookup {
+				if _, err := client.Capabilities(context.Background()); err != nil {
+					t.Fatalf("Capabilities() error = %v", err)
+				}
+			}
+
+			_, err := client.SignSBOM(context.Background(), "key-1", tt.sbom)
+			if tt.expectError {
+				if !errors.Is(err, ErrPayloadTooLarge) {
+					t.Fatalf("error = %v, want ErrPayloadTooLarge", err)
+				}
+				if IsTemporary(err) {
+					t.Errorf("IsTemporary(%v) = true, want false", err)
+				}
+				if signRequests != 0 {
+					t.Errorf("sign requests = %d, want none", signRequests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignSBOM() error = %v", err)
+			}
+			if signRequests != 1 {
+				t.Errorf("sign requests = %d, want 1", signRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitCooldown         = 30 * time.Second
+)
+
+// CircuitState is the state of a CircuitBreakerClient
+type CircuitState int
+
+const (
+	// CircuitClosed passes calls through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every call with ErrCircuitOpen until the cooldown elapses
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe call through to test recovery
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. Defaults to DefaultCircuitFailureThreshold.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a probe is allowed.
+	// Defaults to DefaultCircuitCooldown.
+	Cooldown time.Duration
+	// IsFailure decides whether an error counts towards opening the circuit.
+	// Defaults to IsTemporary, so outages, 5xx and 429 responses count while
+	// client errors such as IsNotFound or IsUnauthorized do not.
+	IsFailure func(error) bool
+
+	// Logger receives state transitions. WithCircuitBreakerClient defaults it to
+	// the client's logger.
+	Logger Logger
+
+	// Metrics counts trips if it implements CircuitBreakerCollector.
+	// WithCircuitBreakerClient defaults it to the client's Collector.
+	Metrics Collector
+}
+
+// CircuitBreakerClient fails fast with ErrCircuitOpen after repeated failures
+// instead of sending more requests to an unhealthy API. It composes with
+// RetryingClient; wrap the breaker with the retrier so each attempt is counted
+// and retries stop as soon as the circuit opens:
+//
+//	client := WithRetryingClient(WithCircuitBreakerClient(base, cbConfig), retryConfig)
+//
+// The breaker state is shared by all goroutines using the client and guarded
+// by a mutex.
+type CircuitBreakerClient struct {
+	client ClientInterface
+	config CircuitBreakerConfig
+	clk    clock
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func WithCircuitBreakerClient(client ClientInterface, config CircuitBreakerConfig) *CircuitBreakerClient {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCircuitCooldown
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = IsTemporary
+	}
+	if provider, ok := client.(loggerProvider); ok && config.Logger == nil {
+		config.Logger = provider.logger()
+	}
+	if config.Logger == nil {
+		config.Logger = nopLogger{}
+	}
+	if provider, ok := client.(metricsProvider); ok && config.Metrics == nil {
+		config.Metrics = provider.metrics()
+	}
+	if config.Metrics == nil {
+		config.Metrics = nopCollector{}
+	}
+
+	clk := systemClock
+	if provider, ok := client.(clockProvider); ok {
+		clk = provider.clock()
+	}
+
+	return &CircuitBreakerClient{
+		client: client,
+		config: config,
+		clk:    clk,
+	}
+}
+
+// State returns the current state of the circuit
+func (c *CircuitBreakerClient) State() CircuitState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == CircuitOpen && !c.clk.Now().Before(c.openedAt.Add(c.config.Cooldown)) {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// allow reports whether a call may proceed, moving an open circuit to
+// half-open once the cooldown has elapsed. probe is true for the single call
+// let through while half-open; it must be passed back to record.
+func (c *CircuitBreakerClient) allow() (probe bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case CircuitOpen:
+		if c.clk.Now().Before(c.openedAt.Add(c.config.Cooldown)) {
+			return false, ErrCircuitOpen
+		}
+		c.state = CircuitHalfOpen
+		c.config.Logger.Info("circuit breaker half-open, probing API")
+		fallthrough
+	case CircuitHalfOpen:
+		if c.probing {
+			return false, ErrCircuitOpen
+		}
+		c.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the circuit with the outcome of a call that was allowed;
+// probe is the value allow returned for it
+func (c *CircuitBreakerClient) record(err error, probe bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	failed := err != nil && c.config.IsFailure(err)
+
+	// Only the probe decides whether a half-open circuit closes or reopens
+	if probe {
+		c.probing = false
+		if failed {
+			c.trip(err)
+			return
+		}
+		c.state = CircuitClosed
+		c.failures = 0
+		c.config.Logger.Info("circuit breaker closed")
+		return
+	}
+
+	// A call that started before the circuit opened has nothing left to decide
+	if c.state != CircuitClosed {
+		return
+	}
+
+	if !failed {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.failures >= c.config.FailureThreshold {
+		c.trip(err)
+	}
+}
+
+// trip opens the circuit; c.mu must be held
+func (c *CircuitBreakerClient) trip(err error) {
+	c.state = CircuitOpen
+	c.openedAt = c.clk.Now()
+	c.failures = 0
+	c.config.Logger.Warn("circuit breaker opened", "cooldown", c.config.Cooldown, "error", err)
+	if collector, ok := c.config.Metrics.(CircuitBreakerCollector); ok {
+		collector.IncCircuitBreakerTrip()
+	}
+}
+
+func (c *CircuitBreakerClient) call(operation func() error) error {
+	probe, err := c.allow()
+	if err != nil {
+		return err
+	}
+	err = operation()
+	c.record(err, probe)
+	return err
+}
+
+func (c *CircuitBreakerClient) HealthCheck(ctx context.Context) error {
+	return c.call(func() error {
+		return c.client.HealthCheck(ctx)
+	})
+}
+
+func (c *CircuitBreakerClient) HealthCheckStatus(ctx context.Context) (*HealthStatus, error) {
+	var result *HealthStatus
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.HealthCheckStatus(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var result *ServerInfo
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.ServerInfo(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) Capabilities(ctx context.Context) (*Capabilities, error) {
+	var result *Capabilities
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.Capabilities(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) ListKeys(ctx context.Context) (*KeyListResponse, error) {
+	var result *KeyListResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.ListKeys(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) ListKeysPaged(ctx context.Context, opts ListKeysOptions) (*KeyListResponse, error) {
+	var result *KeyListResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.ListKeysPaged(ctx, opts)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) GenerateKey(ctx context.Context) (*GenerateKeyCMDResponse, error) {
+	var result *GenerateKeyCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.GenerateKey(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) GenerateKeyWithBackend(ctx context.Context, backend string) (*GenerateKeyCMDResponse, error) {
+	var result *GenerateKeyCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.GenerateKeyWithBackend(ctx, backend)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) GenerateKeyWithOptions(ctx context.Context, opts GenerateKeyOptions) (*GenerateKeyCMDResponse, error) {
+	var result *GenerateKeyCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.GenerateKeyWithOptions(ctx, opts)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) GetKey(ctx context.Context, keyID string) (*GenerateKeyCMDResponse, error) {
+	var result *GenerateKeyCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.GetKey(ctx, keyID)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) GetPublicKey(ctx context.Context, keyID string) (string, error) {
+	var result string
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.GetPublicKey(ctx, keyID)
+		return err
+	})
+	return result, err
+}
+
+// InvalidatePublicKey drops any cached public key for keyID on the wrapped client
+func (c *CircuitBreakerClient) InvalidatePublicKey(keyID string) {
+	if invalidator, ok := c.client.(publicKeyInvalidator); ok {
+		invalidator.InvalidatePublicKey(keyID)
+	}
+}
+
+// InvalidateVerifyCache drops every cached verification result on the wrapped client
+func (c *CircuitBreakerClient) InvalidateVerifyCache() {
+	if invalidator, ok := c.client.(verifyCacheInvalidator); ok {
+		invalidator.InvalidateVerifyCache()
+	}
+}
+
+func (c *CircuitBreakerClient) DeleteKey(ctx context.Context, keyID string) error {
+	return c.call(func() error {
+		return c.client.DeleteKey(ctx, keyID)
+	})
+}
+
+func (c *CircuitBreakerClient) ImportPublicKey(ctx context.Context, keyID, publicKeyPEM, algorithm string) (*GenerateKeyCMDResponse, error) {
+	var result *GenerateKeyCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.ImportPublicKey(ctx, keyID, publicKeyPEM, algorithm)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignSBOM(ctx context.Context, keyID string, sbom interface{}, callOpts ...CallOption) (*SignResultAPIResponseV2, error) {
+	ctx = withCallOptions(ctx, callOpts)
+	var result *SignResultAPIResponseV2
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignSBOM(ctx, keyID, sbom)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts SignOptions, callOpts ...CallOption) (*SignResultAPIResponseV2, error) {
+	ctx = withCallOptions(ctx, callOpts)
+	var result *SignResultAPIResponseV2
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignSBOMWithOptions(ctx, keyID, sbom, opts)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignSBOMFromReader(ctx context.Context, keyID string, r io.Reader, size int64) (*SignResultAPIResponseV2, error) {
+	var result *SignResultAPIResponseV2
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignSBOMFromReader(ctx, keyID, r, size)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignSBOMToWriter(ctx context.Context, keyID string, sbom []byte, w io.Writer) (*SignResultAPIResponseV2, error) {
+	var result *SignResultAPIResponseV2
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignSBOMToWriter(ctx, keyID, sbom, w)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignBytes(ctx context.Context, keyID string, data []byte, contentType string) (*SignResultAPIResponseV2, error) {
+	var result *SignResultAPIResponseV2
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignBytes(ctx, keyID, data, contentType)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) SignDigest(ctx context.Context, req SignDigestRequest, callOpts ...CallOption) (*SignDigestResponse, error) {
+	ctx = withCallOptions(ctx, callOpts)
+	var result *SignDigestResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.SignDigest(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) VerifySBOM(ctx context.Context, req VerifyCMDRequest, callOpts ...CallOption) (*VerifyResultCMDResponse, error) {
+	ctx = withCallOptions(ctx, callOpts)
+	var result *VerifyResultCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.VerifySBOM(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+func (c *CircuitBreakerClient) VerifyWithPublicKey(ctx context.Context, publicKeyPEM string, sbom []byte) (*VerifyResultCMDResponse, error) {
+	var result *VerifyResultCMDResponse
+	err := c.call(func() error {
+		var err error
+		result, err = c.client.VerifyWithPublicKey(ctx, publicKeyPEM, sbom)
+		return err
+	})
+	return result, err
+}
+
+// VerifyKeyless makes no API call, so it is neither blocked by an open
+// circuit nor counted against it
+func (c *CircuitBreakerClient) VerifyKeyless(ctx context.Context, sbom []byte, rekorBundle []byte, identity KeylessIdentity) (*VerifyResultCMDResponse, error) {
+	return c.client.VerifyKeyless(ctx, sbom, rekorBundle, identity)
+}
+
+func (c *CircuitBreakerClient) IsSigned(ctx context.Context, keyID string, sbom []byte) (bool, error) {
+	var signed bool
+	err := c.call(func() error {
+		var err error
+		signed, err = c.client.IsSigned(ctx, keyID, sbom)
+		return err
+	})
+	return signed, err
+}
+
+// SignSBOMFromFile only counts the sign request against the breaker, not
+// errors reading the file
+func (c *CircuitBreakerClient) SignSBOMFromFile(ctx context.Context, keyID, path string) (*SignResultAPIResponseV2, error) {
+	return signSBOMFromFile(ctx, c, keyID, path)
+}
+
+// VerifySBOMFromFile only counts the verify request against the breaker, not
+// errors reading the file
+func (c *CircuitBreakerClient) VerifySBOMFromFile(ctx context.Context, keyID, path string) (*VerifyResultCMDResponse, error) {
+	return verifySBOMFromFile(ctx, c, keyID, path)
+}
+
+// Timeout returns the wrapped client's Timeout. A call rejected by an open
+// circuit fails at once.
+func (c *CircuitBreakerClient) Timeout() time.Duration {
+	return c.client.Timeout()
+}
+
+func (c *CircuitBreakerClient) clock() clock {
+	return c.clk
+}
+
+func (c *CircuitBreakerClient) logger() Logger {
+	return c.config.Logger
+}
+
+func (c *CircuitBreakerClient) metrics() Collector {
+	return c.config.Metrics
+}
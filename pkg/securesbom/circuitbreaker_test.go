@@ -0,0 +1,207 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type circuitStep struct {
+	advance       time.Duration
+	status        int
+	expectOpenErr bool
+	expectState   CircuitState
+}
+
+func TestCircuitBreakerClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		isFailure func(error) bool
+		steps     []circuitStep
+	}{
+		{
+			name: "opens after consecutive failures and fails fast",
+			steps: []circuitStep{
+				{status: 503, expectState: CircuitClosed},
+				{status: 503, expectState: CircuitOpen},
+				{status: 200, expectOpenErr: true, expectState: CircuitOpen},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []circuitStep{
+				{status: 503, expectState: CircuitClosed},
+				{status: 200, expectState: CircuitClosed},
+				{status: 503, expectState: CircuitClosed},
+			},
+		},
+		{
+			name: "client errors do not count as failures",
+			steps: []circuitStep{
+				{status: 404, expectState: CircuitClosed},
+				{status: 401, expectState: CircuitClosed},
+				{status: 404, expectState: CircuitClosed},
+			},
+		},
+		{
+			name: "successful probe after cooldown closes the circuit",
+			steps: []circuitStep{
+				{status: 503, expectState: CircuitClosed},
+				{status: 503, expectState: CircuitOpen},
+				{advance: 30 * time.Second, status: 200, expectState: CircuitClosed},
+				{status: 200, expectState: CircuitClosed},
+			},
+		},
+		{
+			name: "failed probe reopens the circuit",
+			steps: []circuitStep{
+				{status: 503, expectState: CircuitClosed},
+				{status: 503, expectState: CircuitOpen},
+				{advance: 30 * time.Second, status: 500, expectState: CircuitOpen},
+				{advance: 10 * time.Second, status: 200, expectOpenErr: true, expectState: CircuitOpen},
+			},
+		},
+		{
+			name:      "custom failure classification",
+			isFailure: IsNotFound,
+			steps: []circuitStep{
+				{status: 503, expectState: CircuitClosed},
+				{status: 404, expectState: CircuitClosed},
+				{status: 404, expectState: CircuitOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status, requests int
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if status >= 400 {
+					return createMockResponse(status, map[string]string{"error": http.StatusText(status)}), nil
+				}
+				return createMockResponse(status, ""), nil
+			}, nil)
+
+			clk := newFakeClock()
+			breaker := WithCircuitBreakerClient(client, CircuitBreakerConfig{
+				FailureThreshold: 2,
+				Cooldown:         30 * time.Second,
+				IsFailure:        tt.isFailure,
+			})
+			breaker.clk = clk
+
+			for i, step := range tt.steps {
+				clk.Advance(step.advance)
+				status = step.status
+				before := requests
+
+				err := breaker.HealthCheck(context.Background())
+
+				if errors.Is(err, ErrCircuitOpen) != step.expectOpenErr {
+					t.Errorf("step %d: expected ErrCircuitOpen %v, got %v", i, step.expectOpenErr, err)
+				}
+				if step.expectOpenErr && requests != before {
+					t.Errorf("step %d: expected no request while open", i)
+				}
+				if state := breaker.State(); state != step.expectState {
+					t.Errorf("step %d: expected state %s, got %s", i, step.expectState, state)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerClient_StaleCallDuringProbe(t *testing.T) {
+	unavailable := &APIError{StatusCode: http.StatusServiceUnavailable}
+
+	tests := []struct {
+		name        string
+		staleErr    error
+		probeErr    error
+		expectState CircuitState
+	}{
+		{name: "stale success leaves the probe to decide", probeErr: unavailable, expectState: CircuitOpen},
+		{name: "stale failure leaves the probe to decide", staleErr: unavailable, expectState: CircuitClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := newFakeClock()
+			breaker := WithCircuitBreakerClient(newTestClient(t, nil, nil), CircuitBreakerConfig{
+				FailureThreshold: 1,
+				Cooldown:         30 * time.Second,
+			})
+			breaker.clk = clk
+
+			// A slow call starts while closed, then another call trips the circuit
+			stale, err := breaker.allow()
+			if err != nil || stale {
+				t.Fatalf("allow() = %v, %v, want a non-probe call", stale, err)
+			}
+			tripping, _ := breaker.allow()
+			breaker.record(unavailable, tripping)
+
+			clk.Advance(30 * time.Second)
+			probe, err := breaker.allow()
+			if err != nil || !probe {
+				t.Fatalf("allow() = %v, %v, want the probe", probe, err)
+			}
+
+			breaker.record(tt.staleErr, stale)
+			if state := breaker.State(); state != CircuitHalfOpen {
+				t.Errorf("expected the stale call to leave the circuit half-open, got %s", state)
+			}
+			if _, err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Errorf("expected a second probe to be refused, got %v", err)
+			}
+
+			breaker.record(tt.probeErr, probe)
+			if state := breaker.State(); state != tt.expectState {
+				t.Errorf("expected state %s after the probe, got %s", tt.expectState, state)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerClient_WithRetry(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		requests++
+		return createMockResponse(503, map[string]string{"error": "unavailable"}), nil
+	}, func(config *Config) { config.clock = newFakeClock() })
+
+	breaker := WithCircuitBreakerClient(client, CircuitBreakerConfig{FailureThreshold: 2})
+	retrying := WithRetryingClient(breaker, RetryConfig{
+		MaxAttempts: 5,
+		InitialWait: time.Millisecond,
+		MaxWait:     time.Millisecond,
+		Multiplier:  2.0,
+	})
+
+	err := retrying.HealthCheck(context.Background())
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen once the circuit trips, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected retries to stop after the circuit opened at 2 requests, got %d", requests)
+	}
+}
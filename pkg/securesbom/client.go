@@ -37,44 +37,94 @@ package securesbom
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"mime"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	DefaultTimeout = 30 * time.Second
-	UserAgent      = "secure-sbom-sdk-go/2.0"
+	UserAgent      = "secure-sbom-sdk-go/" + Version
 	KeyBackendFile = "file"
 	KeyBackendKMS  = "gcp-kms"
 )
 
+// DefaultMaxResponseSize bounds response bodies when Config.MaxResponseSize is
+// not set
+const DefaultMaxResponseSize = 256 << 20
+
+// Client is safe for concurrent use by multiple goroutines; share one per API
+// endpoint rather than creating one per request. Its caches, rate limiter and
+// token refresh are internally synchronized.
 type Client struct {
 	config     *Config
 	httpClient HTTPClient
+	tracer     trace.Tracer
+	publicKeys *publicKeyCache
+	verified   *verifyCache
+	limiter    *rateLimiter
+	tokens     *tokenCache
+
+	capabilitiesMu sync.Mutex
+	capabilities   *Capabilities
+
+	keyUsagesMu sync.Mutex
+	keyUsages   map[string][]string
 }
 
 type ClientInterface interface {
 	HealthCheck(ctx context.Context) error
+	HealthCheckStatus(ctx context.Context) (*HealthStatus, error)
+	ServerInfo(ctx context.Context) (*ServerInfo, error)
+	Capabilities(ctx context.Context) (*Capabilities, error)
 	ListKeys(ctx context.Context) (*KeyListResponse, error)
+	ListKeysPaged(ctx context.Context, opts ListKeysOptions) (*KeyListResponse, error)
 	GenerateKey(ctx context.Context) (*GenerateKeyCMDResponse, error)
 	GenerateKeyWithBackend(ctx context.Context, backend string) (*GenerateKeyCMDResponse, error)
+	GenerateKeyWithOptions(ctx context.Context, opts GenerateKeyOptions) (*GenerateKeyCMDResponse, error)
+	GetKey(ctx context.Context, keyID string) (*GenerateKeyCMDResponse, error)
 	GetPublicKey(ctx context.Context, keyID string) (string, error)
-	SignSBOM(ctx context.Context, keyID string, sbom interface{}) (*SignResultAPIResponseV2, error)
-	SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts SignOptions) (*SignResultAPIResponseV2, error)
-	SignDigest(ctx context.Context, req SignDigestRequest) (*SignDigestResponse, error)
-	VerifySBOM(ctx context.Context, req VerifyCMDRequest) (*VerifyResultCMDResponse, error)
+	DeleteKey(ctx context.Context, keyID string) error
+	ImportPublicKey(ctx context.Context, keyID, publicKeyPEM, algorithm string) (*GenerateKeyCMDResponse, error)
+	SignSBOM(ctx context.Context, keyID string, sbom interface{}, callOpts ...CallOption) (*SignResultAPIResponseV2, error)
+	SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts SignOptions, callOpts ...CallOption) (*SignResultAPIResponseV2, error)
+	SignSBOMFromReader(ctx context.Context, keyID string, r io.Reader, size int64) (*SignResultAPIResponseV2, error)
+	SignSBOMToWriter(ctx context.Context, keyID string, sbom []byte, w io.Writer) (*SignResultAPIResponseV2, error)
+	SignBytes(ctx context.Context, keyID string, data []byte, contentType string) (*SignResultAPIResponseV2, error)
+	SignDigest(ctx context.Context, req SignDigestRequest, callOpts ...CallOption) (*SignDigestResponse, error)
+	VerifySBOM(ctx context.Context, req VerifyCMDRequest, callOpts ...CallOption) (*VerifyResultCMDResponse, error)
+	VerifyWithPublicKey(ctx context.Context, publicKeyPEM string, sbom []byte) (*VerifyResultCMDResponse, error)
+	SignSBOMFromFile(ctx context.Context, keyID, path string) (*SignResultAPIResponseV2, error)
+	VerifySBOMFromFile(ctx context.Context, keyID, path string) (*VerifyResultCMDResponse, error)
+	VerifyKeyless(ctx context.Context, sbom []byte, rekorBundle []byte, identity KeylessIdentity) (*VerifyResultCMDResponse, error)
+	IsSigned(ctx context.Context, keyID string, sbom []byte) (bool, error)
+	// Timeout is the longest a single call can take, retries included, so a
+	// caller can size its context deadline from it
+	Timeout() time.Duration
 }
 
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("secure-sbom API error %d: %s", e.StatusCode, e.Message)
 	if e.Details != "" {
-		return fmt.Sprintf("secure-sbom API error %d: %s (%s)", e.StatusCode, e.Message, e.Details)
+		msg += fmt.Sprintf(" (%s)", e.Details)
 	}
-	return fmt.Sprintf("secure-sbom API error %d: %s", e.StatusCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request ID %s]", e.RequestID)
+	}
+	return msg
 }
 
 // Temporary returns true if the error is likely temporary and retryable
@@ -92,47 +142,213 @@ func NewClient(config *Config) (*Client, error) {
 	}
 
 	cfg := *config
+	cfg.BaseURL, _ = normalizeBaseURL(cfg.BaseURL)
+	// The client must not see later changes the caller makes to config
+	cfg.AllowedAlgorithms = slices.Clone(cfg.AllowedAlgorithms)
+	cfg.RequestInterceptors = slices.Clone(cfg.RequestInterceptors)
+	cfg.ResponseInterceptors = slices.Clone(cfg.ResponseInterceptors)
+	cfg.DefaultHeaders = maps.Clone(cfg.DefaultHeaders)
 
 	if cfg.Timeout == 0 {
 		cfg.Timeout = DefaultTimeout
 	}
-	if cfg.UserAgent == "" {
-		cfg.UserAgent = UserAgent
+	if cfg.MaxResponseSize == 0 {
+		cfg.MaxResponseSize = DefaultMaxResponseSize
+	}
+	cfg.UserAgent = buildUserAgent(cfg.UserAgent)
+
+	if debugFromEnv() {
+		cfg.DebugHTTP = true
+	}
+	if cfg.DebugHTTP && cfg.Logger == nil {
+		cfg.Logger = debugLogger()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = nopLogger{}
+	}
+
+	if cfg.InsecureSkipVerify {
+		cfg.Logger.Warn("TLS certificate verification is disabled; do not use this in production",
+			"base_url", cfg.BaseURL)
 	}
 
 	var httpClient = cfg.HTTPClient
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: cfg.Timeout,
+		defaultClient, err := newHTTPClient(&cfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
 		}
+		httpClient = defaultClient
 	}
 
-	return &Client{
+	client := &Client{
 		config:     &cfg,
 		httpClient: httpClient,
-	}, nil
+	}
+	if cfg.TracerProvider != nil {
+		client.tracer = cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(Version))
+	}
+	if cfg.PublicKeyCacheTTL > 0 {
+		client.publicKeys = newPublicKeyCache(cfg.PublicKeyCacheTTL, client.clock())
+	}
+	if cfg.VerifyCacheTTL > 0 {
+		client.verified = newVerifyCache(cfg.VerifyCacheTTL, client.clock())
+	}
+	if cfg.RateLimit > 0 {
+		client.limiter = newRateLimiter(cfg.RateLimit, cfg.RateLimitBurst, client.clock())
+	}
+	if cfg.TokenSource != nil {
+		client.tokens = newTokenCache(cfg.TokenSource, client.clock())
+	}
+
+	return client, nil
 }
 
 func validateConfig(config *Config) error {
-	if config.APIKey == "" {
-		return fmt.Errorf("APIKey is required")
+	if config.APIKey == "" && config.TokenSource == nil {
+		return fmt.Errorf("APIKey is required unless a TokenSource is set")
 	}
 
 	if config.BaseURL == "" {
 		return fmt.Errorf("BaseURL is required")
 	}
 
-	if _, err := url.Parse(config.BaseURL); err != nil {
-		return fmt.Errorf("invalid BaseURL: %w", err)
+	if _, err := normalizeBaseURL(config.BaseURL); err != nil {
+		return err
+	}
+
+	if config.MultiTenant && config.TenantID == "" {
+		return fmt.Errorf("TenantID is required for a multi-tenant BaseURL")
+	}
+
+	if config.TenantID != "" {
+		if err := validateTenantID(config.TenantID); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSignatureFormat(config.SignatureFormat); err != nil {
+		return err
+	}
+
+	if err := validateDefaultHeaders(config.DefaultHeaders); err != nil {
+		return err
 	}
 
 	if config.Timeout < 0 {
 		return fmt.Errorf("timeout cannot be negative")
 	}
 
+	if len(config.PinnedSPKI) > 0 {
+		if config.HTTPClient != nil {
+			return fmt.Errorf("PinnedSPKI cannot be applied to a custom HTTPClient; set Transport instead")
+		}
+		if _, err := parseSPKIPins(config.PinnedSPKI); err != nil {
+			return err
+		}
+	}
+
+	if config.MaxIdleConns < 0 || config.MaxConnsPerHost < 0 {
+		return fmt.Errorf("connection limits cannot be negative")
+	}
+
+	if config.IdleConnTimeout < 0 {
+		return fmt.Errorf("idle connection timeout cannot be negative")
+	}
+
+	if config.MaxResponseSize < 0 {
+		return fmt.Errorf("max response size cannot be negative")
+	}
+
+	if config.PublicKeyCacheTTL < 0 {
+		return fmt.Errorf("public key cache TTL cannot be negative")
+	}
+
+	if config.VerifyCacheTTL < 0 {
+		return fmt.Errorf("verify cache TTL cannot be negative")
+	}
+
+	if config.RateLimit < 0 {
+		return fmt.Errorf("rate limit cannot be negative")
+	}
+
+	if config.RateLimit > 0 && config.RateLimitBurst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1")
+	}
+
+	if config.CompressionMinSize < 0 {
+		return fmt.Errorf("compression minimum size cannot be negative")
+	}
+
 	return nil
 }
 
+// buildUserAgent appends the SDK product token to an application's user agent
+// so the server can attribute traffic to both
+func buildUserAgent(userAgent string) string {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return UserAgent
+	}
+	if strings.Contains(userAgent, UserAgent) {
+		return userAgent
+	}
+	return userAgent + " " + UserAgent
+}
+
+// Timeout returns the configured Config.Timeout, which bounds each call of a
+// Client, including reading the response body. A WithTimeout call option
+// overrides it for that call.
+func (c *Client) Timeout() time.Duration {
+	return c.config.Timeout
+}
+
+func (c *Client) clock() clock {
+	return orSystemClock(c.config.clock)
+}
+
+func (c *Client) logger() Logger {
+	if c.config.Logger == nil {
+		return nopLogger{}
+	}
+	return c.config.Logger
+}
+
+func (c *Client) maxResponseSize() int64 {
+	if c.config.MaxResponseSize <= 0 {
+		return DefaultMaxResponseSize
+	}
+	return c.config.MaxResponseSize
+}
+
+func (c *Client) metrics() Collector {
+	if c.config.Metrics == nil {
+		return nopCollector{}
+	}
+	return c.config.Metrics
+}
+
+// normalizeBaseURL checks that baseURL is an absolute http or https URL and
+// strips trailing slashes, keeping any path prefix such as "/api/v2"
+func normalizeBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid BaseURL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid BaseURL %q: scheme must be http or https, e.g. https://api.example.com", baseURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid BaseURL %q: host is required", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid BaseURL %q: query and fragment are not allowed", baseURL)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	return u.String(), nil
+}
+
 func (c *Client) buildURL(endpoint string) string {
 	baseURL := strings.TrimSuffix(c.config.BaseURL, "/")
 	endpoint = strings.TrimPrefix(endpoint, "/")
@@ -140,8 +356,6 @@ func (c *Client) buildURL(endpoint string) string {
 }
 
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
-	url := c.buildURL(endpoint)
-
 	var bodyReader io.Reader
 	if body != nil {
 		bodyBytes, err := json.Marshal(body)
@@ -151,76 +365,305 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
+	return c.doStreamRequest(ctx, method, endpoint, bodyReader, -1)
+}
+
+// send performs a single HTTP exchange, passing it through the configured
+// interceptors, and records it in logs, traces and metrics
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.interceptRequest(req); err != nil {
+		return nil, err
+	}
+
+	c.dumpRequest(req)
+	clk := c.clock()
+	start := clk.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		elapsed := clk.Now().Sub(start)
+		c.metrics().ObserveRequest(operationFromContext(ctx), 0, elapsed)
+		c.logger().Debug("request failed", "method", req.Method, "path", req.URL.Path,
+			"request_id", req.Header.Get(RequestIDHeader), "duration", elapsed, "error", err)
+		return nil, err
+	}
+	if resp.Request == nil {
+		resp.Request = req
+	}
+	c.dumpResponse(resp)
+	c.traceResponse(ctx, resp.StatusCode)
+	elapsed := clk.Now().Sub(start)
+	c.metrics().ObserveRequest(operationFromContext(ctx), resp.StatusCode, elapsed)
+
+	c.logger().Debug("request completed", "method", req.Method, "path", req.URL.Path,
+		"request_id", responseRequestID(resp), "status", resp.StatusCode, "duration", elapsed)
+	if err := c.interceptResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// doStreamRequest sends an already-encoded JSON body. A non-negative size sets
+// the Content-Length; otherwise the body length is inferred where possible and
+// sent chunked when it isn't.
+func (c *Client) doStreamRequest(ctx context.Context, method, endpoint string, bodyReader io.Reader, size int64) (*http.Response, error) {
+	return c.doBodyRequest(ctx, method, endpoint, "application/json", bodyReader, size)
+}
+
+// doBodyRequest is doStreamRequest for a body of any content type
+func (c *Client) doBodyRequest(ctx context.Context, method, endpoint, contentType string, bodyReader io.Reader, size int64) (*http.Response, error) {
+	url := c.buildURL(endpoint)
+
+	if err := c.checkPayloadSize(bodyReader, size); err != nil {
+		return nil, err
+	}
+
+	// Wait for a rate limit token before the request timeout starts
+	waited, err := c.limiter.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if waited > 0 {
+		c.logger().Debug("rate limited", "method", method, "path", endpoint, "wait", waited)
+	}
+
+	// Apply the configured timeout as a deadline so it holds regardless of the
+	// HTTP client in use; it is released when the response body is closed
+	timeout := c.config.Timeout
+	if callTimeout := callOptionsFromContext(ctx).timeout; callTimeout > 0 {
+		timeout = callTimeout
+	}
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	bodyReader, size, compressed, err := c.compressBody(bodyReader, size)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if closer, ok := bodyReader.(io.Closer); ok && compressed {
+		// Stops the compressor if the body is never fully read
+		defer func() { _ = closer.Close() }()
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if bodyReader != nil && size >= 0 {
+		req.ContentLength = size
+	}
 
 	// Set authentication and headers
-	req.Header.Set("x-api-key", c.config.APIKey)
+	token, err := c.authenticate(ctx, req, "")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
 	req.Header.Set("User-Agent", c.config.UserAgent)
 	req.Header.Set("Accept", "application/json")
-
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, newRequestID(ctx))
+	if c.config.TenantID != "" {
+		req.Header.Set(TenantIDHeader, c.config.TenantID)
+	}
+	if format := callOptionsFromContext(ctx).signatureFormat; format != "" {
+		req.Header.Set(SignatureFormatHeader, string(format))
+	}
+	if key := callOptionsFromContext(ctx).idempotencyKey; key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.config.Compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	c.setDefaultHeaders(req)
+	c.traceRequest(ctx, req)
+
+	resp, err := c.send(ctx, req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && token != "" {
+		// The token may have been revoked early; refresh it and try once more
+		retry, retryErr := c.reauthenticate(ctx, req, token)
+		if retryErr != nil {
+			_ = resp.Body.Close()
+			cancel()
+			return nil, retryErr
+		}
+		if retry != nil {
+			_ = resp.Body.Close()
+			c.logger().Debug("access token rejected, retrying with a new token", "method", method, "path", req.URL.Path)
+			resp, err = c.send(ctx, retry)
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", c.redactError(err, token))
+	}
+	if limit := c.maxResponseSize(); resp.ContentLength > limit {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%w: Content-Length %d exceeds the %d byte limit", ErrResponseTooLarge, resp.ContentLength, limit)
 	}
+	// Not every HTTPClient ties the body to the request context, so close it
+	// when the context ends to unblock a read stalled on a slow server
+	rawBody := resp.Body
+	stop := context.AfterFunc(ctx, func() { _ = rawBody.Close() })
+	decompressResponse(resp)
+	resp.Body = &incompleteBody{ReadCloser: resp.Body, ctx: ctx}
+	// Limit the decompressed size, so a small gzip body can't expand without bound
+	resp.Body = &limitedBody{ReadCloser: resp.Body, limit: c.maxResponseSize()}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, stop: stop, cancel: cancel}
 
 	// Handle HTTP error status codes
 	if resp.StatusCode >= 400 {
-		defer func() {
-			_ = resp.Body.Close()
-		}()
+		return nil, c.redactAPIError(parseErrorResponse(resp, c.clock().Now()), token)
+	}
 
-		apiErr := &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    http.StatusText(resp.StatusCode),
-		}
+	return resp, nil
+}
 
-		// Try to parse structured error response
-		if bodyBytes, err := io.ReadAll(resp.Body); err == nil && len(bodyBytes) > 0 {
-			var errorResp struct {
-				Message   string `json:"message"`
-				Details   string `json:"details"`
-				RequestID string `json:"request_id"`
-				Error     string `json:"error"` // Alternative field name
-			}
-
-			if json.Unmarshal(bodyBytes, &errorResp) == nil {
-				if errorResp.Message != "" {
-					apiErr.Message = errorResp.Message
-				} else if errorResp.Error != "" {
-					apiErr.Message = errorResp.Error
-				}
-				apiErr.Details = errorResp.Details
-				apiErr.RequestID = errorResp.RequestID
-			}
-		}
+// unexpectedStatus describes a response whose status the calling method does
+// not handle, in the same form as the error statuses doBodyRequest rejects
+func (c *Client) unexpectedStatus(resp *http.Response) *APIError {
+	return c.redactAPIError(parseErrorResponse(resp, c.clock().Now()), "")
+}
 
-		return nil, apiErr
+// redactAPIError scrubs credentials from the text of apiErr the server echoed
+// back when Config.RedactErrors is set
+func (c *Client) redactAPIError(apiErr *APIError, token string) *APIError {
+	if c.config.RedactErrors {
+		apiErr.Message = redactSecrets(apiErr.Message, c.config.APIKey, token)
+		apiErr.Details = redactSecrets(apiErr.Details, c.config.APIKey, token)
 	}
-
-	return resp, nil
+	return apiErr
 }
 
 func (c *Client) HealthCheck(ctx context.Context) error {
+	_, err := c.healthCheck(ctx)
+	return err
+}
+
+// HealthCheckStatus checks the API like HealthCheck and also reports the status,
+// version and region the health endpoint returns, along with the request latency
+func (c *Client) HealthCheckStatus(ctx context.Context) (*HealthStatus, error) {
+	return c.healthCheck(ctx)
+}
+
+func (c *Client) healthCheck(ctx context.Context) (_ *HealthStatus, err error) {
+	ctx, span := c.startSpan(ctx, "HealthCheck")
+	defer func() { span.end(err) }()
+
+	start := c.clock().Now()
 	resp, err := c.doRequest(ctx, "GET", API_ENDPOINT_HEALTHCHECK, nil)
 	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+		return nil, fmt.Errorf("health check failed: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	return nil
+	// Older deployments answer with plain text, so the body is best effort
+	var status HealthStatus
+	if body, err := io.ReadAll(resp.Body); err == nil {
+		_ = json.Unmarshal(body, &status)
+	}
+	status.Latency = c.clock().Now().Sub(start)
+
+	return &status, nil
+}
+
+// ServerInfo reports the version, build commit, region and supported SBOM
+// formats of the API deployment, which helps spot a stale or mismatched endpoint
+func (c *Client) ServerInfo(ctx context.Context) (_ *ServerInfo, err error) {
+	ctx, span := c.startSpan(ctx, "ServerInfo")
+	defer func() { span.end(err) }()
+
+	resp, err := c.doRequest(ctx, "GET", API_ENDPOINT_INFO, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server info: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var info ServerInfo
+	if err := decodeJSON(resp.Body, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &info, nil
 }
 
-func (c *Client) ListKeys(ctx context.Context) (*KeyListResponse, error) {
-	resp, err := c.doRequest(ctx, "GET", API_VERSION+API_ENDPOINT_KEYS, nil)
+func (c *Client) ListKeys(ctx context.Context) (_ *KeyListResponse, err error) {
+	ctx, span := c.startSpan(ctx, "ListKeys")
+	defer func() { span.end(err) }()
+
+	result := &KeyListResponse{Keys: []GenerateKeyCMDResponse{}}
+	var opts ListKeysOptions
+	for {
+		page, err := c.listKeysPage(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Keys = append(result.Keys, page.Keys...)
+
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+		if page.NextPageToken == opts.PageToken {
+			return nil, fmt.Errorf("failed to list keys: server repeated page token %q", page.NextPageToken)
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+// ListKeysPaged retrieves a single page of keys. Callers continue the listing by
+// passing the returned NextPageToken until it is empty.
+func (c *Client) ListKeysPaged(ctx context.Context, opts ListKeysOptions) (_ *KeyListResponse, err error) {
+	ctx, span := c.startSpan(ctx, "ListKeysPaged")
+	defer func() { span.end(err) }()
+
+	if opts.PageSize < 0 {
+		return nil, fmt.Errorf("page size must not be negative")
+	}
+	if !opts.CreatedBefore.IsZero() && !opts.CreatedAfter.IsZero() && !opts.CreatedAfter.Before(opts.CreatedBefore) {
+		return nil, fmt.Errorf("created after must be earlier than created before")
+	}
+	if _, err := parseLabelSelector(opts.LabelSelector); err != nil {
+		return nil, err
+	}
+
+	return c.listKeysPage(ctx, opts)
+}
+
+func (c *Client) listKeysPage(ctx context.Context, opts ListKeysOptions) (*KeyListResponse, error) {
+	endpoint := API_VERSION + API_ENDPOINT_KEYS
+	query := url.Values{}
+	if opts.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+	if opts.PageToken != "" {
+		query.Set("page_token", opts.PageToken)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if opts.LabelSelector != "" {
+		query.Set("label_selector", opts.LabelSelector)
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list keys: %w", err)
 	}
@@ -229,47 +672,72 @@ func (c *Client) ListKeys(ctx context.Context) (*KeyListResponse, error) {
 	}()
 
 	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to list keys: %w", c.unexpectedStatus(resp))
 	}
 
-	// Parse as array of API key items
-	var apiKeys []ListKeysAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiKeys); err != nil {
+	var raw json.RawMessage
+	if err := decodeJSON(resp.Body, &raw); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// Convert to GeneratedKey
-	keys := make([]GenerateKeyCMDResponse, len(apiKeys))
-	for i, apiKey := range apiKeys {
-		keys[i] = GenerateKeyCMDResponse{
-			ID:              apiKey.ID,
-			CreatedAt:       apiKey.CreatedAt,
-			Algorithm:       apiKey.Algorithm,
-			Backend:         apiKey.Backend,
-			KMSPath:         apiKey.KMSPath,
-			ProtectionLevel: apiKey.ProtectionLevel,
-			Purpose:         apiKey.Purpose,
+	// Servers without pagination return a bare array of keys
+	var page listKeysPageAPIResponse
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(raw, &page.Keys)
+	} else {
+		err = json.Unmarshal(raw, &page)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Filter locally too, for servers that ignore the status and label
+	// selector parameters and for keys that expired without the server
+	// updating their status. The algorithm and creation date filters are only
+	// ever applied here.
+	keys := make([]GenerateKeyCMDResponse, 0, len(page.Keys))
+	for _, apiKey := range page.Keys {
+		c.rememberKeyUsage(apiKey.ID, apiKey.Usage)
+		if key := apiKey.toKeyInfo(); opts.matches(key) {
+			keys = append(keys, key)
 		}
 	}
 
-	return &KeyListResponse{Keys: keys}, nil
+	return &KeyListResponse{Keys: keys, NextPageToken: page.NextPageToken}, nil
 }
 
 func (c *Client) GenerateKey(ctx context.Context) (*GenerateKeyCMDResponse, error) {
 	// Default behavior: no backend specified → server uses default (HSM/KMS)
-	return c.generateKey(ctx, "")
+	return c.generateKey(ctx, GenerateKeyOptions{})
 }
 
 func (c *Client) GenerateKeyWithBackend(ctx context.Context, backend string) (*GenerateKeyCMDResponse, error) {
-	return c.generateKey(ctx, backend)
+	return c.generateKey(ctx, GenerateKeyOptions{Backend: backend})
 }
 
-func (c *Client) generateKey(ctx context.Context, backend string) (*GenerateKeyCMDResponse, error) {
+// GenerateKeyWithOptions creates a key with a specific backend and/or algorithm.
+// Unknown algorithms are rejected with ErrUnsupportedAlgorithm before calling the API.
+func (c *Client) GenerateKeyWithOptions(ctx context.Context, opts GenerateKeyOptions) (*GenerateKeyCMDResponse, error) {
+	return c.generateKey(ctx, opts)
+}
+
+func (c *Client) generateKey(ctx context.Context, opts GenerateKeyOptions) (_ *GenerateKeyCMDResponse, err error) {
+	ctx, span := c.startSpan(ctx, "GenerateKey",
+		attribute.String("sbom.key_backend", opts.Backend),
+		attribute.String("sbom.algorithm", opts.Algorithm))
+	defer func() { span.end(err) }()
+
+	if err := validateAlgorithm(opts.Algorithm); err != nil {
+		return nil, err
+	}
+	if err := validateLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+
 	var body interface{}
 
-	if backend != "" {
-		body = generateKeyRequest{Backend: backend}
+	if opts.Backend != "" || opts.Algorithm != "" || len(opts.Labels) > 0 {
+		body = generateKeyRequest{Backend: opts.Backend, Algorithm: opts.Algorithm, Labels: opts.Labels}
 	} else {
 		body = nil
 	}
@@ -283,14 +751,14 @@ func (c *Client) generateKey(ctx context.Context, backend string) (*GenerateKeyC
 	}()
 
 	if resp.StatusCode != http.StatusCreated {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("failed to generate key: %w", c.unexpectedStatus(resp))
 	}
 
 	var apiResp GenerateKeyAPIReponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decodeJSON(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	c.rememberKeyUsage(apiResp.KeyID, apiResp.Usage)
 
 	return &GenerateKeyCMDResponse{
 		ID:              apiResp.KeyID,
@@ -300,15 +768,58 @@ func (c *Client) generateKey(ctx context.Context, backend string) (*GenerateKeyC
 		Backend:         apiResp.Backend,
 		ProtectionLevel: apiResp.ProtectionLevel,
 		Purpose:         apiResp.Purpose,
+		Usage:           apiResp.Usage,
+		Status:          keyStatus(apiResp.Status, apiResp.ExpiresAt),
+		ExpiresAt:       apiResp.ExpiresAt,
+		Labels:          apiResp.Labels,
 	}, nil
 }
 
+// GetKey retrieves the metadata for a single key without listing every key.
+// A missing key is reported as ErrKeyNotFound.
+func (c *Client) GetKey(ctx context.Context, keyID string) (_ *GenerateKeyCMDResponse, err error) {
+	ctx, span := c.startSpan(ctx, "GetKey", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+
+	endpoint := API_VERSION + API_ENDPOINT_KEYS + "/" + url.PathEscape(keyID)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get key %s: %w", keyID, ErrKeyNotFound)
+		}
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var apiKey ListKeysAPIResponse
+	if err := decodeJSON(resp.Body, &apiKey); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.rememberKeyUsage(apiKey.ID, apiKey.Usage)
+	key := apiKey.toKeyInfo()
+	return &key, nil
+}
+
 // GetPublicKey retrieves the public key for a specific key ID
-func (c *Client) GetPublicKey(ctx context.Context, keyID string) (string, error) {
+func (c *Client) GetPublicKey(ctx context.Context, keyID string) (_ string, err error) {
+	ctx, span := c.startSpan(ctx, "GetPublicKey", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
 	if keyID == "" {
 		return "", fmt.Errorf("keyID is required")
 	}
 
+	if publicKey, ok := c.publicKeys.get(keyID); ok {
+		return publicKey, nil
+	}
+
 	endpoint := API_VERSION + API_ENDPOINT_KEYS + "/public?key_id=" + keyID
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
@@ -319,8 +830,7 @@ func (c *Client) GetPublicKey(ctx context.Context, keyID string) (string, error)
 	}()
 
 	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("failed to get public key: %w", c.unexpectedStatus(resp))
 	}
 
 	// Read the PEM content as plain text
@@ -329,19 +839,114 @@ func (c *Client) GetPublicKey(ctx context.Context, keyID string) (string, error)
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	c.publicKeys.set(keyID, string(body))
 	return string(body), nil
 }
 
-func (c *Client) SignSBOM(ctx context.Context, keyID string, sbom interface{}) (*SignResultAPIResponseV2, error) {
+// InvalidatePublicKey drops any cached public key for keyID, for example after
+// the key has been rotated. It is a no-op when the cache is disabled.
+func (c *Client) InvalidatePublicKey(keyID string) {
+	c.publicKeys.invalidate(keyID)
+}
+
+// InvalidateVerifyCache drops every cached verification result, for example
+// after a key has been revoked. It is a no-op when the cache is disabled.
+func (c *Client) InvalidateVerifyCache() {
+	c.verified.clear()
+}
+
+// DeleteKey deletes the key with the given ID. A key that does not exist is
+// reported as ErrKeyNotFound so callers can treat it as already deleted.
+func (c *Client) DeleteKey(ctx context.Context, keyID string) (err error) {
+	ctx, span := c.startSpan(ctx, "DeleteKey", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return fmt.Errorf("keyID is required")
+	}
+
+	endpoint := API_VERSION + API_ENDPOINT_KEYS + "/" + url.PathEscape(keyID)
+	c.publicKeys.invalidate(keyID)
+	c.verified.invalidateKey(keyID)
+	c.forgetKeyUsage(keyID)
+
+	resp, err := c.doRequest(ctx, HTTP_METHOD_DELETE, endpoint, nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return fmt.Errorf("failed to delete key %s: %w", keyID, ErrKeyNotFound)
+		}
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	return nil
+}
+
+// ImportPublicKey registers the public half of a key generated outside
+// SecureSBOM, for example in your own HSM, so SBOMs signed with it can be
+// verified by key ID. publicKeyPEM must be a PEM-encoded PKIX Ed25519, ECDSA
+// P-256/P-384 or RSA 2048/4096 public key; algorithm names the matching
+// Algorithm constant, or is empty to infer it from the key. Keys that don't
+// parse or don't match algorithm fail with ErrInvalidPublicKey before a
+// request is made, and an ID that is already taken fails with ErrKeyExists.
+func (c *Client) ImportPublicKey(ctx context.Context, keyID, publicKeyPEM, algorithm string) (_ *GenerateKeyCMDResponse, err error) {
+	ctx, span := c.startSpan(ctx, "ImportPublicKey",
+		attribute.String("sbom.key_id", keyID),
+		attribute.String("sbom.algorithm", algorithm))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+	if err := validateAlgorithm(algorithm); err != nil {
+		return nil, err
+	}
+	algorithm, err = importedKeyAlgorithm(publicKeyPEM, algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	body := importKeyRequest{ID: keyID, PublicKey: publicKeyPEM, Algorithm: algorithm}
+	resp, err := c.doRequest(ctx, HTTP_METHOD_POST, API_VERSION+API_ENDPOINT_KEYS+"/import", body)
+	if err != nil {
+		if hasStatus(err, http.StatusConflict) {
+			return nil, fmt.Errorf("failed to import key %s: %w", keyID, ErrKeyExists)
+		}
+		return nil, fmt.Errorf("failed to import key: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var apiKey ListKeysAPIResponse
+	if err := decodeJSON(resp.Body, &apiKey); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.rememberKeyUsage(apiKey.ID, apiKey.Usage)
+	key := apiKey.toKeyInfo()
+	key.PublicKey = publicKeyPEM
+	c.publicKeys.invalidate(keyID)
+	c.verified.invalidateKey(keyID)
+	return &key, nil
+}
+
+func (c *Client) SignSBOM(ctx context.Context, keyID string, sbom interface{}, callOpts ...CallOption) (*SignResultAPIResponseV2, error) {
 	// Default behavior: embedded signature, no extras
-	return c.signSBOM(ctx, keyID, sbom, SignOptions{})
+	return c.signSBOM(withIdempotencyKey(withCallOptions(ctx, callOpts)), keyID, sbom, SignOptions{})
 }
 
-func (c *Client) SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts SignOptions) (*SignResultAPIResponseV2, error) {
-	return c.signSBOM(ctx, keyID, sbom, opts)
+func (c *Client) SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts SignOptions, callOpts ...CallOption) (*SignResultAPIResponseV2, error) {
+	return c.signSBOM(withIdempotencyKey(withCallOptions(ctx, callOpts)), keyID, sbom, opts)
 }
 
-func (c *Client) SignDigest(ctx context.Context, req SignDigestRequest) (*SignDigestResponse, error) {
+func (c *Client) SignDigest(ctx context.Context, req SignDigestRequest, callOpts ...CallOption) (_ *SignDigestResponse, err error) {
+	ctx = withIdempotencyKey(withCallOptions(ctx, callOpts))
+	ctx, span := c.startSpan(ctx, "SignDigest", attribute.String("sbom.key_id", req.KeyID))
+	defer func() { span.end(err) }()
+
 	if req.KeyID == "" {
 		return nil, fmt.Errorf("keyID is required")
 	}
@@ -351,6 +956,9 @@ func (c *Client) SignDigest(ctx context.Context, req SignDigestRequest) (*SignDi
 	if req.HashAlgorithm == "" {
 		return nil, fmt.Errorf("hashAlgorithm is required")
 	}
+	if err := c.checkSigningKey(ctx, req.KeyID); err != nil {
+		return nil, err
+	}
 
 	endpoint := API_VERSION + API_ENDPOING_DIGEST + "/sign"
 
@@ -363,63 +971,371 @@ func (c *Client) SignDigest(ctx context.Context, req SignDigestRequest) (*SignDi
 	}()
 
 	var result SignDigestResponse
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	err = decodeJSON(resp.Body, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode digest sign response: %w", err)
 	}
+	result.RequestID = responseRequestID(resp)
 
 	return &result, nil
 }
 
-func (c *Client) signSBOM(ctx context.Context, keyID string, sbom interface{}, opts SignOptions) (*SignResultAPIResponseV2, error) {
+func (c *Client) signSBOM(ctx context.Context, keyID string, sbom interface{}, opts SignOptions) (_ *SignResultAPIResponseV2, err error) {
+	ctx, span := c.startSpan(ctx, "SignSBOM",
+		attribute.String("sbom.key_id", keyID),
+		attribute.String("sbom.format", detectSBOMFormat(sbom)))
+	defer func() { span.end(err) }()
+
 	if keyID == "" {
 		return nil, fmt.Errorf("keyID is required")
 	}
 	if sbom == nil {
 		return nil, fmt.Errorf("sbom is required")
 	}
+	if err := validateAlgorithm(opts.Algorithm); err != nil {
+		return nil, err
+	}
+	if err := c.checkTimestamping(); err != nil {
+		return nil, err
+	}
+	ctx, format, err := c.signatureFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSigningKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+	// A raw SBOM is sent unparsed, so it has no local digest
+	var digest string
+	if _, ok := rawPayload(sbom); !ok {
+		if digest, err = sbomDigest(sbom, crypto.SHA256); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := API_VERSION_V2 + API_ENDPOINT_SBOM + "/sign"
+
+	var resp *http.Response
+	if payload, ok := tagValuePayload(sbom); ok {
+		// A tag-value document is the whole body, so the options go in the query
+		query := url.Values{}
+		query.Set("key_id", keyID)
+		if opts.Pretty {
+			query.Set("pretty", "true")
+		}
+		if opts.Detached {
+			query.Set("detached", "true")
+		}
+		if opts.Algorithm != "" {
+			query.Set("algorithm", opts.Algorithm)
+		}
+		if c.config.Timestamping {
+			query.Set("timestamp", "true")
+		}
+		resp, err = c.doBodyRequest(ctx, http.MethodPost, endpoint+"?"+query.Encode(),
+			ContentTypeSPDXTagValue, bytes.NewReader(payload), int64(len(payload)))
+	} else {
+		reqBody, encodeErr := encodeSBOMRequest(struct {
+			KeyID     string `json:"key_id"`
+			Pretty    bool   `json:"pretty,omitempty"`
+			Detached  bool   `json:"detached,omitempty"`
+			Algorithm string `json:"algorithm,omitempty"`
+			Timestamp bool   `json:"timestamp,omitempty"`
+		}{
+			KeyID:     keyID,
+			Pretty:    opts.Pretty,
+			Detached:  opts.Detached,
+			Algorithm: opts.Algorithm,
+			Timestamp: c.config.Timestamping,
+		}, sbom)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		resp, err = c.doStreamRequest(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody), int64(len(reqBody)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SBOM: %w", signatureFormatError(err, format))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var result SignResultAPIResponseV2
+	err = decodeJSON(resp.Body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sign response: %w", err)
+	}
+	if err := c.checkTimestampToken(&result); err != nil {
+		return nil, err
+	}
+	if err := checkSignatureFormat(resp, &result, format); err != nil {
+		return nil, err
+	}
+	result.SBOMDigest = digest
+	result.RequestID = responseRequestID(resp)
+	if result.KeyID == "" {
+		result.KeyID = keyID
+	}
+
+	return &result, nil
+}
+
+// SignSBOMFromReader signs a JSON SBOM read from r without holding the document
+// in memory: the request body is streamed to the API as it is read. size is the
+// exact length of the document in bytes, or -1 if unknown, in which case the
+// body is sent with chunked encoding. The SBOM is not parsed or validated
+// locally.
+//
+// The signed SBOM in the response is still decoded into memory, so peak usage
+// is roughly one copy of the signed document rather than the three (input,
+// request body, response) needed by SignSBOM. When used through a
+// RetryingClient, r must also implement io.Seeker to be retried; other readers
+// get a single attempt.
+func (c *Client) SignSBOMFromReader(ctx context.Context, keyID string, r io.Reader, size int64) (_ *SignResultAPIResponseV2, err error) {
+	ctx = withIdempotencyKey(ctx)
+	ctx, span := c.startSpan(ctx, "SignSBOMFromReader", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+	if r == nil {
+		return nil, fmt.Errorf("sbom reader is required")
+	}
+
+	if err := c.checkTimestamping(); err != nil {
+		return nil, err
+	}
+	ctx, format, err := c.signatureFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSigningKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+	body, contentLength, err := signRequestBody(keyID, c.config.Timestamping, r, size)
+	if err != nil {
+		return nil, err
+	}
 
 	endpoint := API_VERSION_V2 + API_ENDPOINT_SBOM + "/sign"
+	resp, err := c.doStreamRequest(ctx, http.MethodPost, endpoint, body, contentLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SBOM: %w", signatureFormatError(err, format))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
 
-	reqBody := struct {
-		KeyID    string      `json:"key_id"`
-		SBOM     interface{} `json:"sbom"`
-		Pretty   bool        `json:"pretty,omitempty"`
-		Detached bool        `json:"detached,omitempty"`
-	}{
-		KeyID:    keyID,
-		SBOM:     sbom,
-		Pretty:   opts.Pretty,
-		Detached: opts.Detached,
+	var result SignResultAPIResponseV2
+	if err := decodeJSON(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode sign response: %w", err)
+	}
+	if err := c.checkTimestampToken(&result); err != nil {
+		return nil, err
+	}
+	if err := checkSignatureFormat(resp, &result, format); err != nil {
+		return nil, err
+	}
+	result.RequestID = responseRequestID(resp)
+	if result.KeyID == "" {
+		result.KeyID = keyID
+	}
+
+	return &result, nil
+}
+
+// SignSBOMToWriter signs a JSON SBOM and streams the signed document to w as
+// the response arrives, so the signed SBOM is never held in memory. The
+// returned result carries the signature metadata; its SignedSBOM is empty.
+//
+// sbom is checked to be JSON but is not parsed, so SBOMDigest is empty too.
+// When used through a RetryingClient, a failed attempt is retried only if
+// nothing has been written to w yet.
+func (c *Client) SignSBOMToWriter(ctx context.Context, keyID string, sbom []byte, w io.Writer) (_ *SignResultAPIResponseV2, err error) {
+	ctx = withIdempotencyKey(ctx)
+	ctx, span := c.startSpan(ctx, "SignSBOMToWriter", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+	if len(sbom) == 0 {
+		return nil, fmt.Errorf("sbom is required")
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	if err := checkNotXML(sbom); err != nil {
+		return nil, err
+	}
+	if !json.Valid(sbom) {
+		return nil, fmt.Errorf("sbom is not valid JSON")
+	}
+
+	if err := c.checkTimestamping(); err != nil {
+		return nil, err
+	}
+	ctx, format, err := c.signatureFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkSigningKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+	body, contentLength, err := signRequestBody(keyID, c.config.Timestamping, bytes.NewReader(sbom), int64(len(sbom)))
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, reqBody)
+	endpoint := API_VERSION_V2 + API_ENDPOINT_SBOM + "/sign"
+	resp, err := c.doStreamRequest(ctx, http.MethodPost, endpoint, body, contentLength)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign SBOM: %w", err)
+		return nil, fmt.Errorf("failed to sign SBOM: %w", signatureFormatError(err, format))
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
 	var result SignResultAPIResponseV2
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err := streamSignResponse(resp.Body, w, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode sign response: %w", err)
+	}
+	if err := c.checkTimestampToken(&result); err != nil {
+		return nil, err
+	}
+	if err := checkSignatureFormat(resp, &result, format); err != nil {
+		return nil, err
+	}
+	result.RequestID = responseRequestID(resp)
+	if result.KeyID == "" {
+		result.KeyID = keyID
+	}
+
+	return &result, nil
+}
+
+// SignBytes signs an arbitrary payload, such as a deployment manifest, with the
+// same keys used for SBOMs. data is sent as-is with the given Content-Type and
+// is not parsed, and the result holds a detached signature in SignatureB64.
+func (c *Client) SignBytes(ctx context.Context, keyID string, data []byte, contentType string) (_ *SignResultAPIResponseV2, err error) {
+	ctx = withIdempotencyKey(ctx)
+	ctx, span := c.startSpan(ctx, "SignBytes",
+		attribute.String("sbom.key_id", keyID),
+		attribute.String("content_type", contentType))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data is required")
+	}
+	if contentType == "" {
+		return nil, fmt.Errorf("contentType is required")
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+	if err := c.checkSigningKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("key_id", keyID)
+	endpoint := API_VERSION_V2 + API_ENDPOINT_BLOB + "/sign?" + query.Encode()
+	resp, err := c.doBodyRequest(ctx, http.MethodPost, endpoint, contentType, bytes.NewReader(data), int64(len(data)))
 	if err != nil {
+		return nil, fmt.Errorf("failed to sign data: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var result SignResultAPIResponseV2
+	if err := decodeJSON(resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode sign response: %w", err)
 	}
+	result.RequestID = responseRequestID(resp)
+	if result.KeyID == "" {
+		result.KeyID = keyID
+	}
 
 	return &result, nil
 }
 
-// VerifySBOM verifies a signed SBOM using the specified key
-func (c *Client) VerifySBOM(ctx context.Context, req VerifyCMDRequest) (*VerifyResultCMDResponse, error) {
+// encodeSBOMRequest encodes envelope, which must encode as a JSON object, with
+// sbom added as its "sbom" member. SBOMs given as bytes, or as an *SBOM loaded
+// from a reader, are embedded exactly as given rather than decoded and
+// re-encoded, so the API sees the caller's key order and formatting.
+func encodeSBOMRequest(envelope interface{}, sbom interface{}) ([]byte, error) {
+	payload, ok := rawPayload(sbom)
+	if !ok {
+		var err error
+		if payload, _, err = marshalSBOM(sbom); err != nil {
+			return nil, err
+		}
+	}
+	encoded, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	body := make([]byte, 0, len(encoded)+len(payload)+len(`,"sbom":`))
+	body = append(body, encoded[:len(encoded)-1]...)
+	if len(encoded) > len("{}") {
+		body = append(body, ',')
+	}
+	body = append(body, `"sbom":`...)
+	body = append(body, payload...)
+	return append(body, '}'), nil
+}
+
+// signRequestBody wraps a JSON document read from r in the sign request
+// envelope without decoding it, returning the body and its length (-1 when
+// size is unknown)
+func signRequestBody(keyID string, timestamp bool, r io.Reader, size int64) (io.Reader, int64, error) {
+	encodedKeyID, err := json.Marshal(keyID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	fields := `{"key_id":` + string(encodedKeyID)
+	if timestamp {
+		fields += `,"timestamp":true`
+	}
+	prefix := []byte(fields + `,"sbom":`)
+	suffix := []byte(`}`)
+	body := io.MultiReader(bytes.NewReader(prefix), r, bytes.NewReader(suffix))
+
+	contentLength := int64(-1)
+	if size >= 0 {
+		contentLength = int64(len(prefix)) + size + int64(len(suffix))
+	}
+	return body, contentLength, nil
+}
+
+// VerifySBOM verifies a signed SBOM using the specified key. When the service
+// rejects the request with an error code a VerifyReason names, such as
+// INVALID_SIGNATURE or KEY_NOT_FOUND, the *APIError comes back together with
+// an invalid result whose Reason says why; other errors return a nil result.
+func (c *Client) VerifySBOM(ctx context.Context, req VerifyCMDRequest, callOpts ...CallOption) (_ *VerifyResultCMDResponse, err error) {
+	ctx = withCallOptions(ctx, callOpts)
+	ctx, span := c.startSpan(ctx, "VerifySBOM",
+		attribute.String("sbom.key_id", req.KeyID),
+		attribute.String("sbom.format", detectSBOMFormat(req.SBOM)))
+	defer func() { span.end(err) }()
+
 	if req.KeyID == "" {
 		return nil, fmt.Errorf("keyID is required")
 	}
 	if req.SBOM == nil {
 		return nil, fmt.Errorf("sbom is required for verification")
 	}
-
-	endpoint := fmt.Sprintf(API_VERSION_V2 + API_ENDPOINT_SBOM + "/verify")
+	digest, metadata, err := verifiedDocument(req.SBOM)
+	if err != nil {
+		return nil, err
+	}
 
 	reqBody := VerifyAPIRequestV2{
 		KeyID: req.KeyID,
@@ -430,9 +1346,100 @@ func (c *Client) VerifySBOM(ctx context.Context, req VerifyCMDRequest) (*VerifyR
 		reqBody.SignatureB64 = req.SignatureB64
 	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, reqBody)
+	cacheKey := verifyCacheKey{keyID: req.KeyID, digest: digest, signatureB64: req.SignatureB64}
+	if result, ok := c.verified.get(cacheKey); ok {
+		c.logger().Debug("using cached verification result", "key_id", req.KeyID, "sbom_digest", digest)
+		return result, nil
+	}
+
+	result, err := c.verify(ctx, reqBody, digest, metadata)
+	if err == nil && result.Valid {
+		c.verified.set(cacheKey, result)
+	}
+	return result, err
+}
+
+// VerifyWithPublicKey verifies a signed SBOM against a caller-supplied public
+// key rather than one looked up by key ID, for SBOMs signed with keys that are
+// not registered with SecureSBOM. publicKeyPEM must be a PEM-encoded PKIX
+// public key; anything else fails with ErrInvalidPublicKey before a request is
+// made.
+func (c *Client) VerifyWithPublicKey(ctx context.Context, publicKeyPEM string, sbom []byte) (_ *VerifyResultCMDResponse, err error) {
+	ctx, span := c.startSpan(ctx, "VerifyWithPublicKey",
+		attribute.String("sbom.format", detectSBOMFormat(json.RawMessage(sbom))))
+	defer func() { span.end(err) }()
+
+	if err := checkPublicKeyPEM(publicKeyPEM); err != nil {
+		return nil, err
+	}
+	if len(sbom) == 0 {
+		return nil, fmt.Errorf("sbom is required for verification")
+	}
+	digest, metadata, err := verifiedDocument(json.RawMessage(sbom))
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify SBOM: %w", err)
+		return nil, err
+	}
+
+	return c.verify(ctx, VerifyAPIRequestV2{
+		SBOM:      json.RawMessage(sbom),
+		PublicKey: publicKeyPEM,
+	}, digest, metadata)
+}
+
+// SignSBOMFromFile loads the SBOM at path, or stdin when path is "-", and signs
+// it. CycloneDX documents get an embedded signature; SPDX documents are signed
+// detached, with the signature in the result's SignatureB64, since they have
+// nowhere to embed one. Other documents fail with ErrUnsupportedFormat.
+func (c *Client) SignSBOMFromFile(ctx context.Context, keyID, path string) (*SignResultAPIResponseV2, error) {
+	return signSBOMFromFile(ctx, c, keyID, path)
+}
+
+// VerifySBOMFromFile loads the SBOM at path, or stdin when path is "-", and
+// verifies it. A detached signature at path + ".sig" is used when present, as
+// written by the sign example's -detached flag; otherwise the signature must be
+// embedded, for SPDX documents by EmbedSPDXSignature.
+func (c *Client) VerifySBOMFromFile(ctx context.Context, keyID, path string) (*VerifyResultCMDResponse, error) {
+	return verifySBOMFromFile(ctx, c, keyID, path)
+}
+
+// verify posts a verify request and converts the response; digest and
+// metadata come from verifiedDocument
+func (c *Client) verify(ctx context.Context, reqBody VerifyAPIRequestV2, digest string, metadata *SBOMMetadata) (*VerifyResultCMDResponse, error) {
+	endpoint := API_VERSION_V2 + API_ENDPOINT_SBOM + "/verify"
+
+	var resp *http.Response
+	var err error
+	if payload, ok := tagValuePayload(reqBody.SBOM); ok {
+		// A tag-value document is the whole body, so the fields go in the query
+		query := url.Values{}
+		if reqBody.KeyID != "" {
+			query.Set("key_id", reqBody.KeyID)
+		}
+		if reqBody.SignatureB64 != "" {
+			query.Set("signature_b64", reqBody.SignatureB64)
+		}
+		if reqBody.PublicKey != "" {
+			query.Set("public_key", reqBody.PublicKey)
+		}
+		resp, err = c.doBodyRequest(ctx, http.MethodPost, endpoint+"?"+query.Encode(),
+			ContentTypeSPDXTagValue, bytes.NewReader(payload), int64(len(payload)))
+	} else {
+		body, encodeErr := encodeSBOMRequest(struct {
+			KeyID        string `json:"key_id,omitempty"`
+			SignatureB64 string `json:"signature_b64"`
+			PublicKey    string `json:"public_key,omitempty"`
+		}{
+			KeyID:        reqBody.KeyID,
+			SignatureB64: reqBody.SignatureB64,
+			PublicKey:    reqBody.PublicKey,
+		}, reqBody.SBOM)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		resp, err = c.doStreamRequest(ctx, http.MethodPost, endpoint, bytes.NewReader(body), int64(len(body)))
+	}
+	if err != nil {
+		return verifyFailure(err, reqBody.KeyID, c.clock().Now(), digest, metadata), fmt.Errorf("failed to verify SBOM: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -446,31 +1453,47 @@ func (c *Client) VerifySBOM(ctx context.Context, req VerifyCMDRequest) (*VerifyR
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var apiResp VerifyResultAPIResponseV2
-		err = json.Unmarshal(bodyBytes, &apiResp)
+		err = unmarshalJSON(bodyBytes, &apiResp)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode success response: %w", err)
 		}
 
-		return &VerifyResultCMDResponse{
-			Valid:     true,
-			Code:      apiResp.Code,
-			Message:   apiResp.Message,
-			KeyID:     reqBody.KeyID,
-			Timestamp: time.Now(),
-		}, nil
+		result := &VerifyResultCMDResponse{
+			Valid:                true,
+			Code:                 apiResp.Code,
+			Message:              apiResp.Message,
+			KeyID:                reqBody.KeyID,
+			Algorithm:            apiResp.Algorithm,
+			Timestamp:            c.clock().Now(),
+			PublicKeyFingerprint: apiResp.PublicKeyFingerprint,
+			CertificateChain:     apiResp.CertificateChain,
+			SBOMDigest:           digest,
+			SBOMMetadata:         metadata,
+			RequestID:            responseRequestID(resp),
+			Warnings:             apiResp.Warnings,
+		}
+		return result, checkAllowedAlgorithm(result, c.config.AllowedAlgorithms)
 	default:
 		var apiResp VerifyResultAPIResponseV2
-		err = json.Unmarshal(bodyBytes, &apiResp)
+		err = unmarshalJSON(bodyBytes, &apiResp)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
+			return nil, fmt.Errorf("failed to verify SBOM: %w", c.redactAPIError(newAPIError(resp, bodyBytes, c.clock().Now()), ""))
 		}
 
 		return &VerifyResultCMDResponse{
-			Valid:     false,
-			Code:      apiResp.Code,
-			Message:   apiResp.Message,
-			KeyID:     reqBody.KeyID,
-			Timestamp: time.Now(),
+			Valid:                false,
+			Code:                 apiResp.Code,
+			Message:              apiResp.Message,
+			Reason:               verifyReason(resp.StatusCode, apiResp.Code),
+			KeyID:                reqBody.KeyID,
+			Algorithm:            apiResp.Algorithm,
+			Timestamp:            c.clock().Now(),
+			PublicKeyFingerprint: apiResp.PublicKeyFingerprint,
+			CertificateChain:     apiResp.CertificateChain,
+			SBOMDigest:           digest,
+			SBOMMetadata:         metadata,
+			RequestID:            responseRequestID(resp),
+			Warnings:             apiResp.Warnings,
 		}, nil
 	}
 }
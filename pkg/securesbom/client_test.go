@@ -19,11 +19,23 @@ package securesbom
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -40,6 +52,27 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return nil, fmt.Errorf("DoFunc not implemented")
 }
 
+// newTestClient builds a Client with NewClient whose requests go to doFunc. Its
+// config has an API key and https://api.example.com as the base URL, and
+// configure, when not nil, adjusts it first, e.g. to enable compression.
+func newTestClient(t *testing.T, doFunc func(req *http.Request) (*http.Response, error), configure func(config *Config)) *Client {
+	t.Helper()
+
+	config := &Config{
+		APIKey:     "test-key",
+		BaseURL:    "https://api.example.com",
+		HTTPClient: &MockHTTPClient{DoFunc: doFunc},
+	}
+	if configure != nil {
+		configure(config)
+	}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
 // Helper function to create a mock HTTP response
 func createMockResponse(statusCode int, body interface{}) *http.Response {
 	var bodyReader io.ReadCloser
@@ -103,6 +136,15 @@ func TestNewClient(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid BaseURL",
 		},
+		{
+			name: "base URL without scheme",
+			config: &Config{
+				APIKey:  "test-key",
+				BaseURL: "api.example.com",
+			},
+			expectError: true,
+			errorMsg:    "scheme must be http or https",
+		},
 		{
 			name: "negative timeout",
 			config: &Config{
@@ -169,13 +211,73 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
-func TestClient_buildURL(t *testing.T) {
-	client := &Client{
-		config: &Config{
-			BaseURL: "https://api.example.com",
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient_Transport(t *testing.T) {
+	calls := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return createMockResponse(200, "OK"), nil
+	})
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithTransport(transport).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected custom transport to be used once, got %d calls", calls)
+	}
+}
+
+func TestClient_TimeoutAppliesToInjectedClient(t *testing.T) {
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if _, ok := req.Context().Deadline(); !ok {
+				t.Error("expected request context to carry a deadline")
+			}
+			<-req.Context().Done()
+			return nil, req.Context().Err()
 		},
 	}
 
+	client, err := NewClient(&Config{
+		APIKey:     "test-key",
+		BaseURL:    "https://api.example.com",
+		HTTPClient: mockClient,
+		Timeout:    20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	err = client.HealthCheck(context.Background())
+	if err == nil {
+		t.Fatal("expected timeout error but got none")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected request to time out promptly, took %v", elapsed)
+	}
+}
+
+func TestClient_buildURL(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+
 	tests := []struct {
 		name     string
 		baseURL  string
@@ -206,6 +308,12 @@ func TestClient_buildURL(t *testing.T) {
 			endpoint: "/v0/keys",
 			expected: "https://api.example.com/v0/keys",
 		},
+		{
+			name:     "base URL with path prefix",
+			baseURL:  "https://gateway.example.com/api/v2",
+			endpoint: "/v0/keys",
+			expected: "https://gateway.example.com/api/v2/v0/keys",
+		},
 	}
 
 	for _, tt := range tests {
@@ -219,6 +327,30 @@ func TestClient_buildURL(t *testing.T) {
 	}
 }
 
+func TestNewClient_BaseURLPathPrefix(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/gateway/securesbom/"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if want := server.URL + "/gateway/securesbom"; client.config.BaseURL != want {
+		t.Errorf("BaseURL = %q, want %q", client.config.BaseURL, want)
+	}
+
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if want := "/gateway/securesbom" + API_ENDPOINT_HEALTHCHECK; gotPath != want {
+		t.Errorf("request path = %q, want %q", gotPath, want)
+	}
+}
+
 func TestClient_doRequest(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -281,38 +413,27 @@ func TestClient_doRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					// Verify request headers
-					if req.Header.Get("x-api-key") != "test-key" {
-						t.Error("expected x-api-key header to be set")
-					}
-					if req.Header.Get("User-Agent") != UserAgent {
-						t.Error("expected User-Agent header to be set")
-					}
-					if req.Header.Get("Accept") != "application/json" {
-						t.Error("expected Accept header to be set")
-					}
-
-					if tt.body != nil && req.Header.Get("Content-Type") != "application/json" {
-						t.Error("expected Content-Type header to be set for requests with body")
-					}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				// Verify request headers
+				if req.Header.Get("x-api-key") != "test-key" {
+					t.Error("expected x-api-key header to be set")
+				}
+				if req.Header.Get("User-Agent") != UserAgent {
+					t.Error("expected User-Agent header to be set")
+				}
+				if req.Header.Get("Accept") != "application/json" {
+					t.Error("expected Accept header to be set")
+				}
 
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+				if tt.body != nil && req.Header.Get("Content-Type") != "application/json" {
+					t.Error("expected Content-Type header to be set for requests with body")
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			resp, err := client.doRequest(ctx, tt.method, tt.endpoint, tt.body)
@@ -377,31 +498,20 @@ func TestClient_HealthCheck(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					expectedURL := "https://api.example.com/infra/healthcheck"
-					if req.URL.String() != expectedURL {
-						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-					}
-					if req.Method != "GET" {
-						t.Errorf("expected GET method, got %q", req.Method)
-					}
-
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/infra/healthcheck"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
+				if req.Method != "GET" {
+					t.Errorf("expected GET method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			err := client.HealthCheck(ctx)
@@ -419,6 +529,119 @@ func TestClient_HealthCheck(t *testing.T) {
 	}
 }
 
+func TestClient_HealthCheckStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockResponse *http.Response
+		expectError  bool
+		expected     HealthStatus
+	}{
+		{
+			name:         "JSON status",
+			mockResponse: createMockResponse(200, map[string]string{"status": "ok", "version": "2.4.1", "region": "us-central1"}),
+			expected:     HealthStatus{Status: "ok", Version: "2.4.1", Region: "us-central1"},
+		},
+		{
+			name:         "plain text status",
+			mockResponse: createMockResponse(200, "OK"),
+		},
+		{
+			name:         "unhealthy",
+			mockResponse: createMockResponse(503, "Service Unavailable"),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				return tt.mockResponse, nil
+			}, nil)
+
+			status, err := client.HealthCheckStatus(context.Background())
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.Latency <= 0 {
+				t.Error("expected latency to be recorded")
+			}
+			status.Latency = 0
+			if *status != tt.expected {
+				t.Errorf("expected status %+v, got %+v", tt.expected, *status)
+			}
+		})
+	}
+}
+
+func TestClient_ServerInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockResponse *http.Response
+		expectError  bool
+		expected     *ServerInfo
+	}{
+		{
+			name: "successful request",
+			mockResponse: createMockResponse(200, map[string]interface{}{
+				"version":           "2.4.1",
+				"commit":            "abc1234",
+				"region":            "us-central1",
+				"supported_formats": []string{"cyclonedx", "spdx"},
+			}),
+			expected: &ServerInfo{
+				Version:          "2.4.1",
+				Commit:           "abc1234",
+				Region:           "us-central1",
+				SupportedFormats: []string{"cyclonedx", "spdx"},
+			},
+		},
+		{
+			name:         "invalid JSON response",
+			mockResponse: createMockResponse(200, "invalid json"),
+			expectError:  true,
+		},
+		{
+			name:         "API error response",
+			mockResponse: createMockResponse(404, map[string]string{"error": "not found"}),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/infra/info"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			info, err := client.ServerInfo(context.Background())
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(info, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, info)
+			}
+		})
+	}
+}
+
 func TestClient_ListKeys(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -459,31 +682,20 @@ func TestClient_ListKeys(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					expectedURL := "https://api.example.com/api/v1/keys"
-					if req.URL.String() != expectedURL {
-						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-					}
-					if req.Method != "GET" {
-						t.Errorf("expected GET method, got %q", req.Method)
-					}
-
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/api/v1/keys"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
+				if req.Method != "GET" {
+					t.Errorf("expected GET method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			result, err := client.ListKeys(ctx)
@@ -509,6 +721,184 @@ func TestClient_ListKeys(t *testing.T) {
 	}
 }
 
+func TestClient_ListKeys_Paginated(t *testing.T) {
+	var requests int
+	keyIDs := []string{"key-1", "key-2", "key-3"}
+	client := newPagedKeysClient(t, keyIDs, 2, &requests)
+
+	result, err := client.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Keys) != len(keyIDs) {
+		t.Errorf("expected %d keys, got %d", len(keyIDs), len(result.Keys))
+	}
+	if result.NextPageToken != "" {
+		t.Errorf("expected no next page token, got %q", result.NextPageToken)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestClient_ListKeysPaged(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            ListKeysOptions
+		mockResponse    *http.Response
+		expectedURL     string
+		expectError     bool
+		expectedKeys    int
+		expectNextToken string
+	}{
+		{
+			name:            "first page",
+			opts:            ListKeysOptions{PageSize: 2},
+			mockResponse:    createMockResponse(200, map[string]interface{}{"keys": []map[string]string{{"id": "key-1"}, {"id": "key-2"}}, "next_page_token": "abc"}),
+			expectedURL:     "https://api.example.com/api/v1/keys?page_size=2",
+			expectedKeys:    2,
+			expectNextToken: "abc",
+		},
+		{
+			name:         "last page",
+			opts:         ListKeysOptions{PageSize: 2, PageToken: "abc"},
+			mockResponse: createMockResponse(200, map[string]interface{}{"keys": []map[string]string{{"id": "key-3"}}}),
+			expectedURL:  "https://api.example.com/api/v1/keys?page_size=2&page_token=abc",
+			expectedKeys: 1,
+		},
+		{
+			name:         "server without pagination",
+			mockResponse: createMockResponse(200, []map[string]string{{"id": "key-1"}}),
+			expectedURL:  "https://api.example.com/api/v1/keys",
+			expectedKeys: 1,
+		},
+		{
+			name: "status filter",
+			opts: ListKeysOptions{Status: KeyStatusActive},
+			mockResponse: createMockResponse(200, map[string]interface{}{"keys": []map[string]string{
+				{"id": "key-1", "status": "active"},
+				{"id": "key-2", "status": "revoked"},
+				{"id": "key-3", "status": "active", "expires_at": "2020-01-01T00:00:00Z"},
+				{"id": "key-4", "status": "active", "expires_at": "2999-01-01T00:00:00Z"},
+			}}),
+			expectedURL:  "https://api.example.com/api/v1/keys?status=active",
+			expectedKeys: 2,
+		},
+		{
+			name: "algorithm family filter",
+			opts: ListKeysOptions{Algorithm: "RSA"},
+			mockResponse: createMockResponse(200, []map[string]string{
+				{"id": "key-1", "algorithm": "rsa-2048"},
+				{"id": "key-2", "algorithm": "rsa-4096"},
+				{"id": "key-3", "algorithm": "ecdsa-p256"},
+				{"id": "key-4", "algorithm": "rsassa"},
+			}),
+			expectedURL:  "https://api.example.com/api/v1/keys",
+			expectedKeys: 2,
+		},
+		{
+			name: "exact algorithm filter",
+			opts: ListKeysOptions{Algorithm: AlgorithmRSA4096},
+			mockResponse: createMockResponse(200, []map[string]string{
+				{"id": "key-1", "algorithm": "rsa-2048"},
+				{"id": "key-2", "algorithm": "rsa-4096"},
+			}),
+			expectedURL:  "https://api.example.com/api/v1/keys",
+			expectedKeys: 1,
+		},
+		{
+			name: "created date range",
+			opts: ListKeysOptions{
+				CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			mockResponse: createMockResponse(200, []map[string]string{
+				{"id": "key-1", "created_at": "2023-06-01T00:00:00Z"},
+				{"id": "key-2", "created_at": "2024-06-01T00:00:00Z"},
+				{"id": "key-3", "created_at": "2025-01-01T00:00:00Z"},
+			}),
+			expectedURL:  "https://api.example.com/api/v1/keys",
+			expectedKeys: 1,
+		},
+		{
+			name: "filters compose",
+			opts: ListKeysOptions{
+				Status:        KeyStatusActive,
+				Algorithm:     "rsa",
+				CreatedBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			mockResponse: createMockResponse(200, []map[string]string{
+				{"id": "key-1", "status": "active", "algorithm": "rsa-2048", "created_at": "2024-06-01T00:00:00Z"},
+				{"id": "key-2", "status": "revoked", "algorithm": "rsa-2048", "created_at": "2024-06-01T00:00:00Z"},
+				{"id": "key-3", "status": "active", "algorithm": "ed25519", "created_at": "2024-06-01T00:00:00Z"},
+				{"id": "key-4", "status": "active", "algorithm": "rsa-4096", "created_at": "2025-06-01T00:00:00Z"},
+			}),
+			expectedURL:  "https://api.example.com/api/v1/keys?status=active",
+			expectedKeys: 1,
+		},
+		{
+			name: "label selector",
+			opts: ListKeysOptions{LabelSelector: "team=payments,env!=dev"},
+			mockResponse: createMockResponse(200, []map[string]interface{}{
+				{"id": "key-1", "labels": map[string]string{"team": "payments", "env": "prod"}},
+				{"id": "key-2", "labels": map[string]string{"team": "payments", "env": "dev"}},
+				{"id": "key-3", "labels": map[string]string{"team": "search"}},
+				{"id": "key-4", "labels": map[string]string{"team": "payments"}},
+				{"id": "key-5"},
+			}),
+			expectedURL:  "https://api.example.com/api/v1/keys?label_selector=team%3Dpayments%2Cenv%21%3Ddev",
+			expectedKeys: 2,
+		},
+		{
+			name:        "malformed label selector",
+			opts:        ListKeysOptions{LabelSelector: "team=payments,"},
+			expectError: true,
+		},
+		{
+			name:        "negative page size",
+			opts:        ListKeysOptions{PageSize: -1},
+			expectError: true,
+		},
+		{
+			name: "empty created date range",
+			opts: ListKeysOptions{
+				CreatedAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if req.URL.String() != tt.expectedURL {
+					t.Errorf("expected URL %q, got %q", tt.expectedURL, req.URL.String())
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			result, err := client.ListKeysPaged(context.Background(), tt.opts)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Keys) != tt.expectedKeys {
+				t.Errorf("expected %d keys, got %d", tt.expectedKeys, len(result.Keys))
+			}
+			if result.NextPageToken != tt.expectNextToken {
+				t.Errorf("expected next page token %q, got %q", tt.expectNextToken, result.NextPageToken)
+			}
+		})
+	}
+}
+
 func TestClient_GenerateKey(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -541,32 +931,21 @@ func TestClient_GenerateKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					expectedURL := "https://api.example.com/api/v1/keys"
-					if req.URL.String() != expectedURL {
-						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-					}
-
-					if req.Method != "POST" {
-						t.Errorf("expected POST method, got %q", req.Method)
-					}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/api/v1/keys"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
 
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+				if req.Method != "POST" {
+					t.Errorf("expected POST method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			result, err := client.GenerateKey(ctx)
@@ -598,66 +977,132 @@ func TestClient_GenerateKey(t *testing.T) {
 	}
 }
 
-func TestClient_GetPublicKey(t *testing.T) {
-	pemKey := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"
-
+func TestClient_GenerateKeyWithOptions(t *testing.T) {
 	tests := []struct {
-		name         string
-		keyID        string
-		mockResponse *http.Response
-		mockError    error
-		expectError  bool
-		expectedPEM  string
+		name            string
+		opts            GenerateKeyOptions
+		expectError     bool
+		expectRequest   bool
+		expectedBody    string
+		expectAlgorithm bool
 	}{
 		{
-			name:         "successful get public key",
-			keyID:        "key-123",
-			mockResponse: createMockResponse(200, pemKey),
-			expectError:  false,
-			expectedPEM:  pemKey,
+			name:          "algorithm and backend",
+			opts:          GenerateKeyOptions{Backend: KeyBackendKMS, Algorithm: AlgorithmEd25519},
+			expectRequest: true,
+			expectedBody:  `{"backend":"gcp-kms","algorithm":"ed25519"}`,
 		},
 		{
-			name:        "empty key ID",
-			keyID:       "",
-			expectError: true,
+			name:          "algorithm only",
+			opts:          GenerateKeyOptions{Algorithm: AlgorithmRSA4096},
+			expectRequest: true,
+			expectedBody:  `{"algorithm":"rsa-4096"}`,
 		},
 		{
-			name:        "request failure",
-			keyID:       "key-123",
-			mockError:   fmt.Errorf("network error"),
+			name:          "labels only",
+			opts:          GenerateKeyOptions{Labels: map[string]string{"team": "payments"}},
+			expectRequest: true,
+			expectedBody:  `{"labels":{"team":"payments"}}`,
+		},
+		{
+			name:          "server defaults",
+			expectRequest: true,
+		},
+		{
+			name:        "unsupported algorithm",
+			opts:        GenerateKeyOptions{Algorithm: "dsa-1024"},
 			expectError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					if tt.keyID != "" {
-						expectedURL := fmt.Sprintf("https://api.example.com/api/v1/keys/public?key_id=%s", tt.keyID)
-						if req.URL.String() != expectedURL {
-							t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-						}
-					}
-					if req.Method != "GET" {
-						t.Errorf("expected GET method, got %q", req.Method)
-					}
+			requested := false
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requested = true
+				var body []byte
+				if req.Body != nil {
+					body, _ = io.ReadAll(req.Body)
+				}
+				if string(body) != tt.expectedBody {
+					t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+				}
+				return createMockResponse(201, map[string]interface{}{
+					"id":        "key-123",
+					"algorithm": tt.opts.Algorithm,
+				}), nil
+			}, nil)
 
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+			key, err := client.GenerateKeyWithOptions(context.Background(), tt.opts)
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
+			if requested != tt.expectRequest {
+				t.Errorf("expected request sent %v, got %v", tt.expectRequest, requested)
+			}
+			if tt.expectError {
+				if !errors.Is(err, ErrUnsupportedAlgorithm) {
+					t.Errorf("expected ErrUnsupportedAlgorithm, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
 			}
+			if key.Algorithm != tt.opts.Algorithm {
+				t.Errorf("expected algorithm %q, got %q", tt.opts.Algorithm, key.Algorithm)
+			}
+		})
+	}
+}
+
+func TestClient_GetPublicKey(t *testing.T) {
+	pemKey := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"
+
+	tests := []struct {
+		name         string
+		keyID        string
+		mockResponse *http.Response
+		mockError    error
+		expectError  bool
+		expectedPEM  string
+	}{
+		{
+			name:         "successful get public key",
+			keyID:        "key-123",
+			mockResponse: createMockResponse(200, pemKey),
+			expectError:  false,
+			expectedPEM:  pemKey,
+		},
+		{
+			name:        "empty key ID",
+			keyID:       "",
+			expectError: true,
+		},
+		{
+			name:        "request failure",
+			keyID:       "key-123",
+			mockError:   fmt.Errorf("network error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if tt.keyID != "" {
+					expectedURL := fmt.Sprintf("https://api.example.com/api/v1/keys/public?key_id=%s", tt.keyID)
+					if req.URL.String() != expectedURL {
+						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+					}
+				}
+				if req.Method != "GET" {
+					t.Errorf("expected GET method, got %q", req.Method)
+				}
+
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			result, err := client.GetPublicKey(ctx, tt.keyID)
@@ -679,6 +1124,309 @@ func TestClient_GetPublicKey(t *testing.T) {
 	}
 }
 
+func TestClient_DeleteKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		keyID          string
+		mockResponse   *http.Response
+		mockError      error
+		expectError    bool
+		expectNotFound bool
+	}{
+		{
+			name:         "successful delete",
+			keyID:        "key-123",
+			mockResponse: createMockResponse(204, ""),
+			expectError:  false,
+		},
+		{
+			name:        "empty key ID",
+			keyID:       "",
+			expectError: true,
+		},
+		{
+			name:           "key not found",
+			keyID:          "key-123",
+			mockResponse:   createMockResponse(404, map[string]string{"error": "key not found"}),
+			expectError:    true,
+			expectNotFound: true,
+		},
+		{
+			name:         "server error",
+			keyID:        "key-123",
+			mockResponse: createMockResponse(500, map[string]string{"error": "internal error"}),
+			expectError:  true,
+		},
+		{
+			name:        "request failure",
+			keyID:       "key-123",
+			mockError:   fmt.Errorf("network error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/api/v1/keys/" + tt.keyID
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
+				if req.Method != "DELETE" {
+					t.Errorf("expected DELETE method, got %q", req.Method)
+				}
+
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			ctx := context.Background()
+			err := client.DeleteKey(ctx, tt.keyID)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+					return
+				}
+				if errors.Is(err, ErrKeyNotFound) != tt.expectNotFound {
+					t.Errorf("expected errors.Is(err, ErrKeyNotFound) to be %v, got %v", tt.expectNotFound, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetKey(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		keyID          string
+		mockResponse   *http.Response
+		mockError      error
+		expectError    bool
+		expectNotFound bool
+		expectKey      *GenerateKeyCMDResponse
+	}{
+		{
+			name:  "successful get",
+			keyID: "key-123",
+			mockResponse: createMockResponse(200, ListKeysAPIResponse{
+				ID:              "key-123",
+				CreatedAt:       createdAt,
+				Algorithm:       "ES256",
+				Backend:         "gcp-kms",
+				ProtectionLevel: "HSM",
+				Purpose:         "signing",
+			}),
+			expectKey: &GenerateKeyCMDResponse{
+				ID:              "key-123",
+				CreatedAt:       createdAt,
+				Algorithm:       "ES256",
+				Backend:         "gcp-kms",
+				ProtectionLevel: "HSM",
+				Purpose:         "signing",
+			},
+		},
+		{
+			name:        "empty key ID",
+			keyID:       "",
+			expectError: true,
+		},
+		{
+			name:           "key not found",
+			keyID:          "key-123",
+			mockResponse:   createMockResponse(404, map[string]string{"error": "key not found"}),
+			expectError:    true,
+			expectNotFound: true,
+		},
+		{
+			name:         "invalid JSON response",
+			keyID:        "key-123",
+			mockResponse: createMockResponse(200, "invalid json"),
+			expectError:  true,
+		},
+		{
+			name:        "request failure",
+			keyID:       "key-123",
+			mockError:   fmt.Errorf("network error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://api.example.com/api/v1/keys/" + tt.keyID
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+				}
+				if req.Method != "GET" {
+					t.Errorf("expected GET method, got %q", req.Method)
+				}
+
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			key, err := client.GetKey(context.Background(), tt.keyID)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+					return
+				}
+				if errors.Is(err, ErrKeyNotFound) != tt.expectNotFound {
+					t.Errorf("expected errors.Is(err, ErrKeyNotFound) to be %v, got %v", tt.expectNotFound, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !reflect.DeepEqual(key, tt.expectKey) {
+				t.Errorf("expected key %+v, got %+v", tt.expectKey, key)
+			}
+		})
+	}
+}
+
+func TestClient_ImportPublicKey(t *testing.T) {
+	publicKeyPEM := testPublicKeyPEM(t)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		keyID           string
+		publicKeyPEM    string
+		algorithm       string
+		mockResponse    *http.Response
+		expectAlgorithm string
+		expectError     error
+		expectRequest   bool
+	}{
+		{
+			name:            "algorithm inferred from key",
+			keyID:           "hsm-key-1",
+			publicKeyPEM:    publicKeyPEM,
+			mockResponse:    createMockResponse(201, ListKeysAPIResponse{ID: "hsm-key-1", CreatedAt: createdAt, Algorithm: AlgorithmECDSAP256, Status: KeyStatusActive}),
+			expectAlgorithm: AlgorithmECDSAP256,
+			expectRequest:   true,
+		},
+		{
+			name:            "matching algorithm",
+			keyID:           "hsm-key-1",
+			publicKeyPEM:    publicKeyPEM,
+			algorithm:       AlgorithmECDSAP256,
+			mockResponse:    createMockResponse(201, ListKeysAPIResponse{ID: "hsm-key-1", CreatedAt: createdAt, Algorithm: AlgorithmECDSAP256, Status: KeyStatusActive}),
+			expectAlgorithm: AlgorithmECDSAP256,
+			expectRequest:   true,
+		},
+		{
+			name:          "key ID already exists",
+			keyID:         "hsm-key-1",
+			publicKeyPEM:  publicKeyPEM,
+			mockResponse:  createMockResponse(409, map[string]string{"code": "KEY_EXISTS", "message": "key already exists"}),
+			expectError:   ErrKeyExists,
+			expectRequest: true,
+		},
+		{
+			name:         "algorithm does not match key",
+			keyID:        "hsm-key-1",
+			publicKeyPEM: publicKeyPEM,
+			algorithm:    AlgorithmRSA2048,
+			expectError:  ErrInvalidPublicKey,
+		},
+		{
+			name:         "unknown algorithm",
+			keyID:        "hsm-key-1",
+			publicKeyPEM: publicKeyPEM,
+			algorithm:    "dsa",
+			expectError:  ErrUnsupportedAlgorithm,
+		},
+		{
+			name:         "not PEM",
+			keyID:        "hsm-key-1",
+			publicKeyPEM: "not a key",
+			expectError:  ErrInvalidPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if want := "https://api.example.com/api/v1/keys/import"; req.URL.String() != want || req.Method != "POST" {
+					t.Errorf("expected POST %s, got %s %s", want, req.Method, req.URL)
+				}
+				var body importKeyRequest
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatalf("request body is not valid JSON: %v", err)
+				}
+				want := importKeyRequest{ID: tt.keyID, PublicKey: tt.publicKeyPEM, Algorithm: AlgorithmECDSAP256}
+				if body != want {
+					t.Errorf("request body = %+v, want %+v", body, want)
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			key, err := client.ImportPublicKey(context.Background(), tt.keyID, tt.publicKeyPEM, tt.algorithm)
+
+			if (requests > 0) != tt.expectRequest {
+				t.Errorf("expected request to be sent: %v, got %d requests", tt.expectRequest, requests)
+			}
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("error = %v, want %v", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.ID != tt.keyID || key.Algorithm != tt.expectAlgorithm || key.PublicKey != tt.publicKeyPEM || !key.CreatedAt.Equal(createdAt) {
+				t.Errorf("key = %+v, want %s %s with the imported public key", key, tt.keyID, tt.expectAlgorithm)
+			}
+		})
+	}
+}
+
+func TestClient_SignSBOM_Raw(t *testing.T) {
+	// Malformed on purpose: a raw SBOM is sent as is and left to the server
+	const doc = `{"bomFormat": "CycloneDX", "components": [}`
+	sbom, err := LoadSBOMFromReaderRaw(strings.NewReader(doc), SBOMFormatCycloneDX)
+	if err != nil {
+		t.Fatalf("LoadSBOMFromReaderRaw() error = %v", err)
+	}
+
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if want := `{"key_id":"key-123","sbom":` + doc + `}`; string(body) != want {
+			t.Errorf("request body = %s, want %s", body, want)
+		}
+		return createMockResponse(200, SignResultAPIResponseV2{SignedSBOM: json.RawMessage(`{"signed": true}`)}), nil
+	}, nil)
+
+	result, err := client.SignSBOM(context.Background(), "key-123", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	if result.SBOMDigest != "" {
+		t.Errorf("SBOMDigest = %q, want empty for a raw SBOM", result.SBOMDigest)
+	}
+}
+
 func TestClient_SignSBOM(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -724,44 +1472,33 @@ func TestClient_SignSBOM(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					if tt.keyID != "" && tt.sbom != nil {
-						expectedURL := "https://api.example.com/api/v2/sbom/sign"
-						if req.URL.String() != expectedURL {
-							t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-						}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if tt.keyID != "" && tt.sbom != nil {
+					expectedURL := "https://api.example.com/api/v2/sbom/sign"
+					if req.URL.String() != expectedURL {
+						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+					}
 
-						// Verify request body contains SBOM
-						if req.Body != nil {
-							bodyBytes, _ := io.ReadAll(req.Body)
-							var requestBody map[string]interface{}
-							if json.Unmarshal(bodyBytes, &requestBody) == nil {
-								if _, ok := requestBody["sbom"]; !ok {
-									t.Error("expected request body to contain 'sbom' field")
-								}
+					// Verify request body contains SBOM
+					if req.Body != nil {
+						bodyBytes, _ := io.ReadAll(req.Body)
+						var requestBody map[string]interface{}
+						if json.Unmarshal(bodyBytes, &requestBody) == nil {
+							if _, ok := requestBody["sbom"]; !ok {
+								t.Error("expected request body to contain 'sbom' field")
 							}
 						}
 					}
-					if req.Method != "POST" {
-						t.Errorf("expected POST method, got %q", req.Method)
-					}
-
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+				}
+				if req.Method != "POST" {
+					t.Errorf("expected POST method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			result, err := client.SignSBOM(ctx, tt.keyID, tt.sbom)
@@ -783,6 +1520,227 @@ func TestClient_SignSBOM(t *testing.T) {
 	}
 }
 
+func TestClient_PreservesSBOMBytes(t *testing.T) {
+	// Keys out of order, indentation and an HTML character would all change if
+	// the document were decoded and re-encoded
+	original := "{\n  \"specVersion\": \"1.5\",\n  \"bomFormat\": \"CycloneDX\",\n" +
+		"  \"metadata\": {\"component\": {\"name\": \"a<b\", \"version\": \"1.0\"}}\n}\n"
+	loaded, err := LoadSBOMFromReader(strings.NewReader(original))
+	if err != nil {
+		t.Fatalf("failed to load SBOM: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		call       func(c *Client) error
+		expectBody string
+	}{
+		{
+			name: "sign loaded SBOM",
+			call: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", loaded)
+				return err
+			},
+			expectBody: `{"key_id":"key-123","sbom":` + original + `}`,
+		},
+		{
+			name: "sign bytes with options",
+			call: func(c *Client) error {
+				_, err := c.SignSBOMWithOptions(context.Background(), "key-123", []byte(original), SignOptions{Pretty: true})
+				return err
+			},
+			expectBody: `{"key_id":"key-123","pretty":true,"sbom":` + original + `}`,
+		},
+		{
+			name: "sign raw message",
+			call: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", json.RawMessage(original))
+				return err
+			},
+			expectBody: `{"key_id":"key-123","sbom":` + original + `}`,
+		},
+		{
+			name: "verify loaded SBOM",
+			call: func(c *Client) error {
+				_, err := c.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-123", SBOM: loaded, SignatureB64: "c2ln"})
+				return err
+			},
+			expectBody: `{"key_id":"key-123","signature_b64":"c2ln","sbom":` + original + `}`,
+		},
+		{
+			name: "sign decoded document is re-encoded",
+			call: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", loaded.Data())
+				return err
+			},
+			expectBody: `{"key_id":"key-123","sbom":{"bomFormat":"CycloneDX",` +
+				`"metadata":{"component":{"name":"a\u003cb","version":"1.0"}},"specVersion":"1.5"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				body, _ = io.ReadAll(req.Body)
+				return createMockResponse(200, map[string]interface{}{"valid": true}), nil
+			}, nil)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != tt.expectBody {
+				t.Errorf("request body = %q, want %q", body, tt.expectBody)
+			}
+		})
+	}
+}
+
+func TestClient_SignSBOMFromReader(t *testing.T) {
+	sbomJSON := `{"bomFormat":"CycloneDX","specVersion":"1.5"}`
+
+	tests := []struct {
+		name                  string
+		keyID                 string
+		reader                io.Reader
+		size                  int64
+		expectError           bool
+		expectedContentLength int64
+	}{
+		{
+			name:                  "known size",
+			keyID:                 "key-123",
+			reader:                strings.NewReader(sbomJSON),
+			size:                  int64(len(sbomJSON)),
+			expectedContentLength: int64(len(`{"key_id":"key-123","sbom":}`) + len(sbomJSON)),
+		},
+		{
+			name:   "unknown size is streamed",
+			keyID:  "key-123",
+			reader: io.MultiReader(strings.NewReader(sbomJSON)),
+			size:   -1,
+		},
+		{
+			name:        "empty key ID",
+			reader:      strings.NewReader(sbomJSON),
+			size:        -1,
+			expectError: true,
+		},
+		{
+			name:        "nil reader",
+			keyID:       "key-123",
+			size:        -1,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if req.ContentLength != tt.expectedContentLength {
+					t.Errorf("expected content length %d, got %d", tt.expectedContentLength, req.ContentLength)
+				}
+				if req.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("expected JSON content type, got %q", req.Header.Get("Content-Type"))
+				}
+
+				var body struct {
+					KeyID string          `json:"key_id"`
+					SBOM  json.RawMessage `json:"sbom"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatalf("request body is not valid JSON: %v", err)
+				}
+				if body.KeyID != tt.keyID {
+					t.Errorf("expected key ID %q, got %q", tt.keyID, body.KeyID)
+				}
+				if string(body.SBOM) != sbomJSON {
+					t.Errorf("expected SBOM %s, got %s", sbomJSON, body.SBOM)
+				}
+
+				return createMockResponse(200, SignResultAPIResponseV2{Signature: "sig"}), nil
+			}, nil)
+
+			result, err := client.SignSBOMFromReader(context.Background(), tt.keyID, tt.reader, tt.size)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.GetSignatureValue() != "sig" {
+				t.Errorf("expected signature %q, got %q", "sig", result.GetSignatureValue())
+			}
+		})
+	}
+}
+
+func TestClient_SignBytes(t *testing.T) {
+	manifest := []byte("apiVersion: apps/v1\nkind: Deployment\n")
+
+	tests := []struct {
+		name        string
+		keyID       string
+		data        []byte
+		contentType string
+		expectError string
+	}{
+		{name: "yaml manifest", keyID: "key/123", data: manifest, contentType: "application/yaml"},
+		{name: "content type with parameters", keyID: "key-123", data: []byte("plain"), contentType: "text/plain; charset=utf-8"},
+		{name: "empty key ID", data: manifest, contentType: "application/yaml", expectError: "keyID is required"},
+		{name: "empty data", keyID: "key-123", contentType: "application/yaml", expectError: "data is required"},
+		{name: "empty content type", keyID: "key-123", data: manifest, expectError: "contentType is required"},
+		{name: "malformed content type", keyID: "key-123", data: manifest, contentType: "application/", expectError: "invalid content type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if req.URL.Path != "/api/v2/blob/sign" {
+					t.Errorf("expected path /api/v2/blob/sign, got %s", req.URL.Path)
+				}
+				if got := req.URL.Query().Get("key_id"); got != tt.keyID {
+					t.Errorf("expected key_id %q, got %q", tt.keyID, got)
+				}
+				if got := req.Header.Get("Content-Type"); got != tt.contentType {
+					t.Errorf("expected Content-Type %q, got %q", tt.contentType, got)
+				}
+				if req.Header.Get(IdempotencyKeyHeader) == "" {
+					t.Error("expected an idempotency key")
+				}
+				body, _ := io.ReadAll(req.Body)
+				if !bytes.Equal(body, tt.data) {
+					t.Errorf("expected raw body %q, got %q", tt.data, body)
+				}
+				return createMockResponse(http.StatusOK, SignResultAPIResponseV2{Algorithm: AlgorithmEd25519, Detached: true, SignatureB64: "c2ln"}), nil
+			}, nil)
+
+			result, err := client.SignBytes(context.Background(), tt.keyID, tt.data, tt.contentType)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.expectError)
+				}
+				if requests != 0 {
+					t.Errorf("expected no request, got %d", requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.SignatureB64 != "c2ln" || result.Algorithm != AlgorithmEd25519 {
+				t.Errorf("result = %+v, want the detached signature", result)
+			}
+		})
+	}
+}
+
 func TestClient_SignDigest(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -844,49 +1802,38 @@ func TestClient_SignDigest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					if tt.req.KeyID != "" && tt.req.Digest != "" && tt.req.HashAlgorithm != "" {
-						expectedURL := "https://api.example.com/api/v1/digest/sign"
-						if req.URL.String() != expectedURL {
-							t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-						}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if tt.req.KeyID != "" && tt.req.Digest != "" && tt.req.HashAlgorithm != "" {
+					expectedURL := "https://api.example.com/api/v1/digest/sign"
+					if req.URL.String() != expectedURL {
+						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+					}
 
-						if req.Body != nil {
-							bodyBytes, _ := io.ReadAll(req.Body)
-							var requestBody map[string]interface{}
-							if json.Unmarshal(bodyBytes, &requestBody) == nil {
-								if requestBody["digest"] != tt.req.Digest {
-									t.Errorf("expected digest %q, got %v", tt.req.Digest, requestBody["digest"])
-								}
-								if requestBody["hash_algorithm"] != tt.req.HashAlgorithm {
-									t.Errorf("expected hash_algorithm %q, got %v", tt.req.HashAlgorithm, requestBody["hash_algorithm"])
-								}
-								if requestBody["key_id"] != tt.req.KeyID {
-									t.Errorf("expected key_id %q, got %v", tt.req.KeyID, requestBody["key_id"])
-								}
+					if req.Body != nil {
+						bodyBytes, _ := io.ReadAll(req.Body)
+						var requestBody map[string]interface{}
+						if json.Unmarshal(bodyBytes, &requestBody) == nil {
+							if requestBody["digest"] != tt.req.Digest {
+								t.Errorf("expected digest %q, got %v", tt.req.Digest, requestBody["digest"])
+							}
+							if requestBody["hash_algorithm"] != tt.req.HashAlgorithm {
+								t.Errorf("expected hash_algorithm %q, got %v", tt.req.HashAlgorithm, requestBody["hash_algorithm"])
+							}
+							if requestBody["key_id"] != tt.req.KeyID {
+								t.Errorf("expected key_id %q, got %v", tt.req.KeyID, requestBody["key_id"])
 							}
 						}
 					}
-					if req.Method != "POST" {
-						t.Errorf("expected POST method, got %q", req.Method)
-					}
-
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+				}
+				if req.Method != "POST" {
+					t.Errorf("expected POST method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			result, err := client.SignDigest(ctx, tt.req)
@@ -929,6 +1876,10 @@ func TestClient_VerifySBOM(t *testing.T) {
 		mockResponse *http.Response
 		mockError    error
 		expectError  bool
+
+		expectedFingerprint string
+		expectedChainLen    int
+		expectedWarnings    []string
 	}{
 		{
 			name:       "successful SBOM verification",
@@ -942,6 +1893,32 @@ func TestClient_VerifySBOM(t *testing.T) {
 			}),
 			expectError: false,
 		},
+		{
+			name:       "verification with key fingerprint and certificate chain",
+			keyID:      "key-123",
+			signedSBOM: map[string]interface{}{"signed": true},
+			mockResponse: createMockResponse(200, map[string]interface{}{
+				"code":                   "VALID",
+				"message":                "signature verified",
+				"public_key_fingerprint": "SHA256:abc123",
+				"certificate_chain":      []string{"-----BEGIN CERTIFICATE-----leaf", "-----BEGIN CERTIFICATE-----root"},
+			}),
+			expectError:         false,
+			expectedFingerprint: "SHA256:abc123",
+			expectedChainLen:    2,
+		},
+		{
+			name:       "valid verification with warnings",
+			keyID:      "key-123",
+			signedSBOM: map[string]interface{}{"signed": true},
+			mockResponse: createMockResponse(200, map[string]interface{}{
+				"code":     "VALID",
+				"message":  "signature verified",
+				"warnings": []string{"key expires in 7 days", "rsa-2048 is deprecated"},
+			}),
+			expectError:      false,
+			expectedWarnings: []string{"key expires in 7 days", "rsa-2048 is deprecated"},
+		},
 		{
 			name:        "empty key ID",
 			keyID:       "",
@@ -965,44 +1942,33 @@ func TestClient_VerifySBOM(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := &MockHTTPClient{
-				DoFunc: func(req *http.Request) (*http.Response, error) {
-					if tt.keyID != "" && tt.signedSBOM != nil {
-						expectedURL := "https://api.example.com/api/v2/sbom/verify"
-						if req.URL.String() != expectedURL {
-							t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
-						}
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if tt.keyID != "" && tt.signedSBOM != nil {
+					expectedURL := "https://api.example.com/api/v2/sbom/verify"
+					if req.URL.String() != expectedURL {
+						t.Errorf("expected URL %q, got %q", expectedURL, req.URL.String())
+					}
 
-						// Verify request body contains signed SBOM
-						if req.Body != nil {
-							bodyBytes, _ := io.ReadAll(req.Body)
-							var requestBody map[string]interface{}
-							if json.Unmarshal(bodyBytes, &requestBody) == nil {
-								if _, ok := requestBody["sbom"]; !ok {
-									t.Error("expected request body to contain 'sbom' field")
-								}
+					// Verify request body contains signed SBOM
+					if req.Body != nil {
+						bodyBytes, _ := io.ReadAll(req.Body)
+						var requestBody map[string]interface{}
+						if json.Unmarshal(bodyBytes, &requestBody) == nil {
+							if _, ok := requestBody["sbom"]; !ok {
+								t.Error("expected request body to contain 'sbom' field")
 							}
 						}
 					}
-					if req.Method != "POST" {
-						t.Errorf("expected POST method, got %q", req.Method)
-					}
-
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockResponse, nil
-				},
-			}
+				}
+				if req.Method != "POST" {
+					t.Errorf("expected POST method, got %q", req.Method)
+				}
 
-			client := &Client{
-				config: &Config{
-					APIKey:    "test-key",
-					BaseURL:   "https://api.example.com",
-					UserAgent: UserAgent,
-				},
-				httpClient: mockClient,
-			}
+				if tt.mockError != nil {
+					return nil, tt.mockError
+				}
+				return tt.mockResponse, nil
+			}, nil)
 
 			ctx := context.Background()
 			cmdRequest := VerifyCMDRequest{
@@ -1022,12 +1988,134 @@ func TestClient_VerifySBOM(t *testing.T) {
 				}
 				if result == nil {
 					t.Error("expected result to be non-nil")
+					return
+				}
+				if result.PublicKeyFingerprint != tt.expectedFingerprint {
+					t.Errorf("expected fingerprint %q, got %q", tt.expectedFingerprint, result.PublicKeyFingerprint)
+				}
+				if len(result.CertificateChain) != tt.expectedChainLen {
+					t.Errorf("expected %d certificates, got %d", tt.expectedChainLen, len(result.CertificateChain))
+				}
+				if !reflect.DeepEqual(result.Warnings, tt.expectedWarnings) {
+					t.Errorf("expected warnings %v, got %v", tt.expectedWarnings, result.Warnings)
 				}
 			}
 		})
 	}
 }
 
+// testPublicKeyPEM returns a freshly generated PEM-encoded ECDSA public key
+func testPublicKeyPEM(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestClient_VerifyWithPublicKey(t *testing.T) {
+	publicKeyPEM := testPublicKeyPEM(t)
+	certPEM, _ := generateTestCertificate(t)
+	signedSBOM := []byte(`{"bomFormat":"CycloneDX","signature":{"algorithm":"ES256","value":"c2ln"}}`)
+
+	tests := []struct {
+		name         string
+		publicKeyPEM string
+		sbom         []byte
+		mockResponse *http.Response
+		expectValid  bool
+		expectError  error
+		expectAPIErr bool
+	}{
+		{
+			name:         "valid signature",
+			publicKeyPEM: publicKeyPEM,
+			sbom:         signedSBOM,
+			mockResponse: createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Message: "signature is valid", PublicKeyFingerprint: "ab12"}),
+			expectValid:  true,
+		},
+		{
+			name:         "invalid signature",
+			publicKeyPEM: publicKeyPEM,
+			sbom:         signedSBOM,
+			mockResponse: createMockResponse(http.StatusBadRequest, map[string]string{"code": "INVALID_SIGNATURE", "message": "signature verification failed"}),
+			expectAPIErr: true,
+		},
+		{
+			name:         "not PEM",
+			publicKeyPEM: "not a key",
+			sbom:         signedSBOM,
+			expectError:  ErrInvalidPublicKey,
+		},
+		{
+			name:         "certificate instead of public key",
+			publicKeyPEM: string(certPEM),
+			sbom:         signedSBOM,
+			expectError:  ErrInvalidPublicKey,
+		},
+		{
+			name:         "corrupt key bytes",
+			publicKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")})),
+			sbom:         signedSBOM,
+			expectError:  ErrInvalidPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				var body map[string]json.RawMessage
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatalf("request body is not valid JSON: %v", err)
+				}
+				if _, ok := body["key_id"]; ok {
+					t.Errorf("expected no key_id in request, got %s", body["key_id"])
+				}
+				var gotKey string
+				_ = json.Unmarshal(body["public_key"], &gotKey)
+				if gotKey != tt.publicKeyPEM {
+					t.Errorf("expected public_key %q, got %q", tt.publicKeyPEM, gotKey)
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			result, err := client.VerifyWithPublicKey(context.Background(), tt.publicKeyPEM, tt.sbom)
+
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("error = %v, want %v", err, tt.expectError)
+				}
+				if requests != 0 {
+					t.Errorf("expected no request for an invalid key, got %d", requests)
+				}
+				return
+			}
+			if tt.expectAPIErr {
+				if apiErr, ok := AsAPIError(err); !ok || apiErr.Code != "INVALID_SIGNATURE" {
+					t.Fatalf("error = %v, want INVALID_SIGNATURE API error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Valid != tt.expectValid || result.PublicKeyFingerprint != "ab12" {
+				t.Errorf("result = %+v, want valid with fingerprint ab12", result)
+			}
+			if result.SBOMDigest == "" {
+				t.Error("expected SBOMDigest to be set")
+			}
+		})
+	}
+}
+
 func TestAPIError_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1087,3 +2175,283 @@ func TestAPIError_Temporary(t *testing.T) {
 		})
 	}
 }
+
+// timeoutError is a net.Error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorHelpers(t *testing.T) {
+	wrap := func(err error) error {
+		return fmt.Errorf("failed to sign SBOM: %w", err)
+	}
+
+	tests := []struct {
+		name         string
+		err          error
+		unauthorized bool
+		notFound     bool
+		rateLimited  bool
+		temporary    bool
+	}{
+		{name: "401", err: wrap(&APIError{StatusCode: 401}), unauthorized: true},
+		{name: "404", err: wrap(&APIError{StatusCode: 404}), notFound: true},
+		{name: "key not found", err: wrap(ErrKeyNotFound), notFound: true},
+		{name: "429", err: wrap(&APIError{StatusCode: 429}), rateLimited: true, temporary: true},
+		{name: "503", err: wrap(&APIError{StatusCode: 503}), temporary: true},
+		{name: "connection reset", err: wrap(fmt.Errorf("read: %w", syscall.ECONNRESET)), temporary: true},
+		{
+			name:      "connection refused",
+			err:       wrap(&url.Error{Op: "Post", URL: "https://api.example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}),
+			temporary: true,
+		},
+		{name: "timeout", err: wrap(&url.Error{Op: "Post", URL: "https://api.example.com", Err: timeoutError{}}), temporary: true},
+		{name: "response cut short", err: wrap(io.ErrUnexpectedEOF), temporary: true},
+		{name: "incomplete response", err: wrap(ErrIncompleteResponse), temporary: true},
+		{
+			name: "untrusted certificate",
+			err:  wrap(&url.Error{Op: "Post", URL: "https://api.example.com", Err: x509.UnknownAuthorityError{}}),
+		},
+		{name: "pin mismatch", err: wrap(&url.Error{Op: "Post", URL: "https://api.example.com", Err: ErrCertificatePinMismatch})},
+		{name: "missing key ID", err: errors.New("keyID is required")},
+		{name: "unsupported algorithm", err: wrap(ErrUnsupportedAlgorithm)},
+		{name: "invalid public key", err: wrap(ErrInvalidPublicKey)},
+		{name: "unsupported format", err: wrap(ErrUnsupportedFormat)},
+		{name: "no signatures", err: wrap(ErrNoSignatures)},
+		{name: "signature invalid", err: wrap(ErrSignatureInvalid)},
+		{name: "signature format unsupported", err: wrap(ErrSignatureFormatUnsupported)},
+		{name: "timestamp invalid", err: wrap(ErrTimestampInvalid)},
+		{name: "circuit open", err: wrap(ErrCircuitOpen)},
+		{name: "missing file", err: wrap(&os.PathError{Op: "open", Path: "sbom.json", Err: syscall.ENOENT})},
+		{name: "context canceled", err: wrap(context.Canceled)},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnauthorized(tt.err); got != tt.unauthorized {
+				t.Errorf("IsUnauthorized: expected %v, got %v", tt.unauthorized, got)
+			}
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound: expected %v, got %v", tt.notFound, got)
+			}
+			if got := IsRateLimited(tt.err); got != tt.rateLimited {
+				t.Errorf("IsRateLimited: expected %v, got %v", tt.rateLimited, got)
+			}
+			if got := IsTemporary(tt.err); got != tt.temporary {
+				t.Errorf("IsTemporary: expected %v, got %v", tt.temporary, got)
+			}
+		})
+	}
+}
+
+func TestClient_doRequest_ErrorCode(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(401, map[string]string{
+			"code":       "invalid_api_key",
+			"message":    "API key is invalid",
+			"request_id": "req-123",
+		}), nil
+	}, nil)
+
+	_, err := client.ListKeys(context.Background())
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError in chain, got %T", err)
+	}
+	if apiErr.Code != "invalid_api_key" {
+		t.Errorf("expected code %q, got %q", "invalid_api_key", apiErr.Code)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("expected request ID %q, got %q", "req-123", apiErr.RequestID)
+	}
+}
+
+func TestClient_ErrorResponseBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantCode    string
+		wantMessage string
+		wantDetails string
+	}{
+		{
+			name:        "empty body",
+			status:      502,
+			wantMessage: "Bad Gateway",
+		},
+		{
+			name:        "JSON message",
+			status:      400,
+			body:        `{"code":"bad_request","message":"key_id is required","details":"field key_id"}`,
+			wantCode:    "bad_request",
+			wantMessage: "key_id is required",
+			wantDetails: "field key_id",
+		},
+		{
+			name:        "JSON error string",
+			status:      403,
+			body:        `{"error":"key is disabled"}`,
+			wantMessage: "key is disabled",
+		},
+		{
+			name:        "JSON nested error",
+			status:      409,
+			body:        `{"error":{"code":"conflict","message":"key exists","details":{"id":"k1"}}}`,
+			wantCode:    "conflict",
+			wantMessage: "key exists",
+			wantDetails: `{"id":"k1"}`,
+		},
+		{
+			name:        "JSON of unexpected shape",
+			status:      500,
+			body:        `{"status":"down"}`,
+			wantMessage: `{"status":"down"}`,
+		},
+		{
+			name:        "HTML gateway page",
+			status:      504,
+			contentType: "text/html; charset=utf-8",
+			body:        "<html><head><title>504 Gateway Time-out</title></head><body><h1>504 Gateway Time-out</h1></body></html>",
+			wantMessage: "504 Gateway Time-out",
+		},
+		{
+			name:        "HTML without title",
+			status:      503,
+			body:        "<html><body>\n<h1>Service   Unavailable</h1><p>Try &amp; retry</p></body></html>",
+			wantMessage: "Service Unavailable Try & retry",
+		},
+		{
+			name:        "plain text",
+			status:      500,
+			body:        "upstream connect error\n",
+			wantMessage: "upstream connect error",
+		},
+		{
+			name:        "long plain text",
+			status:      500,
+			body:        strings.Repeat("x", 600),
+			wantMessage: strings.Repeat("x", maxErrorText) + "...",
+		},
+	}
+
+	calls := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{"SignSBOM", func(c *Client) error {
+			_, err := c.SignSBOM(context.Background(), "key-123", json.RawMessage(`{"bomFormat":"CycloneDX"}`))
+			return err
+		}},
+		{"ListKeys", func(c *Client) error {
+			_, err := c.ListKeys(context.Background())
+			return err
+		}},
+		{"GetPublicKey", func(c *Client) error {
+			_, err := c.GetPublicKey(context.Background(), "key-123")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, call := range calls {
+			t.Run(tt.name+"/"+call.name, func(t *testing.T) {
+				client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+					resp := createMockResponse(tt.status, tt.body)
+					if tt.contentType != "" {
+						resp.Header.Set("Content-Type", tt.contentType)
+					}
+					return resp, nil
+				}, nil)
+
+				apiErr, ok := AsAPIError(call.call(client))
+				if !ok {
+					t.Fatalf("expected APIError")
+				}
+				if apiErr.StatusCode != tt.status {
+					t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+				}
+				if apiErr.Code != tt.wantCode {
+					t.Errorf("Code = %q, want %q", apiErr.Code, tt.wantCode)
+				}
+				if apiErr.Message != tt.wantMessage {
+					t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+				}
+				if apiErr.Details != tt.wantDetails {
+					t.Errorf("Details = %q, want %q", apiErr.Details, tt.wantDetails)
+				}
+			})
+		}
+	}
+}
+
+func TestClient_UnexpectedStatus(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(202, `{"message":"queued"}`), nil
+	}, nil)
+
+	_, err := client.GenerateKey(context.Background())
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != 202 || apiErr.Message != "queued" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+	if !strings.HasPrefix(err.Error(), "failed to generate key: ") {
+		t.Errorf("unexpected error text %q", err)
+	}
+}
+
+func TestClient_RejectsXML(t *testing.T) {
+	xmlSBOM := `<?xml version="1.0"?><bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1"/>`
+
+	tests := []struct {
+		name string
+		call func(c *Client) error
+	}{
+		{
+			name: "SignSBOM with XML string",
+			call: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", xmlSBOM)
+				return err
+			},
+		},
+		{
+			name: "SignSBOM with XML bytes",
+			call: func(c *Client) error {
+				_, err := c.SignSBOM(context.Background(), "key-123", json.RawMessage(xmlSBOM))
+				return err
+			},
+		},
+		{
+			name: "VerifySBOM with XML string",
+			call: func(c *Client) error {
+				_, err := c.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-123", SBOM: xmlSBOM})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				t.Error("expected no request to be sent")
+				return createMockResponse(500, nil), nil
+			}, nil)
+
+			err := tt.call(client)
+			if !errors.Is(err, ErrUnsupportedFormat) {
+				t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+			}
+		})
+	}
+}
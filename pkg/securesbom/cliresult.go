@@ -0,0 +1,110 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CLIResultVersion is the version of the CLIResult envelope. It only changes
+// if fields are removed or change meaning; new fields may be added at any time.
+const CLIResultVersion = 1
+
+// Statuses reported in CLIResult.Status
+const (
+	// CLIStatusOK means the command completed and succeeded
+	CLIStatusOK = "ok"
+	// CLIStatusFailed means the command completed with a negative outcome,
+	// e.g. an invalid signature or some keys in a batch not being created;
+	// Data holds whatever was produced
+	CLIStatusFailed = "failed"
+	// CLIStatusError means the command could not complete; Data is empty
+	CLIStatusError = "error"
+)
+
+// CLIResult is the envelope the example command-line tools write for JSON
+// output, so scripts can check Status and ExitCode the same way whichever
+// command they ran and find the command's own output in Data.
+type CLIResult struct {
+	Version  int    `json:"version"`
+	Command  string `json:"command"`
+	Status   string `json:"status"`
+	ExitCode int    `json:"exit_code"`
+	// Data is the command's output, e.g. a KeyListResponse for a key listing
+	Data  interface{} `json:"data,omitempty"`
+	Error *CLIError   `json:"error,omitempty"`
+}
+
+// CLIError describes why a command failed. The API fields are set when the
+// failure was reported by the SecureSBOM API.
+type CLIError struct {
+	Message    string `json:"message"`
+	Code       string `json:"code,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+// NewCLIResult returns a successful result holding data
+func NewCLIResult(command string, data interface{}) *CLIResult {
+	return &CLIResult{Version: CLIResultVersion, Command: command, Status: CLIStatusOK, Data: data}
+}
+
+// NewCLIFailedResult returns a result for a command that completed with a
+// negative outcome. err may be nil when data itself explains the failure,
+// e.g. a verification result that is not valid.
+func NewCLIFailedResult(command string, data interface{}, err error, exitCode int) *CLIResult {
+	return &CLIResult{
+		Version:  CLIResultVersion,
+		Command:  command,
+		Status:   CLIStatusFailed,
+		ExitCode: exitCode,
+		Data:     data,
+		Error:    newCLIError(err),
+	}
+}
+
+// NewCLIErrorResult returns a result for a command that could not complete
+func NewCLIErrorResult(command string, err error, exitCode int) *CLIResult {
+	return &CLIResult{
+		Version:  CLIResultVersion,
+		Command:  command,
+		Status:   CLIStatusError,
+		ExitCode: exitCode,
+		Error:    newCLIError(err),
+	}
+}
+
+func newCLIError(err error) *CLIError {
+	if err == nil {
+		return nil
+	}
+	cliErr := &CLIError{Message: err.Error()}
+	if apiErr, ok := AsAPIError(err); ok {
+		cliErr.Code = apiErr.Code
+		cliErr.StatusCode = apiErr.StatusCode
+		cliErr.RequestID = apiErr.RequestID
+	}
+	return cliErr
+}
+
+// Write encodes the result to w as indented JSON
+func (r *CLIResult) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCLIResult(t *testing.T) {
+	apiErr := &APIError{StatusCode: 404, Code: "KEY_NOT_FOUND", Message: "key not found", RequestID: "req-1"}
+
+	tests := []struct {
+		name     string
+		result   *CLIResult
+		expected string
+	}{
+		{
+			name:     "ok",
+			result:   NewCLIResult("list", KeyListResponse{Keys: []GenerateKeyCMDResponse{}}),
+			expected: `{"version":1,"command":"list","status":"ok","exit_code":0,"data":{"keys":[]}}`,
+		},
+		{
+			name:     "failed without error",
+			result:   NewCLIFailedResult("verify", map[string]bool{"valid": false}, nil, ExitInvalidSignature),
+			expected: `{"version":1,"command":"verify","status":"failed","exit_code":2,"data":{"valid":false}}`,
+		},
+		{
+			name:     "failed with error",
+			result:   NewCLIFailedResult("generate", []string{"key-1"}, errors.New("1 of 2 items failed"), 1),
+			expected: `{"version":1,"command":"generate","status":"failed","exit_code":1,"data":["key-1"],"error":{"message":"1 of 2 items failed"}}`,
+		},
+		{
+			name:     "plain error",
+			result:   NewCLIErrorResult("sign", errors.New("Error: -key-id is required"), 1),
+			expected: `{"version":1,"command":"sign","status":"error","exit_code":1,"error":{"message":"Error: -key-id is required"}}`,
+		},
+		{
+			name:     "wrapped API error",
+			result:   NewCLIErrorResult("info", fmt.Errorf("Error getting key: %w", apiErr), 1),
+			expected: `{"version":1,"command":"info","status":"error","exit_code":1,"error":{"message":"Error getting key: ` + apiErr.Error() + `","code":"KEY_NOT_FOUND","status_code":404,"request_id":"req-1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.result.Write(&buf); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, buf.Bytes()); err != nil {
+				t.Fatalf("Write() produced invalid JSON: %v", err)
+			}
+			if compact.String() != tt.expected {
+				t.Errorf("Write() = %s, want %s", compact.String(), tt.expected)
+			}
+		})
+	}
+}
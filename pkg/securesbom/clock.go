@@ -0,0 +1,73 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"time"
+)
+
+// clock is the source of time for retry backoff, cache expiry, circuit
+// breaker cooldowns, rate limiting and token refresh. Tests swap in a fake
+// clock so time-based behavior runs instantly and deterministically.
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the real wall clock, used unless a test injects another
+var systemClock clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clockProvider is implemented by clients that carry a clock, so wrappers can
+// share it
+type clockProvider interface {
+	clock() clock
+}
+
+// withClock makes a client use clk instead of the wall clock. It is meant for
+// tests.
+func withClock(clk clock) ClientOption {
+	return func(c *Config) {
+		c.clock = clk
+	}
+}
+
+// orSystemClock returns clk, or the wall clock when clk is nil
+func orSystemClock(clk clock) clock {
+	if clk == nil {
+		return systemClock
+	}
+	return clk
+}
+
+// sleepContext waits for d on clk, returning early with ctx's error if ctx is
+// done first
+func sleepContext(ctx context.Context, clk clock, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clk.After(d):
+		return nil
+	}
+}
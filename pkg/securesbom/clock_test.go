@@ -0,0 +1,140 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for tests. Time only moves when Advance is called or
+// when something sleeps on it, in which case the sleep returns at once and
+// the clock jumps forward by its duration, so timing-sensitive code runs
+// instantly and every wait is recorded.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.After(d)
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(max(d, 0))
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// Advance moves the clock forward by d without recording a sleep
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Sleeps returns every wait requested so far, in order
+func (c *fakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.sleeps)
+}
+
+func TestSleepContext(t *testing.T) {
+	t.Run("waits on the clock", func(t *testing.T) {
+		clk := newFakeClock()
+		start := clk.Now()
+		if err := sleepContext(context.Background(), clk, time.Minute); err != nil {
+			t.Fatalf("sleepContext() error = %v", err)
+		}
+		if got := clk.Now().Sub(start); got != time.Minute {
+			t.Errorf("clock advanced %v, want 1m", got)
+		}
+	})
+
+	t.Run("returns when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// The real clock would block for an hour if the context were ignored
+		if err := sleepContext(ctx, systemClock, time.Hour); !errors.Is(err, context.Canceled) {
+			t.Errorf("sleepContext() error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestWithClock(t *testing.T) {
+	clk := newFakeClock()
+	cfg := &Config{APIKey: "test-key", BaseURL: "https://api.example.com", PublicKeyCacheTTL: time.Minute}
+	withClock(clk)(cfg)
+
+	base, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	calls := 0
+	base.httpClient = &MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return createMockResponse(http.StatusServiceUnavailable, `{"error":"unavailable"}`), nil
+		}
+		return createMockResponse(http.StatusOK, "PEM"), nil
+	}}
+
+	// Wrappers pick up the client's clock, so the retry wait is simulated
+	client := WithRetryingClient(WithCircuitBreakerClient(base, CircuitBreakerConfig{}), DefaultRetryConfig())
+	if _, err := client.GetPublicKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	if got, want := clk.Sleeps(), []time.Duration{DefaultRetryConfig().InitialWait}; !slices.Equal(got, want) {
+		t.Errorf("sleeps = %v, want %v", got, want)
+	}
+
+	// The public key cache expires on the same clock
+	if _, err := client.GetPublicKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want the second lookup served from the cache", calls)
+	}
+	clk.Advance(time.Minute)
+	if _, err := client.GetPublicKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want the expired entry fetched again", calls)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionMinSize is the smallest request body compressed when
+// compression is enabled without an explicit threshold
+const DefaultCompressionMinSize = 1024
+
+// compressBody gzips a request body when compression is enabled and the body
+// is at least CompressionMinSize bytes. In-memory bodies are compressed up
+// front so they can still be replayed; streamed bodies are compressed as they
+// are sent unless their known size is below the threshold.
+func (c *Client) compressBody(body io.Reader, size int64) (io.Reader, int64, bool, error) {
+	if !c.config.Compression || body == nil {
+		return body, size, false, nil
+	}
+	minSize := int64(c.config.CompressionMinSize)
+	if minSize == 0 {
+		minSize = DefaultCompressionMinSize
+	}
+
+	if buffered, ok := body.(*bytes.Reader); ok {
+		if int64(buffered.Len()) < minSize {
+			return body, size, false, nil
+		}
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		if _, err := buffered.WriteTo(gz); err != nil {
+			return nil, 0, false, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return nil, 0, false, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		return bytes.NewReader(compressed.Bytes()), -1, true, nil
+	}
+
+	if size >= 0 && size < minSize {
+		return body, size, false, nil
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		_, err := io.Copy(gz, body)
+		if err == nil {
+			err = gz.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, -1, true, nil
+}
+
+// decompressResponse transparently decodes a gzip-encoded response body
+func decompressResponse(resp *http.Response) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipResponseBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipResponseBody defers reading the gzip header until the body is first read
+type gzipResponseBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (b *gzipResponseBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = gzip.NewReader(b.body)
+		if b.err != nil {
+			b.err = fmt.Errorf("failed to decompress response: %w", b.err)
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *gzipResponseBody) Close() error {
+	return b.body.Close()
+}
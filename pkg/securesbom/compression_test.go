@@ -0,0 +1,208 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readRequestBody returns a request body, decompressing it if it is gzip-encoded
+func readRequestBody(t *testing.T, req *http.Request) []byte {
+	t.Helper()
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			t.Fatalf("request body is not gzip: %v", err)
+		}
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	return data
+}
+
+// withTestCompression configures a newTestClient's request compression
+func withTestCompression(compression bool, minSize int) func(config *Config) {
+	return func(config *Config) {
+		config.Compression = compression
+		config.CompressionMinSize = minSize
+	}
+}
+
+func TestClient_RequestCompression(t *testing.T) {
+	large := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "padding": strings.Repeat("component ", 200)}
+	small := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	tests := []struct {
+		name           string
+		compression    bool
+		minSize        int
+		sbom           interface{}
+		wantCompressed bool
+	}{
+		{name: "disabled", sbom: large},
+		{name: "large body", compression: true, sbom: large, wantCompressed: true},
+		{name: "small body skipped", compression: true, sbom: small},
+		{name: "custom threshold", compression: true, minSize: 10, sbom: small, wantCompressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encoding, acceptEncoding string
+			var body []byte
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				encoding = req.Header.Get("Content-Encoding")
+				acceptEncoding = req.Header.Get("Accept-Encoding")
+				body = readRequestBody(t, req)
+				return createMockResponse(http.StatusOK, `{"signed_sbom":{}}`), nil
+			}, withTestCompression(tt.compression, tt.minSize))
+
+			if _, err := client.SignSBOM(context.Background(), "key-1", tt.sbom); err != nil {
+				t.Fatalf("SignSBOM() error = %v", err)
+			}
+			if got := encoding == "gzip"; got != tt.wantCompressed {
+				t.Errorf("Content-Encoding = %q, want compressed %v", encoding, tt.wantCompressed)
+			}
+			if got := acceptEncoding == "gzip"; got != tt.compression {
+				t.Errorf("Accept-Encoding = %q, want gzip advertised %v", acceptEncoding, tt.compression)
+			}
+
+			var sent struct {
+				SBOM map[string]interface{} `json:"sbom"`
+			}
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Fatalf("request body is not JSON: %v", err)
+			}
+			if sent.SBOM["bomFormat"] != "CycloneDX" {
+				t.Errorf("request SBOM = %v, want the original document", sent.SBOM)
+			}
+		})
+	}
+}
+
+func TestClient_ResponseDecompression(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "success", status: http.StatusOK, body: `{"code":"VALID","message":"signature is valid"}`},
+		{name: "error", status: http.StatusNotFound, body: `{"code":"KEY_NOT_FOUND","message":"key not found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				resp := createMockResponse(tt.status, gzipBytes(t, []byte(tt.body)))
+				resp.Header.Set("Content-Encoding", "gzip")
+				return resp, nil
+			}, withTestCompression(true, 0))
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			result, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"})
+			if tt.status == http.StatusOK {
+				if err != nil || result.Message != "signature is valid" {
+					t.Fatalf("VerifySBOM() = %+v, %v, want the decompressed result", result, err)
+				}
+				return
+			}
+			apiErr, ok := AsAPIError(err)
+			if !ok || apiErr.Code != "KEY_NOT_FOUND" {
+				t.Errorf("VerifySBOM() error = %v, want the decompressed API error", err)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_RecompressesEachAttempt(t *testing.T) {
+	var bodies [][]byte
+	base := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("attempt %d was not compressed", len(bodies)+1)
+		}
+		bodies = append(bodies, readRequestBody(t, req))
+		if len(bodies) == 1 {
+			return createMockResponse(http.StatusServiceUnavailable, `{"error":"unavailable"}`), nil
+		}
+		return createMockResponse(http.StatusOK, `{"signed_sbom":{}}`), nil
+	}, withTestCompression(true, 10))
+	base.config.clock = newFakeClock()
+	client := WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1})
+
+	sbom := strings.NewReader(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`)
+	if _, err := client.SignSBOMFromReader(context.Background(), "key-1", sbom, -1); err != nil {
+		t.Fatalf("SignSBOMFromReader() error = %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("attempts = %d, want 2", len(bodies))
+	}
+	if !bytes.Equal(bodies[0], bodies[1]) || !bytes.Contains(bodies[1], []byte(`"bomFormat":"CycloneDX"`)) {
+		t.Errorf("attempt bodies differ: %s vs %s", bodies[0], bodies[1])
+	}
+}
+
+func TestConfigBuilder_WithCompression(t *testing.T) {
+	tests := []struct {
+		name    string
+		minSize int
+		wantErr bool
+	}{
+		{name: "default threshold", minSize: 0},
+		{name: "custom threshold", minSize: 4096},
+		{name: "negative threshold", minSize: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithCompression(true, tt.minSize).
+				BuildClient()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (!client.config.Compression || client.config.CompressionMinSize != tt.minSize) {
+				t.Errorf("config = %+v, want compression with minimum size %d", client.config, tt.minSize)
+			}
+		})
+	}
+}
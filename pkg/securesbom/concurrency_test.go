@@ -0,0 +1,140 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newConcurrencyTestServer serves just enough of the API for
+// TestClient_ConcurrentUse
+func newConcurrencyTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+API_VERSION_V2+API_ENDPOINT_SBOM+"/sign", func(w http.ResponseWriter, r *http.Request) {
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		var req struct {
+			SBOM json.RawMessage `json:"sbom"`
+		}
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SignResultAPIResponseV2{SignedSBOM: req.SBOM, Algorithm: AlgorithmEd25519})
+	})
+	mux.HandleFunc("POST "+API_VERSION_V2+API_ENDPOINT_SBOM+"/verify", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_ = json.NewEncoder(w).Encode(VerifyResultAPIResponseV2{Code: "VALID", Algorithm: AlgorithmEd25519})
+	})
+	mux.HandleFunc("GET "+API_VERSION+API_ENDPOINT_KEYS, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]ListKeysAPIResponse{{ID: "key-1", Algorithm: AlgorithmEd25519}})
+	})
+	mux.HandleFunc("GET "+API_VERSION+API_ENDPOINT_KEYS+"/public", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "-----BEGIN PUBLIC KEY-----\n-----END PUBLIC KEY-----\n")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestClient_ConcurrentUse shares one client, with every optional piece of
+// internal state enabled, across many goroutines. Run with -race.
+func TestClient_ConcurrentUse(t *testing.T) {
+	server := newConcurrencyTestServer(t)
+
+	base, err := NewConfigBuilder().
+		WithBaseURL(server.URL).
+		WithTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+			return &Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}, nil
+		})).
+		WithPublicKeyCache(time.Minute).
+		WithRateLimit(100000, 1000).
+		WithCompression(true, 64).
+		WithAllowedAlgorithms([]string{AlgorithmEd25519}).
+		WithErrorRedaction(true).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clients := map[string]ClientInterface{
+		"Client":               base,
+		"RetryingClient":       WithRetryingClient(base, DefaultRetryConfig()),
+		"CircuitBreakerClient": WithCircuitBreakerClient(base, CircuitBreakerConfig{}),
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			const goroutines, iterations = 16, 10
+			ctx := context.Background()
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, goroutines*iterations)
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < iterations; i++ {
+						if _, err := client.SignSBOM(ctx, "key-1", sbom); err != nil {
+							errs <- fmt.Errorf("SignSBOM: %w", err)
+						}
+						if _, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: "key-1", SBOM: sbom}); err != nil {
+							errs <- fmt.Errorf("VerifySBOM: %w", err)
+						}
+						if _, err := client.ListKeys(ctx); err != nil {
+							errs <- fmt.Errorf("ListKeys: %w", err)
+						}
+						if _, err := client.GetPublicKey(ctx, "key-1"); err != nil {
+							errs <- fmt.Errorf("GetPublicKey: %w", err)
+						}
+						if i%3 == 0 {
+							client.(publicKeyInvalidator).InvalidatePublicKey("key-1")
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+
+	if stats := base.RateLimitStats(); stats.Requests == 0 {
+		t.Error("expected rate limiter stats to be recorded")
+	}
+}
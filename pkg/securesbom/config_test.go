@@ -17,8 +17,19 @@
 package securesbom
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -28,6 +39,7 @@ func TestConfigBuilder_WithBaseURL(t *testing.T) {
 		name     string
 		baseURL  string
 		expected string
+		errorMsg string
 	}{
 		{
 			name:     "valid URL",
@@ -39,6 +51,46 @@ func TestConfigBuilder_WithBaseURL(t *testing.T) {
 			baseURL:  "",
 			expected: "",
 		},
+		{
+			name:     "trailing slashes",
+			baseURL:  "https://api.example.com//",
+			expected: "https://api.example.com",
+		},
+		{
+			name:     "path prefix",
+			baseURL:  " https://gateway.example.com/securesbom/v2/ ",
+			expected: "https://gateway.example.com/securesbom/v2",
+		},
+		{
+			name:     "plain http",
+			baseURL:  "http://localhost:8080",
+			expected: "http://localhost:8080",
+		},
+		{
+			name:     "missing scheme",
+			baseURL:  "api.example.com",
+			errorMsg: "scheme must be http or https",
+		},
+		{
+			name:     "unsupported scheme",
+			baseURL:  "ftp://api.example.com",
+			errorMsg: "scheme must be http or https",
+		},
+		{
+			name:     "missing host",
+			baseURL:  "https:///v2",
+			errorMsg: "host is required",
+		},
+		{
+			name:     "query string",
+			baseURL:  "https://api.example.com?tenant=a",
+			errorMsg: "query and fragment are not allowed",
+		},
+		{
+			name:     "malformed",
+			baseURL:  "://invalid-url",
+			errorMsg: "invalid BaseURL",
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +102,14 @@ func TestConfigBuilder_WithBaseURL(t *testing.T) {
 				t.Error("expected fluent interface")
 			}
 
+			if tt.errorMsg != "" {
+				_, err := builder.WithAPIKey("test-key").BuildClient()
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("BuildClient() error = %v, want error containing %q", err, tt.errorMsg)
+				}
+				return
+			}
+
 			config := builder.Build()
 			if config.BaseURL != tt.expected {
 				t.Errorf("expected BaseURL to be %q, got %q", tt.expected, config.BaseURL)
@@ -154,6 +214,41 @@ func TestLoadSBOMFromReader(t *testing.T) {
 			expectError: true,
 			errorMsg:    "failed to parse SBOM JSON",
 		},
+		{
+			name:        "CycloneDX XML",
+			input:       "<?xml version=\"1.0\"?>\n<bom xmlns=\"http://cyclonedx.org/schema/bom/1.5\" version=\"1\"></bom>",
+			expectError: true,
+			errorMsg:    "CycloneDX XML SBOMs are not supported",
+		},
+		{
+			name:        "SPDX RDF/XML",
+			input:       "  <rdf:RDF xmlns:spdx=\"http://spdx.org/rdf/terms#\"></rdf:RDF>",
+			expectError: true,
+			errorMsg:    "SPDX RDF/XML SBOMs are not supported",
+		},
+		{
+			name:         "gzipped JSON",
+			input:        gzipString(`{"name": "test", "version": "1.0"}`),
+			expectedName: "test",
+		},
+		{
+			name:        "truncated gzip",
+			input:       gzipString(`{"name": "test", "version": "1.0"}`)[:20],
+			expectError: true,
+			errorMsg:    "failed to decompress gzipped SBOM",
+		},
+		{
+			name:        "gzipped empty document",
+			input:       gzipString(""),
+			expectError: true,
+			errorMsg:    "no data provided after decompression",
+		},
+		{
+			name:        "zstd",
+			input:       "\x28\xb5\x2f\xfd\x00\x00",
+			expectError: true,
+			errorMsg:    "zstd-compressed SBOMs are not supported",
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,6 +283,140 @@ func TestLoadSBOMFromReader(t *testing.T) {
 	}
 }
 
+func TestLoadSBOMFromFile_Gzip(t *testing.T) {
+	const doc = `{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}`
+	path := filepath.Join(t.TempDir(), "sbom.cdx.json.gz")
+	if err := os.WriteFile(path, []byte(gzipString(doc)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sbom, err := LoadSBOMFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format := sbom.Format(); format != "cyclonedx" {
+		t.Errorf("Format() = %q, want cyclonedx", format)
+	}
+	data, err := sbom.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != doc {
+		t.Errorf("Bytes() = %q, want the decompressed document", data)
+	}
+}
+
+func TestLoadSBOMFromReaderRaw(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		format      SBOMFormat
+		expectError bool
+		expectData  bool
+	}{
+		{name: "cyclonedx", input: `{"bomFormat": "CycloneDX"}`, format: SBOMFormatCycloneDX, expectData: true},
+		{name: "asserted format is trusted", input: `{"bomFormat": "CycloneDX"}`, format: SBOMFormatSPDX, expectData: true},
+		{name: "malformed document is accepted", input: `{"bomFormat": `, format: SBOMFormatCycloneDX},
+		{name: "empty input", input: "", format: SBOMFormatCycloneDX, expectError: true},
+		{name: "unknown format", input: `{}`, format: "swid", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbom, err := LoadSBOMFromReaderRaw(strings.NewReader(tt.input), tt.format)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := sbom.Format(); got != string(tt.format) {
+				t.Errorf("Format() = %q, want %q", got, tt.format)
+			}
+			data, err := sbom.Bytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.input {
+				t.Errorf("Bytes() = %q, want %q", data, tt.input)
+			}
+			if got := sbom.Data() != nil; got != tt.expectData {
+				t.Errorf("Data() != nil is %v, want %v", got, tt.expectData)
+			}
+		})
+	}
+}
+
+// gzipString returns s gzipped
+func gzipString(s string) string {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte(s))
+	_ = zw.Close()
+	return buf.String()
+}
+
+func TestLoadSBOMFromReader_GzipLimit(t *testing.T) {
+	defer func(limit int64) { maxDecompressedSBOMSize = limit }(maxDecompressedSBOMSize)
+	maxDecompressedSBOMSize = 1024
+
+	tests := []struct {
+		name        string
+		input       string
+		expectError bool
+	}{
+		{name: "at the limit", input: `"` + strings.Repeat("a", 1022) + `"`},
+		{name: "over the limit", input: `"` + strings.Repeat("a", 1023) + `"`, expectError: true},
+		{name: "bomb", input: strings.Repeat(" ", 1<<20), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSBOMFromReader(strings.NewReader(gzipString(tt.input)))
+			if tt.expectError {
+				if !errors.Is(err, ErrSBOMTooLarge) {
+					t.Errorf("expected ErrSBOMTooLarge, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSBOM_Bytes(t *testing.T) {
+	loaded, err := LoadSBOMFromReader(strings.NewReader("{\"version\": \"1.0\",  \"name\": \"test\"}\n"))
+	if err != nil {
+		t.Fatalf("failed to load SBOM: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sbom     *SBOM
+		expected string
+	}{
+		{name: "loaded SBOM keeps its bytes", sbom: loaded, expected: "{\"version\": \"1.0\",  \"name\": \"test\"}\n"},
+		{name: "constructed SBOM is encoded", sbom: NewSBOM(map[string]string{"version": "1.0", "name": "test"}), expected: `{"name":"test","version":"1.0"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.sbom.Bytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Bytes() = %q, want %q", data, tt.expected)
+			}
+		})
+	}
+}
+
 func TestWithRetry(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -243,6 +472,20 @@ func TestWithRetry(t *testing.T) {
 			expectError:   true,
 			expectedCalls: 1,
 		},
+		{
+			name:        "stops on wrapped non-temporary error",
+			maxAttempts: 3,
+			initialWait: time.Millisecond,
+			setupFunc: func() (func() error, *int) {
+				callCount := 0
+				return func() error {
+					callCount++
+					return fmt.Errorf("failed to sign SBOM: %w", &APIError{StatusCode: 401, Message: "unauthorized"})
+				}, &callCount
+			},
+			expectError:   true,
+			expectedCalls: 1,
+		},
 		{
 			name:        "fails after max attempts",
 			maxAttempts: 2,
@@ -293,3 +536,556 @@ func TestWithRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryingClient_ContextDeadline(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return createMockResponse(http.StatusServiceUnavailable, `{"message":"unavailable"}`), nil
+	}, nil)
+	retrying := WithRetryingClient(client, RetryConfig{
+		MaxAttempts: 5,
+		InitialWait: 200 * time.Millisecond,
+		MaxWait:     time.Second,
+		Multiplier:  2,
+	})
+
+	// Room for the first 200ms wait but not the second 400ms one
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := retrying.GetKey(ctx, "key-123")
+	elapsed := time.Since(start)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want the last API error rather than a deadline error", err)
+	}
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want the 503 API error", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	if elapsed >= 300*time.Millisecond {
+		t.Errorf("took %v, want to give up before the 300ms deadline", elapsed)
+	}
+}
+
+// deadlineContext reports a fixed deadline without ever expiring, so retry
+// decisions can be checked against a fake clock
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c deadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func TestWithRetry_ContextDeadlineUsesClock(t *testing.T) {
+	clk := newFakeClock()
+	// Room on the fake clock for the first 200ms wait but not the second 400ms one
+	ctx := deadlineContext{Context: context.Background(), deadline: clk.Now().Add(300 * time.Millisecond)}
+
+	attempts := 0
+	err := WithRetry(ctx, RetryConfig{
+		MaxAttempts: 5,
+		InitialWait: 200 * time.Millisecond,
+		MaxWait:     time.Second,
+		Multiplier:  2,
+		clock:       clk,
+	}, func() error {
+		attempts++
+		return &APIError{StatusCode: http.StatusServiceUnavailable}
+	})
+
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want the 503 API error", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	if sleeps := clk.Sleeps(); !slices.Equal(sleeps, []time.Duration{200 * time.Millisecond}) {
+		t.Errorf("sleeps = %v, want one 200ms wait", sleeps)
+	}
+}
+
+func TestRetryingClient_RetryAfter(t *testing.T) {
+	clk := newFakeClock()
+	callCount := 0
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount == 1 {
+			resp := createMockResponse(429, map[string]string{"error": "rate limited"})
+			resp.Header.Set("Retry-After", "5")
+			return resp, nil
+		}
+		return createMockResponse(200, []map[string]interface{}{}), nil
+	}, func(config *Config) { config.clock = clk })
+
+	retrying := WithRetryingClient(client, RetryConfig{
+		MaxAttempts: 3,
+		InitialWait: time.Millisecond,
+		MaxWait:     10 * time.Second,
+		Multiplier:  2.0,
+	})
+
+	if _, err := retrying.ListKeys(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if callCount != 2 {
+		t.Errorf("expected 2 calls, got %d", callCount)
+	}
+	if slept := clk.Sleeps(); len(slept) != 1 || slept[0] != 5*time.Second {
+		t.Errorf("expected a single 5s wait, got %v", slept)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	base, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithTimeout(10 * time.Second).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defaulted, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		client ClientInterface
+		want   time.Duration
+	}{
+		{
+			name:   "client",
+			client: base,
+			want:   10 * time.Second,
+		},
+		{
+			name:   "default",
+			client: defaulted,
+			want:   DefaultTimeout,
+		},
+		{
+			name: "retrying client",
+			client: WithRetryingClient(base, RetryConfig{
+				MaxAttempts: 3,
+				InitialWait: time.Second,
+				MaxWait:     5 * time.Second,
+				Multiplier:  2.0,
+			}),
+			want: 3*10*time.Second + 2*5*time.Second,
+		},
+		{
+			name: "retrying client with elapsed time budget",
+			client: WithRetryingClient(base, RetryConfig{
+				MaxAttempts:    10,
+				MaxWait:        5 * time.Second,
+				MaxElapsedTime: 20 * time.Second,
+			}),
+			want: 20*time.Second + 10*time.Second,
+		},
+		{
+			name:   "retrying client without attempts",
+			client: WithRetryingClient(base, RetryConfig{MaxWait: 5 * time.Second}),
+			want:   10 * time.Second,
+		},
+		{
+			name:   "circuit breaker",
+			client: WithCircuitBreakerClient(base, CircuitBreakerConfig{}),
+			want:   10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.Timeout(); got != tt.want {
+				t.Errorf("Timeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_MaxElapsedTime(t *testing.T) {
+	clk := newFakeClock()
+	callCount := 0
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		callCount++
+		resp := createMockResponse(429, map[string]string{"error": "rate limited"})
+		resp.Header.Set("Retry-After", "5")
+		return resp, nil
+	}, func(config *Config) { config.clock = clk })
+
+	// MaxAttempts alone would allow 50s of waiting
+	retrying := WithRetryingClient(client, RetryConfig{
+		MaxAttempts:    10,
+		InitialWait:    time.Millisecond,
+		MaxWait:        time.Minute,
+		Multiplier:     2.0,
+		MaxElapsedTime: 12 * time.Second,
+	})
+
+	_, err := retrying.ListKeys(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "elapsed time budget of 12s exhausted") {
+		t.Fatalf("expected the elapsed time budget to be reported, got %v", err)
+	}
+	if !IsRateLimited(err) {
+		t.Errorf("expected the last error to be wrapped, got %v", err)
+	}
+	if callCount != 3 {
+		t.Errorf("expected 3 calls, got %d", callCount)
+	}
+	if slept := clk.Sleeps(); !slices.Equal(slept, []time.Duration{5 * time.Second, 5 * time.Second}) {
+		t.Errorf("expected two 5s waits, got %v", slept)
+	}
+
+	t.Run("set by the builder", func(t *testing.T) {
+		built, err := NewConfigBuilder().
+			WithAPIKey("test-key").
+			WithBaseURL("https://api.example.com").
+			WithMaxRetryElapsedTime(30 * time.Second).
+			BuildRetryingClient()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if built.retryConfig.MaxElapsedTime != 30*time.Second {
+			t.Errorf("expected MaxElapsedTime 30s, got %v", built.retryConfig.MaxElapsedTime)
+		}
+
+		if _, err := NewConfigBuilder().WithAPIKey("test-key").WithBaseURL("https://api.example.com").WithMaxRetryElapsedTime(-time.Second).BuildRetryingClient(); err == nil {
+			t.Error("expected an error for a negative budget")
+		}
+	})
+}
+
+func TestRetryingClient_RetryableFunc(t *testing.T) {
+	// Retries conflicts, which are normally permanent, and nothing else
+	retryConflicts := func(resp *http.Response, err error) bool {
+		return resp != nil && resp.StatusCode == http.StatusConflict
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		networkErr  bool
+		retryable   func(resp *http.Response, err error) bool
+		expectCalls int
+	}{
+		{name: "default does not retry conflicts", status: http.StatusConflict, expectCalls: 1},
+		{name: "default retries server errors", status: http.StatusInternalServerError, expectCalls: 3},
+		{name: "default retries network errors", networkErr: true, expectCalls: 3},
+		{name: "func retries conflicts", status: http.StatusConflict, retryable: retryConflicts, expectCalls: 3},
+		{name: "func skips server errors", status: http.StatusInternalServerError, retryable: retryConflicts, expectCalls: 1},
+		{name: "func gets no response for network errors", networkErr: true, retryable: retryConflicts, expectCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				callCount++
+				if tt.networkErr {
+					return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+				}
+				return createMockResponse(tt.status, map[string]string{"error": "failed"}), nil
+			}, func(config *Config) { config.clock = newFakeClock() })
+
+			var seen []int
+			retryConfig := RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Second, Multiplier: 2}
+			if tt.retryable != nil {
+				retryConfig.RetryableFunc = func(resp *http.Response, err error) bool {
+					if err == nil {
+						t.Error("RetryableFunc called without an error")
+					}
+					if resp != nil {
+						seen = append(seen, resp.StatusCode)
+					}
+					return tt.retryable(resp, err)
+				}
+			}
+
+			if _, err := WithRetryingClient(client, retryConfig).ListKeys(context.Background()); err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if callCount != tt.expectCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectCalls, callCount)
+			}
+			if tt.retryable != nil && !tt.networkErr && len(seen) != tt.expectCalls {
+				t.Errorf("RetryableFunc saw responses %v", seen)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_SignSBOMFromReader(t *testing.T) {
+	sbomJSON := `{"bomFormat":"CycloneDX"}`
+
+	tests := []struct {
+		name          string
+		reader        func() io.Reader
+		expectError   bool
+		expectedCalls int
+	}{
+		{
+			name:          "seekable reader is replayed",
+			reader:        func() io.Reader { return strings.NewReader(sbomJSON) },
+			expectedCalls: 2,
+		},
+		{
+			name:          "plain reader gets a single attempt",
+			reader:        func() io.Reader { return io.MultiReader(strings.NewReader(sbomJSON)) },
+			expectError:   true,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				callCount++
+				body, _ := io.ReadAll(req.Body)
+				if !strings.Contains(string(body), sbomJSON) {
+					t.Errorf("attempt %d sent incomplete body %q", callCount, body)
+				}
+				if callCount == 1 {
+					return createMockResponse(503, map[string]string{"error": "unavailable"}), nil
+				}
+				return createMockResponse(200, SignResultAPIResponseV2{Signature: "sig"}), nil
+			}, func(config *Config) { config.clock = newFakeClock() })
+
+			retrying := WithRetryingClient(client, RetryConfig{
+				MaxAttempts: 3,
+				InitialWait: time.Millisecond,
+				MaxWait:     time.Millisecond,
+				Multiplier:  2.0,
+			})
+
+			_, err := retrying.SignSBOMFromReader(context.Background(), "key-123", tt.reader(), -1)
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error %v, got %v", tt.expectError, err)
+			}
+			if callCount != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, callCount)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "delta seconds", value: "5", expected: 5 * time.Second},
+		{name: "negative seconds", value: "-1", expected: 0},
+		{name: "http date", value: now.Add(30 * time.Second).Format(http.TimeFormat), expected: 30 * time.Second},
+		{name: "http date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0},
+		{name: "garbage", value: "soon", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	base := RetryConfig{
+		MaxAttempts: 5,
+		InitialWait: 100 * time.Millisecond,
+		MaxWait:     time.Second,
+		Multiplier:  2.0,
+	}
+
+	tests := []struct {
+		name     string
+		jitter   float64
+		random   float64
+		attempt  int
+		err      error
+		expected time.Duration
+	}{
+		{name: "no jitter first attempt", attempt: 0, expected: 100 * time.Millisecond},
+		{name: "no jitter grows exponentially", attempt: 2, expected: 400 * time.Millisecond},
+		{name: "no jitter capped by max wait", attempt: 10, expected: time.Second},
+		{name: "half jitter", jitter: 0.5, random: 0.5, attempt: 2, expected: 300 * time.Millisecond},
+		{name: "half jitter with zero random", jitter: 0.5, random: 0, attempt: 2, expected: 400 * time.Millisecond},
+		{name: "full jitter", jitter: 1, random: 0.25, attempt: 1, expected: 150 * time.Millisecond},
+		{name: "jitter fraction clamped", jitter: 3, random: 0.5, attempt: 0, expected: 50 * time.Millisecond},
+		{
+			name:     "retry-after wins over jittered wait",
+			jitter:   1,
+			random:   0.5,
+			attempt:  0,
+			err:      &APIError{StatusCode: 429, RetryAfter: 700 * time.Millisecond},
+			expected: 700 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			config.JitterFraction = tt.jitter
+			config.Rand = func() float64 { return tt.random }
+
+			if got := config.backoffStrategy().NextDelay(tt.attempt, tt.err, nil); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_DoesNotRetryInvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(client *RetryingClient) error
+		wantErr error
+	}{
+		{
+			name: "unsupported algorithm",
+			call: func(client *RetryingClient) error {
+				_, err := client.GenerateKeyWithOptions(context.Background(), GenerateKeyOptions{Algorithm: "bogus"})
+				return err
+			},
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "invalid public key",
+			call: func(client *RetryingClient) error {
+				_, err := client.VerifyWithPublicKey(context.Background(), "not a key", []byte(`{"bomFormat":"CycloneDX"}`))
+				return err
+			},
+			wantErr: ErrInvalidPublicKey,
+		},
+		{
+			name: "missing key ID",
+			call: func(client *RetryingClient) error {
+				_, err := client.GetPublicKey(context.Background(), "")
+				return err
+			},
+		},
+		{
+			name: "XML SBOM",
+			call: func(client *RetryingClient) error {
+				_, err := client.SignSBOM(context.Background(), "key-123", []byte(`<?xml version="1.0"?><bom/>`))
+				return err
+			},
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			clk := newFakeClock()
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				return createMockResponse(http.StatusOK, nil), nil
+			}, func(config *Config) { config.clock = clk })
+			retryConfig := RetryConfig{MaxAttempts: 3, InitialWait: 200 * time.Millisecond, MaxWait: time.Second, Multiplier: 2}
+
+			err := tt.call(WithRetryingClient(client, retryConfig))
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "attempts") || len(clk.Sleeps()) != 0 || requests != 0 {
+				t.Errorf("error = %v after %d requests and waits %v, want it returned at once", err, requests, clk.Sleeps())
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_WithUserAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		product     string
+		version     string
+		expected    string
+		expectError bool
+	}{
+		{name: "product and version", product: "myapp", version: "1.2.3", expected: "myapp/1.2.3 " + UserAgent},
+		{name: "product only", product: "myapp", expected: "myapp " + UserAgent},
+		{name: "empty product", version: "1.0", expectError: true},
+		{name: "product with slash", product: "my/app", version: "1.0", expectError: true},
+		{name: "version with space", product: "myapp", version: "1 0", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userAgents []string
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithHTTPClient(&MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						userAgents = append(userAgents, req.Header.Get("User-Agent"))
+						return createMockResponse(200, []map[string]interface{}{}), nil
+					},
+				}).
+				WithUserAgent(tt.product, tt.version).
+				BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Every request carries the header, not just signing calls
+			_ = client.HealthCheck(context.Background())
+			_, _ = client.ListKeys(context.Background())
+
+			if len(userAgents) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(userAgents))
+			}
+			for _, userAgent := range userAgents {
+				if userAgent != tt.expected {
+					t.Errorf("expected User-Agent %q, got %q", tt.expected, userAgent)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		expected  string
+	}{
+		{userAgent: "", expected: UserAgent},
+		{userAgent: "custom-agent", expected: "custom-agent " + UserAgent},
+		{userAgent: "custom-agent " + UserAgent, expected: "custom-agent " + UserAgent},
+	}
+
+	for _, tt := range tests {
+		if got := buildUserAgent(tt.userAgent); got != tt.expected {
+			t.Errorf("buildUserAgent(%q) = %q, expected %q", tt.userAgent, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// fileConfig is the layout of a configuration file read by FromFile. JSON files
+// use the same keys.
+type fileConfig struct {
+	APIKey  string         `json:"api_key"`
+	BaseURL string         `json:"base_url"`
+	Timeout string         `json:"timeout"`
+	Retries int            `json:"retries"`
+	TLS     *fileTLSConfig `json:"tls"`
+}
+
+type fileTLSConfig struct {
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// FromFile loads configuration from a YAML or JSON file such as:
+//
+//	api_key: ...
+//	base_url: https://sbom.example.com
+//	timeout: 30s
+//	retries: 3
+//	tls:
+//	  ca_file: ca.pem
+//	  cert_file: client.pem
+//	  key_file: client-key.pem
+//	  insecure_skip_verify: false
+//
+// Relative TLS paths are resolved against the file's directory. File values
+// override FromEnv but not explicit With calls. An empty path is ignored, so a
+// command-line flag can be passed through unconditionally; a path that does not
+// exist, an unknown key, or an invalid value is reported by BuildClient. When
+// called more than once, later files take precedence.
+func (b *ConfigBuilder) FromFile(path string) *ConfigBuilder {
+	if path == "" {
+		return b
+	}
+
+	config, err := loadConfigFile(path)
+	if err != nil {
+		b.addError(err)
+		return b
+	}
+	if b.file != nil {
+		fillUnset(config, b.file)
+	}
+	b.file = config
+	return b
+}
+
+func loadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("config file %s does not exist: %w", path, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var fc fileConfig
+	if err := yaml.UnmarshalStrict(data, &fc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	config, err := fc.toConfig(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// toConfig converts the file layout to a Config, resolving relative paths
+// against dir
+func (fc fileConfig) toConfig(dir string) (*Config, error) {
+	config := &Config{
+		APIKey:  fc.APIKey,
+		BaseURL: fc.BaseURL,
+		Retries: fc.Retries,
+	}
+
+	if fc.Timeout != "" {
+		timeout, err := time.ParseDuration(fc.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("timeout cannot be negative")
+		}
+		config.Timeout = timeout
+	}
+	if fc.Retries < 0 {
+		return nil, fmt.Errorf("retries cannot be negative")
+	}
+
+	if fc.TLS == nil {
+		return config, nil
+	}
+	config.InsecureSkipVerify = fc.TLS.InsecureSkipVerify
+
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	if fc.TLS.CAFile != "" {
+		pemBytes, err := os.ReadFile(resolve(fc.TLS.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", fc.TLS.CAFile, err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no valid CA certificates found in %s", fc.TLS.CAFile)
+		}
+	}
+
+	if (fc.TLS.CertFile == "") != (fc.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("tls cert_file and key_file must be set together")
+	}
+	if fc.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(resolve(fc.TLS.CertFile), resolve(fc.TLS.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from %s: %w", fc.TLS.CertFile, err)
+		}
+		config.ClientCertificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
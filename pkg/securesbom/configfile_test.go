@@ -0,0 +1,290 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestConfigBuilder_FromFile(t *testing.T) {
+	yamlPath := writeConfigFile(t, "config.yaml", `
+api_key: file-key
+base_url: https://file.example.com
+timeout: 45s
+retries: 5
+`)
+	jsonPath := writeConfigFile(t, "config.json", `{"api_key": "json-key", "timeout": "10s"}`)
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		build         func(b *ConfigBuilder) *ConfigBuilder
+		expectError   string
+		expectAPIKey  string
+		expectBaseURL string
+		expectTimeout time.Duration
+		expectRetries int
+	}{
+		{
+			name: "yaml file",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(yamlPath)
+			},
+			expectAPIKey:  "file-key",
+			expectBaseURL: "https://file.example.com",
+			expectTimeout: 45 * time.Second,
+			expectRetries: 5,
+		},
+		{
+			name: "json file uses default base URL",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(jsonPath)
+			},
+			expectAPIKey:  "json-key",
+			expectBaseURL: DEFAULT_SECURE_SBOM_BASE_URL,
+			expectTimeout: 10 * time.Second,
+		},
+		{
+			name: "file overrides env",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_BASE_URL": "https://env.example.com"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(jsonPath).FromEnv()
+			},
+			expectAPIKey:  "json-key",
+			expectBaseURL: "https://env.example.com",
+			expectTimeout: 10 * time.Second,
+		},
+		{
+			name: "explicit overrides file regardless of order",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithAPIKey("explicit-key").WithTimeout(time.Second).FromFile(yamlPath)
+			},
+			expectAPIKey:  "explicit-key",
+			expectBaseURL: "https://file.example.com",
+			expectTimeout: time.Second,
+			expectRetries: 5,
+		},
+		{
+			name: "explicit overrides env regardless of order",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithAPIKey("explicit-key").FromEnv()
+			},
+			expectAPIKey:  "explicit-key",
+			expectBaseURL: DEFAULT_SECURE_SBOM_BASE_URL,
+		},
+		{
+			name: "env timeout and retries",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_TIMEOUT": "90s", "SECURE_SBOM_RETRIES": "4"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv()
+			},
+			expectAPIKey:  "env-key",
+			expectBaseURL: DEFAULT_SECURE_SBOM_BASE_URL,
+			expectTimeout: 90 * time.Second,
+			expectRetries: 4,
+		},
+		{
+			name: "explicit timeout and retries override env",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_TIMEOUT": "90s", "SECURE_SBOM_RETRIES": "4"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv().WithTimeout(5 * time.Second).WithRetries(2)
+			},
+			expectAPIKey:  "env-key",
+			expectBaseURL: DEFAULT_SECURE_SBOM_BASE_URL,
+			expectTimeout: 5 * time.Second,
+			expectRetries: 2,
+		},
+		{
+			name: "file retries override env",
+			env:  map[string]string{"SECURE_SBOM_RETRIES": "4"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv().FromFile(yamlPath)
+			},
+			expectAPIKey:  "file-key",
+			expectBaseURL: "https://file.example.com",
+			expectTimeout: 45 * time.Second,
+			expectRetries: 5,
+		},
+		{
+			name: "invalid env timeout",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_TIMEOUT": "30"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv()
+			},
+			expectError: `invalid SECURE_SBOM_TIMEOUT "30"`,
+		},
+		{
+			name: "invalid env retries",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_RETRIES": "three"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv()
+			},
+			expectError: `invalid SECURE_SBOM_RETRIES "three"`,
+		},
+		{
+			name: "negative env retries",
+			env:  map[string]string{"SECURE_SBOM_API_KEY": "env-key", "SECURE_SBOM_RETRIES": "-1"},
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromEnv()
+			},
+			expectError: "retries cannot be negative",
+		},
+		{
+			name: "later file overrides earlier file",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(yamlPath).FromFile(jsonPath)
+			},
+			expectAPIKey:  "json-key",
+			expectBaseURL: "https://file.example.com",
+			expectTimeout: 10 * time.Second,
+			expectRetries: 5,
+		},
+		{
+			name: "empty path is ignored",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithAPIKey("explicit-key").WithBaseURL("https://api.example.com").FromFile("")
+			},
+			expectAPIKey:  "explicit-key",
+			expectBaseURL: "https://api.example.com",
+		},
+		{
+			name: "missing file",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(filepath.Join(t.TempDir(), "missing.yaml"))
+			},
+			expectError: "does not exist",
+		},
+		{
+			name: "unknown key",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(writeConfigFile(t, "typo.yaml", "apikey: oops\n"))
+			},
+			expectError: "failed to parse config file",
+		},
+		{
+			name: "invalid timeout",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(writeConfigFile(t, "timeout.yaml", "api_key: k\ntimeout: soon\n"))
+			},
+			expectError: "invalid timeout",
+		},
+		{
+			name: "cert without key",
+			build: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.FromFile(writeConfigFile(t, "tls.yaml", "api_key: k\ntls:\n  cert_file: client.pem\n"))
+			},
+			expectError: "must be set together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SECURE_SBOM_API_KEY", "")
+			t.Setenv("SECURE_SBOM_BASE_URL", "")
+			t.Setenv("SECURE_SBOM_TIMEOUT", "")
+			t.Setenv("SECURE_SBOM_RETRIES", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			builder := tt.build(NewConfigBuilder())
+			client, err := builder.BuildClient()
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			config := builder.Build()
+			if config.APIKey != tt.expectAPIKey {
+				t.Errorf("expected APIKey %q, got %q", tt.expectAPIKey, config.APIKey)
+			}
+			if config.BaseURL != tt.expectBaseURL {
+				t.Errorf("expected BaseURL %q, got %q", tt.expectBaseURL, config.BaseURL)
+			}
+			if config.Timeout != tt.expectTimeout {
+				t.Errorf("expected Timeout %v, got %v", tt.expectTimeout, config.Timeout)
+			}
+			if config.Retries != tt.expectRetries {
+				t.Errorf("expected Retries %d, got %d", tt.expectRetries, config.Retries)
+			}
+			if client == nil {
+				t.Error("expected client")
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_FromFileTLS(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{"ca.pem": certPEM, "client.pem": certPEM, "client-key.pem": keyPEM} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	path := filepath.Join(dir, "config.yaml")
+	content := "api_key: k\ntls:\n  ca_file: ca.pem\n  cert_file: client.pem\n  key_file: client-key.pem\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	config := NewConfigBuilder().FromFile(path).Build()
+	if config.RootCAs == nil {
+		t.Error("expected RootCAs to be loaded relative to the config file")
+	}
+	if len(config.ClientCertificates) != 1 {
+		t.Errorf("expected 1 client certificate, got %d", len(config.ClientCertificates))
+	}
+}
+
+func TestConfigBuilder_BuildRetryingClient(t *testing.T) {
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithRetries(7).
+		BuildRetryingClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.retryConfig.MaxAttempts != 7 {
+		t.Errorf("expected 7 attempts, got %d", client.retryConfig.MaxAttempts)
+	}
+
+	if _, err := NewConfigBuilder().WithRetries(-1).BuildRetryingClient(); err == nil {
+		t.Error("expected error for negative retries")
+	}
+}
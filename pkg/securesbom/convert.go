@@ -0,0 +1,471 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SBOMFormat names an SBOM format, as returned by SBOM.Format
+type SBOMFormat string
+
+const (
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+	SBOMFormatSPDX      SBOMFormat = "spdx"
+)
+
+// Versions written by ConvertSBOM
+const (
+	convertedCycloneDXVersion = "1.5"
+	convertedSPDXVersion      = "SPDX-2.3"
+)
+
+// ConvertSBOM converts a JSON CycloneDX document to SPDX 2.3 JSON, or an SPDX
+// JSON document to CycloneDX 1.5, so one pipeline can sign each consumer's
+// preferred format. A document already in the target format is returned
+// unchanged. The conversion is best effort and keeps the core package data:
+//
+//   - CycloneDX components, nested ones included, become SPDX packages; the
+//     metadata.component becomes the package the document DESCRIBES, and the
+//     other way round
+//   - name, version (versionInfo), purl (a PACKAGE-MANAGER purl external
+//     reference), hashes (checksums), supplier, description, and licenses
+//     (licenseDeclared, as one expression) carry over
+//   - dependencies become DEPENDS_ON relationships; DEPENDS_ON and any
+//     *DEPENDENCY_OF relationship between packages become dependencies
+//   - nested CycloneDX components also get a CONTAINS relationship from their
+//     parent
+//   - the serial number and SPDX document namespace derive from each other,
+//     so repeated conversions of a document match, and the creation time
+//     carries over; a CycloneDX document without metadata.timestamp gets the
+//     current time, which SPDX requires
+//
+// Everything else is dropped, including embedded signatures, which no longer
+// match the converted document, so sign the output rather than the input.
+// Dropped CycloneDX data includes services, vulnerabilities, compositions,
+// properties, evidence, and external references other than the purl. Dropped
+// SPDX data includes files, snippets, annotations, extracted licensing info,
+// other relationships, and license information that isn't a declared license
+// expression. A CycloneDX license with only a name becomes NOASSERTION.
+func ConvertSBOM(sbom []byte, target SBOMFormat) ([]byte, error) {
+	if target != SBOMFormatCycloneDX && target != SBOMFormatSPDX {
+		return nil, fmt.Errorf("unknown target format %q", target)
+	}
+	if isSPDXTagValue(sbom) {
+		return nil, fmt.Errorf("SPDX tag-value documents can't be converted, use SPDX JSON: %w", ErrUnsupportedFormat)
+	}
+	_, parsed, err := marshalSBOM(sbom)
+	if err != nil {
+		return nil, err
+	}
+	doc, _ := parsed.(map[string]interface{})
+
+	source := SBOMFormat(detectSBOMFormat(doc))
+	var converted interface{}
+	switch {
+	case source == "":
+		return nil, fmt.Errorf("sbom is neither CycloneDX nor SPDX: %w", ErrUnsupportedFormat)
+	case source == target:
+		return slices.Clone(sbom), nil
+	case target == SBOMFormatSPDX:
+		converted = cycloneDXToSPDX(doc, sbom)
+	default:
+		converted = spdxToCycloneDX(doc, sbom)
+	}
+
+	data, err := json.MarshalIndent(converted, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal converted SBOM: %w", err)
+	}
+	return data, nil
+}
+
+type convertedSPDX struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	Supplier         string            `json:"supplier,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Description      string            `json:"description,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type convertedCycloneDX struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components,omitempty"`
+	Dependencies []cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp,omitempty"`
+	Component *cdxComponent `json:"component,omitempty"`
+}
+
+type cdxComponent struct {
+	Type        string       `json:"type"`
+	BOMRef      string       `json:"bom-ref,omitempty"`
+	Supplier    *cdxSupplier `json:"supplier,omitempty"`
+	Name        string       `json:"name"`
+	Version     string       `json:"version,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Hashes      []cdxHash    `json:"hashes,omitempty"`
+	Licenses    []cdxLicense `json:"licenses,omitempty"`
+	PURL        string       `json:"purl,omitempty"`
+}
+
+type cdxSupplier struct {
+	Name string `json:"name"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// hashAlgorithms maps CycloneDX hash algorithm names to SPDX checksum
+// algorithms; others are dropped
+var hashAlgorithms = map[string]string{
+	"MD5":         "MD5",
+	"SHA-1":       "SHA1",
+	"SHA-256":     "SHA256",
+	"SHA-384":     "SHA384",
+	"SHA-512":     "SHA512",
+	"SHA3-256":    "SHA3-256",
+	"SHA3-384":    "SHA3-384",
+	"SHA3-512":    "SHA3-512",
+	"BLAKE2b-256": "BLAKE2b-256",
+	"BLAKE2b-384": "BLAKE2b-384",
+	"BLAKE2b-512": "BLAKE2b-512",
+	"BLAKE3":      "BLAKE3",
+}
+
+// spdxIDInvalid matches characters not allowed in an SPDX identifier
+var spdxIDInvalid = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// cycloneDXToSPDX converts a parsed CycloneDX document; raw seeds the
+// document namespace when there is no serial number
+func cycloneDXToSPDX(doc map[string]interface{}, raw []byte) *convertedSPDX {
+	name := "sbom"
+	created := time.Now().UTC().Format(time.RFC3339)
+	meta, _ := doc["metadata"].(map[string]interface{})
+	root, _ := meta["component"].(map[string]interface{})
+	if n, _ := root["name"].(string); n != "" {
+		name = n
+	}
+	if timestamp, _ := meta["timestamp"].(string); timestamp != "" {
+		created = timestamp
+	}
+
+	id := uuid.NewSHA1(uuid.NameSpaceURL, raw)
+	if serial, _ := doc["serialNumber"].(string); serial != "" {
+		if parsed, err := uuid.Parse(strings.TrimPrefix(serial, "urn:uuid:")); err == nil {
+			id = parsed
+		} else {
+			id = uuid.NewSHA1(uuid.NameSpaceURL, []byte(serial))
+		}
+	}
+
+	out := &convertedSPDX{
+		SPDXVersion:       convertedSPDXVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentRef,
+		Name:              name,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + spdxIDInvalid.ReplaceAllString(name, "-") + "-" + id.String(),
+		CreationInfo: spdxCreationInfo{
+			Created:  created,
+			Creators: []string{spdxAnnotator},
+		},
+		Packages: []spdxPackage{},
+	}
+
+	ids := make(map[string]bool)
+	refs := make(map[string]string)
+	var add func(component map[string]interface{}, parent string) string
+	add = func(component map[string]interface{}, parent string) string {
+		pkg := cycloneDXComponentToPackage(component, ids)
+		out.Packages = append(out.Packages, pkg)
+		if ref, _ := component["bom-ref"].(string); ref != "" {
+			refs[ref] = pkg.SPDXID
+		}
+		if parent != "" {
+			out.Relationships = append(out.Relationships, spdxRelationship{parent, "CONTAINS", pkg.SPDXID})
+		}
+		for _, child := range objects(component["components"]) {
+			add(child, pkg.SPDXID)
+		}
+		return pkg.SPDXID
+	}
+
+	if root != nil {
+		out.Relationships = append(out.Relationships, spdxRelationship{spdxDocumentRef, "DESCRIBES", add(root, "")})
+	}
+	for _, component := range objects(doc["components"]) {
+		id := add(component, "")
+		if root == nil {
+			out.Relationships = append(out.Relationships, spdxRelationship{spdxDocumentRef, "DESCRIBES", id})
+		}
+	}
+
+	for _, dependency := range objects(doc["dependencies"]) {
+		ref, _ := dependency["ref"].(string)
+		from, ok := refs[ref]
+		if !ok {
+			continue
+		}
+		dependsOn, _ := dependency["dependsOn"].([]interface{})
+		for _, d := range dependsOn {
+			depRef, _ := d.(string)
+			if to, ok := refs[depRef]; ok {
+				out.Relationships = append(out.Relationships, spdxRelationship{from, "DEPENDS_ON", to})
+			}
+		}
+	}
+	return out
+}
+
+// cycloneDXComponentToPackage converts one component, giving it an SPDX ID
+// not yet in ids
+func cycloneDXComponentToPackage(component map[string]interface{}, ids map[string]bool) spdxPackage {
+	pkg := spdxPackage{
+		DownloadLocation: "NOASSERTION",
+		LicenseConcluded: "NOASSERTION",
+		LicenseDeclared:  "NOASSERTION",
+		CopyrightText:    "NOASSERTION",
+	}
+	pkg.Name, _ = component["name"].(string)
+	pkg.VersionInfo, _ = component["version"].(string)
+	pkg.Description, _ = component["description"].(string)
+	if supplier, ok := component["supplier"].(map[string]interface{}); ok {
+		if name, _ := supplier["name"].(string); name != "" {
+			pkg.Supplier = "Organization: " + name
+		}
+	}
+	if purl, _ := component["purl"].(string); purl != "" {
+		pkg.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}}
+	}
+	for _, hash := range objects(component["hashes"]) {
+		alg, _ := hash["alg"].(string)
+		content, _ := hash["content"].(string)
+		if algorithm, ok := hashAlgorithms[alg]; ok && content != "" {
+			pkg.Checksums = append(pkg.Checksums, spdxChecksum{Algorithm: algorithm, ChecksumValue: content})
+		}
+	}
+	if license := cycloneDXLicenseExpression(component["licenses"]); license != "" {
+		pkg.LicenseDeclared = license
+	}
+
+	base, _ := component["bom-ref"].(string)
+	if base == "" {
+		base = strings.Trim(pkg.Name+"-"+pkg.VersionInfo, "-")
+	}
+	base = "SPDXRef-" + strings.Trim(spdxIDInvalid.ReplaceAllString(base, "-"), "-")
+	pkg.SPDXID = base
+	for n := 2; ids[pkg.SPDXID] || pkg.SPDXID == spdxDocumentRef; n++ {
+		pkg.SPDXID = fmt.Sprintf("%s-%d", base, n)
+	}
+	ids[pkg.SPDXID] = true
+	return pkg
+}
+
+// cycloneDXLicenseExpression joins the license IDs and expressions of a
+// CycloneDX licenses array with AND. It returns NOASSERTION if any license has
+// only a name, and an empty string if there are none.
+func cycloneDXLicenseExpression(licenses interface{}) string {
+	var terms []string
+	for _, entry := range objects(licenses) {
+		if expression, _ := entry["expression"].(string); expression != "" {
+			terms = append(terms, "("+expression+")")
+			continue
+		}
+		license, _ := entry["license"].(map[string]interface{})
+		id, _ := license["id"].(string)
+		if id == "" {
+			return "NOASSERTION"
+		}
+		terms = append(terms, id)
+	}
+	if len(terms) == 1 {
+		return strings.TrimSuffix(strings.TrimPrefix(terms[0], "("), ")")
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// spdxToCycloneDX converts a parsed SPDX document; raw seeds the serial
+// number when there is no document namespace
+func spdxToCycloneDX(doc map[string]interface{}, raw []byte) *convertedCycloneDX {
+	seed := raw
+	if namespace, _ := doc["documentNamespace"].(string); namespace != "" {
+		seed = []byte(namespace)
+	}
+	out := &convertedCycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  convertedCycloneDXVersion,
+		SerialNumber: "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, seed).String(),
+		Version:      1,
+	}
+	if info, ok := doc["creationInfo"].(map[string]interface{}); ok {
+		out.Metadata.Timestamp, _ = info["created"].(string)
+	}
+
+	packages, _ := doc["packages"].([]interface{})
+	root := spdxDescribedPackage(doc, packages)
+	isPackage := make(map[string]bool)
+	for _, pkg := range objects(packages) {
+		component := spdxPackageToComponent(pkg)
+		isPackage[component.BOMRef] = true
+		if root != nil && pkg["SPDXID"] == root["SPDXID"] {
+			component.Type = "application"
+			out.Metadata.Component = &component
+			continue
+		}
+		out.Components = append(out.Components, component)
+	}
+
+	var order []string
+	dependsOn := make(map[string][]string)
+	addDependency := func(from, to string) {
+		if !isPackage[from] || !isPackage[to] || slices.Contains(dependsOn[from], to) {
+			return
+		}
+		if _, ok := dependsOn[from]; !ok {
+			order = append(order, from)
+		}
+		dependsOn[from] = append(dependsOn[from], to)
+	}
+	for _, rel := range objects(doc["relationships"]) {
+		element, _ := rel["spdxElementId"].(string)
+		related, _ := rel["relatedSpdxElement"].(string)
+		relationship, _ := rel["relationshipType"].(string)
+		switch {
+		case relationship == "DEPENDS_ON":
+			addDependency(element, related)
+		case strings.HasSuffix(relationship, "DEPENDENCY_OF"):
+			addDependency(related, element)
+		}
+	}
+	for _, ref := range order {
+		out.Dependencies = append(out.Dependencies, cdxDependency{Ref: ref, DependsOn: dependsOn[ref]})
+	}
+	return out
+}
+
+// spdxPackageToComponent converts one package to a library component whose
+// bom-ref is the package's SPDX ID
+func spdxPackageToComponent(pkg map[string]interface{}) cdxComponent {
+	component := cdxComponent{Type: "library"}
+	component.BOMRef, _ = pkg["SPDXID"].(string)
+	component.Name, _ = pkg["name"].(string)
+	component.Version, _ = pkg["versionInfo"].(string)
+	component.Description, _ = pkg["description"].(string)
+	if supplier, _ := pkg["supplier"].(string); supplier != "" && supplier != "NOASSERTION" {
+		_, name, found := strings.Cut(supplier, ": ")
+		if !found {
+			name = supplier
+		}
+		component.Supplier = &cdxSupplier{Name: name}
+	}
+	for _, ref := range objects(pkg["externalRefs"]) {
+		if ref["referenceType"] == "purl" {
+			component.PURL, _ = ref["referenceLocator"].(string)
+			break
+		}
+	}
+	for _, checksum := range objects(pkg["checksums"]) {
+		algorithm, _ := checksum["algorithm"].(string)
+		value, _ := checksum["checksumValue"].(string)
+		for alg, spdxAlgorithm := range hashAlgorithms {
+			if spdxAlgorithm == algorithm && value != "" {
+				component.Hashes = append(component.Hashes, cdxHash{Alg: alg, Content: value})
+				break
+			}
+		}
+	}
+	if license, _ := pkg["licenseDeclared"].(string); license != "" && license != "NOASSERTION" && license != "NONE" {
+		component.Licenses = []cdxLicense{{Expression: license}}
+	}
+	return component
+}
+
+// objects returns the JSON objects in v, a JSON array, skipping anything else
+func objects(v interface{}) []map[string]interface{} {
+	items, _ := v.([]interface{})
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const convertCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2026-01-02T03:04:05Z",
+    "component": {"type": "application", "bom-ref": "app", "name": "app", "version": "1.2.3"}
+  },
+  "components": [
+    {
+      "type": "library", "bom-ref": "pkg:npm/lodash@4.17.21", "name": "lodash", "version": "4.17.21",
+      "purl": "pkg:npm/lodash@4.17.21", "supplier": {"name": "OpenJS"},
+      "hashes": [{"alg": "SHA-256", "content": "abc123"}, {"alg": "unknown", "content": "x"}],
+      "licenses": [{"license": {"id": "MIT"}}],
+      "components": [{"type": "library", "name": "lodash.get", "version": "4.4.2", "purl": "pkg:npm/lodash.get@4.4.2"}]
+    },
+    {"type": "library", "bom-ref": "left-pad", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["pkg:npm/lodash@4.17.21", "left-pad", "missing"]}
+  ],
+  "signature": {"algorithm": "ES256", "value": "c2ln"}
+}`
+
+const convertSPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "app-sbom",
+  "documentNamespace": "https://example.com/app-1.2.3",
+  "creationInfo": {"created": "2026-01-02T03:04:05Z", "creators": ["Tool: example"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "versionInfo": "1.2.3", "downloadLocation": "NOASSERTION"},
+    {
+      "SPDXID": "SPDXRef-lodash", "name": "lodash", "versionInfo": "4.17.21", "downloadLocation": "NOASSERTION",
+      "supplier": "Organization: OpenJS", "licenseDeclared": "MIT",
+      "checksums": [{"algorithm": "SHA256", "checksumValue": "abc123"}],
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/lodash@4.17.21"}]
+    },
+    {"SPDXID": "SPDXRef-left-pad", "name": "left-pad", "versionInfo": "1.3.0", "licenseDeclared": "NOASSERTION"}
+  ],
+  "files": [{"SPDXID": "SPDXRef-file", "fileName": "./index.js"}],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-lodash"},
+    {"spdxElementId": "SPDXRef-left-pad", "relationshipType": "DEV_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-file"}
+  ]
+}`
+
+func TestConvertSBOM_CycloneDXToSPDX(t *testing.T) {
+	data, err := ConvertSBOM([]byte(convertCycloneDX), SBOMFormatSPDX)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc convertedSPDX
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if doc.SPDXVersion != "SPDX-2.3" || doc.Name != "app" || doc.CreationInfo.Created != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected document fields: %+v", doc)
+	}
+	if doc.DocumentNamespace != "https://spdx.org/spdxdocs/app-3e671687-395b-41f5-a30f-a58921a69b79" {
+		t.Errorf("unexpected namespace %q", doc.DocumentNamespace)
+	}
+
+	var got []string
+	for _, pkg := range doc.Packages {
+		purl := ""
+		if len(pkg.ExternalRefs) > 0 {
+			purl = pkg.ExternalRefs[0].ReferenceLocator
+		}
+		got = append(got, pkg.SPDXID+" "+pkg.Name+"@"+pkg.VersionInfo+" "+purl)
+	}
+	expected := []string{
+		"SPDXRef-app app@1.2.3 ",
+		"SPDXRef-pkg-npm-lodash-4.17.21 lodash@4.17.21 pkg:npm/lodash@4.17.21",
+		"SPDXRef-lodash.get-4.4.2 lodash.get@4.4.2 pkg:npm/lodash.get@4.4.2",
+		"SPDXRef-left-pad left-pad@1.3.0 pkg:npm/left-pad@1.3.0",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("packages = %q, want %q", got, expected)
+	}
+
+	lodash := doc.Packages[1]
+	if lodash.Supplier != "Organization: OpenJS" || lodash.LicenseDeclared != "MIT" ||
+		!slices.Equal(lodash.Checksums, []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: "abc123"}}) {
+		t.Errorf("unexpected lodash package: %+v", lodash)
+	}
+
+	var relationships []string
+	for _, r := range doc.Relationships {
+		relationships = append(relationships, r.SPDXElementID+" "+r.RelationshipType+" "+r.RelatedSPDXElement)
+	}
+	expectedRelationships := []string{
+		"SPDXRef-DOCUMENT DESCRIBES SPDXRef-app",
+		"SPDXRef-pkg-npm-lodash-4.17.21 CONTAINS SPDXRef-lodash.get-4.4.2",
+		"SPDXRef-app DEPENDS_ON SPDXRef-pkg-npm-lodash-4.17.21",
+		"SPDXRef-app DEPENDS_ON SPDXRef-left-pad",
+	}
+	if !slices.Equal(relationships, expectedRelationships) {
+		t.Errorf("relationships = %q, want %q", relationships, expectedRelationships)
+	}
+	if strings.Contains(string(data), "c2ln") {
+		t.Error("expected the embedded signature to be dropped")
+	}
+
+	again, err := ConvertSBOM([]byte(convertCycloneDX), SBOMFormatSPDX)
+	if err != nil || string(again) != string(data) {
+		t.Error("expected repeated conversions to match")
+	}
+}
+
+func TestConvertSBOM_SPDXToCycloneDX(t *testing.T) {
+	data, err := ConvertSBOM([]byte(convertSPDX), SBOMFormatCycloneDX)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sbom, err := LoadSBOMFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("output does not load: %v", err)
+	}
+	if sbom.Format() != "cyclonedx" {
+		t.Fatalf("expected CycloneDX output, got %q", sbom.Format())
+	}
+
+	var doc convertedCycloneDX
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc.SerialNumber, "urn:uuid:") || doc.Metadata.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected document fields: %+v", doc)
+	}
+	if root := doc.Metadata.Component; root == nil || root.Name != "app" || root.Version != "1.2.3" || root.Type != "application" {
+		t.Errorf("unexpected metadata component %+v", root)
+	}
+
+	expected := []cdxComponent{
+		{
+			Type: "library", BOMRef: "SPDXRef-lodash", Supplier: &cdxSupplier{Name: "OpenJS"}, Name: "lodash", Version: "4.17.21",
+			Hashes: []cdxHash{{Alg: "SHA-256", Content: "abc123"}}, Licenses: []cdxLicense{{Expression: "MIT"}}, PURL: "pkg:npm/lodash@4.17.21",
+		},
+		{Type: "library", BOMRef: "SPDXRef-left-pad", Name: "left-pad", Version: "1.3.0"},
+	}
+	if len(doc.Components) != len(expected) {
+		t.Fatalf("expected %d components, got %+v", len(expected), doc.Components)
+	}
+	for i := range expected {
+		got, _ := json.Marshal(doc.Components[i])
+		want, _ := json.Marshal(expected[i])
+		if string(got) != string(want) {
+			t.Errorf("component %d = %s, want %s", i, got, want)
+		}
+	}
+
+	if len(doc.Dependencies) != 1 || doc.Dependencies[0].Ref != "SPDXRef-app" ||
+		!slices.Equal(doc.Dependencies[0].DependsOn, []string{"SPDXRef-lodash", "SPDXRef-left-pad"}) {
+		t.Errorf("unexpected dependencies %+v", doc.Dependencies)
+	}
+	if strings.Contains(string(data), "index.js") {
+		t.Error("expected files to be dropped")
+	}
+}
+
+func TestConvertSBOM(t *testing.T) {
+	tests := []struct {
+		name        string
+		sbom        string
+		target      SBOMFormat
+		expectSame  bool
+		expectError string
+		unsupported bool
+	}{
+		{name: "already CycloneDX", sbom: convertCycloneDX, target: SBOMFormatCycloneDX, expectSame: true},
+		{name: "already SPDX", sbom: convertSPDX, target: SBOMFormatSPDX, expectSame: true},
+		{name: "unknown target", sbom: convertSPDX, target: "swid", expectError: "unknown target format"},
+		{name: "not an SBOM", sbom: `{"name":"x"}`, target: SBOMFormatSPDX, expectError: "neither CycloneDX nor SPDX", unsupported: true},
+		{name: "tag-value", sbom: "SPDXVersion: SPDX-2.3\n", target: SBOMFormatCycloneDX, expectError: "tag-value", unsupported: true},
+		{name: "invalid JSON", sbom: `{`, target: SBOMFormatSPDX, expectError: "not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ConvertSBOM([]byte(tt.sbom), tt.target)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				if errors.Is(err, ErrUnsupportedFormat) != tt.unsupported {
+					t.Errorf("errors.Is(err, ErrUnsupportedFormat) = %v, want %v", !tt.unsupported, tt.unsupported)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectSame && string(data) != tt.sbom {
+				t.Error("expected the document unchanged")
+			}
+		})
+	}
+}
+
+func TestCycloneDXLicenseExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		licenses string
+		expected string
+	}{
+		{name: "none", licenses: `[]`, expected: ""},
+		{name: "single ID", licenses: `[{"license":{"id":"MIT"}}]`, expected: "MIT"},
+		{name: "single expression", licenses: `[{"expression":"MIT OR Apache-2.0"}]`, expected: "MIT OR Apache-2.0"},
+		{name: "ID and expression", licenses: `[{"license":{"id":"MIT"}},{"expression":"GPL-2.0-only OR BSD-3-Clause"}]`, expected: "MIT AND (GPL-2.0-only OR BSD-3-Clause)"},
+		{name: "name only", licenses: `[{"license":{"id":"MIT"}},{"license":{"name":"Custom"}}]`, expected: "NOASSERTION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var licenses interface{}
+			if err := json.Unmarshal([]byte(tt.licenses), &licenses); err != nil {
+				t.Fatal(err)
+			}
+			if got := cycloneDXLicenseExpression(licenses); got != tt.expected {
+				t.Errorf("cycloneDXLicenseExpression() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
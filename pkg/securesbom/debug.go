@@ -0,0 +1,148 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DebugEnv names the environment variable that turns on Config.DebugHTTP for
+// every client when set to a true value such as 1
+const DebugEnv = "SECURE_SBOM_DEBUG"
+
+// maxDebugBody bounds how much of each body is dumped; the rest is still sent
+// and received, just not logged
+const maxDebugBody = 64 << 10
+
+// debugHeaders are masked in dumps
+var debugHeaders = []string{"Authorization", "Proxy-Authorization", "X-Api-Key", "Cookie", "Set-Cookie"}
+
+// signaturePatterns match signature values in JSON bodies. JSF signature
+// values are only masked when they look like encoded bytes, so ordinary
+// "value" fields in an SBOM stay readable.
+var signaturePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`("(?:signature|signature_b64|signatureB64|sig|timestamp_token)"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`("value"\s*:\s*")[A-Za-z0-9+/_=-]{40,}`),
+}
+
+// debugFromEnv reports whether DebugEnv is set to a true value
+func debugFromEnv() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(DebugEnv))
+	return enabled
+}
+
+// debugLogger logs at debug level to stderr, for DebugHTTP without a Logger
+func debugLogger() Logger {
+	return NewSlogLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+// dumpRequest logs req, with its body up to maxDebugBody, when DebugHTTP is
+// set. The body is put back so it is still sent in full.
+func (c *Client) dumpRequest(req *http.Request) {
+	if !c.config.DebugHTTP {
+		return
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s %s\r\n", req.Method, redactURL(req.URL.String()), req.Proto)
+	c.writeDebugHeaders(&buf, req.Header)
+	if req.Body != nil && req.Body != http.NoBody {
+		var body []byte
+		body, req.Body = peekBody(req.Body)
+		c.writeDebugBody(&buf, body, req.Header.Get("Content-Encoding"))
+	}
+	c.logger().Debug("http request", "dump", buf.String())
+}
+
+// dumpResponse logs resp like dumpRequest
+func (c *Client) dumpResponse(resp *http.Response) {
+	if !c.config.DebugHTTP {
+		return
+	}
+
+	// Responses from injected HTTP clients may leave these unset
+	proto, status := resp.Proto, resp.Status
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	if status == "" {
+		status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s\r\n", proto, status)
+	c.writeDebugHeaders(&buf, resp.Header)
+	if resp.Body != nil && resp.Body != http.NoBody {
+		var body []byte
+		body, resp.Body = peekBody(resp.Body)
+		c.writeDebugBody(&buf, body, resp.Header.Get("Content-Encoding"))
+	}
+	c.logger().Debug("http response", "dump", buf.String())
+}
+
+// writeDebugHeaders writes header with credentials masked
+func (c *Client) writeDebugHeaders(buf *strings.Builder, header http.Header) {
+	header = header.Clone()
+	for _, name := range debugHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+	_ = header.Write(buf)
+	buf.WriteString("\r\n")
+}
+
+// writeDebugBody writes body, decompressed if it is gzipped, with secrets and
+// signatures masked
+func (c *Client) writeDebugBody(buf *strings.Builder, body []byte, encoding string) {
+	truncated := len(body) > maxDebugBody
+	body = body[:min(len(body), maxDebugBody)]
+	if strings.EqualFold(encoding, "gzip") {
+		// A truncated stream still yields what it holds
+		if zr, err := gzip.NewReader(bytes.NewReader(body)); err == nil {
+			body, _ = io.ReadAll(io.LimitReader(zr, maxDebugBody))
+		}
+	}
+
+	text := redactSecrets(string(body), c.config.APIKey)
+	for _, pattern := range signaturePatterns {
+		text = pattern.ReplaceAllString(text, "${1}"+redacted)
+	}
+	buf.WriteString(text)
+	if truncated {
+		buf.WriteString("\n[truncated]")
+	}
+}
+
+// peekBody reads up to one byte more than maxDebugBody from body and returns
+// it along with a body that yields the read bytes followed by the rest
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser) {
+	data, _ := io.ReadAll(io.LimitReader(body, maxDebugBody+1))
+	return data, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), body), body}
+}
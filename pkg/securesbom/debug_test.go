@@ -0,0 +1,109 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_DebugHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		env        string
+		expectDump bool
+	}{
+		{name: "off by default"},
+		{name: "enabled in config", enabled: true, expectDump: true},
+		{name: "enabled by environment", env: "1", expectDump: true},
+		{name: "environment set to false", env: "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(DebugEnv, tt.env)
+			logger := &recordingLogger{}
+
+			var sent string
+			mockClient := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+					sent = string(body)
+					return createMockResponse(200, map[string]string{"signature_b64": "c2lnbmF0dXJl"}), nil
+				},
+			}
+
+			client, err := NewConfigBuilder().
+				WithAPIKey("super-secret-api-key").
+				WithBaseURL("https://api.example.com").
+				WithHTTPClient(mockClient).
+				WithLogger(logger).
+				WithHTTPDebug(tt.enabled).
+				BuildClient()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := client.SignSBOM(context.Background(), "key-123", map[string]string{"name": "test-sbom"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(sent, "test-sbom") || result.SignatureB64 != "c2lnbmF0dXJl" {
+				t.Errorf("dumping changed the exchange: sent %q, got signature %q", sent, result.SignatureB64)
+			}
+
+			output := logger.String()
+			if !tt.expectDump {
+				if strings.Contains(output, "http request") {
+					t.Errorf("expected no dumps, got:\n%s", output)
+				}
+				return
+			}
+			for _, expected := range []string{"http request", "POST https://api.example.com/api/v2/sbom/sign", "test-sbom", "http response", "200 OK", `"signature_b64":"[REDACTED]"`, "X-Api-Key: [REDACTED]"} {
+				if !strings.Contains(output, expected) {
+					t.Errorf("expected dump to contain %q, got:\n%s", expected, output)
+				}
+			}
+			for _, secret := range []string{"super-secret-api-key", "c2lnbmF0dXJl"} {
+				if strings.Contains(output, secret) {
+					t.Errorf("dump leaked %q:\n%s", secret, output)
+				}
+			}
+		})
+	}
+}
+
+func TestPeekBody(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), maxDebugBody+100)
+
+	peeked, body := peekBody(io.NopCloser(bytes.NewReader(data)))
+	if len(peeked) != maxDebugBody+1 {
+		t.Errorf("expected %d peeked bytes, got %d", maxDebugBody+1, len(peeked))
+	}
+	rest, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(rest, data) {
+		t.Errorf("expected the whole body after peeking, got %d bytes", len(rest))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// DetachedSignature is a signature stored separately from the SBOM it covers
+type DetachedSignature struct {
+	// Signature holds the raw signature bytes
+	Signature []byte `json:"signature"`
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+}
+
+// Base64 returns the signature in the encoding expected by
+// VerifyCMDRequest.SignatureB64 and the verify example's -signature flag
+func (d *DetachedSignature) Base64() string {
+	return base64.StdEncoding.EncodeToString(d.Signature)
+}
+
+// SignSBOMDetached signs the SBOM and returns only the signature, leaving the
+// document untouched. Store the signature alongside the SBOM and pass it back
+// through VerifyCMDRequest.SignatureB64 to verify.
+func SignSBOMDetached(ctx context.Context, client ClientInterface, keyID string, sbom interface{}) (*DetachedSignature, error) {
+	result, err := client.SignSBOMWithOptions(ctx, keyID, sbom, SignOptions{Detached: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.HasSignature() {
+		return nil, fmt.Errorf("sign response did not include a detached signature")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(result.GetSignatureValue())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode detached signature: %w", err)
+	}
+
+	return &DetachedSignature{
+		Signature: signature,
+		Algorithm: result.GetSignatureAlgorithm(),
+		KeyID:     keyID,
+	}, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestSignSBOMDetached(t *testing.T) {
+	tests := []struct {
+		name            string
+		mockResponse    *http.Response
+		expectError     bool
+		expectSignature string
+	}{
+		{
+			name: "signature field",
+			mockResponse: createMockResponse(200, SignResultAPIResponseV2{
+				Algorithm: "ES256",
+				Detached:  true,
+				Signature: "c2lnbmF0dXJl",
+			}),
+			expectSignature: "signature",
+		},
+		{
+			name: "signature_b64 field",
+			mockResponse: createMockResponse(200, SignResultAPIResponseV2{
+				Algorithm:    "ES256",
+				Detached:     true,
+				SignatureB64: "c2lnbmF0dXJl",
+			}),
+			expectSignature: "signature",
+		},
+		{
+			name:         "no signature in response",
+			mockResponse: createMockResponse(200, SignResultAPIResponseV2{Algorithm: "ES256"}),
+			expectError:  true,
+		},
+		{
+			name: "malformed signature",
+			mockResponse: createMockResponse(200, SignResultAPIResponseV2{
+				Algorithm: "ES256",
+				Signature: "not base64!",
+			}),
+			expectError: true,
+		},
+		{
+			name:         "API error",
+			mockResponse: createMockResponse(403, map[string]string{"error": "forbidden"}),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				bodyBytes, _ := io.ReadAll(req.Body)
+				var body struct {
+					Detached bool `json:"detached"`
+				}
+				_ = json.Unmarshal(bodyBytes, &body)
+				if !body.Detached {
+					t.Error("expected a detached sign request")
+				}
+				return tt.mockResponse, nil
+			}, nil)
+
+			sig, err := SignSBOMDetached(context.Background(), client, "key-123", map[string]string{"spdxVersion": "SPDX-2.3"})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(sig.Signature) != tt.expectSignature {
+				t.Errorf("expected signature %q, got %q", tt.expectSignature, sig.Signature)
+			}
+			if sig.Algorithm != "ES256" || sig.KeyID != "key-123" {
+				t.Errorf("unexpected signature metadata: %+v", sig)
+			}
+			if sig.Base64() != "c2lnbmF0dXJl" {
+				t.Errorf("expected base64 %q, got %q", "c2lnbmF0dXJl", sig.Base64())
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// DSSEPayloadTypeInToto is the DSSE payload type of an in-toto statement
+	DSSEPayloadTypeInToto = "application/vnd.in-toto+json"
+	// InTotoStatementType is the _type of an in-toto v1 statement
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+
+	// Predicate types for SBOM attestations
+	PredicateTypeCycloneDX = "https://cyclonedx.org/bom"
+	PredicateTypeSPDX      = "https://spdx.dev/Document"
+)
+
+// DSSEEnvelope is a Dead Simple Signing Envelope as consumed by cosign and Rekor
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// InTotoStatement is the in-toto v1 statement carried in a DSSE payload
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SignSBOMAsDSSE wraps the SBOM in an in-toto statement and signs it as a DSSE
+// envelope. The statement's subject is the SBOM document itself, named after
+// its top-level component and identified by its SHA-256. An empty
+// predicateType is inferred from the SBOM format.
+//
+// The signature is produced with SignDigest over the SHA-256 of the DSSE
+// pre-authentication encoding, so keyID must be a key that signs digests.
+func SignSBOMAsDSSE(ctx context.Context, client ClientInterface, keyID string, sbom interface{}, predicateType string) (*DSSEEnvelope, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+
+	sbomBytes, doc, err := marshalSBOM(sbom)
+	if err != nil {
+		return nil, err
+	}
+
+	if predicateType == "" {
+		switch detectSBOMFormat(doc) {
+		case "cyclonedx":
+			predicateType = PredicateTypeCycloneDX
+		case "spdx":
+			predicateType = PredicateTypeSPDX
+		default:
+			return nil, fmt.Errorf("predicateType is required for this SBOM: %w", ErrUnsupportedFormat)
+		}
+	}
+
+	sbomDigest := sha256.Sum256(sbomBytes)
+	statement := InTotoStatement{
+		Type: InTotoStatementType,
+		Subject: []InTotoSubject{{
+			Name:   sbomSubjectName(doc),
+			Digest: map[string]string{"sha256": hex.EncodeToString(sbomDigest[:])},
+		}},
+		PredicateType: predicateType,
+		Predicate:     sbomBytes,
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal in-toto statement: %w", err)
+	}
+
+	paeDigest := sha256.Sum256(dssePAE(DSSEPayloadTypeInToto, payload))
+	result, err := client.SignDigest(ctx, SignDigestRequest{
+		Digest:        base64.StdEncoding.EncodeToString(paeDigest[:]),
+		HashAlgorithm: "sha256",
+		KeyID:         keyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign DSSE envelope: %w", err)
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(result.Signature); err != nil || result.Signature == "" {
+		return nil, fmt.Errorf("sign response did not include a valid base64 signature")
+	}
+
+	return &DSSEEnvelope{
+		PayloadType: DSSEPayloadTypeInToto,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []DSSESignature{{KeyID: keyID, Sig: result.Signature}},
+	}, nil
+}
+
+// dssePAE returns the DSSE v1 pre-authentication encoding that is signed in
+// place of the raw payload
+func dssePAE(payloadType string, payload []byte) []byte {
+	pae := []byte("DSSEv1 ")
+	pae = strconv.AppendInt(pae, int64(len(payloadType)), 10)
+	pae = append(pae, ' ')
+	pae = append(pae, payloadType...)
+	pae = append(pae, ' ')
+	pae = strconv.AppendInt(pae, int64(len(payload)), 10)
+	pae = append(pae, ' ')
+	return append(pae, payload...)
+}
+
+// marshalSBOM returns the JSON encoding of sbom along with its parsed form.
+// Raw JSON is kept byte-for-byte so digests match the caller's file.
+func marshalSBOM(sbom interface{}) ([]byte, interface{}, error) {
+	// An XML document passed as a string would otherwise be sent as a JSON string
+	if s, ok := sbom.(string); ok {
+		if err := checkNotXML([]byte(s)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var raw []byte
+	switch v := sbom.(type) {
+	case nil:
+		return nil, nil, fmt.Errorf("sbom is required")
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	case *SBOM:
+		if v != nil && v.tagValue {
+			return nil, nil, fmt.Errorf("SPDX tag-value documents are not JSON: %w", ErrUnsupportedFormat)
+		}
+		if v != nil && v.raw != nil {
+			return marshalSBOM(v.raw)
+		}
+		return marshalSBOM(v.Data())
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal SBOM: %w", err)
+		}
+		raw = encoded
+	}
+
+	if err := checkNotXML(raw); err != nil {
+		return nil, nil, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, fmt.Errorf("sbom is not valid JSON: %w", err)
+	}
+	return raw, doc, nil
+}
+
+// sbomSubjectName names an SBOM after the component it describes
+func sbomSubjectName(doc interface{}) string {
+	m, _ := doc.(map[string]interface{})
+	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+		if component, ok := metadata["component"].(map[string]interface{}); ok {
+			if name, ok := component["name"].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	if name, ok := m["name"].(string); ok && name != "" {
+		return name
+	}
+	return "sbom"
+}
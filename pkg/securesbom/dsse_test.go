@@ -0,0 +1,174 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestDSSEPAE(t *testing.T) {
+	// Test vector from the DSSE specification
+	got := string(dssePAE("http://example.com/HelloWorld", []byte("hello world")))
+	expected := "DSSEv1 29 http://example.com/HelloWorld 11 hello world"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSignSBOMAsDSSE(t *testing.T) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cycloneDX := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5","metadata":{"component":{"name":"acme-app"}}}`)
+
+	tests := []struct {
+		name              string
+		sbom              interface{}
+		predicateType     string
+		signature         string
+		expectError       bool
+		expectUnsupported bool
+		expectPredicate   string
+		expectSubject     string
+	}{
+		{
+			name:            "CycloneDX with inferred predicate",
+			sbom:            cycloneDX,
+			expectPredicate: PredicateTypeCycloneDX,
+			expectSubject:   "acme-app",
+		},
+		{
+			name:            "SPDX with inferred predicate",
+			sbom:            map[string]interface{}{"spdxVersion": "SPDX-2.3", "name": "acme-spdx"},
+			expectPredicate: PredicateTypeSPDX,
+			expectSubject:   "acme-spdx",
+		},
+		{
+			name:            "explicit predicate type",
+			sbom:            map[string]interface{}{"custom": true},
+			predicateType:   "https://example.com/custom-sbom",
+			expectPredicate: "https://example.com/custom-sbom",
+			expectSubject:   "sbom",
+		},
+		{
+			name:              "unknown format without predicate type",
+			sbom:              map[string]interface{}{"custom": true},
+			expectError:       true,
+			expectUnsupported: true,
+		},
+		{
+			name:        "invalid JSON",
+			sbom:        []byte(`{`),
+			expectError: true,
+		},
+		{
+			name:        "missing signature in response",
+			sbom:        cycloneDX,
+			signature:   "-",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/api/v1/digest/sign" {
+					t.Errorf("expected digest sign endpoint, got %q", req.URL.Path)
+				}
+				bodyBytes, _ := io.ReadAll(req.Body)
+				var signReq SignDigestRequest
+				_ = json.Unmarshal(bodyBytes, &signReq)
+
+				signature := tt.signature
+				if signature == "" {
+					digest, _ := base64.StdEncoding.DecodeString(signReq.Digest)
+					sig, err := ecdsa.SignASN1(rand.Reader, signingKey, digest)
+					if err != nil {
+						t.Fatalf("failed to sign: %v", err)
+					}
+					signature = base64.StdEncoding.EncodeToString(sig)
+				} else if signature == "-" {
+					signature = ""
+				}
+				return createMockResponse(200, SignDigestResponse{KeyID: signReq.KeyID, Signature: signature}), nil
+			}, nil)
+
+			envelope, err := SignSBOMAsDSSE(context.Background(), client, "key-123", tt.sbom, tt.predicateType)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if errors.Is(err, ErrUnsupportedFormat) != tt.expectUnsupported {
+					t.Errorf("expected errors.Is(err, ErrUnsupportedFormat) to be %v, got %v", tt.expectUnsupported, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if envelope.PayloadType != DSSEPayloadTypeInToto {
+				t.Errorf("expected payload type %q, got %q", DSSEPayloadTypeInToto, envelope.PayloadType)
+			}
+			if len(envelope.Signatures) != 1 || envelope.Signatures[0].KeyID != "key-123" {
+				t.Fatalf("expected one signature for key-123, got %+v", envelope.Signatures)
+			}
+
+			// Verify the signature the way a DSSE consumer would
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			if err != nil {
+				t.Fatalf("payload is not base64: %v", err)
+			}
+			sig, _ := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			paeDigest := sha256.Sum256(dssePAE(envelope.PayloadType, payload))
+			if !ecdsa.VerifyASN1(&signingKey.PublicKey, paeDigest[:], sig) {
+				t.Error("signature does not verify over the DSSE PAE")
+			}
+
+			var statement InTotoStatement
+			if err := json.Unmarshal(payload, &statement); err != nil {
+				t.Fatalf("payload is not an in-toto statement: %v", err)
+			}
+			if statement.Type != InTotoStatementType || statement.PredicateType != tt.expectPredicate {
+				t.Errorf("unexpected statement header: %s %s", statement.Type, statement.PredicateType)
+			}
+			if len(statement.Subject) != 1 || statement.Subject[0].Name != tt.expectSubject {
+				t.Fatalf("expected subject %q, got %+v", tt.expectSubject, statement.Subject)
+			}
+			if raw, ok := tt.sbom.([]byte); ok {
+				sum := sha256.Sum256(raw)
+				if statement.Subject[0].Digest["sha256"] != hex.EncodeToString(sum[:]) {
+					t.Error("expected subject digest to match the SBOM bytes")
+				}
+			}
+		})
+	}
+}
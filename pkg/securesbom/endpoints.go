@@ -21,15 +21,19 @@ import (
 )
 
 const (
-	API_VERSION              = "/api/v1"
-	API_VERSION_V2           = "/api/v2"
-	API_ENDPOINT_HEALTHCHECK = "/infra/healthcheck"
-	API_ENDPOINT_KEYS        = "/keys"
-	API_ENDPOINT_SBOM        = "/sbom"
-	API_ENDPOING_DIGEST      = "/digest"
+	API_VERSION               = "/api/v1"
+	API_VERSION_V2            = "/api/v2"
+	API_ENDPOINT_HEALTHCHECK  = "/infra/healthcheck"
+	API_ENDPOINT_INFO         = "/infra/info"
+	API_ENDPOINT_CAPABILITIES = "/capabilities"
+	API_ENDPOINT_KEYS         = "/keys"
+	API_ENDPOINT_SBOM         = "/sbom"
+	API_ENDPOING_DIGEST       = "/digest"
+	API_ENDPOINT_BLOB         = "/blob"
 
 	DEFAULT_SECURE_SBOM_BASE_URL = "https://secure-sbom-api-prod-gateway-dhncnyq8.uc.gateway.dev"
 
-	HTTP_METHOD_GET  = http.MethodGet
-	HTTP_METHOD_POST = http.MethodPost
+	HTTP_METHOD_GET    = http.MethodGet
+	HTTP_METHOD_POST   = http.MethodPost
+	HTTP_METHOD_DELETE = http.MethodDelete
 )
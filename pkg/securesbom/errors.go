@@ -16,12 +16,113 @@
 
 package securesbom
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrKeyNotFound is returned when the requested key does not exist
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrUnsupportedFormat is returned when an SBOM is not a format the SDK can process
+var ErrUnsupportedFormat = errors.New("unsupported SBOM format")
+
+// ErrNoSignatures is returned when an SBOM carries no signatures to verify
+var ErrNoSignatures = errors.New("SBOM has no signatures")
+
+// ErrSignatureInvalid is returned when an operation requires a valid signature
+// and verification failed
+var ErrSignatureInvalid = errors.New("signature is invalid")
+
+// ErrKeyExists is returned when importing a key whose ID is already in use
+var ErrKeyExists = errors.New("key already exists")
+
+// ErrInvalidPublicKey is returned when a caller-supplied public key is not a
+// PEM-encoded PKIX public key
+var ErrInvalidPublicKey = errors.New("invalid public key")
+
+// ErrDisallowedAlgorithm is returned when a signature verifies but was made
+// with an algorithm outside Config.AllowedAlgorithms
+var ErrDisallowedAlgorithm = errors.New("signature algorithm is not allowed")
+
+// ErrResponseTooLarge is returned when a response body is larger than
+// Config.MaxResponseSize. It is not temporary, since the server would most
+// likely send the same response again.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrIncompleteResponse is returned when a response body ends before it is
+// complete, typically because the connection dropped mid-stream. It is
+// temporary, so the retrying client tries the request again.
+var ErrIncompleteResponse = errors.New("incomplete response")
+
+// ErrSBOMTooLarge is returned when a gzipped SBOM decompresses to more than
+// MaxDecompressedSBOMSize bytes
+var ErrSBOMTooLarge = errors.New("SBOM too large")
+
+// ErrPayloadTooLarge is returned without contacting the API when a request
+// body exceeds the MaxPayloadSize reported by Capabilities
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// ErrCertificatePinMismatch is returned when the API's TLS certificate does
+// not carry a public key pinned with WithTLSPinnedSPKI. It is not temporary:
+// the server, or something intercepting the connection, is presenting a key
+// the client was told not to trust.
+var ErrCertificatePinMismatch = errors.New("server certificate does not match any pinned public key")
+
+// ErrTimestampingUnsupported is returned by signing calls when timestamping
+// is enabled with WithTimestamping but the server does not return a timestamp
+// token, so the signature could not be timestamped as required
+var ErrTimestampingUnsupported = errors.New("server does not support timestamping")
+
+// ErrSignatureFormatUnsupported is returned by signing calls when the server
+// cannot produce the signature format asked for with WithSignatureFormat
+var ErrSignatureFormatUnsupported = errors.New("signature format is not supported")
+
+// ErrTimestampInvalid is returned by VerifyTimestamp when a token is malformed,
+// does not cover the signature, or is not validly signed by its authority
+var ErrTimestampInvalid = errors.New("timestamp token is invalid")
+
+// ErrKeyNotUsableForSigning is returned by signing calls, when
+// WithKeyUsageCheck is enabled, for a key whose Usage does not permit signing
+// such as a verify-only key
+var ErrKeyNotUsableForSigning = errors.New("key is not usable for signing")
+
+// ErrKeylessBundleMissing is returned by VerifyKeyless when no Sigstore or
+// cosign bundle is given for the keyless signature
+var ErrKeylessBundleMissing = errors.New("keyless signature bundle is missing")
+
+// ErrComponentNotFound is returned by SignSBOMSubset for a requested bom-ref
+// that no component in the SBOM has
+var ErrComponentNotFound = errors.New("component not found")
+
 // APIError represents an error response from the API
 type APIError struct {
 	StatusCode int    `json:"status_code"`
+	Code       string `json:"code,omitempty"`
 	Message    string `json:"message"`
 	Details    string `json:"details,omitempty"`
 	RequestID  string `json:"request_id,omitempty"`
+
+	// RetryAfter is the delay requested by the server via the Retry-After header, if any
+	RetryAfter time.Duration `json:"-"`
+
+	// response is the HTTP response the error was built from, passed to
+	// RetryConfig.RetryableFunc. Its body has already been read and closed.
+	response *http.Response
 }
 
 // APIErrorResponse represents error responses from the API
@@ -29,3 +130,225 @@ type APIErrorResponse struct {
 	Error   string `json:"error,omitempty"`
 	Message string `json:"message,omitempty"`
 }
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsUnauthorized reports whether err was caused by a 401 response
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err was caused by a 403 response
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err was caused by a 404 response or a missing key
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrKeyNotFound) || hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err was caused by a 429 response
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsTemporary reports whether err is likely transient and the operation can be
+// retried: an API error for a 5xx or 429 response, a network timeout, a
+// connection that could not be made or was reset, or a response cut short.
+// Every other error is permanent, including those the SDK returns for invalid
+// input before any request is made and context cancellation.
+func IsTemporary(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.Temporary()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrIncompleteResponse) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	// Not every net.Error: a TLS verification failure arrives as one too
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "read" || opErr.Op == "write")
+}
+
+// errorResponse returns the HTTP response behind the APIError wrapped in err,
+// or nil if the request failed before a response arrived
+func errorResponse(err error) *http.Response {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.response
+	}
+	return nil
+}
+
+func hasStatus(err error, statusCode int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == statusCode
+}
+
+// parseRetryAfter parses a Retry-After header value in either delta-seconds or
+// HTTP-date form. It returns zero if the value is empty, malformed, or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// maxErrorText bounds the raw body text kept in an APIError message
+const maxErrorText = 512
+
+var (
+	htmlTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlNoise = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>|<[^>]*>`)
+)
+
+// parseErrorResponse reads and closes the body of resp, an unsuccessful
+// response, and builds the APIError describing it
+func parseErrorResponse(resp *http.Response, now time.Time) *APIError {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, _ := io.ReadAll(resp.Body)
+	return newAPIError(resp, body, now)
+}
+
+// newAPIError builds an APIError from a response and its body. A JSON body
+// supplies the code, message, details and request ID, with "error" accepted
+// as a string or as a nested object. An HTML body, such as a gateway error
+// page, contributes its title or text, and any other body is used as is. An
+// empty body leaves the status text as the message.
+func newAPIError(resp *http.Response, body []byte, now time.Time) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		response:   resp,
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	if !parseJSONError(apiErr, body) {
+		if text := errorBodyText(resp, body); text != "" {
+			apiErr.Message = text
+		}
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = responseRequestID(resp)
+	}
+	return apiErr
+}
+
+// parseJSONError fills apiErr from a JSON error body, reporting whether the
+// body had the expected shape
+func parseJSONError(apiErr *APIError, body []byte) bool {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return false
+	}
+
+	message := jsonErrorText(fields["message"])
+	code := jsonErrorText(fields["code"])
+	details := jsonErrorText(fields["details"])
+	var nested map[string]json.RawMessage
+	if json.Unmarshal(fields["error"], &nested) == nil {
+		if message == "" {
+			message = jsonErrorText(nested["message"])
+		}
+		if code == "" {
+			code = jsonErrorText(nested["code"])
+		}
+		if details == "" {
+			details = jsonErrorText(nested["details"])
+		}
+	} else if message == "" {
+		message = jsonErrorText(fields["error"])
+	}
+	if message == "" && code == "" {
+		return false
+	}
+
+	if message != "" {
+		apiErr.Message = message
+	}
+	apiErr.Code = code
+	apiErr.Details = details
+	apiErr.RequestID = jsonErrorText(fields["request_id"])
+	return true
+}
+
+// jsonErrorText returns a JSON string value, or the compact encoding of any
+// other non-null value
+func jsonErrorText(value json.RawMessage) string {
+	if len(value) == 0 || string(value) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, value) != nil {
+		return ""
+	}
+	return truncateErrorText(compact.String())
+}
+
+// errorBodyText returns a readable, bounded summary of a non-JSON error body
+func errorBodyText(resp *http.Response, body []byte) string {
+	text := strings.TrimSpace(string(body))
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/html" || strings.HasPrefix(text, "<") {
+		if m := htmlTitle.FindStringSubmatch(text); m != nil && strings.TrimSpace(m[1]) != "" {
+			text = m[1]
+		} else {
+			text = htmlNoise.ReplaceAllString(text, " ")
+		}
+		text = html.UnescapeString(text)
+	}
+	return truncateErrorText(strings.Join(strings.Fields(text), " "))
+}
+
+// truncateErrorText cuts s to maxErrorText bytes without splitting a rune
+func truncateErrorText(s string) string {
+	if len(s) <= maxErrorText {
+		return s
+	}
+	cut := maxErrorText
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+)
+
+// Exit codes for command-line tools that verify SBOMs, so scripts can tell an
+// SBOM that fails verification from one that could not be checked. ExitCode
+// maps a verification outcome to one of them.
+const (
+	// ExitValid means the signature is valid
+	ExitValid = 0
+	// ExitInvalidSignature means the SBOM is unsigned, its signature does not
+	// match, or it was signed with a disallowed algorithm
+	ExitInvalidSignature = 2
+	// ExitAPIError means the API could not be reached or returned an error, so
+	// the SBOM was not checked
+	ExitAPIError = 3
+	// ExitBadInput means the SBOM, signature or key ID given could not be used,
+	// e.g. a missing file, malformed JSON or an unknown key
+	ExitBadInput = 4
+)
+
+// ExitCode returns the process exit code for the outcome of a verification
+// call such as VerifySBOM or VerifySBOMFromFile. Failures reaching or reported
+// by the API map to ExitAPIError, except for an unknown key, which is
+// ExitBadInput like every other error not caused by the server or network.
+// A VerifyReason, from an invalid result or from err, decides the code: a
+// signature mismatch is ExitInvalidSignature, and an unknown or revoked key or
+// a malformed SBOM is ExitBadInput.
+func ExitCode(result *VerifyResultCMDResponse, err error) int {
+	if err == nil {
+		switch {
+		case result == nil:
+			return ExitAPIError
+		case result.Valid:
+			return ExitValid
+		case result.Reason != "":
+			return reasonExitCode(result.Reason)
+		default:
+			return ExitInvalidSignature
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrSignatureInvalid), errors.Is(err, ErrNoSignatures), errors.Is(err, ErrDisallowedAlgorithm):
+		return ExitInvalidSignature
+	case VerifyReasonFromError(err) != "":
+		return reasonExitCode(VerifyReasonFromError(err))
+	case IsNotFound(err):
+		return ExitBadInput
+	case isTransportError(err):
+		return ExitAPIError
+	default:
+		return ExitBadInput
+	}
+}
+
+// reasonExitCode returns the exit code for a verification that failed for
+// reason
+func reasonExitCode(reason VerifyReason) int {
+	switch reason {
+	case VerifyReasonSignatureMismatch, VerifyReasonSignatureMissing:
+		return ExitInvalidSignature
+	default:
+		return ExitBadInput
+	}
+}
+
+// isTransportError reports whether err came from talking to the API rather
+// than from the caller's input
+func isTransportError(err error) bool {
+	if _, ok := AsAPIError(err); ok {
+		return true
+	}
+	// Not net.Error: syscall.Errno satisfies it, so file errors would match
+	var urlErr *url.Error
+	var opErr *net.OpError
+	return errors.As(err, &urlErr) || errors.As(err, &opErr) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrIncompleteResponse) || errors.Is(err, ErrResponseTooLarge)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	_, missingFileErr := LoadSBOMFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	var syntaxErr *json.SyntaxError
+	_, malformedErr := LoadSBOMFromReader(strings.NewReader("{not json"))
+	if !errors.As(malformedErr, &syntaxErr) {
+		t.Fatalf("LoadSBOMFromReader() error = %v, want a JSON syntax error", malformedErr)
+	}
+
+	tests := []struct {
+		name     string
+		result   *VerifyResultCMDResponse
+		err      error
+		expected int
+	}{
+		{name: "valid", result: &VerifyResultCMDResponse{Valid: true}, expected: ExitValid},
+		{name: "invalid signature", result: &VerifyResultCMDResponse{Valid: false}, expected: ExitInvalidSignature},
+		{name: "no result", expected: ExitAPIError},
+		{name: "signature invalid error", err: fmt.Errorf("verify: %w", ErrSignatureInvalid), expected: ExitInvalidSignature},
+		{name: "unsigned SBOM", err: fmt.Errorf("SPDX SBOM has no embedded signature: %w", ErrNoSignatures), expected: ExitInvalidSignature},
+		{
+			name:     "disallowed algorithm",
+			result:   &VerifyResultCMDResponse{Valid: false, Code: VerifyCodeDisallowedAlgorithm},
+			err:      fmt.Errorf("signature algorithm is not allowed: %w", ErrDisallowedAlgorithm),
+			expected: ExitInvalidSignature,
+		},
+		{
+			name:     "signature mismatch reason",
+			err:      fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusBadRequest, Code: "INVALID_SIGNATURE"}),
+			expected: ExitInvalidSignature,
+		},
+		{
+			name:     "revoked key reason",
+			err:      fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusGone, Code: "KEY_REVOKED"}),
+			expected: ExitBadInput,
+		},
+		{name: "malformed document reason", result: &VerifyResultCMDResponse{Reason: VerifyReasonDocumentMalformed}, expected: ExitBadInput},
+		{name: "server error", err: fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusServiceUnavailable}), expected: ExitAPIError},
+		{
+			name:     "unknown key code",
+			err:      fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusNotFound, Code: "KEY_NOT_FOUND"}),
+			expected: ExitBadInput,
+		},
+		{name: "unknown key", err: fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusNotFound}), expected: ExitBadInput},
+		{name: "network failure", err: fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "/api/v2/sbom/verify", Err: errors.New("connection refused")}), expected: ExitAPIError},
+		{name: "timeout", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), expected: ExitAPIError},
+		{name: "circuit open", err: ErrCircuitOpen, expected: ExitAPIError},
+		{name: "missing file", err: missingFileErr, expected: ExitBadInput},
+		{name: "malformed JSON", err: malformedErr, expected: ExitBadInput},
+		{name: "unsupported format", err: fmt.Errorf("sbom.xml: %w", ErrUnsupportedFormat), expected: ExitBadInput},
+		{name: "missing key ID", err: errors.New("keyID is required"), expected: ExitBadInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.result, tt.err); got != tt.expected {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// sdkOnlyHeaders can't be set with WithDefaultHeaders: the credentials, which
+// the SDK always controls, and the headers that frame the request body
+var sdkOnlyHeaders = []string{
+	"Authorization",
+	"X-Api-Key",
+	"Proxy-Authorization",
+	"Content-Length",
+	"Content-Encoding",
+	"Transfer-Encoding",
+	"Host",
+}
+
+func validateDefaultHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if !isHeaderToken(name) {
+			return fmt.Errorf("invalid default header name %q", name)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("invalid value for default header %q", name)
+		}
+		if slices.Contains(sdkOnlyHeaders, http.CanonicalHeaderKey(name)) {
+			return fmt.Errorf("header %q is managed by the SDK and can't be a default header", name)
+		}
+	}
+	return nil
+}
+
+// isHeaderToken reports whether name is a valid header field name, an RFC 9110
+// token
+func isHeaderToken(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// setDefaultHeaders adds Config.DefaultHeaders to req, replacing any header of
+// the same name the SDK set, which validateDefaultHeaders limits to headers
+// other than credentials and body framing
+func (c *Client) setDefaultHeaders(req *http.Request) {
+	for name, value := range c.config.DefaultHeaders {
+		req.Header.Set(name, value)
+	}
+}
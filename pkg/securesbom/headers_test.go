@@ -0,0 +1,139 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_WithDefaultHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "single call",
+			headers: []map[string]string{{"X-Api-Version": "2026-01-01", "X-Feature-Flags": "beta"}},
+			want:    map[string]string{"X-Api-Version": "2026-01-01", "X-Feature-Flags": "beta"},
+		},
+		{
+			name:    "calls merge",
+			headers: []map[string]string{{"X-Api-Version": "1", "X-Feature-Flags": "beta"}, {"X-Api-Version": "2"}},
+			want:    map[string]string{"X-Api-Version": "2", "X-Feature-Flags": "beta"},
+		},
+		{
+			name:    "authorization",
+			headers: []map[string]string{{"authorization": "Bearer other"}},
+			wantErr: "managed by the SDK",
+		},
+		{
+			name:    "api key",
+			headers: []map[string]string{{"x-api-key": "other-key"}},
+			wantErr: "managed by the SDK",
+		},
+		{
+			name:    "content encoding",
+			headers: []map[string]string{{"Content-Encoding": "br"}},
+			wantErr: "managed by the SDK",
+		},
+		{
+			name:    "invalid name",
+			headers: []map[string]string{{"X Api Version": "1"}},
+			wantErr: "invalid default header name",
+		},
+		{
+			name:    "invalid value",
+			headers: []map[string]string{{"X-Api-Version": "1\r\nX-Injected: 1"}},
+			wantErr: "invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewConfigBuilder().WithAPIKey("test-key").WithBaseURL("https://api.example.com")
+			for _, headers := range tt.headers {
+				b.WithDefaultHeaders(headers)
+			}
+			client, err := b.BuildClient()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildClient() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildClient() error = %v", err)
+			}
+			if len(client.config.DefaultHeaders) != len(tt.want) {
+				t.Errorf("DefaultHeaders = %v, want %v", client.config.DefaultHeaders, tt.want)
+			}
+			for name, value := range tt.want {
+				if client.config.DefaultHeaders[name] != value {
+					t.Errorf("DefaultHeaders[%s] = %q, want %q", name, client.config.DefaultHeaders[name], value)
+				}
+			}
+		})
+	}
+}
+
+func TestNewClient_DefaultHeadersRejected(t *testing.T) {
+	_, err := NewClient(&Config{
+		APIKey:         "test-key",
+		BaseURL:        "https://api.example.com",
+		DefaultHeaders: map[string]string{"Authorization": "Bearer other"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "managed by the SDK") {
+		t.Fatalf("NewClient() error = %v, want a rejected Authorization header", err)
+	}
+}
+
+func TestClient_DefaultHeaders(t *testing.T) {
+	var sent http.Header
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		sent = req.Header.Clone()
+		return createMockResponse(http.StatusOK, `{"keys":[]}`), nil
+	}, nil)
+	client.config.DefaultHeaders = map[string]string{
+		"X-Api-Version": "2026-01-01",
+		"Accept":        "application/vnd.securesbom+json",
+	}
+
+	if _, err := client.ListKeys(context.Background()); err != nil {
+		t.Fatalf("ListKeys() error = %v", err)
+	}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "X-Api-Version", want: "2026-01-01"},
+		// The caller wins over SDK headers other than credentials
+		{header: "Accept", want: "application/vnd.securesbom+json"},
+		{header: "x-api-key", want: "test-key"},
+		{header: "User-Agent", want: UserAgent},
+	}
+	for _, tt := range tests {
+		if got := sent.Get(tt.header); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
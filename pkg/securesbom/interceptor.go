@@ -0,0 +1,53 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RequestInterceptor inspects or modifies an outgoing request, e.g. to add a
+// tenant header, just before it is sent. Returning an error fails the attempt
+// without sending it.
+type RequestInterceptor func(req *http.Request) error
+
+// ResponseInterceptor inspects a response as soon as it arrives, before the
+// client checks its status or reads its body. Returning an error fails the
+// attempt.
+type ResponseInterceptor func(resp *http.Response) error
+
+// interceptRequest runs the request interceptors in the order they were added
+func (c *Client) interceptRequest(req *http.Request) error {
+	for _, intercept := range c.config.RequestInterceptors {
+		if err := intercept(req); err != nil {
+			return fmt.Errorf("request interceptor failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// interceptResponse runs the response interceptors in the order they were
+// added, stopping at the first error
+func (c *Client) interceptResponse(resp *http.Response) error {
+	for _, intercept := range c.config.ResponseInterceptors {
+		if err := intercept(resp); err != nil {
+			return fmt.Errorf("response interceptor failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Interceptors(t *testing.T) {
+	errRejected := errors.New("rejected")
+
+	tests := []struct {
+		name           string
+		statuses       []int
+		retry          bool
+		failRequest    bool
+		failResponse   bool
+		expectRequests int
+		expectEvents   []string
+		errIs          error
+	}{
+		{
+			name:           "run in order around the exchange",
+			statuses:       []int{http.StatusOK},
+			expectRequests: 1,
+			expectEvents:   []string{"req 1", "req 2", "send tenant-a", "resp 1 200", "resp 2 200"},
+		},
+		{
+			name:           "run for every retry attempt",
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusOK},
+			retry:          true,
+			expectRequests: 2,
+			expectEvents: []string{
+				"req 1", "req 2", "send tenant-a", "resp 1 503", "resp 2 503",
+				"req 1", "req 2", "send tenant-a", "resp 1 200", "resp 2 200",
+			},
+		},
+		{
+			name:         "request interceptor error stops the request",
+			statuses:     []int{http.StatusOK},
+			failRequest:  true,
+			expectEvents: []string{"req 1"},
+			errIs:        errRejected,
+		},
+		{
+			name:           "response interceptor error fails the call",
+			statuses:       []int{http.StatusOK},
+			failResponse:   true,
+			expectRequests: 1,
+			expectEvents:   []string{"req 1", "req 2", "send tenant-a", "resp 1 200"},
+			errIs:          errRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			requests := 0
+			base := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				events = append(events, "send "+req.Header.Get("X-Tenant-ID"))
+				status := tt.statuses[requests]
+				requests++
+				return createMockResponse(status, map[string]string{"status": "ok"}), nil
+			}, func(config *Config) {
+				config.RequestInterceptors = []RequestInterceptor{
+					func(req *http.Request) error {
+						events = append(events, "req 1")
+						if tt.failRequest {
+							return errRejected
+						}
+						// Set, not Add, so the header stays single across retries
+						req.Header.Set("X-Tenant-ID", "tenant-a")
+						return nil
+					},
+					func(req *http.Request) error {
+						events = append(events, "req 2")
+						return nil
+					},
+				}
+				config.ResponseInterceptors = []ResponseInterceptor{
+					func(resp *http.Response) error {
+						events = append(events, "resp 1 "+strconv.Itoa(resp.StatusCode))
+						if tt.failResponse {
+							return errRejected
+						}
+						return nil
+					},
+					func(resp *http.Response) error {
+						events = append(events, "resp 2 "+strconv.Itoa(resp.StatusCode))
+						return nil
+					},
+				}
+			})
+			var client ClientInterface = base
+			if tt.retry {
+				client = WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1})
+			}
+
+			err := client.HealthCheck(context.Background())
+			if tt.errIs != nil {
+				if !errors.Is(err, tt.errIs) {
+					t.Fatalf("expected error wrapping %v, got %v", tt.errIs, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requests != tt.expectRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.expectRequests)
+			}
+			if !reflect.DeepEqual(events, tt.expectEvents) {
+				t.Errorf("events = %v, want %v", events, tt.expectEvents)
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_WithInterceptors(t *testing.T) {
+	_, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithRequestInterceptor(nil).
+		BuildClient()
+	if err == nil || !strings.Contains(err.Error(), "request interceptor cannot be nil") {
+		t.Errorf("expected an error for a nil request interceptor, got %v", err)
+	}
+
+	noop := func(*http.Request) error { return nil }
+	config := NewConfigBuilder().
+		WithRequestInterceptor(noop).
+		WithRequestInterceptor(noop).
+		WithResponseInterceptor(func(*http.Response) error { return nil }).
+		Build()
+	if len(config.RequestInterceptors) != 2 || len(config.ResponseInterceptors) != 1 {
+		t.Errorf("expected interceptors to accumulate, got %d request and %d response",
+			len(config.RequestInterceptors), len(config.ResponseInterceptors))
+	}
+}
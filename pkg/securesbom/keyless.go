@@ -0,0 +1,831 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Result codes VerifyKeyless sets when a keyless signature does not verify
+const (
+	// VerifyCodeUntrustedCertificate means the signing certificate does not
+	// chain to a Fulcio root, or was not valid when the entry was logged
+	VerifyCodeUntrustedCertificate = "UNTRUSTED_CERTIFICATE"
+	// VerifyCodeIdentityMismatch means the certificate was issued to a
+	// different OIDC identity or by a different issuer
+	VerifyCodeIdentityMismatch = "IDENTITY_MISMATCH"
+	// VerifyCodeSignatureInvalid means the signature does not cover the SBOM
+	VerifyCodeSignatureInvalid = "SIGNATURE_INVALID"
+	// VerifyCodeTransparencyLogInvalid means the Rekor entry is missing, not
+	// signed by a trusted log, or does not record this signature
+	VerifyCodeTransparencyLogInvalid = "TRANSPARENCY_LOG_INVALID"
+)
+
+// Fulcio certificate extensions holding the OIDC issuer: the original raw
+// string form and its DER-encoded replacement
+var (
+	oidFulcioIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidFulcioIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// KeylessIdentity is the signer a keyless signature must come from, as
+// recorded in its Fulcio certificate. Issuer and one of Subject or
+// SubjectRegexp are required.
+type KeylessIdentity struct {
+	// Issuer is the OIDC issuer that authenticated the signer, e.g.
+	// "https://token.actions.githubusercontent.com"
+	Issuer string
+	// Subject is the email or URI subject alternative name of the
+	// certificate, e.g. a CI workflow identity
+	Subject string
+	// SubjectRegexp matches the subject instead of Subject. Like cosign's
+	// --certificate-identity-regexp it is not anchored.
+	SubjectRegexp string
+}
+
+// KeylessTrustRoot holds the Sigstore trust material keyless signatures are
+// checked against, for the public-good instance or a private deployment
+type KeylessTrustRoot struct {
+	// FulcioRoots are the trusted Fulcio root certificates
+	FulcioRoots *x509.CertPool
+	// FulcioIntermediates are Fulcio intermediate certificates, used along
+	// with any chain carried in the bundle
+	FulcioIntermediates *x509.CertPool
+	// RekorKeys are the public keys of the trusted Rekor logs. An entry's log
+	// ID selects the key, as the SHA-256 digest of its DER encoding.
+	RekorKeys []crypto.PublicKey
+	// RekorOrigins maps the hex log ID of a trusted log to the origin its
+	// checkpoints name, their first line, of the form "<host> - <tree ID>". A
+	// bundle with an inclusion proof from a log not listed here fails, since
+	// its checkpoint can't be tied to the log.
+	RekorOrigins map[string]string
+}
+
+// LoadKeylessTrustRoot builds a KeylessTrustRoot from PEM data as Sigstore
+// distributes it, e.g. fulcio_v1.crt.pem and rekor.pub. Self-signed
+// certificates in fulcioPEM become roots and the rest intermediates; rekorPEM
+// holds one or more PKIX public keys.
+func LoadKeylessTrustRoot(fulcioPEM, rekorPEM []byte) (*KeylessTrustRoot, error) {
+	root := &KeylessTrustRoot{
+		FulcioRoots:         x509.NewCertPool(),
+		FulcioIntermediates: x509.NewCertPool(),
+	}
+
+	roots := 0
+	for block, rest := pem.Decode(fulcioPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Fulcio certificate: %w", err)
+		}
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil {
+			root.FulcioRoots.AddCert(cert)
+			roots++
+		} else {
+			root.FulcioIntermediates.AddCert(cert)
+		}
+	}
+	if roots == 0 {
+		return nil, fmt.Errorf("no Fulcio root certificate found")
+	}
+
+	for block, rest := pem.Decode(rekorPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Rekor public key: %w", err)
+		}
+		root.RekorKeys = append(root.RekorKeys, key)
+	}
+	if len(root.RekorKeys) == 0 {
+		return nil, fmt.Errorf("no Rekor public key found")
+	}
+	return root, nil
+}
+
+// SetRekorOrigin records origin as the checkpoint origin of every log in
+// RekorKeys, for the usual trust root of a single log
+func (r *KeylessTrustRoot) SetRekorOrigin(origin string) {
+	if r.RekorOrigins == nil {
+		r.RekorOrigins = make(map[string]string, len(r.RekorKeys))
+	}
+	for _, key := range r.RekorKeys {
+		if logID := rekorLogID(key); logID != nil {
+			r.RekorOrigins[hex.EncodeToString(logID)] = origin
+		}
+	}
+}
+
+// rekorKey returns the trusted log key whose ID is logID
+func (r *KeylessTrustRoot) rekorKey(logID []byte) crypto.PublicKey {
+	for _, key := range r.RekorKeys {
+		if id := rekorLogID(key); id != nil && bytes.Equal(id, logID) {
+			return key
+		}
+	}
+	return nil
+}
+
+// rekorLogID is the ID of the log with key, the SHA-256 digest of its DER
+// encoding; nil for keys that can't be encoded
+func rekorLogID(key crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil
+	}
+	id := sha256.Sum256(der)
+	return id[:]
+}
+
+// VerifyKeyless verifies an SBOM signed by cosign or another Sigstore client
+// with a short-lived Fulcio certificate rather than a SecureSBOM key.
+// rekorBundle is the bundle written at signing time, either a Sigstore bundle
+// (cosign sign-blob --new-bundle-format) or a cosign bundle (--bundle). The
+// check is made locally against Config.KeylessTrustRoot, without calling the
+// API:
+//
+//   - the Rekor entry carries a signed entry timestamp from a trusted log,
+//     which vouches for the time it was logged, and records this signature
+//     and certificate; an inclusion proof, if present, must also verify
+//     against a checkpoint naming the log's origin in
+//     KeylessTrustRoot.RekorOrigins
+//   - the certificate chains to a Fulcio root and was valid when the entry
+//     was logged
+//   - the certificate names identity's issuer and subject
+//   - the signature covers the SBOM bytes as given
+//
+// A signature that fails any check yields Valid=false with one of the
+// VerifyCode constants above and a Message explaining why. An empty bundle
+// fails with ErrKeylessBundleMissing; a malformed one, a missing trust root or
+// an incomplete identity with a plain error.
+func (c *Client) VerifyKeyless(ctx context.Context, sbom []byte, rekorBundle []byte, identity KeylessIdentity) (_ *VerifyResultCMDResponse, err error) {
+	_, span := c.startSpan(ctx, "VerifyKeyless",
+		attribute.String("sbom.format", detectSBOMFormat(json.RawMessage(sbom))))
+	defer func() { span.end(err) }()
+
+	if len(bytes.TrimSpace(rekorBundle)) == 0 {
+		return nil, ErrKeylessBundleMissing
+	}
+	root := c.config.KeylessTrustRoot
+	if root == nil {
+		return nil, fmt.Errorf("keyless verification requires a trust root, see WithKeylessTrustRoot")
+	}
+	subject, err := identity.subjectMatcher()
+	if err != nil {
+		return nil, err
+	}
+	if len(sbom) == 0 {
+		return nil, fmt.Errorf("sbom is required for verification")
+	}
+	digest, metadata, err := verifiedDocument(json.RawMessage(sbom))
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := parseKeylessBundle(rekorBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse keyless bundle: %w", err)
+	}
+
+	leaf := bundle.certificates[0]
+	result := &VerifyResultCMDResponse{
+		Algorithm:        keylessAlgorithm(leaf.PublicKey),
+		Timestamp:        c.clock().Now(),
+		CertificateChain: encodeCertificateChain(bundle.certificates),
+		SBOMDigest:       digest,
+		SBOMMetadata:     metadata,
+	}
+	if code, message := bundle.verify(sbom, identity, subject, root); code != "" {
+		result.Code = code
+		result.Message = message
+		result.Reason = verifyReason(0, code)
+		return result, nil
+	}
+
+	result.Valid = true
+	result.Code = "VALID"
+	result.Message = fmt.Sprintf("signed by %s (issuer %s), Rekor log index %d",
+		strings.Join(certificateSubjects(leaf), ", "), identity.Issuer, bundle.entry.logIndex)
+	return result, checkAllowedAlgorithm(result, c.config.AllowedAlgorithms)
+}
+
+// subjectMatcher validates the identity and returns the check for a
+// certificate subject
+func (id KeylessIdentity) subjectMatcher() (func(string) bool, error) {
+	if id.Issuer == "" {
+		return nil, fmt.Errorf("keyless identity requires an issuer")
+	}
+	switch {
+	case id.Subject != "" && id.SubjectRegexp != "":
+		return nil, fmt.Errorf("keyless identity takes a subject or a subject regexp, not both")
+	case id.Subject != "":
+		return func(subject string) bool { return subject == id.Subject }, nil
+	case id.SubjectRegexp != "":
+		re, err := regexp.Compile(id.SubjectRegexp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyless subject regexp: %w", err)
+		}
+		return re.MatchString, nil
+	default:
+		return nil, fmt.Errorf("keyless identity requires a subject or a subject regexp")
+	}
+}
+
+// keylessBundle is the part of a Sigstore or cosign bundle VerifyKeyless uses
+type keylessBundle struct {
+	signature []byte
+	// messageDigest is the SHA-256 digest of the signed content, when the
+	// bundle records one
+	messageDigest []byte
+	// certificates holds the signing certificate first, then any chain
+	certificates []*x509.Certificate
+	entry        *rekorEntry
+}
+
+// rekorEntry is a Rekor transparency log entry and the evidence of its inclusion
+type rekorEntry struct {
+	body           []byte
+	integratedTime int64
+	logIndex       int64
+	logID          []byte
+	// signedEntryTimestamp is the log's signature promising inclusion
+	signedEntryTimestamp []byte
+	proof                *rekorInclusionProof
+}
+
+type rekorInclusionProof struct {
+	logIndex   int64
+	treeSize   int64
+	rootHash   []byte
+	hashes     [][]byte
+	checkpoint string
+}
+
+// protoInt64 is an int64 that protojson may encode as a string or a number
+type protoInt64 int64
+
+func (i *protoInt64) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = protoInt64(n)
+	return nil
+}
+
+type protoRawBytes struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+// sigstoreBundleJSON is the JSON form of a Sigstore bundle, v0.1 to v0.3
+type sigstoreBundleJSON struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		Certificate          *protoRawBytes `json:"certificate"`
+		X509CertificateChain *struct {
+			Certificates []protoRawBytes `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		TlogEntries []struct {
+			LogIndex protoInt64 `json:"logIndex"`
+			LogID    struct {
+				KeyID []byte `json:"keyId"`
+			} `json:"logId"`
+			IntegratedTime   protoInt64 `json:"integratedTime"`
+			InclusionPromise *struct {
+				SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+			} `json:"inclusionPromise"`
+			InclusionProof *struct {
+				LogIndex   protoInt64 `json:"logIndex"`
+				RootHash   []byte     `json:"rootHash"`
+				TreeSize   protoInt64 `json:"treeSize"`
+				Hashes     [][]byte   `json:"hashes"`
+				Checkpoint struct {
+					Envelope string `json:"envelope"`
+				} `json:"checkpoint"`
+			} `json:"inclusionProof"`
+			CanonicalizedBody []byte `json:"canonicalizedBody"`
+		} `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	MessageSignature *struct {
+		MessageDigest struct {
+			Algorithm string `json:"algorithm"`
+			Digest    []byte `json:"digest"`
+		} `json:"messageDigest"`
+		Signature []byte `json:"signature"`
+	} `json:"messageSignature"`
+	DSSEEnvelope json.RawMessage `json:"dsseEnvelope"`
+}
+
+// cosignBundleJSON is the bundle cosign sign-blob --bundle writes
+type cosignBundleJSON struct {
+	Base64Signature string `json:"base64Signature"`
+	Cert            string `json:"cert"`
+	RekorBundle     *struct {
+		SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+		Payload              struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogIndex       int64  `json:"logIndex"`
+			LogID          string `json:"logID"`
+		} `json:"Payload"`
+	} `json:"rekorBundle"`
+}
+
+// parseKeylessBundle reads a Sigstore bundle or a cosign bundle
+func parseKeylessBundle(data []byte) (*keylessBundle, error) {
+	var probe struct {
+		MediaType       string `json:"mediaType"`
+		Base64Signature string `json:"base64Signature"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.HasPrefix(probe.MediaType, "application/vnd.dev.sigstore.bundle"):
+		return parseSigstoreBundle(data)
+	case probe.Base64Signature != "":
+		return parseCosignBundle(data)
+	default:
+		return nil, fmt.Errorf("not a Sigstore or cosign bundle")
+	}
+}
+
+func parseSigstoreBundle(data []byte) (*keylessBundle, error) {
+	var raw sigstoreBundleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.MessageSignature == nil {
+		if len(raw.DSSEEnvelope) > 0 {
+			return nil, fmt.Errorf("bundle holds a DSSE attestation, not a signature over the SBOM")
+		}
+		return nil, fmt.Errorf("bundle has no message signature")
+	}
+
+	var ders [][]byte
+	if material := raw.VerificationMaterial; material.Certificate != nil {
+		ders = append(ders, material.Certificate.RawBytes)
+	} else if material.X509CertificateChain != nil {
+		for _, cert := range material.X509CertificateChain.Certificates {
+			ders = append(ders, cert.RawBytes)
+		}
+	}
+	if len(ders) == 0 {
+		return nil, fmt.Errorf("bundle has no signing certificate; it may have been signed with a key")
+	}
+	certs := make([]*x509.Certificate, 0, len(ders))
+	for _, der := range ders {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	bundle := &keylessBundle{
+		signature:    raw.MessageSignature.Signature,
+		certificates: certs,
+	}
+	if digest := raw.MessageSignature.MessageDigest; len(digest.Digest) > 0 {
+		if digest.Algorithm != "SHA2_256" {
+			return nil, fmt.Errorf("unsupported message digest algorithm %s", digest.Algorithm)
+		}
+		bundle.messageDigest = digest.Digest
+	}
+	if len(raw.VerificationMaterial.TlogEntries) > 0 {
+		tlog := raw.VerificationMaterial.TlogEntries[0]
+		bundle.entry = &rekorEntry{
+			body:           tlog.CanonicalizedBody,
+			integratedTime: int64(tlog.IntegratedTime),
+			logIndex:       int64(tlog.LogIndex),
+			logID:          tlog.LogID.KeyID,
+		}
+		if tlog.InclusionPromise != nil {
+			bundle.entry.signedEntryTimestamp = tlog.InclusionPromise.SignedEntryTimestamp
+		}
+		if proof := tlog.InclusionProof; proof != nil {
+			bundle.entry.proof = &rekorInclusionProof{
+				logIndex:   int64(proof.LogIndex),
+				treeSize:   int64(proof.TreeSize),
+				rootHash:   proof.RootHash,
+				hashes:     proof.Hashes,
+				checkpoint: proof.Checkpoint.Envelope,
+			}
+		}
+	}
+	return bundle, nil
+}
+
+func parseCosignBundle(data []byte) (*keylessBundle, error) {
+	var raw cosignBundleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(raw.Base64Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	// cosign stores the PEM certificate base64 encoded
+	certPEM := []byte(raw.Cert)
+	if !bytes.HasPrefix(bytes.TrimSpace(certPEM), []byte("-----BEGIN")) {
+		if certPEM, err = base64.StdEncoding.DecodeString(raw.Cert); err != nil {
+			return nil, fmt.Errorf("failed to decode certificate: %w", err)
+		}
+	}
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("bundle has no signing certificate; it may have been signed with a key")
+	}
+
+	bundle := &keylessBundle{signature: signature, certificates: certs}
+	if raw.RekorBundle != nil {
+		payload := raw.RekorBundle.Payload
+		body, err := base64.StdEncoding.DecodeString(payload.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Rekor entry: %w", err)
+		}
+		logID, err := hex.DecodeString(payload.LogID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Rekor log ID: %w", err)
+		}
+		bundle.entry = &rekorEntry{
+			body:                 body,
+			integratedTime:       payload.IntegratedTime,
+			logIndex:             payload.LogIndex,
+			logID:                logID,
+			signedEntryTimestamp: raw.RekorBundle.SignedEntryTimestamp,
+		}
+	}
+	return bundle, nil
+}
+
+// verify checks the bundle against the SBOM, identity and trust root,
+// returning the result code and message of the first failure, or empty
+// strings if the signature is valid
+func (b *keylessBundle) verify(sbom []byte, identity KeylessIdentity, subject func(string) bool, root *KeylessTrustRoot) (code, message string) {
+	// The log entry comes first: its integrated time is only trustworthy once
+	// the log's signature over it has been checked
+	if b.entry == nil {
+		return VerifyCodeTransparencyLogInvalid, "bundle has no Rekor transparency log entry"
+	}
+	if err := b.entry.verify(root); err != nil {
+		return VerifyCodeTransparencyLogInvalid, err.Error()
+	}
+
+	leaf := b.certificates[0]
+	intermediates := x509.NewCertPool()
+	if root.FulcioIntermediates != nil {
+		intermediates = root.FulcioIntermediates.Clone()
+	}
+	for _, cert := range b.certificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	logged := time.Unix(b.entry.integratedTime, 0)
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         root.FulcioRoots,
+		Intermediates: intermediates,
+		CurrentTime:   logged,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return VerifyCodeUntrustedCertificate, fmt.Sprintf("signing certificate is not trusted at %s: %v", logged.UTC().Format(time.RFC3339), err)
+	}
+
+	issuer := certificateIssuer(leaf)
+	if issuer != identity.Issuer {
+		return VerifyCodeIdentityMismatch, fmt.Sprintf("certificate issuer %q does not match %q", issuer, identity.Issuer)
+	}
+	subjects := certificateSubjects(leaf)
+	matched := false
+	for _, s := range subjects {
+		matched = matched || subject(s)
+	}
+	if !matched {
+		want := identity.Subject
+		if want == "" {
+			want = identity.SubjectRegexp
+		}
+		return VerifyCodeIdentityMismatch, fmt.Sprintf("certificate subjects %q do not match %q", subjects, want)
+	}
+
+	hash := keylessHash(leaf.PublicKey)
+	h := hash.New()
+	h.Write(sbom)
+	sbomDigest := h.Sum(nil)
+	if b.messageDigest != nil {
+		sum := sha256.Sum256(sbom)
+		if !bytes.Equal(b.messageDigest, sum[:]) {
+			return VerifyCodeSignatureInvalid, "bundle was made for different content: message digest does not match the SBOM"
+		}
+	}
+	if err := verifyCMSSignature(leaf.PublicKey, hash, sbom, b.signature); err != nil {
+		return VerifyCodeSignatureInvalid, fmt.Sprintf("signature does not match the SBOM: %v", err)
+	}
+
+	if err := b.checkEntryBody(hash, sbomDigest); err != nil {
+		return VerifyCodeTransparencyLogInvalid, err.Error()
+	}
+	return "", ""
+}
+
+// hashedRekord is the body of a Rekor hashedrekord entry
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// checkEntryBody checks that the log entry records this signature, made by
+// this certificate over content with this digest
+func (b *keylessBundle) checkEntryBody(hash crypto.Hash, digest []byte) error {
+	var entry hashedRekord
+	if err := json.Unmarshal(b.entry.body, &entry); err != nil {
+		return fmt.Errorf("failed to parse Rekor entry: %v", err)
+	}
+	if entry.Kind != "hashedrekord" {
+		return fmt.Errorf("unsupported Rekor entry kind %q", entry.Kind)
+	}
+	algorithm := strings.ToLower(strings.ReplaceAll(hash.String(), "-", ""))
+	if entry.Spec.Data.Hash.Algorithm != algorithm || entry.Spec.Data.Hash.Value != hex.EncodeToString(digest) {
+		return fmt.Errorf("Rekor entry records a different artifact digest")
+	}
+	if !bytes.Equal(entry.Spec.Signature.Content, b.signature) {
+		return fmt.Errorf("Rekor entry records a different signature")
+	}
+	block, _ := pem.Decode(entry.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, b.certificates[0].Raw) {
+		return fmt.Errorf("Rekor entry records a different signing certificate")
+	}
+	return nil
+}
+
+// verify checks that a trusted log vouches for the entry by its signed entry
+// timestamp, and by its inclusion proof when it has one. The timestamp is
+// required: it is the log's signature over integratedTime, which an inclusion
+// proof does not cover, so without it the time the certificate is checked at
+// would be the bundle author's choice.
+func (e *rekorEntry) verify(root *KeylessTrustRoot) error {
+	logID := hex.EncodeToString(e.logID)
+	key := root.rekorKey(e.logID)
+	if key == nil {
+		return fmt.Errorf("entry was logged by an untrusted Rekor log %s", logID)
+	}
+	if len(e.signedEntryTimestamp) == 0 {
+		return fmt.Errorf("entry has no signed entry timestamp, so the time it was logged is not vouched for")
+	}
+
+	// Rekor signs the canonical JSON of these fields, which the struct
+	// reproduces: keys in order, and no characters json escapes
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{
+		Body:           base64.StdEncoding.EncodeToString(e.body),
+		IntegratedTime: e.integratedTime,
+		LogID:          logID,
+		LogIndex:       e.logIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if err := verifyCMSSignature(key, crypto.SHA256, payload, e.signedEntryTimestamp); err != nil {
+		return fmt.Errorf("signed entry timestamp does not verify: %v", err)
+	}
+
+	if e.proof != nil {
+		origin, ok := root.RekorOrigins[logID]
+		if !ok {
+			return fmt.Errorf("no checkpoint origin is configured for Rekor log %s", logID)
+		}
+		if err := e.proof.verify(e.body, key, origin, e.logID[:4]); err != nil {
+			return fmt.Errorf("inclusion proof does not verify: %v", err)
+		}
+	}
+	return nil
+}
+
+// verify checks the RFC 6962 inclusion proof of body and that the log, known
+// by its origin and key hint, signed the checkpoint committing to the proof's
+// root
+func (p *rekorInclusionProof) verify(body []byte, key crypto.PublicKey, origin string, keyHint []byte) error {
+	if p.logIndex < 0 || p.treeSize <= p.logIndex {
+		return fmt.Errorf("index %d is outside a tree of size %d", p.logIndex, p.treeSize)
+	}
+	index, size := uint64(p.logIndex), uint64(p.treeSize)
+
+	// Hash up from the leaf: inner nodes on the path first, then the right
+	// border of the tree
+	inner := bits.Len64(index ^ (size - 1))
+	border := bits.OnesCount64(index >> inner)
+	if len(p.hashes) != inner+border {
+		return fmt.Errorf("proof has %d hashes, want %d", len(p.hashes), inner+border)
+	}
+	node := merkleHash([]byte{0x00}, body)
+	for i, sibling := range p.hashes[:inner] {
+		if index>>i&1 == 0 {
+			node = merkleHash([]byte{0x01}, node, sibling)
+		} else {
+			node = merkleHash([]byte{0x01}, sibling, node)
+		}
+	}
+	for _, sibling := range p.hashes[inner:] {
+		node = merkleHash([]byte{0x01}, sibling, node)
+	}
+	if !bytes.Equal(node, p.rootHash) {
+		return fmt.Errorf("computed root hash does not match")
+	}
+
+	checkpointSize, checkpointRoot, err := verifyCheckpoint(p.checkpoint, key, origin, keyHint)
+	if err != nil {
+		return err
+	}
+	if checkpointSize != size || !bytes.Equal(checkpointRoot, p.rootHash) {
+		return fmt.Errorf("checkpoint is for a different tree")
+	}
+	return nil
+}
+
+func merkleHash(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// verifyCheckpoint checks that the checkpoint, a signed note of the log's
+// origin, tree size and root hash, names origin and carries a signature by
+// key, and returns the size and hash. Rekor names the signer after the host in
+// its origin and hints at the key with the first four bytes of the log ID.
+func verifyCheckpoint(checkpoint string, key crypto.PublicKey, origin string, keyHint []byte) (uint64, []byte, error) {
+	text, signatures, ok := strings.Cut(checkpoint, "\n\n")
+	if !ok {
+		return 0, nil, fmt.Errorf("checkpoint is missing")
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) < 3 {
+		return 0, nil, fmt.Errorf("malformed checkpoint")
+	}
+	if lines[0] != origin {
+		return 0, nil, fmt.Errorf("checkpoint is for log %q, not %q", lines[0], origin)
+	}
+	size, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed checkpoint size: %v", err)
+	}
+	rootHash, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed checkpoint root hash: %v", err)
+	}
+
+	// Each signature line is "— <name> <base64 of key hint and signature>"
+	for _, line := range strings.Split(signatures, "\n") {
+		fields := strings.Fields(strings.TrimPrefix(line, "— "))
+		if !strings.HasPrefix(line, "— ") || len(fields) != 2 {
+			continue
+		}
+		if name := fields[0]; name != origin && !strings.HasPrefix(origin, name+" - ") {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(signature) <= 4 || !bytes.Equal(signature[:4], keyHint) {
+			continue
+		}
+		if verifyCMSSignature(key, crypto.SHA256, []byte(text+"\n"), signature[4:]) == nil {
+			return size, rootHash, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("checkpoint is not signed by the log")
+}
+
+// certificateIssuer returns the OIDC issuer Fulcio recorded in cert
+func certificateIssuer(cert *x509.Certificate) string {
+	var legacy string
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidFulcioIssuerV2):
+			var issuer string
+			if rest, err := asn1.Unmarshal(ext.Value, &issuer); err == nil && len(rest) == 0 {
+				return issuer
+			}
+		case ext.Id.Equal(oidFulcioIssuer):
+			legacy = string(ext.Value)
+		}
+	}
+	return legacy
+}
+
+// certificateSubjects returns the email and URI subject alternative names of
+// cert, which Fulcio sets to the signer's OIDC identity
+func certificateSubjects(cert *x509.Certificate) []string {
+	subjects := append([]string(nil), cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	return subjects
+}
+
+// keylessHash is the digest Sigstore clients pair with the key type
+func keylessHash(publicKey crypto.PublicKey) crypto.Hash {
+	if key, ok := publicKey.(*ecdsa.PublicKey); ok {
+		switch key.Curve {
+		case elliptic.P384():
+			return crypto.SHA384
+		case elliptic.P521():
+			return crypto.SHA512
+		}
+	}
+	return crypto.SHA256
+}
+
+// keylessAlgorithm names the signing key's algorithm as an Algorithm
+// constant, or returns empty for other keys
+func keylessAlgorithm(publicKey crypto.PublicKey) string {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return AlgorithmECDSAP256
+		case elliptic.P384():
+			return AlgorithmECDSAP384
+		}
+	case *rsa.PublicKey:
+		switch key.N.BitLen() {
+		case 2048:
+			return AlgorithmRSA2048
+		case 4096:
+			return AlgorithmRSA4096
+		}
+	}
+	return ""
+}
+
+// encodeCertificateChain PEM-encodes each certificate, leaf first
+func encodeCertificateChain(certs []*x509.Certificate) []string {
+	chain := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		chain = append(chain, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	}
+	return chain
+}
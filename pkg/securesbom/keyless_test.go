@@ -0,0 +1,562 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testKeylessIssuer  = "https://token.actions.example.com"
+	testKeylessSubject = "https://github.com/example/repo/.github/workflows/release.yml@refs/heads/main"
+	testKeylessSBOM    = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"components":[]}`
+	testRekorOrigin    = "rekor.example.com - 1"
+)
+
+// keylessFixture signs testKeylessSBOM the way cosign keyless does, with a
+// test Fulcio CA and Rekor log
+type keylessFixture struct {
+	trustRoot *KeylessTrustRoot
+	caPEM     []byte
+	rekorPEM  []byte
+
+	cert      *x509.Certificate
+	signature []byte
+	body      []byte
+	logID     []byte
+	rekorKey  *ecdsa.PrivateKey
+	signedAt  time.Time
+}
+
+func newKeylessFixture(t *testing.T, sbom string) *keylessFixture {
+	t.Helper()
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	// Fulcio certificates live for minutes, so checking them at the current
+	// time rather than the logged time would fail
+	signedAt := now.Add(-30 * time.Minute)
+	issuer, _ := asn1.Marshal(testKeylessIssuer)
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       signedAt.Add(-time.Minute),
+		NotAfter:        signedAt.Add(9 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: oidFulcioIssuerV2, Value: issuer}},
+	}
+	subject, err := url.Parse(testKeylessSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate.URIs = []*url.URL{subject}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(leafDER)
+
+	digest := sha256.Sum256([]byte(sbom))
+	signature, err := ecdsa.SignASN1(rand.Reader, leafKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rekorDER, _ := x509.MarshalPKIXPublicKey(&rekorKey.PublicKey)
+	logID := sha256.Sum256(rekorDER)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	body, _ := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{
+				"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+			"signature": map[string]interface{}{
+				"content":   signature,
+				"publicKey": map[string]interface{}{"content": certPEM},
+			},
+		},
+	})
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	rekorPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rekorDER})
+	trustRoot, err := LoadKeylessTrustRoot(caPEM, rekorPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustRoot.RekorOrigins = map[string]string{hex.EncodeToString(logID[:]): testRekorOrigin}
+
+	return &keylessFixture{
+		trustRoot: trustRoot,
+		caPEM:     caPEM,
+		rekorPEM:  rekorPEM,
+		cert:      leaf,
+		signature: signature,
+		body:      body,
+		logID:     logID[:],
+		rekorKey:  rekorKey,
+		signedAt:  signedAt,
+	}
+}
+
+// signedEntryTimestamp is the Rekor signature over the entry
+func (f *keylessFixture) signedEntryTimestamp(t *testing.T) []byte {
+	t.Helper()
+	payload := fmt.Sprintf(`{"body":%q,"integratedTime":%d,"logID":%q,"logIndex":7}`,
+		base64.StdEncoding.EncodeToString(f.body), f.signedAt.Unix(), hex.EncodeToString(f.logID))
+	digest := sha256.Sum256([]byte(payload))
+	set, err := ecdsa.SignASN1(rand.Reader, f.rekorKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+// inclusionProof places the entry at index 1 of a three leaf tree and signs a
+// checkpoint for it, naming origin and hinting at the key with keyHint
+func (f *keylessFixture) inclusionProof(t *testing.T, origin string, keyHint []byte) map[string]interface{} {
+	t.Helper()
+	leaf0 := merkleHash([]byte{0x00}, []byte("entry 0"))
+	leaf1 := merkleHash([]byte{0x00}, f.body)
+	leaf2 := merkleHash([]byte{0x00}, []byte("entry 2"))
+	root := merkleHash([]byte{0x01}, merkleHash([]byte{0x01}, leaf0, leaf1), leaf2)
+
+	text := fmt.Sprintf("%s\n3\n%s\n", origin, base64.StdEncoding.EncodeToString(root))
+	digest := sha256.Sum256([]byte(text))
+	signature, err := ecdsa.SignASN1(rand.Reader, f.rekorKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := base64.StdEncoding.EncodeToString(append(keyHint[:len(keyHint):len(keyHint)], signature...))
+
+	return map[string]interface{}{
+		"logIndex": "1",
+		"rootHash": root,
+		"treeSize": "3",
+		"hashes":   [][]byte{leaf0, leaf2},
+		"checkpoint": map[string]string{
+			"envelope": text + "\n— " + strings.Split(origin, " - ")[0] + " " + line + "\n",
+		},
+	}
+}
+
+// sigstoreBundle builds a v0.3 Sigstore bundle; edit adjusts the tlog entry
+func (f *keylessFixture) sigstoreBundle(t *testing.T, edit func(entry map[string]interface{})) []byte {
+	t.Helper()
+	digest := sha256.Sum256([]byte(testKeylessSBOM))
+	entry := map[string]interface{}{
+		"logIndex":          "7",
+		"logId":             map[string]interface{}{"keyId": f.logID},
+		"kindVersion":       map[string]string{"kind": "hashedrekord", "version": "0.0.1"},
+		"integratedTime":    fmt.Sprint(f.signedAt.Unix()),
+		"inclusionPromise":  map[string]interface{}{"signedEntryTimestamp": f.signedEntryTimestamp(t)},
+		"inclusionProof":    f.inclusionProof(t, testRekorOrigin, f.logID[:4]),
+		"canonicalizedBody": f.body,
+	}
+	if edit != nil {
+		edit(entry)
+	}
+	bundle, _ := json.Marshal(map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": map[string]interface{}{
+			"certificate": map[string]interface{}{"rawBytes": f.cert.Raw},
+			"tlogEntries": []interface{}{entry},
+		},
+		"messageSignature": map[string]interface{}{
+			"messageDigest": map[string]interface{}{"algorithm": "SHA2_256", "digest": digest[:]},
+			"signature":     f.signature,
+		},
+	})
+	return bundle
+}
+
+// cosignBundle builds the bundle cosign sign-blob --bundle writes
+func (f *keylessFixture) cosignBundle(t *testing.T) []byte {
+	t.Helper()
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.cert.Raw})
+	bundle, _ := json.Marshal(map[string]interface{}{
+		"base64Signature": base64.StdEncoding.EncodeToString(f.signature),
+		"cert":            base64.StdEncoding.EncodeToString(certPEM),
+		"rekorBundle": map[string]interface{}{
+			"SignedEntryTimestamp": f.signedEntryTimestamp(t),
+			"Payload": map[string]interface{}{
+				"body":           base64.StdEncoding.EncodeToString(f.body),
+				"integratedTime": f.signedAt.Unix(),
+				"logIndex":       7,
+				"logID":          hex.EncodeToString(f.logID),
+			},
+		},
+	})
+	return bundle
+}
+
+func newKeylessTestClient(t *testing.T, root *KeylessTrustRoot) *Client {
+	t.Helper()
+
+	return newTestClient(t, nil, func(config *Config) { config.KeylessTrustRoot = root })
+}
+
+func TestVerifyKeyless(t *testing.T) {
+	fixture := newKeylessFixture(t, testKeylessSBOM)
+	other := newKeylessFixture(t, testKeylessSBOM)
+	identity := KeylessIdentity{Issuer: testKeylessIssuer, Subject: testKeylessSubject}
+
+	tests := []struct {
+		name      string
+		sbom      string
+		bundle    []byte
+		identity  KeylessIdentity
+		root      *KeylessTrustRoot
+		wantValid bool
+		wantCode  string
+	}{
+		{
+			name:      "sigstore bundle",
+			bundle:    fixture.sigstoreBundle(t, nil),
+			wantValid: true,
+			wantCode:  "VALID",
+		},
+		{
+			name:      "cosign bundle",
+			bundle:    fixture.cosignBundle(t),
+			wantValid: true,
+			wantCode:  "VALID",
+		},
+
+		{
+			name:      "subject regexp",
+			bundle:    fixture.sigstoreBundle(t, nil),
+			identity:  KeylessIdentity{Issuer: testKeylessIssuer, SubjectRegexp: `^https://github\.com/example/`},
+			wantValid: true,
+			wantCode:  "VALID",
+		},
+		{
+			name:     "other subject",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: KeylessIdentity{Issuer: testKeylessIssuer, Subject: "someone@example.com"},
+			wantCode: VerifyCodeIdentityMismatch,
+		},
+		{
+			name:     "other issuer",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: KeylessIdentity{Issuer: "https://accounts.example.com", Subject: testKeylessSubject},
+			wantCode: VerifyCodeIdentityMismatch,
+		},
+		{
+			name:     "modified SBOM",
+			sbom:     strings.Replace(testKeylessSBOM, `"version":1`, `"version":2`, 1),
+			bundle:   fixture.cosignBundle(t),
+			wantCode: VerifyCodeSignatureInvalid,
+		},
+		{
+			name:   "untrusted Fulcio root",
+			bundle: fixture.sigstoreBundle(t, nil),
+			root: &KeylessTrustRoot{
+				FulcioRoots:  other.trustRoot.FulcioRoots,
+				RekorKeys:    fixture.trustRoot.RekorKeys,
+				RekorOrigins: fixture.trustRoot.RekorOrigins,
+			},
+			wantCode: VerifyCodeUntrustedCertificate,
+		},
+		{
+			name:     "untrusted Rekor log",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			root:     &KeylessTrustRoot{FulcioRoots: fixture.trustRoot.FulcioRoots, RekorKeys: other.trustRoot.RekorKeys},
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "tampered integrated time",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				entry["integratedTime"] = fmt.Sprint(fixture.signedAt.Unix() + 1)
+				delete(entry, "inclusionProof")
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "inclusion proof only",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				delete(entry, "inclusionPromise")
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			// The proof doesn't cover integratedTime, so a backdated entry
+			// would otherwise have an expired certificate checked as valid
+			name: "inclusion proof only with tampered integrated time",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				delete(entry, "inclusionPromise")
+				entry["integratedTime"] = fmt.Sprint(fixture.signedAt.Add(-time.Hour).Unix())
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "checkpoint from another origin",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				entry["inclusionProof"] = fixture.inclusionProof(t, "rekor.other.example.com - 2", fixture.logID[:4])
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "checkpoint with another key hint",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				entry["inclusionProof"] = fixture.inclusionProof(t, testRekorOrigin, []byte{0, 0, 0, 0})
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name:     "no configured checkpoint origin",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			root:     &KeylessTrustRoot{FulcioRoots: fixture.trustRoot.FulcioRoots, RekorKeys: fixture.trustRoot.RekorKeys},
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "wrong inclusion proof",
+			bundle: fixture.sigstoreBundle(t, func(entry map[string]interface{}) {
+				entry["inclusionProof"].(map[string]interface{})["logIndex"] = "0"
+			}),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name: "no transparency log entry",
+			bundle: func() []byte {
+				var bundle map[string]interface{}
+				_ = json.Unmarshal(fixture.sigstoreBundle(t, nil), &bundle)
+				delete(bundle["verificationMaterial"].(map[string]interface{}), "tlogEntries")
+				data, _ := json.Marshal(bundle)
+				return data
+			}(),
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+		{
+			name:   "entry for another signature",
+			bundle: withEntryBody(t, fixture.sigstoreBundle(t, nil), other),
+			root: &KeylessTrustRoot{
+				FulcioRoots:  fixture.trustRoot.FulcioRoots,
+				RekorKeys:    other.trustRoot.RekorKeys,
+				RekorOrigins: other.trustRoot.RekorOrigins,
+			},
+			wantCode: VerifyCodeTransparencyLogInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbom := tt.sbom
+			if sbom == "" {
+				sbom = testKeylessSBOM
+			}
+			if tt.identity == (KeylessIdentity{}) {
+				tt.identity = identity
+			}
+			if tt.root == nil {
+				tt.root = fixture.trustRoot
+			}
+
+			result, err := newKeylessTestClient(t, tt.root).VerifyKeyless(context.Background(), []byte(sbom), tt.bundle, tt.identity)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Valid != tt.wantValid || result.Code != tt.wantCode {
+				t.Fatalf("got valid=%v code=%s (%s), want valid=%v code=%s", result.Valid, result.Code, result.Message, tt.wantValid, tt.wantCode)
+			}
+			if len(result.CertificateChain) != 1 || result.SBOMDigest == "" {
+				t.Errorf("expected the certificate and SBOM digest in the result, got %+v", result)
+			}
+			if tt.wantValid && result.Algorithm != AlgorithmECDSAP256 {
+				t.Errorf("expected algorithm %s, got %s", AlgorithmECDSAP256, result.Algorithm)
+			}
+		})
+	}
+}
+
+// withEntryBody swaps the log entry of bundle for a properly logged entry of
+// other's signature
+func withEntryBody(t *testing.T, bundle []byte, other *keylessFixture) []byte {
+	t.Helper()
+	var swapped map[string]interface{}
+	_ = json.Unmarshal(bundle, &swapped)
+	var replacement map[string]interface{}
+	_ = json.Unmarshal(other.sigstoreBundle(t, nil), &replacement)
+	swapped["verificationMaterial"].(map[string]interface{})["tlogEntries"] =
+		replacement["verificationMaterial"].(map[string]interface{})["tlogEntries"]
+	data, _ := json.Marshal(swapped)
+	return data
+}
+
+func TestVerifyKeyless_Errors(t *testing.T) {
+	fixture := newKeylessFixture(t, testKeylessSBOM)
+	identity := KeylessIdentity{Issuer: testKeylessIssuer, Subject: testKeylessSubject}
+
+	tests := []struct {
+		name     string
+		bundle   []byte
+		identity KeylessIdentity
+		root     *KeylessTrustRoot
+		wantErr  error
+		wantText string
+	}{
+		{
+			name:     "missing bundle",
+			bundle:   nil,
+			identity: identity,
+			root:     fixture.trustRoot,
+			wantErr:  ErrKeylessBundleMissing,
+		},
+		{
+			name:     "no trust root",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: identity,
+			wantText: "requires a trust root",
+		},
+		{
+			name:     "identity without issuer",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: KeylessIdentity{Subject: testKeylessSubject},
+			root:     fixture.trustRoot,
+			wantText: "requires an issuer",
+		},
+		{
+			name:     "identity without subject",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: KeylessIdentity{Issuer: testKeylessIssuer},
+			root:     fixture.trustRoot,
+			wantText: "requires a subject",
+		},
+		{
+			name:     "invalid subject regexp",
+			bundle:   fixture.sigstoreBundle(t, nil),
+			identity: KeylessIdentity{Issuer: testKeylessIssuer, SubjectRegexp: "("},
+			root:     fixture.trustRoot,
+			wantText: "invalid keyless subject regexp",
+		},
+		{
+			name:     "not a bundle",
+			bundle:   []byte(`{"payloadType":"application/vnd.in-toto+json"}`),
+			identity: identity,
+			root:     fixture.trustRoot,
+			wantText: "not a Sigstore or cosign bundle",
+		},
+		{
+			name:     "key-signed bundle",
+			bundle:   []byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","verificationMaterial":{"publicKey":{"hint":"k"}},"messageSignature":{"signature":"c2ln"}}`),
+			identity: identity,
+			root:     fixture.trustRoot,
+			wantText: "no signing certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newKeylessTestClient(t, tt.root).VerifyKeyless(context.Background(), []byte(testKeylessSBOM), tt.bundle, tt.identity)
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", result)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantText != "" && !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("expected error containing %q, got %v", tt.wantText, err)
+			}
+		})
+	}
+}
+
+func TestLoadKeylessTrustRoot(t *testing.T) {
+	fixture := newKeylessFixture(t, testKeylessSBOM)
+
+	root, err := LoadKeylessTrustRoot(fixture.caPEM, fixture.rekorPEM)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.rekorKey(fixture.logID) == nil {
+		t.Errorf("expected the Rekor key to be found by its log ID")
+	}
+	root.SetRekorOrigin(testRekorOrigin)
+	if !maps.Equal(root.RekorOrigins, fixture.trustRoot.RekorOrigins) {
+		t.Errorf("RekorOrigins = %v, want %v", root.RekorOrigins, fixture.trustRoot.RekorOrigins)
+	}
+
+	if _, err := LoadKeylessTrustRoot(nil, fixture.rekorPEM); err == nil {
+		t.Errorf("expected an error without a Fulcio root")
+	}
+	if _, err := LoadKeylessTrustRoot(fixture.caPEM, nil); err == nil {
+		t.Errorf("expected an error without a Rekor key")
+	}
+	leafPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fixture.cert.Raw})
+	if _, err := LoadKeylessTrustRoot(leafPEM, fixture.rekorPEM); err == nil {
+		t.Errorf("expected an error when only an intermediate is given")
+	}
+}
+
+func TestKeylessHash(t *testing.T) {
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if got := keylessHash(&p384.PublicKey); got != crypto.SHA384 {
+		t.Errorf("P-384 hash = %v, want SHA-384", got)
+	}
+	if got := keylessHash(&p256.PublicKey); got != crypto.SHA256 {
+		t.Errorf("P-256 hash = %v, want SHA-256", got)
+	}
+}
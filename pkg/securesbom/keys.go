@@ -0,0 +1,320 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key statuses reported in GenerateKeyCMDResponse.Status
+const (
+	KeyStatusActive  = "active"
+	KeyStatusRevoked = "revoked"
+	KeyStatusExpired = "expired"
+)
+
+// IsExpired reports whether the key has expired and can no longer sign
+func (k GenerateKeyCMDResponse) IsExpired() bool {
+	return k.Status == KeyStatusExpired || (!k.ExpiresAt.IsZero() && !time.Now().Before(k.ExpiresAt))
+}
+
+// keyStatus flags keys past their expiry as expired, since the server may only
+// update a key's status some time after it expires
+func keyStatus(status string, expiresAt time.Time) string {
+	if !expiresAt.IsZero() && !time.Now().Before(expiresAt) && status != KeyStatusRevoked {
+		return KeyStatusExpired
+	}
+	return status
+}
+
+// matches reports whether key passes every filter set in o
+func (o ListKeysOptions) matches(key GenerateKeyCMDResponse) bool {
+	if o.Status != "" && key.Status != o.Status {
+		return false
+	}
+	if o.Algorithm != "" && !algorithmMatches(key.Algorithm, o.Algorithm) {
+		return false
+	}
+	if !o.CreatedBefore.IsZero() && !key.CreatedAt.Before(o.CreatedBefore) {
+		return false
+	}
+	if !o.CreatedAfter.IsZero() && !key.CreatedAt.After(o.CreatedAfter) {
+		return false
+	}
+	// ListKeysPaged has already rejected malformed selectors
+	if selector, _ := parseLabelSelector(o.LabelSelector); !selector.matches(key.Labels) {
+		return false
+	}
+	return true
+}
+
+// labelRequirement is one comma separated term of a label selector
+type labelRequirement struct {
+	key    string
+	value  string
+	negate bool
+	// exists is set for the bare "k" and "!k" forms, which test only whether
+	// the label is present
+	exists bool
+}
+
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses a selector such as "team=payments,env!=dev,!legacy"
+func parseLabelSelector(selector string) (labelSelector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	var reqs labelSelector
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		var req labelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			req.key, req.value, _ = strings.Cut(term, "!=")
+			req.negate = true
+		case strings.Contains(term, "="):
+			req.key, req.value, _ = strings.Cut(term, "=")
+		case strings.HasPrefix(term, "!"):
+			req.key, req.negate, req.exists = term[1:], true, true
+		default:
+			req.key, req.exists = term, true
+		}
+		req.key, req.value = strings.TrimSpace(req.key), strings.TrimSpace(req.value)
+		if req.key == "" || strings.ContainsAny(req.key, "!=") || strings.Contains(req.value, "=") {
+			return nil, fmt.Errorf("invalid label selector %q: malformed requirement %q", selector, term)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// matches reports whether labels satisfy every requirement in s
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		var match bool
+		if req.exists {
+			match = ok
+		} else {
+			match = ok && value == req.value
+		}
+		if match == req.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLabels rejects labels that could not be selected unambiguously
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("label keys must not be empty")
+		}
+		if strings.ContainsAny(key, "!=,") || strings.ContainsAny(value, "=,") {
+			return fmt.Errorf("invalid label %q: keys must not contain '!', '=' or ',' and values must not contain '=' or ','", key+"="+value)
+		}
+	}
+	return nil
+}
+
+// algorithmMatches reports whether algorithm is filter or, when filter names
+// a family such as "rsa", one of its members such as "rsa-2048"
+func algorithmMatches(algorithm, filter string) bool {
+	algorithm, filter = strings.ToLower(algorithm), strings.ToLower(filter)
+	return algorithm == filter || strings.HasPrefix(algorithm, filter+"-")
+}
+
+// IterateKeys returns an iterator over every key visible to the client,
+// fetching pages of opts.PageSize on demand. If a page cannot be fetched the
+// error is yielded once and iteration stops.
+//
+//	for key, err := range securesbom.IterateKeys(ctx, client, securesbom.ListKeysOptions{}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(key.ID)
+//	}
+func IterateKeys(ctx context.Context, client ClientInterface, opts ListKeysOptions) iter.Seq2[GenerateKeyCMDResponse, error] {
+	return func(yield func(GenerateKeyCMDResponse, error) bool) {
+		for {
+			page, err := client.ListKeysPaged(ctx, opts)
+			if err != nil {
+				yield(GenerateKeyCMDResponse{}, err)
+				return
+			}
+
+			for _, key := range page.Keys {
+				if !yield(key, nil) {
+					return
+				}
+			}
+
+			if page.NextPageToken == "" {
+				return
+			}
+			if page.NextPageToken == opts.PageToken {
+				yield(GenerateKeyCMDResponse{}, fmt.Errorf("failed to list keys: server repeated page token %q", page.NextPageToken))
+				return
+			}
+			opts.PageToken = page.NextPageToken
+		}
+	}
+}
+
+// GenerateKeys creates count keys with the same options concurrently, e.g. to
+// provision a new environment. Results are positional and nil for keys that
+// failed to be created, with a *BatchError describing the failures.
+//
+// A quota error, a 429 response or an API error code naming a quota, stops
+// further creations: keys already being created finish, and the rest fail with
+// an error wrapping the quota error without being attempted.
+func GenerateKeys(ctx context.Context, client ClientInterface, count int, opts GenerateKeyOptions, batch BatchOptions) ([]*GenerateKeyCMDResponse, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	if err := validateAlgorithm(opts.Algorithm); err != nil {
+		return nil, err
+	}
+	if err := validateLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+
+	var quotaMu sync.Mutex
+	var quotaErr error
+
+	results := make([]*GenerateKeyCMDResponse, count)
+	errs := runBatch(ctx, count, batch, func(ctx context.Context, i int) error {
+		quotaMu.Lock()
+		stopped := quotaErr
+		quotaMu.Unlock()
+		if stopped != nil {
+			return fmt.Errorf("key not created after quota error: %w", stopped)
+		}
+
+		key, err := client.GenerateKeyWithOptions(ctx, opts)
+		if err != nil {
+			if isQuotaError(err) {
+				quotaMu.Lock()
+				if quotaErr == nil {
+					quotaErr = err
+				}
+				quotaMu.Unlock()
+			}
+			return err
+		}
+		results[i] = key
+		return nil
+	})
+	return results, errs
+}
+
+// isQuotaError reports whether err means the account may not create more
+// resources for now
+func isQuotaError(err error) bool {
+	if IsRateLimited(err) {
+		return true
+	}
+	apiErr, ok := AsAPIError(err)
+	return ok && strings.Contains(strings.ToLower(apiErr.Code), "quota")
+}
+
+// checkPublicKeyPEM ensures publicKeyPEM holds a single PEM-encoded PKIX
+// ("PUBLIC KEY") public key
+func checkPublicKeyPEM(publicKeyPEM string) error {
+	_, err := parsePublicKeyPEM(publicKeyPEM)
+	return err
+}
+
+// parsePublicKeyPEM parses a single PEM-encoded PKIX ("PUBLIC KEY") public key
+func parsePublicKeyPEM(publicKeyPEM string) (crypto.PublicKey, error) {
+	block, rest := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidPublicKey)
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%w: expected a PUBLIC KEY PEM block, got %q", ErrInvalidPublicKey, block.Type)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("%w: unexpected data after the PEM block", ErrInvalidPublicKey)
+	}
+	return publicKey, nil
+}
+
+// publicKeyAlgorithm returns the Algorithm constant for publicKey, or an
+// ErrInvalidPublicKey error if SecureSBOM cannot verify with that kind of key
+func publicKeyAlgorithm(publicKey crypto.PublicKey) (string, error) {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return AlgorithmECDSAP256, nil
+		case elliptic.P384():
+			return AlgorithmECDSAP384, nil
+		}
+		return "", fmt.Errorf("%w: unsupported ECDSA curve %s", ErrInvalidPublicKey, key.Curve.Params().Name)
+	case *rsa.PublicKey:
+		switch key.N.BitLen() {
+		case 2048:
+			return AlgorithmRSA2048, nil
+		case 4096:
+			return AlgorithmRSA4096, nil
+		}
+		return "", fmt.Errorf("%w: unsupported RSA key size %d", ErrInvalidPublicKey, key.N.BitLen())
+	default:
+		return "", fmt.Errorf("%w: unsupported key type %T", ErrInvalidPublicKey, publicKey)
+	}
+}
+
+// importedKeyAlgorithm checks that publicKeyPEM is a supported public key of
+// the given algorithm and returns the algorithm, inferred from the key when
+// algorithm is empty
+func importedKeyAlgorithm(publicKeyPEM, algorithm string) (string, error) {
+	publicKey, err := parsePublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	keyAlgorithm, err := publicKeyAlgorithm(publicKey)
+	if err != nil {
+		return "", err
+	}
+	if algorithm != "" && algorithm != keyAlgorithm {
+		return "", fmt.Errorf("%w: key is %s, not %s", ErrInvalidPublicKey, keyAlgorithm, algorithm)
+	}
+	return keyAlgorithm, nil
+}
@@ -0,0 +1,379 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newPagedKeysClient returns a client whose key listing serves keyIDs in pages
+// of pageSize, using the offset of the next page as the page token
+func newPagedKeysClient(t *testing.T, keyIDs []string, pageSize int, requests *int) *Client {
+	t.Helper()
+
+	return newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		*requests++
+
+		start := 0
+		if token := req.URL.Query().Get("page_token"); token != "" {
+			if _, err := fmt.Sscanf(token, "offset-%d", &start); err != nil {
+				return createMockResponse(400, map[string]string{"error": "bad page token"}), nil
+			}
+		}
+		end := min(start+pageSize, len(keyIDs))
+
+		page := listKeysPageAPIResponse{Keys: []ListKeysAPIResponse{}}
+		for _, id := range keyIDs[start:end] {
+			page.Keys = append(page.Keys, ListKeysAPIResponse{ID: id, Algorithm: "ES256"})
+		}
+		if end < len(keyIDs) {
+			page.NextPageToken = fmt.Sprintf("offset-%d", end)
+		}
+		return createMockResponse(200, page), nil
+	}, nil)
+}
+
+func TestIterateKeys(t *testing.T) {
+	allKeys := []string{"key-1", "key-2", "key-3", "key-4", "key-5"}
+
+	tests := []struct {
+		name             string
+		keyIDs           []string
+		stopAfter        int
+		expectKeys       []string
+		expectedRequests int
+	}{
+		{
+			name:             "walks every page",
+			keyIDs:           allKeys,
+			expectKeys:       allKeys,
+			expectedRequests: 3,
+		},
+		{
+			name:             "stops fetching when caller breaks",
+			keyIDs:           allKeys,
+			stopAfter:        2,
+			expectKeys:       []string{"key-1", "key-2"},
+			expectedRequests: 1,
+		},
+		{
+			name:             "no keys",
+			keyIDs:           []string{},
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := newPagedKeysClient(t, tt.keyIDs, 2, &requests)
+
+			var got []string
+			for key, err := range IterateKeys(context.Background(), client, ListKeysOptions{PageSize: 2}) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, key.ID)
+				if tt.stopAfter > 0 && len(got) == tt.stopAfter {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.expectKeys) {
+				t.Errorf("expected keys %v, got %v", tt.expectKeys, got)
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestIterateKeys_Error(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(500, map[string]string{"error": "internal error"}), nil
+	}, nil)
+
+	var errs int
+	for _, err := range IterateKeys(context.Background(), client, ListKeysOptions{}) {
+		if err == nil {
+			t.Fatal("expected error but got a key")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected exactly one error, got %d", errs)
+	}
+}
+
+func TestGenerateKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		count          int
+		concurrency    int
+		failAt         map[int]int
+		expectError    bool
+		expectRequests int
+		expectCreated  int
+		expectQuota    bool
+	}{
+		{name: "all created", count: 5, concurrency: 2, expectRequests: 5, expectCreated: 5},
+		{name: "invalid count", count: 0, expectError: true},
+		{
+			name:           "partial failure",
+			count:          4,
+			concurrency:    1,
+			failAt:         map[int]int{2: http.StatusBadRequest},
+			expectError:    true,
+			expectRequests: 4,
+			expectCreated:  3,
+		},
+		{
+			name:           "quota stops remaining creations",
+			count:          5,
+			concurrency:    1,
+			failAt:         map[int]int{2: http.StatusTooManyRequests},
+			expectError:    true,
+			expectRequests: 2,
+			expectCreated:  1,
+			expectQuota:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				requests++
+				n := requests
+				mu.Unlock()
+				if status, ok := tt.failAt[n]; ok {
+					return createMockResponse(status, map[string]string{"error": "failed"}), nil
+				}
+				return createMockResponse(http.StatusCreated, map[string]interface{}{
+					"id":        fmt.Sprintf("key-%d", n),
+					"algorithm": AlgorithmEd25519,
+				}), nil
+			}, nil)
+
+			keys, err := GenerateKeys(context.Background(), client, tt.count,
+				GenerateKeyOptions{Algorithm: AlgorithmEd25519}, BatchOptions{Concurrency: tt.concurrency})
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error = %t, got %v", tt.expectError, err)
+			}
+			if requests != tt.expectRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.expectRequests)
+			}
+
+			created := map[string]bool{}
+			for _, key := range keys {
+				if key != nil {
+					created[key.ID] = true
+				}
+			}
+			if len(created) != tt.expectCreated {
+				t.Errorf("created %d distinct keys, want %d", len(created), tt.expectCreated)
+			}
+
+			var batchErr *BatchError
+			if err != nil && tt.count > 0 {
+				if !errors.As(err, &batchErr) {
+					t.Fatalf("expected *BatchError, got %T", err)
+				}
+				for i, itemErr := range batchErr.Errors {
+					if (itemErr == nil) != (keys[i] != nil) {
+						t.Errorf("item %d: error %v with key %v", i, itemErr, keys[i])
+					}
+				}
+			}
+			if tt.expectQuota {
+				for i := 1; i < tt.count; i++ {
+					if !IsRateLimited(batchErr.Errors[i]) {
+						t.Errorf("item %d: expected a quota error, got %v", i, batchErr.Errors[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestKeyStatus(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		status        string
+		expiresAt     time.Time
+		expectStatus  string
+		expectExpired bool
+	}{
+		{name: "active", status: KeyStatusActive, expectStatus: KeyStatusActive},
+		{name: "active until later", status: KeyStatusActive, expiresAt: future, expectStatus: KeyStatusActive},
+		{name: "active past expiry", status: KeyStatusActive, expiresAt: past, expectStatus: KeyStatusExpired, expectExpired: true},
+		{name: "unreported past expiry", expiresAt: past, expectStatus: KeyStatusExpired, expectExpired: true},
+		{name: "expired", status: KeyStatusExpired, expectStatus: KeyStatusExpired, expectExpired: true},
+		{name: "revoked stays revoked", status: KeyStatusRevoked, expiresAt: past, expectStatus: KeyStatusRevoked, expectExpired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := ListKeysAPIResponse{ID: "key-1", Status: tt.status, ExpiresAt: tt.expiresAt}.toKeyInfo()
+			if key.Status != tt.expectStatus {
+				t.Errorf("Status = %q, want %q", key.Status, tt.expectStatus)
+			}
+			if key.IsExpired() != tt.expectExpired {
+				t.Errorf("IsExpired() = %v, want %v", key.IsExpired(), tt.expectExpired)
+			}
+		})
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod"}
+
+	tests := []struct {
+		name        string
+		selector    string
+		expectMatch bool
+		expectError bool
+	}{
+		{name: "empty", selector: "", expectMatch: true},
+		{name: "equality", selector: "team=payments", expectMatch: true},
+		{name: "equality mismatch", selector: "team=search", expectMatch: false},
+		{name: "all requirements", selector: "team=payments, env=prod", expectMatch: true},
+		{name: "one requirement fails", selector: "team=payments,env=dev", expectMatch: false},
+		{name: "inequality", selector: "env!=dev", expectMatch: true},
+		{name: "inequality mismatch", selector: "env!=prod", expectMatch: false},
+		{name: "inequality on missing label", selector: "region!=eu", expectMatch: true},
+		{name: "exists", selector: "team", expectMatch: true},
+		{name: "exists on missing label", selector: "region", expectMatch: false},
+		{name: "not exists", selector: "!region", expectMatch: true},
+		{name: "not exists on set label", selector: "!team", expectMatch: false},
+		{name: "empty value", selector: "team=", expectMatch: false},
+		{name: "trailing comma", selector: "team=payments,", expectError: true},
+		{name: "missing key", selector: "=payments", expectError: true},
+		{name: "double equals", selector: "team==payments", expectError: true},
+		{name: "negated equality", selector: "!team=payments", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := parseLabelSelector(tt.selector)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := selector.matches(labels); got != tt.expectMatch {
+				t.Errorf("matches() = %v, want %v", got, tt.expectMatch)
+			}
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		expectError bool
+	}{
+		{name: "none"},
+		{name: "valid", labels: map[string]string{"team": "payments", "env": ""}},
+		{name: "empty key", labels: map[string]string{"": "payments"}, expectError: true},
+		{name: "equals in key", labels: map[string]string{"team=x": "payments"}, expectError: true},
+		{name: "bang in key", labels: map[string]string{"!team": "payments"}, expectError: true},
+		{name: "comma in value", labels: map[string]string{"team": "payments,search"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLabels(tt.labels)
+			if (err != nil) != tt.expectError {
+				t.Errorf("validateLabels() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestPublicKeyAlgorithm(t *testing.T) {
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	p224Key, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	smallRSAKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+
+	tests := []struct {
+		name      string
+		publicKey crypto.PublicKey
+		want      string
+		wantErr   bool
+	}{
+		{name: "ed25519", publicKey: edKey, want: AlgorithmEd25519},
+		{name: "ecdsa p-256", publicKey: &p256Key.PublicKey, want: AlgorithmECDSAP256},
+		{name: "ecdsa p-384", publicKey: &p384Key.PublicKey, want: AlgorithmECDSAP384},
+		{name: "rsa 2048", publicKey: &rsaKey.PublicKey, want: AlgorithmRSA2048},
+		{name: "unsupported curve", publicKey: &p224Key.PublicKey, wantErr: true},
+		{name: "unsupported rsa size", publicKey: &smallRSAKey.PublicKey, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			der, err := x509.MarshalPKIXPublicKey(tt.publicKey)
+			if err != nil {
+				t.Fatalf("failed to marshal public key: %v", err)
+			}
+			publicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+			got, err := importedKeyAlgorithm(publicKeyPEM, "")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPublicKey) {
+					t.Fatalf("error = %v, want ErrInvalidPublicKey", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("algorithm = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
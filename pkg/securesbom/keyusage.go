@@ -0,0 +1,91 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Key usages reported in GenerateKeyCMDResponse.Usage
+const (
+	KeyUsageSign   = "sign"
+	KeyUsageVerify = "verify"
+)
+
+// CanSign reports whether the key's Usage permits signing. A key without a
+// reported usage is assumed to permit it.
+func (k GenerateKeyCMDResponse) CanSign() bool {
+	return keyUsageAllows(k.Usage, KeyUsageSign)
+}
+
+func keyUsageAllows(usage []string, want string) bool {
+	return len(usage) == 0 || slices.ContainsFunc(usage, func(u string) bool { return strings.EqualFold(u, want) })
+}
+
+// checkSigningKey returns ErrKeyNotUsableForSigning, when the check is
+// enabled, if keyID's usage does not permit signing. Keys the client hasn't
+// seen yet are fetched with GetKey.
+func (c *Client) checkSigningKey(ctx context.Context, keyID string) error {
+	if !c.config.CheckKeyUsage {
+		return nil
+	}
+
+	usage, ok := c.knownKeyUsage(keyID)
+	if !ok {
+		key, err := c.GetKey(ctx, keyID)
+		if err != nil {
+			return fmt.Errorf("failed to check key usage: %w", err)
+		}
+		usage = key.Usage
+	}
+	if !keyUsageAllows(usage, KeyUsageSign) {
+		return fmt.Errorf("%w: key %s permits %s", ErrKeyNotUsableForSigning, keyID, strings.Join(usage, ", "))
+	}
+	return nil
+}
+
+// rememberKeyUsage records the usage of a key the API has returned, so the
+// signing check needs no request for it. Nothing is kept unless the check is
+// enabled.
+func (c *Client) rememberKeyUsage(keyID string, usage []string) {
+	if !c.config.CheckKeyUsage || keyID == "" {
+		return
+	}
+
+	c.keyUsagesMu.Lock()
+	defer c.keyUsagesMu.Unlock()
+	if c.keyUsages == nil {
+		c.keyUsages = make(map[string][]string)
+	}
+	c.keyUsages[keyID] = slices.Clone(usage)
+}
+
+func (c *Client) knownKeyUsage(keyID string) ([]string, bool) {
+	c.keyUsagesMu.Lock()
+	defer c.keyUsagesMu.Unlock()
+	usage, ok := c.keyUsages[keyID]
+	return usage, ok
+}
+
+func (c *Client) forgetKeyUsage(keyID string) {
+	c.keyUsagesMu.Lock()
+	defer c.keyUsagesMu.Unlock()
+	delete(c.keyUsages, keyID)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKeyCMDResponse_CanSign(t *testing.T) {
+	tests := []struct {
+		name     string
+		usage    []string
+		expected bool
+	}{
+		{name: "no usage reported", expected: true},
+		{name: "sign only", usage: []string{KeyUsageSign}, expected: true},
+		{name: "sign and verify", usage: []string{KeyUsageVerify, KeyUsageSign}, expected: true},
+		{name: "case insensitive", usage: []string{"SIGN"}, expected: true},
+		{name: "verify only", usage: []string{KeyUsageVerify}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := GenerateKeyCMDResponse{ID: "key-123", Usage: tt.usage}
+			if got := key.CanSign(); got != tt.expected {
+				t.Errorf("CanSign() = %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_KeyUsageCheck(t *testing.T) {
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	tests := []struct {
+		name          string
+		checkUsage    bool
+		usage         []string
+		keyMissing    bool
+		listFirst     bool
+		expectedPaths []string
+		errIs         error
+	}{
+		{
+			name:          "check disabled",
+			usage:         []string{KeyUsageVerify},
+			expectedPaths: []string{"POST /api/v2/sbom/sign", "POST /api/v2/sbom/sign"},
+		},
+		{
+			name:          "fetched once then remembered",
+			checkUsage:    true,
+			usage:         []string{KeyUsageSign, KeyUsageVerify},
+			expectedPaths: []string{"GET /api/v1/keys/key-123", "POST /api/v2/sbom/sign", "POST /api/v2/sbom/sign"},
+		},
+		{
+			name:          "no usage reported",
+			checkUsage:    true,
+			expectedPaths: []string{"GET /api/v1/keys/key-123", "POST /api/v2/sbom/sign", "POST /api/v2/sbom/sign"},
+		},
+		{
+			name:          "verify-only key",
+			checkUsage:    true,
+			usage:         []string{KeyUsageVerify},
+			expectedPaths: []string{"GET /api/v1/keys/key-123"},
+			errIs:         ErrKeyNotUsableForSigning,
+		},
+		{
+			name:          "verify-only key already listed",
+			checkUsage:    true,
+			usage:         []string{KeyUsageVerify},
+			listFirst:     true,
+			expectedPaths: []string{"GET /api/v1/keys"},
+			errIs:         ErrKeyNotUsableForSigning,
+		},
+		{
+			name:          "missing key",
+			checkUsage:    true,
+			keyMissing:    true,
+			expectedPaths: []string{"GET /api/v1/keys/key-123", "GET /api/v1/keys/key-123"},
+			errIs:         ErrKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := map[string]interface{}{"id": "key-123", "algorithm": AlgorithmECDSAP256, "usage": tt.usage}
+			var paths []string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				paths = append(paths, req.Method+" "+req.URL.Path)
+				switch {
+				case strings.HasSuffix(req.URL.Path, "/sign"):
+					return createMockResponse(http.StatusOK, map[string]interface{}{"signed_sbom": sbom}), nil
+				case tt.keyMissing:
+					return createMockResponse(http.StatusNotFound, map[string]string{"error": "key not found"}), nil
+				case strings.HasSuffix(req.URL.Path, "/keys"):
+					return createMockResponse(http.StatusOK, map[string]interface{}{"keys": []interface{}{key}}), nil
+				default:
+					return createMockResponse(http.StatusOK, key), nil
+				}
+			}, func(config *Config) { config.CheckKeyUsage = tt.checkUsage })
+
+			if tt.listFirst {
+				if _, err := client.ListKeys(context.Background()); err != nil {
+					t.Fatalf("unexpected error listing keys: %v", err)
+				}
+			}
+			for range 2 {
+				_, err := client.SignSBOM(context.Background(), "key-123", sbom)
+				if tt.errIs != nil {
+					if !errors.Is(err, tt.errIs) {
+						t.Fatalf("expected error wrapping %v, got %v", tt.errIs, err)
+					}
+					if IsTemporary(err) {
+						t.Error("expected a permanent error")
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if strings.Join(paths, ", ") != strings.Join(tt.expectedPaths, ", ") {
+				t.Errorf("requests = %v, want %v", paths, tt.expectedPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	kv    []any
+}
+
+// recordingLogger captures log entries for assertions
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, kv []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, kv: kv})
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...any) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...any)  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...any)  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...any) { l.record("error", msg, kv) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var b strings.Builder
+	for _, e := range l.entries {
+		fmt.Fprintf(&b, "%s %s %v\n", e.level, e.msg, e.kv)
+	}
+	return b.String()
+}
+
+func TestClient_Logging(t *testing.T) {
+	logger := &recordingLogger{}
+	callCount := 0
+
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			if callCount == 1 {
+				return createMockResponse(503, map[string]string{"error": "unavailable"}), nil
+			}
+			return createMockResponse(200, map[string]string{"signature": "c2lnbmF0dXJl"}), nil
+		},
+	}
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("super-secret-api-key").
+		WithBaseURL("https://api.example.com").
+		WithHTTPClient(mockClient).
+		WithLogger(logger).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	retrying := WithRetryingClient(client, RetryConfig{
+		MaxAttempts: 2,
+		InitialWait: time.Millisecond,
+		MaxWait:     time.Millisecond,
+		Multiplier:  1,
+	})
+
+	_, err = retrying.SignSBOM(context.Background(), "key-123", map[string]string{"name": "test-sbom"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := logger.String()
+	for _, expected := range []string{"request completed", "POST", "/api/v2/sbom/sign", "503", "200", "retrying after error"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected log output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	for _, secret := range []string{"super-secret-api-key", "c2lnbmF0dXJl", "test-sbom"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("log output leaked %q:\n%s", secret, output)
+		}
+	}
+}
+
+func TestNewClient_InsecureSkipVerifyWarns(t *testing.T) {
+	logger := &recordingLogger{}
+
+	_, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithInsecureSkipVerify(true).
+		WithLogger(logger).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(logger.entries) != 1 || logger.entries[0].level != "warn" {
+		t.Errorf("expected a single warning, got:\n%s", logger.String())
+	}
+}
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	logger.Debug("request completed", "status", 200)
+
+	if !strings.Contains(buf.String(), "status=200") {
+		t.Errorf("expected slog output to contain key-value pairs, got %q", buf.String())
+	}
+
+	if NewSlogLogger(nil) == nil {
+		t.Error("expected default slog logger for nil input")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"crypto"
+	"time"
+)
+
+// SBOMMetadata identifies the document a verify result is for. It is read
+// from the SBOM locally, not reported by the server.
+type SBOMMetadata struct {
+	// Format is "cyclonedx" or "spdx"
+	Format string `json:"format"`
+	// SerialNumber is the CycloneDX serialNumber or the SPDX documentNamespace
+	SerialNumber string `json:"serial_number,omitempty"`
+	// SpecVersion is the CycloneDX specVersion, e.g. "1.5", or the SPDX
+	// spdxVersion, e.g. "SPDX-2.3"
+	SpecVersion string `json:"spec_version,omitempty"`
+	// Name and Version describe the top-level component: the CycloneDX
+	// metadata.component, or the package the SPDX document describes. For an
+	// SPDX document that describes no package, Name is the document name.
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Created is the CycloneDX metadata.timestamp or the SPDX
+	// creationInfo.created; zero if missing or not RFC 3339
+	Created time.Time `json:"created,omitempty"`
+	// ComponentCount is the number of top-level CycloneDX components, not
+	// counting nested ones, or the number of SPDX packages
+	ComponentCount int `json:"component_count"`
+}
+
+// Equal reports whether m and other hold the same metadata; two nil values are
+// equal
+func (m *SBOMMetadata) Equal(other *SBOMMetadata) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	return m.Format == other.Format &&
+		m.SerialNumber == other.SerialNumber &&
+		m.SpecVersion == other.SpecVersion &&
+		m.Name == other.Name &&
+		m.Version == other.Version &&
+		m.Created.Equal(other.Created) &&
+		m.ComponentCount == other.ComponentCount
+}
+
+// verifiedDocument parses sbom once for the SBOMDigest and SBOMMetadata of a
+// verify result
+func verifiedDocument(sbom interface{}) (string, *SBOMMetadata, error) {
+	if payload, ok := tagValuePayload(sbom); ok {
+		digest, err := sbomDigest(payload, crypto.SHA256)
+		if err != nil {
+			return "", nil, err
+		}
+		return digest, parseTagValueMetadata(payload), nil
+	}
+	_, doc, err := marshalSBOM(sbom)
+	if err != nil {
+		return "", nil, err
+	}
+	digest, err := documentDigest(doc, crypto.SHA256)
+	if err != nil {
+		return "", nil, err
+	}
+	return digest, parseSBOMMetadata(doc), nil
+}
+
+// parseSBOMMetadata reads the metadata of a parsed JSON SBOM, or returns nil if
+// the document is neither CycloneDX nor SPDX
+func parseSBOMMetadata(doc interface{}) *SBOMMetadata {
+	m, _ := doc.(map[string]interface{})
+	switch detectSBOMFormat(m) {
+	case "cyclonedx":
+		metadata := &SBOMMetadata{Format: "cyclonedx"}
+		metadata.SerialNumber, _ = m["serialNumber"].(string)
+		metadata.SpecVersion, _ = m["specVersion"].(string)
+		components, _ := m["components"].([]interface{})
+		metadata.ComponentCount = len(components)
+		if meta, ok := m["metadata"].(map[string]interface{}); ok {
+			metadata.Created = parseMetadataTime(meta["timestamp"])
+			if component, ok := meta["component"].(map[string]interface{}); ok {
+				metadata.Name, _ = component["name"].(string)
+				metadata.Version, _ = component["version"].(string)
+			}
+		}
+		return metadata
+	case "spdx":
+		metadata := &SBOMMetadata{Format: "spdx"}
+		metadata.SerialNumber, _ = m["documentNamespace"].(string)
+		metadata.SpecVersion, _ = m["spdxVersion"].(string)
+		metadata.Name, _ = m["name"].(string)
+		if info, ok := m["creationInfo"].(map[string]interface{}); ok {
+			metadata.Created = parseMetadataTime(info["created"])
+		}
+		packages, _ := m["packages"].([]interface{})
+		metadata.ComponentCount = len(packages)
+		if pkg := spdxDescribedPackage(m, packages); pkg != nil {
+			metadata.Name, _ = pkg["name"].(string)
+			metadata.Version, _ = pkg["versionInfo"].(string)
+		}
+		return metadata
+	}
+	return nil
+}
+
+// spdxDescribedPackage returns the first package the SPDX document describes,
+// named by documentDescribes or a DESCRIBES relationship of the document
+func spdxDescribedPackage(doc map[string]interface{}, packages []interface{}) map[string]interface{} {
+	var described string
+	if ids, ok := doc["documentDescribes"].([]interface{}); ok && len(ids) > 0 {
+		described, _ = ids[0].(string)
+	}
+	if described == "" {
+		documentID, _ := doc["SPDXID"].(string)
+		relationships, _ := doc["relationships"].([]interface{})
+		for _, r := range relationships {
+			rel, _ := r.(map[string]interface{})
+			if rel["relationshipType"] == "DESCRIBES" && documentID != "" && rel["spdxElementId"] == documentID {
+				described, _ = rel["relatedSpdxElement"].(string)
+				break
+			}
+		}
+	}
+	if described == "" {
+		return nil
+	}
+
+	for _, p := range packages {
+		if pkg, ok := p.(map[string]interface{}); ok && pkg["SPDXID"] == described {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// parseMetadataTime parses an RFC 3339 timestamp, returning the zero time for
+// anything else
+func parseMetadataTime(v interface{}) time.Time {
+	s, _ := v.(string)
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
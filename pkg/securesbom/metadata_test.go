@@ -0,0 +1,134 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseSBOMMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected *SBOMMetadata
+	}{
+		{
+			name: "CycloneDX",
+			doc: `{"bomFormat":"CycloneDX","specVersion":"1.5","serialNumber":"urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+				"metadata":{"timestamp":"2026-01-02T03:04:05Z","component":{"name":"app","version":"1.2.3"}},
+				"components":[{"name":"lib-a","components":[{"name":"nested"}]},{"name":"lib-b"}]}`,
+			expected: &SBOMMetadata{
+				Format:         "cyclonedx",
+				SerialNumber:   "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+				SpecVersion:    "1.5",
+				Name:           "app",
+				Version:        "1.2.3",
+				Created:        time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				ComponentCount: 2,
+			},
+		},
+		{
+			name:     "CycloneDX without metadata",
+			doc:      `{"bomFormat":"CycloneDX","specVersion":"1.4","metadata":{"timestamp":"yesterday"}}`,
+			expected: &SBOMMetadata{Format: "cyclonedx", SpecVersion: "1.4"},
+		},
+		{
+			name: "SPDX with documentDescribes",
+			doc: `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","name":"app-sbom","documentNamespace":"https://example.com/app-1.2.3",
+				"creationInfo":{"created":"2026-01-02T03:04:05Z"},"documentDescribes":["SPDXRef-app"],
+				"packages":[{"SPDXID":"SPDXRef-lib","name":"lib","versionInfo":"0.1"},{"SPDXID":"SPDXRef-app","name":"app","versionInfo":"1.2.3"}]}`,
+			expected: &SBOMMetadata{
+				Format:         "spdx",
+				SerialNumber:   "https://example.com/app-1.2.3",
+				SpecVersion:    "SPDX-2.3",
+				Name:           "app",
+				Version:        "1.2.3",
+				Created:        time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				ComponentCount: 2,
+			},
+		},
+		{
+			name: "SPDX with a DESCRIBES relationship",
+			doc: `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","name":"app-sbom",
+				"packages":[{"SPDXID":"SPDXRef-app","name":"app","versionInfo":"2.0"}],
+				"relationships":[{"spdxElementId":"SPDXRef-DOCUMENT","relationshipType":"DESCRIBES","relatedSpdxElement":"SPDXRef-app"}]}`,
+			expected: &SBOMMetadata{Format: "spdx", SpecVersion: "SPDX-2.3", Name: "app", Version: "2.0", ComponentCount: 1},
+		},
+		{
+			name:     "SPDX describing no package",
+			doc:      `{"spdxVersion":"SPDX-2.2","name":"app-sbom"}`,
+			expected: &SBOMMetadata{Format: "spdx", SpecVersion: "SPDX-2.2", Name: "app-sbom"},
+		},
+		{name: "unsupported format", doc: `{"name":"not an sbom"}`},
+		{name: "not an object", doc: `["CycloneDX"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc interface{}
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if got := parseSBOMMetadata(doc); !got.Equal(tt.expected) {
+				t.Errorf("parseSBOMMetadata() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_VerifySBOMMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		sbom     interface{}
+		expected *SBOMMetadata
+	}{
+		{
+			name:     "CycloneDX",
+			sbom:     json.RawMessage(`{"bomFormat":"CycloneDX","specVersion":"1.5","metadata":{"component":{"name":"app","version":"1.0"}}}`),
+			expected: &SBOMMetadata{Format: "cyclonedx", SpecVersion: "1.5", Name: "app", Version: "1.0"},
+		},
+		{
+			name:     "SPDX",
+			sbom:     map[string]interface{}{"spdxVersion": "SPDX-2.3", "name": "app-sbom"},
+			expected: &SBOMMetadata{Format: "spdx", SpecVersion: "SPDX-2.3", Name: "app-sbom"},
+		},
+		{
+			name: "unsupported format",
+			sbom: map[string]interface{}{"signed": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(http.StatusOK, map[string]string{"code": "VERIFY", "message": "done"}), nil
+			}, nil)
+
+			result, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-123", SBOM: tt.sbom, SignatureB64: "c2ln"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.SBOMMetadata.Equal(tt.expected) {
+				t.Errorf("SBOMMetadata = %+v, want %+v", result.SBOMMetadata, tt.expected)
+			}
+		})
+	}
+}
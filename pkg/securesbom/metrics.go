@@ -0,0 +1,65 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"time"
+)
+
+// Collector receives metrics about API usage so they can be exported to a
+// monitoring system such as Prometheus without the SDK depending on it. method
+// is the SDK operation, e.g. "SignSBOM". Implementations must be safe for
+// concurrent use.
+type Collector interface {
+	// ObserveRequest is called once per HTTP request, including each retry
+	// attempt. statusCode is 0 when no response was received.
+	ObserveRequest(method string, statusCode int, duration time.Duration)
+	// IncRetry is called each time RetryingClient retries an operation
+	IncRetry(method string)
+}
+
+// CircuitBreakerCollector is optionally implemented by a Collector to count
+// circuit breaker trips
+type CircuitBreakerCollector interface {
+	IncCircuitBreakerTrip()
+}
+
+// metricsProvider is implemented by clients that carry a configured Collector,
+// so wrappers can default to it
+type metricsProvider interface {
+	metrics() Collector
+}
+
+// nopCollector discards all metrics and is the default
+type nopCollector struct{}
+
+func (nopCollector) ObserveRequest(string, int, time.Duration) {}
+func (nopCollector) IncRetry(string)                           {}
+
+type operationKey struct{}
+
+// withOperation records the SDK operation name on ctx for metrics
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// operationFromContext returns the SDK operation recorded by withOperation
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observedRequest struct {
+	method     string
+	statusCode int
+}
+
+type recordingCollector struct {
+	mu       sync.Mutex
+	requests []observedRequest
+	retries  map[string]int
+	trips    int
+}
+
+func (c *recordingCollector) ObserveRequest(method string, statusCode int, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, observedRequest{method: method, statusCode: statusCode})
+}
+
+func (c *recordingCollector) IncRetry(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retries == nil {
+		c.retries = make(map[string]int)
+	}
+	c.retries[method]++
+}
+
+func (c *recordingCollector) IncCircuitBreakerTrip() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.trips++
+}
+
+func TestMetrics_Requests(t *testing.T) {
+	tests := []struct {
+		name         string
+		doFunc       func(req *http.Request) (*http.Response, error)
+		expectStatus int
+	}{
+		{
+			name: "response",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(200, map[string]string{"public_key": "pem"}), nil
+			},
+			expectStatus: 200,
+		},
+		{
+			name: "error status",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(404, map[string]string{"error": "not found"}), nil
+			},
+			expectStatus: 404,
+		},
+		{
+			name: "transport error",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectStatus: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &recordingCollector{}
+			client := newTestClient(t, tt.doFunc, func(config *Config) { config.Metrics = collector })
+
+			_, _ = client.GetPublicKey(context.Background(), "key-123")
+
+			want := []observedRequest{{method: "GetPublicKey", statusCode: tt.expectStatus}}
+			if len(collector.requests) != 1 || collector.requests[0] != want[0] {
+				t.Errorf("expected requests %+v, got %+v", want, collector.requests)
+			}
+		})
+	}
+}
+
+func TestMetrics_RetriesAndCircuitBreaker(t *testing.T) {
+	collector := &recordingCollector{}
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(503, map[string]string{"error": "unavailable"}), nil
+	}, func(config *Config) {
+		config.Metrics = collector
+		config.clock = newFakeClock()
+	})
+
+	breaker := WithCircuitBreakerClient(client, CircuitBreakerConfig{FailureThreshold: 2})
+	retrying := WithRetryingClient(breaker, RetryConfig{
+		MaxAttempts: 5,
+		InitialWait: time.Millisecond,
+		MaxWait:     time.Millisecond,
+		Multiplier:  2.0,
+	})
+
+	if err := retrying.HealthCheck(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	if len(collector.requests) != 2 {
+		t.Fatalf("expected 2 observed requests, got %+v", collector.requests)
+	}
+	for _, req := range collector.requests {
+		if req.method != "HealthCheck" || req.statusCode != 503 {
+			t.Errorf("unexpected observed request %+v", req)
+		}
+	}
+	if collector.retries["HealthCheck"] != 2 {
+		t.Errorf("expected 2 retries of HealthCheck, got %v", collector.retries)
+	}
+	if collector.trips != 1 {
+		t.Errorf("expected 1 circuit breaker trip, got %d", collector.trips)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// spkiPinPrefix is accepted before a pin, as in the "sha256/..." form used by
+// HPKP and OkHttp
+const spkiPinPrefix = "sha256/"
+
+// SPKIPin returns the pin for cert accepted by WithTLSPinnedSPKI: the
+// base64-encoded SHA-256 digest of its DER SubjectPublicKeyInfo. The same
+// value is printed by
+//
+//	openssl x509 -in cert.pem -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// parseSPKIPins decodes base64 SHA-256 SPKI digests, with or without the
+// sha256/ prefix
+func parseSPKIPins(pins []string) ([][]byte, error) {
+	decoded := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(pin), spkiPinPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPKI pin %q: %w", pin, err)
+		}
+		if len(digest) != sha256.Size {
+			return nil, fmt.Errorf("invalid SPKI pin %q: want a base64 SHA-256 digest of %d bytes, got %d", pin, sha256.Size, len(digest))
+		}
+		decoded = append(decoded, digest)
+	}
+	return decoded, nil
+}
+
+// verifyPinnedSPKI returns a tls.Config.VerifyPeerCertificate callback that
+// rejects the connection unless the leaf certificate's public key matches one
+// of pins. It runs after the usual chain verification, so pinning narrows
+// what RootCAs or the system roots accept rather than replacing them.
+func verifyPinnedSPKI(pins [][]byte, next func([][]byte, [][]*x509.Certificate) error) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if next != nil {
+			if err := next(rawCerts, verifiedChains); err != nil {
+				return err
+			}
+		}
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("%w: server presented no certificate", ErrCertificatePinMismatch)
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse server certificate: %w", err)
+		}
+
+		sum := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		for _, pin := range pins {
+			if bytes.Equal(sum[:], pin) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: public key of %q has pin %s, which is not one of the %d configured",
+			ErrCertificatePinMismatch, leaf.Subject.CommonName, SPKIPin(leaf), len(pins))
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_WithTLSPinnedSPKI(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	serverPin := SPKIPin(server.Certificate())
+
+	otherCertPEM, _ := generateTestCertificate(t)
+	block, _ := pem.Decode(otherCertPEM)
+	otherCert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPin := SPKIPin(otherCert)
+
+	tests := []struct {
+		name             string
+		configure        func(b *ConfigBuilder) *ConfigBuilder
+		expectBuildError string
+		expectMismatch   bool
+	}{
+		{
+			name: "matching pin with custom CA",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs(serverCAPEM).WithTLSPinnedSPKI(serverPin)
+			},
+		},
+		{
+			name: "next key pinned alongside the current one",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs(serverCAPEM).WithTLSPinnedSPKI(otherPin).WithTLSPinnedSPKI(serverPin)
+			},
+		},
+		{
+			name: "pin with sha256 prefix",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs(serverCAPEM).WithTLSPinnedSPKI("sha256/" + serverPin)
+			},
+		},
+		{
+			name: "mismatched pin with trusted CA",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs(serverCAPEM).WithTLSPinnedSPKI(otherPin)
+			},
+			expectMismatch: true,
+		},
+		{
+			name: "pin still enforced when verification is skipped",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithInsecureSkipVerify(true).WithTLSPinnedSPKI(otherPin)
+			},
+			expectMismatch: true,
+		},
+		{
+			name: "invalid base64",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithTLSPinnedSPKI("not base64!")
+			},
+			expectBuildError: "invalid SPKI pin",
+		},
+		{
+			name: "wrong digest length",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithTLSPinnedSPKI("c2hvcnQ=")
+			},
+			expectBuildError: "want a base64 SHA-256 digest",
+		},
+		{
+			name: "custom HTTP client",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithHTTPClient(server.Client()).WithTLSPinnedSPKI(serverPin)
+			},
+			expectBuildError: "cannot be applied to a custom HTTPClient",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL(server.URL)
+
+			client, err := tt.configure(builder).BuildClient()
+			if tt.expectBuildError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectBuildError) {
+					t.Errorf("expected build error containing %q, got %v", tt.expectBuildError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected build error: %v", err)
+			}
+
+			err = client.HealthCheck(context.Background())
+			if !tt.expectMismatch {
+				if err != nil {
+					t.Errorf("unexpected call error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrCertificatePinMismatch) {
+				t.Fatalf("expected ErrCertificatePinMismatch, got %v", err)
+			}
+			if !strings.Contains(err.Error(), serverPin) {
+				t.Errorf("expected error to name the server's pin %s, got %v", serverPin, err)
+			}
+			if IsTemporary(err) {
+				t.Error("expected a pin mismatch not to be temporary")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// VerifyCodePolicyViolation is the result Code set when a signature is valid but
+// the signing key or algorithm is not allowed by a TrustPolicy
+const VerifyCodePolicyViolation = "POLICY_VIOLATION"
+
+// TrustPolicy restricts which signatures are accepted beyond cryptographic
+// validity. Each non-empty list must contain the signature's value; at least
+// one list must be set.
+type TrustPolicy struct {
+	// AllowedKeyIDs lists the signing keys that are trusted
+	AllowedKeyIDs []string
+	// AllowedAlgorithms lists the signature algorithms that are trusted
+	AllowedAlgorithms []string
+}
+
+// check returns a description of how keyID and algorithm violate the policy,
+// or an empty string if they satisfy it
+func (p TrustPolicy) check(keyID, algorithm string) string {
+	if len(p.AllowedKeyIDs) > 0 && !slices.Contains(p.AllowedKeyIDs, keyID) {
+		return fmt.Sprintf("signing key %q is not in the trust policy", keyID)
+	}
+	if len(p.AllowedAlgorithms) > 0 {
+		if algorithm == "" {
+			return "signature algorithm was not reported and the trust policy restricts algorithms"
+		}
+		if !slices.Contains(p.AllowedAlgorithms, algorithm) {
+			return fmt.Sprintf("signature algorithm %q of key %q is not in the trust policy", algorithm, keyID)
+		}
+	}
+	return ""
+}
+
+// VerifySBOMWithPolicy verifies the SBOM and then applies policy. A signature
+// that is valid but made by a key or algorithm outside the policy yields
+// Valid=false with Code VerifyCodePolicyViolation, a Message explaining why, and
+// KeyID set to the rejected key. Keys outside the allowlist are rejected without
+// calling the API.
+func VerifySBOMWithPolicy(ctx context.Context, client ClientInterface, policy TrustPolicy, req VerifyCMDRequest) (*VerifyResultCMDResponse, error) {
+	if len(policy.AllowedKeyIDs) == 0 && len(policy.AllowedAlgorithms) == 0 {
+		return nil, fmt.Errorf("trust policy must allow at least one key ID or algorithm")
+	}
+
+	if len(policy.AllowedKeyIDs) > 0 && !slices.Contains(policy.AllowedKeyIDs, req.KeyID) {
+		return &VerifyResultCMDResponse{
+			Valid:   false,
+			Code:    VerifyCodePolicyViolation,
+			Message: policy.check(req.KeyID, ""),
+			KeyID:   req.KeyID,
+		}, nil
+	}
+
+	result, err := client.VerifySBOM(ctx, req)
+	if err != nil || !result.Valid {
+		return result, err
+	}
+
+	if violation := policy.check(result.KeyID, result.Algorithm); violation != "" {
+		result.Valid = false
+		result.Code = VerifyCodePolicyViolation
+		result.Message = violation
+	}
+	return result, nil
+}
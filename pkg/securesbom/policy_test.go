@@ -0,0 +1,129 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVerifySBOMWithPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        TrustPolicy
+		keyID         string
+		mockResponse  *http.Response
+		expectError   bool
+		expectCall    bool
+		expectValid   bool
+		expectCode    string
+		expectMessage string
+	}{
+		{
+			name:         "allowed key and algorithm",
+			policy:       TrustPolicy{AllowedKeyIDs: []string{"key-123"}, AllowedAlgorithms: []string{AlgorithmEd25519}},
+			keyID:        "key-123",
+			mockResponse: createMockResponse(200, VerifyResultAPIResponseV2{Message: "ok", Algorithm: AlgorithmEd25519}),
+			expectCall:   true,
+			expectValid:  true,
+		},
+		{
+			name:          "key outside policy is rejected without calling the API",
+			policy:        TrustPolicy{AllowedKeyIDs: []string{"key-123"}},
+			keyID:         "key-999",
+			expectValid:   false,
+			expectCode:    VerifyCodePolicyViolation,
+			expectMessage: `"key-999"`,
+		},
+		{
+			name:          "algorithm outside policy",
+			policy:        TrustPolicy{AllowedAlgorithms: []string{AlgorithmECDSAP256}},
+			keyID:         "key-123",
+			mockResponse:  createMockResponse(200, VerifyResultAPIResponseV2{Algorithm: AlgorithmRSA2048}),
+			expectCall:    true,
+			expectValid:   false,
+			expectCode:    VerifyCodePolicyViolation,
+			expectMessage: AlgorithmRSA2048,
+		},
+		{
+			name:          "unreported algorithm fails closed",
+			policy:        TrustPolicy{AllowedAlgorithms: []string{AlgorithmECDSAP256}},
+			keyID:         "key-123",
+			mockResponse:  createMockResponse(200, VerifyResultAPIResponseV2{}),
+			expectCall:    true,
+			expectValid:   false,
+			expectCode:    VerifyCodePolicyViolation,
+			expectMessage: "not reported",
+		},
+		{
+			name:         "API error",
+			policy:       TrustPolicy{AllowedKeyIDs: []string{"key-123"}},
+			keyID:        "key-123",
+			mockResponse: createMockResponse(400, map[string]string{"error": "bad signature"}),
+			expectError:  true,
+		},
+		{
+			name:        "empty policy",
+			policy:      TrustPolicy{},
+			keyID:       "key-123",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				called = true
+				return tt.mockResponse, nil
+			}, nil)
+
+			result, err := VerifySBOMWithPolicy(context.Background(), client, tt.policy, VerifyCMDRequest{
+				KeyID:        tt.keyID,
+				SBOM:         map[string]string{"bomFormat": "CycloneDX"},
+				SignatureB64: "c2lnbmF0dXJl",
+			})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if called != tt.expectCall {
+				t.Errorf("expected API call %v, got %v", tt.expectCall, called)
+			}
+			if result.Valid != tt.expectValid {
+				t.Errorf("expected valid %v, got %v", tt.expectValid, result.Valid)
+			}
+			if result.Code != tt.expectCode {
+				t.Errorf("expected code %q, got %q", tt.expectCode, result.Code)
+			}
+			if !strings.Contains(result.Message, tt.expectMessage) {
+				t.Errorf("expected message containing %q, got %q", tt.expectMessage, result.Message)
+			}
+			if result.KeyID != tt.keyID {
+				t.Errorf("expected key ID %q, got %q", tt.keyID, result.KeyID)
+			}
+		})
+	}
+}
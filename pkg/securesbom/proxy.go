@@ -0,0 +1,157 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// parseProxyURL parses a proxy URL for WithProxy. A URL without a scheme is
+// taken to be an HTTP proxy, as with HTTP_PROXY.
+func parseProxyURL(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		// url.Error repeats the input, which may contain a password
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (supported: http, https, socks5)", proxyURL.Scheme)
+	}
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("proxy URL %s has no host", proxyURL.Redacted())
+	}
+	return proxyURL, nil
+}
+
+// noProxyRule is one NO_PROXY pattern: every host, an IP address, a CIDR
+// range, or a domain, optionally limited to a port
+type noProxyRule struct {
+	all            bool
+	ip             net.IP
+	network        *net.IPNet
+	domain         string
+	subdomainsOnly bool
+	port           string
+}
+
+// parseNoProxy parses NO_PROXY-style patterns. Like NO_PROXY, "example.com"
+// matches the domain and its subdomains, ".example.com" and "*.example.com"
+// only its subdomains.
+func parseNoProxy(patterns []string) ([]noProxyRule, error) {
+	var rules []noProxyRule
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if pattern == "*" {
+			rules = append(rules, noProxyRule{all: true})
+			continue
+		}
+		if _, network, err := net.ParseCIDR(pattern); err == nil {
+			rules = append(rules, noProxyRule{network: network})
+			continue
+		}
+
+		var rule noProxyRule
+		host := pattern
+		if ip := net.ParseIP(pattern); ip == nil {
+			if h, port, err := net.SplitHostPort(pattern); err == nil {
+				host, rule.port = h, port
+			}
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			rule.ip = ip
+			rules = append(rules, rule)
+			continue
+		}
+
+		if strings.HasPrefix(host, "*.") {
+			host = host[1:]
+		}
+		if strings.HasPrefix(host, ".") {
+			host = host[1:]
+			rule.subdomainsOnly = true
+		}
+		if host == "" || strings.ContainsAny(host, "*/:") {
+			return nil, fmt.Errorf("invalid no-proxy pattern %q", pattern)
+		}
+		rule.domain = host
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r noProxyRule) matches(host, port string) bool {
+	if r.all {
+		return true
+	}
+	if r.port != "" && r.port != port {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	switch {
+	case r.network != nil:
+		return ip != nil && r.network.Contains(ip)
+	case r.ip != nil:
+		return ip != nil && r.ip.Equal(ip)
+	case ip != nil:
+		return false
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if strings.HasSuffix(host, "."+r.domain) {
+		return true
+	}
+	return !r.subdomainsOnly && host == r.domain
+}
+
+// proxyFunc returns an http.Transport Proxy function that sends every request
+// through proxyURL unless its host matches one of rules
+func proxyFunc(proxyURL *url.URL, rules []noProxyRule) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		host, port := req.URL.Hostname(), req.URL.Port()
+		if port == "" {
+			port = "80"
+			if req.URL.Scheme == "https" {
+				port = "443"
+			}
+		}
+		for _, rule := range rules {
+			if rule.matches(host, port) {
+				return nil, nil
+			}
+		}
+		return proxyURL, nil
+	}
+}
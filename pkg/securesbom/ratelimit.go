@@ -0,0 +1,98 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitStats summarizes time spent waiting on the client-side rate limiter
+type RateLimitStats struct {
+	// Requests is the number of requests that passed through the limiter
+	Requests int64
+	// Delayed is the number of requests that had to wait for a token
+	Delayed int64
+	// TotalWait is the cumulative time requests spent waiting
+	TotalWait time.Duration
+	// MaxWait is the longest single wait
+	MaxWait time.Duration
+}
+
+// rateLimiter wraps a token bucket and records how long callers waited. A nil
+// *rateLimiter is valid and never blocks.
+type rateLimiter struct {
+	limiter *rate.Limiter
+	clock   clock
+
+	mu    sync.Mutex
+	stats RateLimitStats
+}
+
+func newRateLimiter(rps float64, burst int, clk clock) *rateLimiter {
+	return &rateLimiter{limiter: rate.NewLimiter(rate.Limit(rps), burst), clock: clk}
+}
+
+// wait blocks until a token is available or ctx is done, returning how long
+// it waited
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	now := l.clock.Now()
+	reservation := l.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+
+	if delay > 0 {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			reservation.CancelAt(now)
+			return 0, fmt.Errorf("rate limit wait of %s would exceed context deadline: %w", delay, context.DeadlineExceeded)
+		}
+		if err := sleepContext(ctx, l.clock, delay); err != nil {
+			reservation.CancelAt(l.clock.Now())
+			return 0, fmt.Errorf("rate limit wait cancelled: %w", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Requests++
+	if delay > 0 {
+		l.stats.Delayed++
+		l.stats.TotalWait += delay
+		l.stats.MaxWait = max(l.stats.MaxWait, delay)
+	}
+	return delay, nil
+}
+
+// RateLimitStats reports how much the client-side rate limiter has delayed
+// requests, to help tune WithRateLimit. It returns zero stats when rate
+// limiting is disabled.
+func (c *Client) RateLimitStats() RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	return c.limiter.stats
+}
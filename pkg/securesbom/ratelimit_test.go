@@ -0,0 +1,126 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newRateLimitedTestClient(t *testing.T, rps float64, burst int, requests *int) *Client {
+	t.Helper()
+
+	return newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		*requests++
+		return createMockResponse(200, ""), nil
+	}, func(config *Config) {
+		config.RateLimit = rps
+		config.RateLimitBurst = burst
+	})
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	var requests int
+	client := newRateLimitedTestClient(t, 20, 1, &requests)
+	clk := newFakeClock()
+	client.limiter.clock = clk
+
+	for range 3 {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The first request uses the burst token; the next two wait 50ms each
+	if got, want := clk.Sleeps(), []time.Duration{50 * time.Millisecond, 50 * time.Millisecond}; !slices.Equal(got, want) {
+		t.Errorf("waits = %v, want %v", got, want)
+	}
+
+	stats := client.RateLimitStats()
+	want := RateLimitStats{Requests: 3, Delayed: 2, TotalWait: 100 * time.Millisecond, MaxWait: 50 * time.Millisecond}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestClient_RateLimit_ContextDeadline(t *testing.T) {
+	var requests int
+	client := newRateLimitedTestClient(t, 0.1, 1, &requests)
+
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.HealthCheck(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if IsTemporary(err) {
+		t.Error("expected rate limit deadline errors not to be retried")
+	}
+	if time.Since(start) > 40*time.Millisecond {
+		t.Error("expected the call to fail without waiting for the deadline")
+	}
+	if requests != 1 {
+		t.Errorf("expected only the first request to be sent, got %d", requests)
+	}
+}
+
+func TestConfigBuilder_WithRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		rps         float64
+		burst       int
+		expectError bool
+	}{
+		{name: "valid", rps: 10, burst: 5},
+		{name: "zero rate", rps: 0, burst: 1, expectError: true},
+		{name: "negative rate", rps: -1, burst: 1, expectError: true},
+		{name: "zero burst", rps: 10, burst: 0, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithRateLimit(tt.rps, tt.burst).
+				BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.limiter == nil {
+				t.Error("expected rate limiter to be configured")
+			}
+		})
+	}
+}
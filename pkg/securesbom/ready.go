@@ -0,0 +1,76 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxReadyInterval caps the backoff between WaitForReady polls, unless the
+// requested interval is already longer
+const maxReadyInterval = 30 * time.Second
+
+// WaitForReady polls HealthCheck until the API responds healthy or ctx is
+// done, for use during startup when the API may not be reachable yet. The
+// first retry waits interval, doubling after each failure up to 30 seconds.
+// When ctx expires first, the last health check error is returned.
+func (c *Client) WaitForReady(ctx context.Context, interval time.Duration) error {
+	return waitForReady(ctx, c.HealthCheck, interval, c.logger(), c.clock())
+}
+
+// WaitForReady is Client.WaitForReady on the wrapped client. It does its own
+// polling, so each health check is attempted once rather than retried.
+func (r *RetryingClient) WaitForReady(ctx context.Context, interval time.Duration) error {
+	return waitForReady(ctx, r.client.HealthCheck, interval, r.logger(), r.clock())
+}
+
+// WaitForReady is Client.WaitForReady on the wrapped client. The health checks
+// bypass the breaker so an API that is still starting up doesn't trip it.
+func (c *CircuitBreakerClient) WaitForReady(ctx context.Context, interval time.Duration) error {
+	return waitForReady(ctx, c.client.HealthCheck, interval, c.logger(), c.clock())
+}
+
+func waitForReady(ctx context.Context, healthCheck func(context.Context) error, interval time.Duration, logger Logger, clk clock) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	maxWait := max(interval, maxReadyInterval)
+	wait := interval
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		err := healthCheck(ctx)
+		if err == nil {
+			return nil
+		}
+		// A check cut short by ctx says nothing about the API
+		if ctx.Err() == nil {
+			lastErr = err
+		}
+		if lastErr == nil {
+			return ctx.Err()
+		}
+
+		logger.Debug("API not ready", "attempt", attempt, "wait", wait, "error", err)
+		if err := sleepContext(ctx, clk, wait); err != nil {
+			return fmt.Errorf("API not ready after %d attempts: %w", attempt, lastErr)
+		}
+		wait = min(wait*2, maxWait)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_WaitForReady(t *testing.T) {
+	tests := []struct {
+		name        string
+		interval    time.Duration
+		failures    int
+		expectWaits []time.Duration
+	}{
+		{name: "ready immediately", interval: time.Second},
+		{
+			name:        "backs off until ready",
+			interval:    time.Second,
+			failures:    3,
+			expectWaits: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:        "backoff is capped",
+			interval:    20 * time.Second,
+			failures:    3,
+			expectWaits: []time.Duration{20 * time.Second, 30 * time.Second, 30 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := newFakeClock()
+			calls := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				calls++
+				if calls <= tt.failures {
+					return createMockResponse(http.StatusServiceUnavailable, "starting"), nil
+				}
+				return createMockResponse(http.StatusOK, "OK"), nil
+			}, func(config *Config) { config.clock = clk })
+
+			if err := client.WaitForReady(context.Background(), tt.interval); err != nil {
+				t.Fatalf("WaitForReady() error = %v", err)
+			}
+			if calls != tt.failures+1 {
+				t.Errorf("expected %d health checks, got %d", tt.failures+1, calls)
+			}
+			if waits := clk.Sleeps(); !slices.Equal(waits, tt.expectWaits) {
+				t.Errorf("waits = %v, want %v", waits, tt.expectWaits)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForReady_Timeout(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return createMockResponse(http.StatusServiceUnavailable, "starting"), nil
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.WaitForReady(ctx, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected WaitForReady to time out")
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the last health check error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "API not ready after") {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestRetryingClient_WaitForReady(t *testing.T) {
+	calls := 0
+	client := WithRetryingClient(newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls <= 2 {
+			return createMockResponse(http.StatusServiceUnavailable, "starting"), nil
+		}
+		return createMockResponse(http.StatusOK, "OK"), nil
+	}, func(config *Config) { config.clock = newFakeClock() }), DefaultRetryConfig())
+
+	if err := client.WaitForReady(context.Background(), time.Second); err != nil {
+		t.Fatalf("WaitForReady() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 health checks, got %d", calls)
+	}
+}
+
+func TestWaitForReady_InvalidInterval(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	if err := client.WaitForReady(context.Background(), 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
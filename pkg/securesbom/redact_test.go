@@ -0,0 +1,150 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		secrets []string
+		want    string
+	}{
+		{
+			name:    "configured key",
+			input:   "invalid key sk-live-12345",
+			secrets: []string{"sk-live-12345"},
+			want:    "invalid key [REDACTED]",
+		},
+		{
+			name:  "query parameter",
+			input: "bad request: api_key=abc123&page=2",
+			want:  "bad request: api_key=[REDACTED]&page=2",
+		},
+		{
+			name:  "json field",
+			input: `{"token": "abc123", "code": "BAD"}`,
+			want:  `{"token": "[REDACTED]", "code": "BAD"}`,
+		},
+		{
+			name:  "authorization header",
+			input: "rejected Authorization: Bearer abc.def.ghi",
+			want:  "rejected Authorization: [REDACTED]",
+		},
+		{
+			name:  "bearer token",
+			input: "token bearer abc123 expired",
+			want:  "token bearer [REDACTED] expired",
+		},
+		{
+			name:  "jwt",
+			input: "expired eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.c2ln",
+			want:  "expired [REDACTED]",
+		},
+		{
+			name:  "nothing to redact",
+			input: "key not found",
+			want:  "key not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactSecrets(tt.input, tt.secrets...); got != tt.want {
+				t.Errorf("redactSecrets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ErrorRedaction(t *testing.T) {
+	const apiKey = "sk-live-0123456789abcdef"
+	body := map[string]string{
+		"code":    "INVALID_REQUEST",
+		"message": "bad request from key " + apiKey,
+		"details": "x-api-key: " + apiKey,
+	}
+
+	tests := []struct {
+		name       string
+		redact     bool
+		wantLeaked bool
+	}{
+		{name: "redaction enabled", redact: true},
+		{name: "redaction disabled", redact: false, wantLeaked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(http.StatusBadRequest, body), nil
+			}, func(config *Config) {
+				config.APIKey = apiKey
+				config.RedactErrors = tt.redact
+			})
+
+			_, err := client.SignSBOM(context.Background(), "key-1", map[string]interface{}{"bomFormat": "CycloneDX"})
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+				t.Fatalf("SignSBOM() error = %v, want a 400 APIError", err)
+			}
+			if leaked := strings.Contains(err.Error(), apiKey); leaked != tt.wantLeaked {
+				t.Errorf("error %q leaks API key = %v, want %v", err, leaked, tt.wantLeaked)
+			}
+			if apiErr.Code != "INVALID_REQUEST" {
+				t.Errorf("Code = %q, want INVALID_REQUEST", apiErr.Code)
+			}
+		})
+	}
+}
+
+func TestClient_TransportErrorOmitsQuery(t *testing.T) {
+	const apiKey = "sk-live-0123456789abcdef"
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: errors.New("connection refused using " + apiKey)}
+	}, func(config *Config) {
+		config.APIKey = apiKey
+		config.RedactErrors = true
+	})
+
+	_, err := client.SignBytes(context.Background(), "secret-key-id", []byte("payload"), "text/plain")
+	if err == nil {
+		t.Fatal("SignBytes() expected an error")
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "secret-key-id") || strings.Contains(msg, "?") {
+		t.Errorf("error %q includes the query string", msg)
+	}
+	if strings.Contains(msg, apiKey) {
+		t.Errorf("error %q leaks the API key", msg)
+	}
+	if !strings.Contains(msg, "/api/v2/blob/sign") {
+		t.Errorf("error %q should still name the request path", msg)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("redacted error should still unwrap to *url.Error")
+	}
+}
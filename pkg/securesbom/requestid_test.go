@@ -0,0 +1,164 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestClient_RequestIDHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "caller supplied", ctx: WithRequestID(context.Background(), "req-123"), want: "req-123"},
+		{name: "generated", ctx: context.Background()},
+		{name: "empty id is generated", ctx: WithRequestID(context.Background(), "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				sent = append(sent, req.Header.Get(RequestIDHeader))
+				return createMockResponse(http.StatusOK, `{"keys":[]}`), nil
+			}, nil)
+
+			for i := 0; i < 2; i++ {
+				if _, err := client.ListKeys(tt.ctx); err != nil {
+					t.Fatalf("ListKeys() error = %v", err)
+				}
+			}
+
+			for _, id := range sent {
+				if tt.want != "" {
+					if id != tt.want {
+						t.Errorf("%s = %q, want %q", RequestIDHeader, id, tt.want)
+					}
+					continue
+				}
+				if _, err := uuid.Parse(id); err != nil {
+					t.Errorf("%s = %q is not a UUID: %v", RequestIDHeader, id, err)
+				}
+			}
+			if tt.want == "" && sent[0] == sent[1] {
+				t.Errorf("generated request IDs should differ per request, both were %q", sent[0])
+			}
+		})
+	}
+}
+
+func TestClient_RequestIDOnResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{name: "returned by server", response: "srv-456", want: "srv-456"},
+		{name: "falls back to sent id", want: "req-123"},
+	}
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				var resp *http.Response
+				switch {
+				case strings.HasSuffix(req.URL.Path, "/verify"):
+					resp = createMockResponse(http.StatusOK, `{"code":"VALID","message":"ok"}`)
+				case strings.HasSuffix(req.URL.Path, "/sign-digest"):
+					resp = createMockResponse(http.StatusOK, `{"signature":"c2ln"}`)
+				default:
+					resp = createMockResponse(http.StatusOK, `{"signed_sbom":{"bomFormat":"CycloneDX"}}`)
+				}
+				if tt.response != "" {
+					resp.Header.Set(RequestIDHeader, tt.response)
+				}
+				return resp, nil
+			}, nil)
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			signed, err := client.SignSBOM(ctx, "key-1", sbom)
+			if err != nil {
+				t.Fatalf("SignSBOM() error = %v", err)
+			}
+			if signed.RequestID != tt.want {
+				t.Errorf("SignSBOM() RequestID = %q, want %q", signed.RequestID, tt.want)
+			}
+
+			digest, err := client.SignDigest(ctx, SignDigestRequest{Digest: "ZGlnZXN0", HashAlgorithm: "sha256", KeyID: "key-1"})
+			if err != nil {
+				t.Fatalf("SignDigest() error = %v", err)
+			}
+			if digest.RequestID != tt.want {
+				t.Errorf("SignDigest() RequestID = %q, want %q", digest.RequestID, tt.want)
+			}
+
+			verified, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"})
+			if err != nil {
+				t.Fatalf("VerifySBOM() error = %v", err)
+			}
+			if verified.RequestID != tt.want {
+				t.Errorf("VerifySBOM() RequestID = %q, want %q", verified.RequestID, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RequestIDOnAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		header string
+		want   string
+	}{
+		{name: "from error body", body: `{"error":"boom","request_id":"body-1"}`, header: "srv-1", want: "body-1"},
+		{name: "from response header", body: `{"error":"boom"}`, header: "srv-1", want: "srv-1"},
+		{name: "from sent header", body: `{"error":"boom"}`, want: "req-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				resp := createMockResponse(http.StatusInternalServerError, tt.body)
+				if tt.header != "" {
+					resp.Header.Set(RequestIDHeader, tt.header)
+				}
+				return resp, nil
+			}, nil)
+
+			_, err := client.ListKeys(WithRequestID(context.Background(), "req-123"))
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("ListKeys() error = %v, want *APIError", err)
+			}
+			if apiErr.RequestID != tt.want {
+				t.Errorf("RequestID = %q, want %q", apiErr.RequestID, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Error() = %q, should mention request ID %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
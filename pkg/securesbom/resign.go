@@ -0,0 +1,132 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// cycloneDXSignatureField is the top-level member holding a CycloneDX JSF signature
+const cycloneDXSignatureField = "signature"
+
+// ResignResult is the outcome of ResignSBOM
+type ResignResult struct {
+	// Verification is the result of verifying the original signature
+	Verification *VerifyResultCMDResponse
+	// Signed is the SBOM signed with the new key. It is nil if the original
+	// signature was invalid or signing failed.
+	Signed *SignResultAPIResponseV2
+}
+
+// ResignSBOM migrates a signed CycloneDX SBOM to a new key: it verifies the
+// embedded signature against oldKeyID, removes it, and signs the document with
+// newKeyID. The document is signed as given, byte for byte apart from the
+// removed signature member, so key order and number formatting are kept.
+//
+// Nothing is signed unless the original signature is valid. When it does not
+// match or is missing, the error wraps ErrSignatureInvalid and the result
+// carries the invalid verification with its Reason; other verification errors
+// return a nil result.
+func ResignSBOM(ctx context.Context, client ClientInterface, oldKeyID, newKeyID string, signedSBOM []byte) (*ResignResult, error) {
+	if oldKeyID == "" || newKeyID == "" {
+		return nil, fmt.Errorf("oldKeyID and newKeyID are required")
+	}
+
+	raw, doc, err := marshalSBOM(signedSBOM)
+	if err != nil {
+		return nil, err
+	}
+	if detectSBOMFormat(doc) != "cyclonedx" {
+		return nil, fmt.Errorf("re-signing requires a CycloneDX SBOM with an embedded signature: %w", ErrUnsupportedFormat)
+	}
+
+	verification, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: oldKeyID, SBOM: json.RawMessage(raw)})
+	if err != nil {
+		switch reason := VerifyReasonFromError(err); reason {
+		case VerifyReasonSignatureMismatch, VerifyReasonSignatureMissing:
+			if verification == nil {
+				verification = &VerifyResultCMDResponse{Reason: reason, KeyID: oldKeyID, Message: err.Error()}
+			}
+			return &ResignResult{Verification: verification},
+				fmt.Errorf("original signature by key %s did not verify: %w: %w", oldKeyID, ErrSignatureInvalid, err)
+		}
+		return nil, fmt.Errorf("failed to verify original signature: %w", err)
+	}
+	result := &ResignResult{Verification: verification}
+	if !verification.Valid {
+		return result, fmt.Errorf("original signature by key %s did not verify (%s): %w",
+			oldKeyID, verification.Message, ErrSignatureInvalid)
+	}
+
+	unsigned, err := removeMember(raw, cycloneDXSignatureField)
+	if err != nil {
+		return result, err
+	}
+
+	signed, err := client.SignSBOM(ctx, newKeyID, json.RawMessage(unsigned))
+	if err != nil {
+		return result, fmt.Errorf("failed to sign with key %s: %w", newKeyID, err)
+	}
+	result.Signed = signed
+	return result, nil
+}
+
+// removeMember returns a copy of the JSON object raw without its top-level
+// member name. Every other byte is kept, so the rest of the document reads
+// exactly as it did.
+func removeMember(raw []byte, name string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("failed to remove %q: sbom is not a JSON object", name)
+	}
+
+	first := true
+	for dec.More() {
+		// start is just past '{' or the previous value, before any comma
+		start := dec.InputOffset()
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove %q: %w", name, err)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to remove %q: %w", name, err)
+		}
+		end := dec.InputOffset()
+
+		if key != name {
+			first = false
+			continue
+		}
+		if !first {
+			// Drop the preceding comma along with the member
+			return append(raw[:start:start], raw[end:]...), nil
+		}
+		// The first member has no preceding comma, so drop the following one
+		// and keep the whitespace after '{'
+		start += int64(len(raw[start:]) - len(bytes.TrimLeft(raw[start:], " \t\r\n")))
+		rest := bytes.TrimLeft(raw[end:], " \t\r\n")
+		if len(rest) > 0 && rest[0] == ',' {
+			end = int64(len(raw) - len(bytes.TrimLeft(rest[1:], " \t\r\n")))
+		}
+		return append(raw[:start:start], raw[end:]...), nil
+	}
+	return raw, nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResignSBOM(t *testing.T) {
+	signed := []byte(`{
+		"specVersion": "1.5",
+		"bomFormat": "CycloneDX",
+		"signature": {"algorithm": "ES256", "value": "b2xk"},
+		"version": 1.50
+	}`)
+	unsigned := `{
+		"specVersion": "1.5",
+		"bomFormat": "CycloneDX",
+		"version": 1.50
+	}`
+
+	tests := []struct {
+		name              string
+		oldKeyID          string
+		sbom              []byte
+		verifyResponse    *http.Response
+		expectError       bool
+		expectInvalid     bool
+		expectUnsupported bool
+		expectReason      VerifyReason
+		expectResult      bool
+	}{
+		{
+			name:           "valid signature is replaced",
+			oldKeyID:       "old-key",
+			sbom:           signed,
+			verifyResponse: createMockResponse(http.StatusOK, VerifyResultCMDResponse{Valid: true, KeyID: "old-key"}),
+			expectResult:   true,
+		},
+		{
+			name:     "mismatched signature is not re-signed",
+			oldKeyID: "old-key",
+			sbom:     signed,
+			verifyResponse: createMockResponse(http.StatusBadRequest,
+				map[string]string{"code": "INVALID_SIGNATURE", "message": "signature verification failed"}),
+			expectError:   true,
+			expectInvalid: true,
+			expectReason:  VerifyReasonSignatureMismatch,
+			expectResult:  true,
+		},
+		{
+			name:     "service failure returns no result",
+			oldKeyID: "old-key",
+			sbom:     signed,
+			verifyResponse: createMockResponse(http.StatusServiceUnavailable,
+				map[string]string{"code": "UNAVAILABLE", "message": "try again"}),
+			expectError: true,
+		},
+		{
+			name:              "SPDX is unsupported",
+			oldKeyID:          "old-key",
+			sbom:              []byte(`{"spdxVersion": "SPDX-2.3"}`),
+			expectError:       true,
+			expectUnsupported: true,
+		},
+		{
+			name:        "missing key ID",
+			sbom:        signed,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verifiedWith, signedWith string
+			var verifiedSBOM, signedSBOM string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				bodyBytes, _ := io.ReadAll(req.Body)
+				var body struct {
+					KeyID string          `json:"key_id"`
+					SBOM  json.RawMessage `json:"sbom"`
+				}
+				_ = json.Unmarshal(bodyBytes, &body)
+				if strings.HasSuffix(req.URL.Path, "/verify") {
+					verifiedWith, verifiedSBOM = body.KeyID, string(body.SBOM)
+					return tt.verifyResponse, nil
+				}
+				signedWith, signedSBOM = body.KeyID, string(body.SBOM)
+				return createMockResponse(http.StatusOK, SignResultAPIResponseV2{Algorithm: "ES256", Signature: "bmV3"}), nil
+			}, nil)
+
+			result, err := ResignSBOM(context.Background(), client, tt.oldKeyID, "new-key", tt.sbom)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if errors.Is(err, ErrSignatureInvalid) != tt.expectInvalid {
+					t.Errorf("expected errors.Is(err, ErrSignatureInvalid) to be %v, got %v", tt.expectInvalid, err)
+				}
+				if errors.Is(err, ErrUnsupportedFormat) != tt.expectUnsupported {
+					t.Errorf("expected errors.Is(err, ErrUnsupportedFormat) to be %v, got %v", tt.expectUnsupported, err)
+				}
+				if tt.expectResult {
+					if result == nil || result.Verification == nil || result.Verification.Valid {
+						t.Fatalf("expected an invalid verification alongside the error, got %+v", result)
+					}
+					if result.Verification.Reason != tt.expectReason {
+						t.Errorf("expected reason %q, got %q", tt.expectReason, result.Verification.Reason)
+					}
+				} else if result != nil {
+					t.Errorf("expected no result, got %+v", result)
+				}
+				if signedWith != "" {
+					t.Error("expected no sign request")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if verifiedWith != tt.oldKeyID {
+				t.Errorf("expected verification with %q, got %q", tt.oldKeyID, verifiedWith)
+			}
+			if verifiedSBOM != string(tt.sbom) {
+				t.Errorf("expected the SBOM to be verified as given, got %s", verifiedSBOM)
+			}
+			if result.Verification == nil || !result.Verification.Valid {
+				t.Errorf("expected the valid verification result, got %+v", result.Verification)
+			}
+			if result.Signed == nil || result.Signed.Signature != "bmV3" {
+				t.Errorf("expected the new signature, got %+v", result.Signed)
+			}
+			if signedWith != "new-key" {
+				t.Errorf("expected signing with %q, got %q", "new-key", signedWith)
+			}
+			if signedSBOM != unsigned {
+				t.Errorf("expected only the signature to be removed, got %s", signedSBOM)
+			}
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "first member",
+			input:    `{"signature": {"value": "x"}, "a": 1.0, "b": [1, 2]}`,
+			expected: `{"a": 1.0, "b": [1, 2]}`,
+		},
+		{
+			name:     "middle member",
+			input:    `{"a": 1.0, "signature": {"value": "x"}, "b": [1, 2]}`,
+			expected: `{"a": 1.0, "b": [1, 2]}`,
+		},
+		{
+			name:     "last member",
+			input:    "{\n  \"a\": 1.0,\n  \"signature\": {\"value\": \"x\"}\n}",
+			expected: "{\n  \"a\": 1.0\n}",
+		},
+		{
+			name:     "only member",
+			input:    `{"signature": "x"}`,
+			expected: `{}`,
+		},
+		{
+			name:     "nested member is kept",
+			input:    `{"a": {"signature": "x"}}`,
+			expected: `{"a": {"signature": "x"}}`,
+		},
+		{
+			name:     "escaped key",
+			input:    `{"a": 1, "sig\u006eature": "x"}`,
+			expected: `{"a": 1}`,
+		},
+		{
+			name:        "not an object",
+			input:       `["signature"]`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(tt.input)
+			got, err := removeMember(input, "signature")
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+			if !bytes.Equal(input, []byte(tt.input)) {
+				t.Error("expected the input to be left unchanged")
+			}
+		})
+	}
+}
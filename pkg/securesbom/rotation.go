@@ -0,0 +1,121 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// KeySignError reports a failure while signing with several keys
+type KeySignError struct {
+	// KeyID is the key whose signature failed
+	KeyID string
+	// Succeeded lists the keys that signed before the failure
+	Succeeded []string
+	Err       error
+}
+
+func (e *KeySignError) Error() string {
+	return fmt.Sprintf("signing with key %s failed (succeeded: %v): %v", e.KeyID, e.Succeeded, e.Err)
+}
+
+func (e *KeySignError) Unwrap() error {
+	return e.Err
+}
+
+// SignSBOMWithKeys signs the SBOM once per key, in order, so that artifacts carry
+// signatures from both the outgoing and incoming key during a rotation. It stops
+// at the first failure and returns the signatures produced so far together with a
+// *KeySignError naming the failed key.
+func SignSBOMWithKeys(ctx context.Context, client ClientInterface, keyIDs []string, sbom interface{}) ([]*SignResultAPIResponseV2, error) {
+	if len(keyIDs) == 0 {
+		return nil, fmt.Errorf("at least one keyID is required")
+	}
+
+	results := make([]*SignResultAPIResponseV2, 0, len(keyIDs))
+	succeeded := make([]string, 0, len(keyIDs))
+
+	for _, keyID := range keyIDs {
+		result, err := client.SignSBOM(ctx, keyID, sbom)
+		if err != nil {
+			return results, &KeySignError{KeyID: keyID, Succeeded: succeeded, Err: err}
+		}
+		results = append(results, result)
+		succeeded = append(succeeded, keyID)
+	}
+
+	return results, nil
+}
+
+// VerifySBOMAnyKey verifies a signed SBOM against each candidate key in order,
+// for verifiers that don't know which of several active keys signed it during
+// a rotation. It returns the result for the first key the signature is valid
+// for, whose KeyID names the matching key, without trying the rest. If no key
+// matches, the error joins the reason each key failed; it wraps
+// ErrSignatureInvalid for keys the signature didn't verify against, including
+// keys the service rejected with a signature mismatch, but not for unknown keys
+// or transport failures. A done context stops the search at once.
+func VerifySBOMAnyKey(ctx context.Context, client ClientInterface, keyIDs []string, sbom []byte) (*VerifyResultCMDResponse, error) {
+	if len(keyIDs) == 0 {
+		return nil, fmt.Errorf("at least one keyID is required")
+	}
+	for _, keyID := range keyIDs {
+		if keyID == "" {
+			return nil, fmt.Errorf("keyIDs cannot contain an empty key ID")
+		}
+	}
+
+	// Send the bytes as given so each key verifies the document that was signed
+	raw, _, err := marshalSBOM(sbom)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		result, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: keyID, SBOM: json.RawMessage(raw)})
+		switch {
+		case err == nil && result.Valid:
+			return result, nil
+		case ctx.Err() != nil:
+			return nil, fmt.Errorf("verification stopped at key %s: %w", keyID, ctx.Err())
+		case err != nil && isSignatureRejection(err):
+			errs = append(errs, fmt.Errorf("key %s: %w: %w", keyID, ErrSignatureInvalid, err))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("key %s: %w", keyID, err))
+		case result.Message == "":
+			errs = append(errs, fmt.Errorf("key %s: %w", keyID, ErrSignatureInvalid))
+		default:
+			errs = append(errs, fmt.Errorf("key %s: %s: %w", keyID, result.Message, ErrSignatureInvalid))
+		}
+	}
+
+	return nil, fmt.Errorf("signature did not verify against any of %d keys: %w", len(keyIDs), errors.Join(errs...))
+}
+
+// isSignatureRejection reports whether err is the service saying the signature
+// does not match or is missing, as opposed to failing to check it
+func isSignatureRejection(err error) bool {
+	switch VerifyReasonFromError(err) {
+	case VerifyReasonSignatureMismatch, VerifyReasonSignatureMissing:
+		return true
+	}
+	return false
+}
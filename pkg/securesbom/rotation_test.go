@@ -0,0 +1,276 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newKeyTestClient returns a client that signs with any key except those in
+// failingKeys, which are rejected with a 403
+func newKeyTestClient(t *testing.T, failingKeys ...string) *Client {
+	t.Helper()
+
+	return newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		bodyBytes, _ := io.ReadAll(req.Body)
+		var body struct {
+			KeyID string `json:"key_id"`
+		}
+		_ = json.Unmarshal(bodyBytes, &body)
+
+		for _, key := range failingKeys {
+			if body.KeyID == key {
+				return createMockResponse(403, map[string]string{"error": "key disabled"}), nil
+			}
+		}
+		return createMockResponse(200, SignResultAPIResponseV2{
+			Algorithm: "ES256",
+			Signature: "sig-" + body.KeyID,
+		}), nil
+	}, nil)
+}
+
+func TestSignSBOMWithKeys(t *testing.T) {
+	sbom := map[string]string{"name": "test-sbom"}
+
+	tests := []struct {
+		name             string
+		keyIDs           []string
+		failingKeys      []string
+		expectSignatures []string
+		expectFailedKey  string
+		expectSucceeded  []string
+		expectOtherError bool
+	}{
+		{
+			name:             "signs with every key",
+			keyIDs:           []string{"old-key", "new-key"},
+			expectSignatures: []string{"sig-old-key", "sig-new-key"},
+		},
+		{
+			name:             "fails fast and reports succeeded keys",
+			keyIDs:           []string{"old-key", "new-key", "third-key"},
+			failingKeys:      []string{"new-key"},
+			expectSignatures: []string{"sig-old-key"},
+			expectFailedKey:  "new-key",
+			expectSucceeded:  []string{"old-key"},
+		},
+		{
+			name:             "no keys",
+			expectOtherError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newKeyTestClient(t, tt.failingKeys...)
+			results, err := SignSBOMWithKeys(context.Background(), client, tt.keyIDs, sbom)
+
+			if tt.expectOtherError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+
+			var signatures []string
+			for _, result := range results {
+				signatures = append(signatures, result.GetSignatureValue())
+			}
+			if !reflect.DeepEqual(signatures, tt.expectSignatures) {
+				t.Errorf("expected signatures %v, got %v", tt.expectSignatures, signatures)
+			}
+
+			if tt.expectFailedKey == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var keyErr *KeySignError
+			if !errors.As(err, &keyErr) {
+				t.Fatalf("expected *KeySignError, got %v", err)
+			}
+			if keyErr.KeyID != tt.expectFailedKey {
+				t.Errorf("expected failed key %q, got %q", tt.expectFailedKey, keyErr.KeyID)
+			}
+			if !reflect.DeepEqual(keyErr.Succeeded, tt.expectSucceeded) {
+				t.Errorf("expected succeeded keys %v, got %v", tt.expectSucceeded, keyErr.Succeeded)
+			}
+			if !IsForbidden(err) {
+				t.Errorf("expected underlying 403 to be preserved, got %v", err)
+			}
+		})
+	}
+}
+
+// keyVerifyStubClient answers VerifySBOM per key ID and records the keys tried
+type keyVerifyStubClient struct {
+	ClientInterface
+	results map[string]*VerifyResultCMDResponse
+	errs    map[string]error
+	cancel  context.CancelFunc
+	tried   []string
+	sboms   []interface{}
+}
+
+func (c *keyVerifyStubClient) VerifySBOM(ctx context.Context, req VerifyCMDRequest, callOpts ...CallOption) (*VerifyResultCMDResponse, error) {
+	c.tried = append(c.tried, req.KeyID)
+	c.sboms = append(c.sboms, req.SBOM)
+	if c.cancel != nil {
+		c.cancel()
+		return nil, ctx.Err()
+	}
+	if err := c.errs[req.KeyID]; err != nil {
+		return nil, err
+	}
+	if result, ok := c.results[req.KeyID]; ok {
+		return result, nil
+	}
+	// The service rejects a signature made by another key
+	err := &APIError{StatusCode: http.StatusBadRequest, Code: "INVALID_SIGNATURE", Message: "signature mismatch"}
+	return &VerifyResultCMDResponse{Reason: VerifyReasonSignatureMismatch, KeyID: req.KeyID, Message: err.Error()},
+		fmt.Errorf("failed to verify SBOM: %w", err)
+}
+
+func TestVerifySBOMAnyKey(t *testing.T) {
+	signed := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "signature": {"algorithm": "ES256", "value": "c2ln"}}`)
+	valid := func(keyID string) *VerifyResultCMDResponse {
+		return &VerifyResultCMDResponse{Valid: true, KeyID: keyID}
+	}
+
+	tests := []struct {
+		name          string
+		keyIDs        []string
+		sbom          []byte
+		results       map[string]*VerifyResultCMDResponse
+		errs          map[string]error
+		cancel        bool
+		expectKey     string
+		expectTried   []string
+		expectInvalid bool
+		expectError   bool
+	}{
+		{
+			name:        "first key matches",
+			keyIDs:      []string{"new-key", "old-key"},
+			sbom:        signed,
+			results:     map[string]*VerifyResultCMDResponse{"new-key": valid("new-key")},
+			expectKey:   "new-key",
+			expectTried: []string{"new-key"},
+		},
+		{
+			name:        "later key matches after a mismatch and an error",
+			keyIDs:      []string{"gone-key", "new-key", "old-key", "spare-key"},
+			sbom:        signed,
+			results:     map[string]*VerifyResultCMDResponse{"old-key": valid("old-key")},
+			errs:        map[string]error{"gone-key": &APIError{StatusCode: http.StatusNotFound}},
+			expectKey:   "old-key",
+			expectTried: []string{"gone-key", "new-key", "old-key"},
+		},
+		{
+			name:          "no key matches",
+			keyIDs:        []string{"new-key", "old-key"},
+			sbom:          signed,
+			expectTried:   []string{"new-key", "old-key"},
+			expectInvalid: true,
+			expectError:   true,
+		},
+		{
+			name:        "unknown keys are not signature mismatches",
+			keyIDs:      []string{"gone-key", "old-key"},
+			sbom:        signed,
+			errs:        map[string]error{"gone-key": &APIError{StatusCode: http.StatusNotFound, Code: "KEY_NOT_FOUND"}, "old-key": &APIError{StatusCode: http.StatusServiceUnavailable}},
+			expectTried: []string{"gone-key", "old-key"},
+			expectError: true,
+		},
+		{
+			name:        "cancelled context stops the search",
+			keyIDs:      []string{"new-key", "old-key"},
+			sbom:        signed,
+			cancel:      true,
+			expectTried: []string{"new-key"},
+			expectError: true,
+		},
+		{
+			name:        "no keys",
+			sbom:        signed,
+			expectError: true,
+		},
+		{
+			name:        "empty key ID",
+			keyIDs:      []string{"new-key", ""},
+			sbom:        signed,
+			expectError: true,
+		},
+		{
+			name:        "malformed SBOM",
+			keyIDs:      []string{"new-key"},
+			sbom:        []byte("{not json"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client := &keyVerifyStubClient{results: tt.results, errs: tt.errs}
+			if tt.cancel {
+				client.cancel = cancel
+			}
+
+			result, err := VerifySBOMAnyKey(ctx, client, tt.keyIDs, tt.sbom)
+
+			if !reflect.DeepEqual(client.tried, tt.expectTried) {
+				t.Errorf("tried keys %v, want %v", client.tried, tt.expectTried)
+			}
+			for i, sbom := range client.sboms {
+				if raw, ok := sbom.(json.RawMessage); !ok || !bytes.Equal(raw, tt.sbom) {
+					t.Errorf("request %d sent SBOM %v, want the bytes as given", i, sbom)
+				}
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if errors.Is(err, ErrSignatureInvalid) != tt.expectInvalid {
+					t.Errorf("errors.Is(err, ErrSignatureInvalid) = %v, want %v: %v", !tt.expectInvalid, tt.expectInvalid, err)
+				}
+				if tt.cancel && !errors.Is(err, context.Canceled) {
+					t.Errorf("error = %v, want context.Canceled", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Valid || result.KeyID != tt.expectKey {
+				t.Errorf("result = %+v, want valid for %q", result, tt.expectKey)
+			}
+		})
+	}
+}
@@ -16,12 +16,29 @@
 
 package securesbom
 
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
 // GetSignatureValue returns the signature value as a string for convenience.
+// For a detached signature this is the Signature or SignatureB64 field; for an
+// embedded CycloneDX signature it is the value of the first signer in
+// SignedSBOM, so callers can store it in a detached file without parsing the
+// signed document themselves. It is empty if the result holds no signature.
 func (sr SignResultAPIResponseV2) GetSignatureValue() string {
 	if sr.Signature != "" {
 		return sr.Signature
 	}
-	return sr.SignatureB64
+	if sr.SignatureB64 != "" {
+		return sr.SignatureB64
+	}
+	value, _ := sr.embeddedSigner()["value"].(string)
+	return value
 }
 
 // GetSignatureAlgorithm returns the signature algorithm.
@@ -29,6 +46,34 @@ func (sr SignResultAPIResponseV2) GetSignatureAlgorithm() string {
 	return sr.Algorithm
 }
 
+// GetKeyID returns the ID of the key the content was signed with, falling back
+// to the keyId of an embedded CycloneDX signature.
+func (sr SignResultAPIResponseV2) GetKeyID() string {
+	if sr.KeyID != "" {
+		return sr.KeyID
+	}
+	keyID, _ := sr.embeddedSigner()["keyId"].(string)
+	return keyID
+}
+
+// embeddedSigner returns the first JSF signer embedded in SignedSBOM, or nil
+// if the signed SBOM is not a signed CycloneDX document
+func (sr SignResultAPIResponseV2) embeddedSigner() map[string]interface{} {
+	if len(sr.SignedSBOM) == 0 {
+		return nil
+	}
+	var doc struct {
+		Signature interface{} `json:"signature"`
+	}
+	if err := json.Unmarshal(sr.SignedSBOM, &doc); err != nil {
+		return nil
+	}
+	if signers := embeddedSignatures(doc.Signature); len(signers) > 0 {
+		return signers[0]
+	}
+	return nil
+}
+
 // GetSignedSBOMBytes returns the signed SBOM payload as JSON bytes.
 func (sr SignResultAPIResponseV2) GetSignedSBOMBytes() ([]byte, error) {
 	return sr.SignedSBOM, nil
@@ -38,3 +83,136 @@ func (sr SignResultAPIResponseV2) GetSignedSBOMBytes() ([]byte, error) {
 func (sr SignResultAPIResponseV2) HasSignature() bool {
 	return sr.Signature != "" || sr.SignatureB64 != ""
 }
+
+// EqualOption changes what VerifyResultCMDResponse.Equal compares
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	volatile bool
+}
+
+// IncludeVolatileFields makes Equal also compare Timestamp and RequestID, which
+// differ between otherwise identical verifications
+func IncludeVolatileFields() EqualOption {
+	return func(o *equalOptions) { o.volatile = true }
+}
+
+// Equal reports whether two verification results agree. It compares Valid,
+// Code, Message, Reason, KeyID, Algorithm, PublicKeyFingerprint, SBOMDigest,
+// SBOMMetadata, CertificateChain and Warnings, the last two in order. Timestamp and
+// RequestID are ignored unless IncludeVolatileFields is given. Two nil results
+// are equal; a nil and a non-nil result are not.
+func (r *VerifyResultCMDResponse) Equal(other *VerifyResultCMDResponse, opts ...EqualOption) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.volatile && (!r.Timestamp.Equal(other.Timestamp) || r.RequestID != other.RequestID) {
+		return false
+	}
+	return r.Valid == other.Valid &&
+		r.Code == other.Code &&
+		r.Message == other.Message &&
+		r.Reason == other.Reason &&
+		r.KeyID == other.KeyID &&
+		r.Algorithm == other.Algorithm &&
+		r.PublicKeyFingerprint == other.PublicKeyFingerprint &&
+		r.SBOMDigest == other.SBOMDigest &&
+		r.SBOMMetadata.Equal(other.SBOMMetadata) &&
+		slices.Equal(r.CertificateChain, other.CertificateChain) &&
+		slices.Equal(r.Warnings, other.Warnings)
+}
+
+// detectSBOMFormat returns "cyclonedx" or "spdx" for a parsed JSON SBOM, or an
+// empty string if the format can't be determined
+func detectSBOMFormat(sbom interface{}) string {
+	if s, ok := sbom.(*SBOM); ok {
+		if s != nil && s.format != "" {
+			return string(s.format)
+		}
+		sbom = s.Data()
+	}
+
+	doc, ok := sbom.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if format, ok := doc["bomFormat"].(string); ok && format == "CycloneDX" {
+		return "cyclonedx"
+	}
+	if _, ok := doc["spdxVersion"]; ok {
+		return "spdx"
+	}
+	return ""
+}
+
+// Format returns "cyclonedx" or "spdx", or an empty string if the document is
+// neither. For an SBOM loaded with LoadSBOMFromReaderRaw it returns the format
+// the caller asserted.
+func (s *SBOM) Format() string {
+	return detectSBOMFormat(s)
+}
+
+// checkNotXML returns an error wrapping ErrUnsupportedFormat if data looks like
+// an XML document, such as CycloneDX XML or SPDX RDF/XML. The API only accepts
+// JSON, and sending XML would otherwise fail with an opaque server error.
+func checkNotXML(data []byte) error {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '<' {
+		return nil
+	}
+
+	format := "XML"
+	head := trimmed[:min(len(trimmed), 1024)]
+	switch {
+	case bytes.Contains(head, []byte("cyclonedx.org/schema/bom")):
+		format = "CycloneDX XML"
+	case bytes.Contains(head, []byte("spdx.org/rdf")):
+		format = "SPDX RDF/XML"
+	}
+	return fmt.Errorf("%s SBOMs are not supported, convert the document to JSON first "+
+		"(e.g. cyclonedx convert --output-format json): %w", format, ErrUnsupportedFormat)
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// MaxDecompressedSBOMSize bounds a gzipped SBOM once decompressed, so a small
+// file that expands without limit can't exhaust memory. It matches
+// DefaultMaxResponseSize.
+const MaxDecompressedSBOMSize = DefaultMaxResponseSize
+
+// maxDecompressedSBOMSize is MaxDecompressedSBOMSize, lowered by tests
+var maxDecompressedSBOMSize int64 = MaxDecompressedSBOMSize
+
+// decompressSBOM returns data decompressed if it starts with the gzip magic
+// bytes, such as an sbom.json.gz file, and data unchanged otherwise. Zstandard
+// is recognized but not supported, so it fails with a clear error instead of
+// a JSON parse error.
+func decompressSBOM(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzipped SBOM: %w", err)
+		}
+		decompressed, err := io.ReadAll(io.LimitReader(zr, maxDecompressedSBOMSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzipped SBOM: %w", err)
+		}
+		if int64(len(decompressed)) > maxDecompressedSBOMSize {
+			return nil, fmt.Errorf("%w: gzipped SBOM decompresses to more than %d bytes", ErrSBOMTooLarge, maxDecompressedSBOMSize)
+		}
+		return decompressed, nil
+	case bytes.HasPrefix(data, zstdMagic):
+		return nil, fmt.Errorf("zstd-compressed SBOMs are not supported, decompress the document first "+
+			"(e.g. zstd -d): %w", ErrUnsupportedFormat)
+	}
+	return data, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSignResultAPIResponseV2_Accessors(t *testing.T) {
+	tests := []struct {
+		name            string
+		result          SignResultAPIResponseV2
+		expectSignature string
+		expectKeyID     string
+		expectHasSig    bool
+	}{
+		{
+			name:            "detached base64 signature",
+			result:          SignResultAPIResponseV2{Detached: true, SignatureB64: "c2ln", KeyID: "key-1"},
+			expectSignature: "c2ln",
+			expectKeyID:     "key-1",
+			expectHasSig:    true,
+		},
+		{
+			name:            "signature field preferred",
+			result:          SignResultAPIResponseV2{Signature: "raw", SignatureB64: "c2ln"},
+			expectSignature: "raw",
+			expectHasSig:    true,
+		},
+		{
+			name:            "embedded CycloneDX signature",
+			result:          SignResultAPIResponseV2{SignedSBOM: json.RawMessage(`{"bomFormat":"CycloneDX","signature":{"algorithm":"ES256","keyId":"key-2","value":"ZW1iZWRkZWQ="}}`)},
+			expectSignature: "ZW1iZWRkZWQ=",
+			expectKeyID:     "key-2",
+		},
+		{
+			name:            "first of several embedded signers",
+			result:          SignResultAPIResponseV2{KeyID: "key-1", SignedSBOM: json.RawMessage(`{"signature":{"signers":[{"keyId":"key-3","value":"Zmlyc3Q="},{"keyId":"key-4","value":"c2Vjb25k"}]}}`)},
+			expectSignature: "Zmlyc3Q=",
+			expectKeyID:     "key-1",
+		},
+		{
+			name:   "unsigned document",
+			result: SignResultAPIResponseV2{SignedSBOM: json.RawMessage(`{"bomFormat":"CycloneDX"}`)},
+		},
+		{
+			name:   "malformed signed SBOM",
+			result: SignResultAPIResponseV2{SignedSBOM: json.RawMessage(`not json`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.GetSignatureValue(); got != tt.expectSignature {
+				t.Errorf("GetSignatureValue() = %q, want %q", got, tt.expectSignature)
+			}
+			if got := tt.result.GetKeyID(); got != tt.expectKeyID {
+				t.Errorf("GetKeyID() = %q, want %q", got, tt.expectKeyID)
+			}
+			if got := tt.result.HasSignature(); got != tt.expectHasSig {
+				t.Errorf("HasSignature() = %v, want %v", got, tt.expectHasSig)
+			}
+		})
+	}
+}
+
+func TestSignResultAPIResponseV2_JSONCompatible(t *testing.T) {
+	// Responses from servers that don't report key_id still decode, and the
+	// field is omitted when empty so existing consumers see the same output
+	var result SignResultAPIResponseV2
+	if err := json.Unmarshal([]byte(`{"algorithm":"ed25519","detached":true,"signature_b64":"c2ln"}`), &result); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"algorithm":"ed25519","detached":true,"signature_b64":"c2ln"}`; string(encoded) != want {
+		t.Errorf("Marshal() = %s, want %s", encoded, want)
+	}
+}
+
+func TestClient_SignSBOM_KeyID(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    SignResultAPIResponseV2
+		expectKeyID string
+	}{
+		{name: "taken from the request", response: SignResultAPIResponseV2{Algorithm: AlgorithmEd25519, SignatureB64: "c2ln"}, expectKeyID: "key-1"},
+		{name: "reported by the server", response: SignResultAPIResponseV2{Algorithm: AlgorithmEd25519, SignatureB64: "c2ln", KeyID: "key-1-v2"}, expectKeyID: "key-1-v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(http.StatusOK, tt.response), nil
+			}, nil)
+
+			result, err := client.SignSBOM(context.Background(), "key-1", map[string]interface{}{"bomFormat": "CycloneDX"})
+			if err != nil {
+				t.Fatalf("SignSBOM() error = %v", err)
+			}
+			if result.KeyID != tt.expectKeyID || result.GetKeyID() != tt.expectKeyID {
+				t.Errorf("KeyID = %q, GetKeyID() = %q, want %q", result.KeyID, result.GetKeyID(), tt.expectKeyID)
+			}
+		})
+	}
+}
+
+func TestVerifyResultCMDResponse_Equal(t *testing.T) {
+	base := func() *VerifyResultCMDResponse {
+		return &VerifyResultCMDResponse{
+			Valid:                true,
+			Code:                 "VALID",
+			Message:              "signature is valid",
+			KeyID:                "key-123",
+			Algorithm:            AlgorithmECDSAP256,
+			Timestamp:            time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			PublicKeyFingerprint: "SHA256:abc",
+			CertificateChain:     []string{"leaf", "root"},
+			SBOMDigest:           "deadbeef",
+			SBOMMetadata:         &SBOMMetadata{Format: "cyclonedx", Name: "app", Version: "1.0.0"},
+			RequestID:            "req-1",
+			Warnings:             []string{"key expires soon"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(r *VerifyResultCMDResponse)
+		opts     []EqualOption
+		expected bool
+	}{
+		{name: "identical", mutate: func(r *VerifyResultCMDResponse) {}, expected: true},
+		{name: "different timestamp ignored", mutate: func(r *VerifyResultCMDResponse) { r.Timestamp = time.Now() }, expected: true},
+		{name: "different request ID ignored", mutate: func(r *VerifyResultCMDResponse) { r.RequestID = "req-2" }, expected: true},
+		{
+			name:     "different timestamp with volatile fields",
+			mutate:   func(r *VerifyResultCMDResponse) { r.Timestamp = time.Now() },
+			opts:     []EqualOption{IncludeVolatileFields()},
+			expected: false,
+		},
+		{
+			name:     "same instant in another zone with volatile fields",
+			mutate:   func(r *VerifyResultCMDResponse) { r.Timestamp = r.Timestamp.In(time.FixedZone("UTC+2", 2*60*60)) },
+			opts:     []EqualOption{IncludeVolatileFields()},
+			expected: true,
+		},
+		{
+			name:     "different request ID with volatile fields",
+			mutate:   func(r *VerifyResultCMDResponse) { r.RequestID = "req-2" },
+			opts:     []EqualOption{IncludeVolatileFields()},
+			expected: false,
+		},
+		{name: "different validity", mutate: func(r *VerifyResultCMDResponse) { r.Valid = false }, expected: false},
+		{name: "different code", mutate: func(r *VerifyResultCMDResponse) { r.Code = "INVALID" }, expected: false},
+		{name: "different reason", mutate: func(r *VerifyResultCMDResponse) { r.Reason = VerifyReasonKeyRevoked }, expected: false},
+		{name: "different key", mutate: func(r *VerifyResultCMDResponse) { r.KeyID = "key-456" }, expected: false},
+		{name: "different digest", mutate: func(r *VerifyResultCMDResponse) { r.SBOMDigest = "cafef00d" }, expected: false},
+		{name: "different metadata", mutate: func(r *VerifyResultCMDResponse) { r.SBOMMetadata.Version = "1.0.1" }, expected: false},
+		{name: "metadata missing", mutate: func(r *VerifyResultCMDResponse) { r.SBOMMetadata = nil }, expected: false},
+		{
+			name:     "reordered chain",
+			mutate:   func(r *VerifyResultCMDResponse) { r.CertificateChain = []string{"root", "leaf"} },
+			expected: false,
+		},
+		{name: "extra warning", mutate: func(r *VerifyResultCMDResponse) { r.Warnings = append(r.Warnings, "x") }, expected: false},
+		{name: "warnings dropped", mutate: func(r *VerifyResultCMDResponse) { r.Warnings = nil }, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base()
+			tt.mutate(other)
+			if got := base().Equal(other, tt.opts...); got != tt.expected {
+				t.Errorf("Equal() = %t, want %t", got, tt.expected)
+			}
+			if got := other.Equal(base(), tt.opts...); got != tt.expected {
+				t.Errorf("Equal() is not symmetric: got %t, want %t", got, tt.expected)
+			}
+		})
+	}
+
+	var nilResult *VerifyResultCMDResponse
+	if !nilResult.Equal(nil) {
+		t.Error("expected two nil results to be equal")
+	}
+	if nilResult.Equal(base()) || base().Equal(nil) {
+		t.Error("expected a nil and a non-nil result to differ")
+	}
+	empty := &VerifyResultCMDResponse{Warnings: []string{}}
+	if !empty.Equal(&VerifyResultCMDResponse{}) {
+		t.Error("expected empty and nil slices to be equal")
+	}
+
+	// Equal lists every field; a new field needs a decision there
+	if n := reflect.TypeOf(VerifyResultCMDResponse{}).NumField(); n != 13 {
+		t.Errorf("VerifyResultCMDResponse has %d fields; update Equal and this count", n)
+	}
+}
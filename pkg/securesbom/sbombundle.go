@@ -0,0 +1,74 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SplitSBOMBundle reads a file that may hold several JSON SBOMs in a
+// top-level array and returns each as its own SBOM, so they can be signed and
+// verified separately, e.g. with BatchSignSBOM. Each element must be a
+// CycloneDX or SPDX document, and its Bytes are the element exactly as it
+// appears in the bundle. Anything other than an array is loaded with
+// LoadSBOMFromReader and returned as a one-element slice.
+func SplitSBOMBundle(r io.Reader) ([]*SBOM, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data provided")
+	}
+	data, err = decompressSBOM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if !bytes.HasPrefix(trimmed, []byte("[")) {
+		sbom, err := LoadSBOMFromReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []*SBOM{sbom}, nil
+	}
+
+	var documents []json.RawMessage
+	if err := json.Unmarshal(trimmed, &documents); err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM bundle: %w", err)
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("SBOM bundle is empty")
+	}
+
+	sboms := make([]*SBOM, len(documents))
+	for i, document := range documents {
+		var sbomData interface{}
+		if err := json.Unmarshal(document, &sbomData); err != nil {
+			return nil, fmt.Errorf("failed to parse SBOM %d of the bundle: %w", i, err)
+		}
+		if detectSBOMFormat(sbomData) == "" {
+			return nil, fmt.Errorf("SBOM %d of the bundle is neither CycloneDX nor SPDX: %w", i, ErrUnsupportedFormat)
+		}
+		sboms[i] = &SBOM{data: sbomData, raw: document}
+	}
+	return sboms, nil
+}
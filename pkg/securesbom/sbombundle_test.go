@@ -0,0 +1,146 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSplitSBOMBundle(t *testing.T) {
+	cdxA := `{"bomFormat":"CycloneDX","specVersion":"1.5","serialNumber":"urn:uuid:a"}`
+	cdxB := `{"bomFormat": "CycloneDX", "specVersion": "1.6", "serialNumber": "urn:uuid:b"}`
+	spdx := `{"spdxVersion":"SPDX-2.3","name":"app"}`
+
+	tests := []struct {
+		name        string
+		input       string
+		wantRaw     []string
+		wantFormats []string
+		wantErr     error
+		expectError bool
+	}{
+		{
+			name:        "array of CycloneDX documents",
+			input:       "[\n  " + cdxA + ",\n  " + cdxB + "\n]\n",
+			wantRaw:     []string{cdxA, cdxB},
+			wantFormats: []string{"cyclonedx", "cyclonedx"},
+		},
+		{
+			name:        "mixed formats",
+			input:       "[" + spdx + "," + cdxA + "]",
+			wantRaw:     []string{spdx, cdxA},
+			wantFormats: []string{"spdx", "cyclonedx"},
+		},
+		{
+			name:        "single document",
+			input:       cdxB,
+			wantRaw:     []string{cdxB},
+			wantFormats: []string{"cyclonedx"},
+		},
+		{
+			name:        "gzipped bundle",
+			input:       gzipString("[" + cdxA + "]"),
+			wantRaw:     []string{cdxA},
+			wantFormats: []string{"cyclonedx"},
+		},
+		{
+			name:        "byte order mark",
+			input:       "\xef\xbb\xbf[" + cdxA + "]",
+			wantRaw:     []string{cdxA},
+			wantFormats: []string{"cyclonedx"},
+		},
+		{name: "empty input", input: "", expectError: true},
+		{name: "empty array", input: "[]", expectError: true},
+		{name: "malformed array", input: "[" + cdxA + ",", expectError: true},
+		{name: "element is not an SBOM", input: "[" + cdxA + `,{"name":"x"}]`, wantErr: ErrUnsupportedFormat, expectError: true},
+		{name: "element is not an object", input: "[" + cdxA + `,"x"]`, wantErr: ErrUnsupportedFormat, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sboms, err := SplitSBOMBundle(strings.NewReader(tt.input))
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("SplitSBOMBundle() = %d SBOMs, want an error", len(sboms))
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("SplitSBOMBundle() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitSBOMBundle() error = %v", err)
+			}
+			if len(sboms) != len(tt.wantRaw) {
+				t.Fatalf("SplitSBOMBundle() = %d SBOMs, want %d", len(sboms), len(tt.wantRaw))
+			}
+			for i, sbom := range sboms {
+				raw, err := sbom.Bytes()
+				if err != nil {
+					t.Fatalf("Bytes() error = %v", err)
+				}
+				if string(raw) != tt.wantRaw[i] {
+					t.Errorf("SBOM %d Bytes() = %s, want %s", i, raw, tt.wantRaw[i])
+				}
+				if got := sbom.Format(); got != tt.wantFormats[i] {
+					t.Errorf("SBOM %d Format() = %q, want %q", i, got, tt.wantFormats[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitSBOMBundle_SignEach(t *testing.T) {
+	sboms, err := SplitSBOMBundle(strings.NewReader(`[
+		{"bomFormat":"CycloneDX","specVersion":"1.5","serialNumber":"urn:uuid:a"},
+		{"bomFormat":"CycloneDX","specVersion":"1.5","serialNumber":"urn:uuid:b"}
+	]`))
+	if err != nil {
+		t.Fatalf("SplitSBOMBundle() error = %v", err)
+	}
+
+	var bodies []string
+	client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		return createMockResponse(http.StatusOK, map[string]interface{}{
+			"signed_sbom": map[string]interface{}{"bomFormat": "CycloneDX"},
+		}), nil
+	}, nil)
+
+	for i, sbom := range sboms {
+		if _, err := client.SignSBOM(context.Background(), "key-123", sbom); err != nil {
+			t.Fatalf("SignSBOM(%d) error = %v", i, err)
+		}
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("sent %d sign requests, want 2", len(bodies))
+	}
+	for i, serial := range []string{"urn:uuid:a", "urn:uuid:b"} {
+		if !strings.Contains(bodies[i], serial) {
+			t.Errorf("sign request %d = %s, want only the SBOM %s", i, bodies[i], serial)
+		}
+		if strings.Count(bodies[i], "serialNumber") != 1 {
+			t.Errorf("sign request %d = %s, want a single SBOM", i, bodies[i])
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// StdinPath is the path SignSBOMFromFile and VerifySBOMFromFile read as stdin
+const StdinPath = "-"
+
+// DetachedSignatureExt is appended to an SBOM's path to find its detached
+// signature, e.g. sbom.spdx.json.sig
+const DetachedSignatureExt = ".sig"
+
+// stdin is read for StdinPath; tests replace it
+var stdin io.Reader = os.Stdin
+
+// loadSBOMFromPath loads an SBOM from path, or from stdin for StdinPath
+func loadSBOMFromPath(path string) (*SBOM, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	if path == StdinPath {
+		sbom, err := LoadSBOMFromReader(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SBOM from stdin: %w", err)
+		}
+		return sbom, nil
+	}
+	return LoadSBOMFromFile(path)
+}
+
+// signSBOMFromFile loads the SBOM at path and signs it through client. SPDX
+// documents have nowhere to embed a signature, so they are signed detached.
+func signSBOMFromFile(ctx context.Context, client ClientInterface, keyID, path string) (*SignResultAPIResponseV2, error) {
+	sbom, err := loadSBOMFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sbom.Format() {
+	case "cyclonedx":
+		return client.SignSBOM(ctx, keyID, sbom)
+	case "spdx":
+		return client.SignSBOMWithOptions(ctx, keyID, sbom, SignOptions{Detached: true})
+	default:
+		return nil, fmt.Errorf("%s is neither CycloneDX nor SPDX: %w", path, ErrUnsupportedFormat)
+	}
+}
+
+// verifySBOMFromFile loads the SBOM at path and verifies it through client,
+// using the detached signature beside it when there is one
+func verifySBOMFromFile(ctx context.Context, client ClientInterface, keyID, path string) (*VerifyResultCMDResponse, error) {
+	sbom, err := loadSBOMFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := fileVerifyRequest(sbom, keyID, path)
+	if err != nil {
+		return nil, err
+	}
+	return client.VerifySBOM(ctx, req)
+}
+
+// fileVerifyRequest builds the request verifying sbom, loaded from path, with
+// keyID, using the detached signature beside it when there is one
+func fileVerifyRequest(sbom *SBOM, keyID, path string) (VerifyCMDRequest, error) {
+	format := sbom.Format()
+	if format == "" {
+		return VerifyCMDRequest{}, fmt.Errorf("%s is neither CycloneDX nor SPDX: %w", path, ErrUnsupportedFormat)
+	}
+
+	req := VerifyCMDRequest{KeyID: keyID, SBOM: sbom}
+	if path != StdinPath {
+		var err error
+		req.SignatureB64, err = readDetachedSignature(path + DetachedSignatureExt)
+		if err != nil {
+			return VerifyCMDRequest{}, err
+		}
+	}
+	if format == "spdx" && req.SignatureB64 == "" {
+		// Fall back to a signature embedded by EmbedSPDXSignature
+		var signature string
+		var found bool
+		if sbom.tagValue {
+			_, _, signature, found = findTagValueSignature(sbom.raw)
+			req.SBOM = &SBOM{raw: stripTagValueSignature(sbom.raw), format: SBOMFormatSPDX, tagValue: true}
+		} else {
+			doc := sbom.Data().(map[string]interface{})
+			signature, found = spdxSignature(doc)
+			req.SBOM = withoutSPDXSignature(doc)
+		}
+		if !found {
+			if path == StdinPath {
+				return VerifyCMDRequest{}, fmt.Errorf("SPDX SBOM read from stdin has no embedded signature; use VerifySBOM with SignatureB64: %w", ErrNoSignatures)
+			}
+			return VerifyCMDRequest{}, fmt.Errorf("SPDX SBOM has no embedded signature and no detached signature at %s: %w", path+DetachedSignatureExt, ErrNoSignatures)
+		}
+		req.SignatureB64 = signature
+	}
+	return req, nil
+}
+
+// readDetachedSignature returns the base64 signature stored at sigPath, or an
+// empty string if there is no such file
+func readDetachedSignature(sigPath string) (string, error) {
+	data, err := os.ReadFile(sigPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read detached signature %s: %w", sigPath, err)
+	}
+
+	signature := strings.TrimSpace(string(data))
+	if signature == "" {
+		return "", fmt.Errorf("detached signature %s is empty", sigPath)
+	}
+	return signature, nil
+}
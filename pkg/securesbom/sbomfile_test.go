@@ -0,0 +1,193 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testCycloneDX = `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[]}`
+	testSPDX      = `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","name":"app"}`
+)
+
+// writeTestFiles writes name -> content under a temp dir and returns the dir
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// recordingClient returns a client whose requests are decoded into *body
+func recordingClient(t *testing.T, body *map[string]interface{}, response *http.Response) *Client {
+	t.Helper()
+
+	return newTestClient(t, func(req *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(data, body)
+		return response, nil
+	}, nil)
+}
+
+func TestClient_SignSBOMFromFile(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"bom.cdx.json":  testCycloneDX,
+		"bom.spdx.json": testSPDX,
+		"other.json":    `{"name":"not an sbom"}`,
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		stdin          string
+		expectDetached bool
+		expectError    error
+		expectErrMsg   string
+	}{
+		{name: "CycloneDX embeds the signature", path: filepath.Join(dir, "bom.cdx.json")},
+		{name: "SPDX is signed detached", path: filepath.Join(dir, "bom.spdx.json"), expectDetached: true},
+		{name: "stdin", path: "-", stdin: testCycloneDX},
+		{name: "unknown format", path: filepath.Join(dir, "other.json"), expectError: ErrUnsupportedFormat},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), expectErrMsg: "failed to open file"},
+		{name: "empty stdin", path: "-", expectErrMsg: "failed to load SBOM from stdin"},
+		{name: "no path", expectErrMsg: "path is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.stdin)
+			defer func() { stdin = os.Stdin }()
+
+			var body map[string]interface{}
+			client := recordingClient(t, &body, createMockResponse(http.StatusOK, SignResultAPIResponseV2{Algorithm: "ES256"}))
+
+			result, err := client.SignSBOMFromFile(context.Background(), "key-1", tt.path)
+			if tt.expectError != nil || tt.expectErrMsg != "" {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if tt.expectError != nil && !errors.Is(err, tt.expectError) {
+					t.Errorf("expected %v, got %v", tt.expectError, err)
+				}
+				if !strings.Contains(err.Error(), tt.expectErrMsg) {
+					t.Errorf("expected error to contain %q, got %q", tt.expectErrMsg, err.Error())
+				}
+				if body != nil {
+					t.Error("expected no request to be made")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Algorithm != "ES256" {
+				t.Errorf("Algorithm = %q, want ES256", result.Algorithm)
+			}
+			if detached, _ := body["detached"].(bool); detached != tt.expectDetached {
+				t.Errorf("detached = %v, want %v", detached, tt.expectDetached)
+			}
+			if body["key_id"] != "key-1" || body["sbom"] == nil {
+				t.Errorf("unexpected request body %v", body)
+			}
+		})
+	}
+}
+
+func TestClient_VerifySBOMFromFile(t *testing.T) {
+	embedded, err := EmbedSPDXSignature([]byte(testSPDX), "ZW1iZWRkZWQ=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := writeTestFiles(t, map[string]string{
+		"signed.cdx.json":        `{"bomFormat":"CycloneDX","signature":{"algorithm":"ES256","value":"c2ln"}}`,
+		"bom.spdx.json":          testSPDX,
+		"bom.spdx.json.sig":      "ZGV0YWNoZWQ=\n",
+		"bom.cdx.json":           testCycloneDX,
+		"bom.cdx.json.sig":       "Y2R4LWRldGFjaGVk",
+		"embedded.spdx.json":     string(embedded),
+		"nosig.spdx.json":        testSPDX,
+		"emptysig.spdx.json":     testSPDX,
+		"emptysig.spdx.json.sig": "\n",
+	})
+
+	tests := []struct {
+		name            string
+		path            string
+		stdin           string
+		expectSignature string
+		expectErrMsg    string
+	}{
+		{name: "embedded CycloneDX signature", path: filepath.Join(dir, "signed.cdx.json")},
+		{name: "SPDX with detached signature", path: filepath.Join(dir, "bom.spdx.json"), expectSignature: "ZGV0YWNoZWQ="},
+		{name: "CycloneDX with detached signature", path: filepath.Join(dir, "bom.cdx.json"), expectSignature: "Y2R4LWRldGFjaGVk"},
+		{name: "stdin CycloneDX", path: "-", stdin: testCycloneDX},
+		{name: "SPDX with embedded signature", path: filepath.Join(dir, "embedded.spdx.json"), expectSignature: "ZW1iZWRkZWQ="},
+		{name: "stdin SPDX with embedded signature", path: "-", stdin: string(embedded), expectSignature: "ZW1iZWRkZWQ="},
+		{name: "SPDX without signature", path: filepath.Join(dir, "nosig.spdx.json"), expectErrMsg: "no detached signature at"},
+		{name: "SPDX with empty signature", path: filepath.Join(dir, "emptysig.spdx.json"), expectErrMsg: "is empty"},
+		{name: "stdin SPDX", path: "-", stdin: testSPDX, expectErrMsg: "read from stdin has no embedded signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.stdin)
+			defer func() { stdin = os.Stdin }()
+
+			var body map[string]interface{}
+			client := recordingClient(t, &body, createMockResponse(http.StatusOK, VerifyResultAPIResponseV2{Code: "VALID", Message: "signature is valid"}))
+
+			result, err := client.VerifySBOMFromFile(context.Background(), "key-1", tt.path)
+			if tt.expectErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErrMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectErrMsg, err)
+				}
+				if body != nil {
+					t.Error("expected no request to be made")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Valid {
+				t.Error("expected a valid result")
+			}
+			signature, _ := body["signature_b64"].(string)
+			if signature != tt.expectSignature {
+				t.Errorf("signature_b64 = %q, want %q", signature, tt.expectSignature)
+			}
+			// The signature covers the document without its annotation
+			if sbom, _ := body["sbom"].(map[string]interface{}); sbom["annotations"] != nil {
+				t.Errorf("expected the signature annotation to be removed, got %v", sbom["annotations"])
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package securesbomtest provides test doubles for code built on the
+// securesbom SDK: a FakeClient implementing securesbom.ClientInterface for unit
+// tests, and NewServer, an in-process fake of the HTTP API for integration
+// tests.
+package securesbomtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+)
+
+// FakeSignature is the signature returned by FakeClient's default responses
+var FakeSignature = base64.StdEncoding.EncodeToString([]byte("fake-signature"))
+
+// Call records a single FakeClient method call
+type Call struct {
+	// Method is the ClientInterface method name, e.g. "SignSBOM"
+	Method string
+	// KeyID is the key the call referred to, if any
+	KeyID string
+	// Args holds the remaining arguments after the context, in order
+	Args []interface{}
+}
+
+// FakeClient is an in-memory securesbom.ClientInterface that records every call.
+// Each method delegates to its Func field when set and otherwise returns a
+// canned success response, so tests only program the methods they care about:
+//
+//	fake := &securesbomtest.FakeClient{
+//		SignSBOMFunc: func(ctx context.Context, keyID string, sbom interface{}, opts ...securesbom.CallOption) (*securesbom.SignResultAPIResponseV2, error) {
+//			return nil, securesbom.ErrKeyNotFound
+//		},
+//	}
+//	runCodeUnderTest(fake)
+//	fake.AssertCalledWithKey(t, "SignSBOM", "key-123")
+//
+// A FakeClient is safe for concurrent use. Its zero value is ready to use.
+type FakeClient struct {
+	HealthCheckFunc            func(ctx context.Context) error
+	HealthCheckStatusFunc      func(ctx context.Context) (*securesbom.HealthStatus, error)
+	ServerInfoFunc             func(ctx context.Context) (*securesbom.ServerInfo, error)
+	CapabilitiesFunc           func(ctx context.Context) (*securesbom.Capabilities, error)
+	ListKeysFunc               func(ctx context.Context) (*securesbom.KeyListResponse, error)
+	ListKeysPagedFunc          func(ctx context.Context, opts securesbom.ListKeysOptions) (*securesbom.KeyListResponse, error)
+	GenerateKeyFunc            func(ctx context.Context) (*securesbom.GenerateKeyCMDResponse, error)
+	GenerateKeyWithBackendFunc func(ctx context.Context, backend string) (*securesbom.GenerateKeyCMDResponse, error)
+	GenerateKeyWithOptionsFunc func(ctx context.Context, opts securesbom.GenerateKeyOptions) (*securesbom.GenerateKeyCMDResponse, error)
+	GetKeyFunc                 func(ctx context.Context, keyID string) (*securesbom.GenerateKeyCMDResponse, error)
+	GetPublicKeyFunc           func(ctx context.Context, keyID string) (string, error)
+	DeleteKeyFunc              func(ctx context.Context, keyID string) error
+	ImportPublicKeyFunc        func(ctx context.Context, keyID, publicKeyPEM, algorithm string) (*securesbom.GenerateKeyCMDResponse, error)
+	SignSBOMFunc               func(ctx context.Context, keyID string, sbom interface{}, callOpts ...securesbom.CallOption) (*securesbom.SignResultAPIResponseV2, error)
+	SignSBOMWithOptionsFunc    func(ctx context.Context, keyID string, sbom interface{}, opts securesbom.SignOptions, callOpts ...securesbom.CallOption) (*securesbom.SignResultAPIResponseV2, error)
+	SignSBOMFromReaderFunc     func(ctx context.Context, keyID string, r io.Reader, size int64) (*securesbom.SignResultAPIResponseV2, error)
+	SignSBOMToWriterFunc       func(ctx context.Context, keyID string, sbom []byte, w io.Writer) (*securesbom.SignResultAPIResponseV2, error)
+	SignBytesFunc              func(ctx context.Context, keyID string, data []byte, contentType string) (*securesbom.SignResultAPIResponseV2, error)
+	SignDigestFunc             func(ctx context.Context, req securesbom.SignDigestRequest, callOpts ...securesbom.CallOption) (*securesbom.SignDigestResponse, error)
+	VerifySBOMFunc             func(ctx context.Context, req securesbom.VerifyCMDRequest, callOpts ...securesbom.CallOption) (*securesbom.VerifyResultCMDResponse, error)
+	VerifyWithPublicKeyFunc    func(ctx context.Context, publicKeyPEM string, sbom []byte) (*securesbom.VerifyResultCMDResponse, error)
+	SignSBOMFromFileFunc       func(ctx context.Context, keyID, path string) (*securesbom.SignResultAPIResponseV2, error)
+	VerifySBOMFromFileFunc     func(ctx context.Context, keyID, path string) (*securesbom.VerifyResultCMDResponse, error)
+	VerifyKeylessFunc          func(ctx context.Context, sbom []byte, rekorBundle []byte, identity securesbom.KeylessIdentity) (*securesbom.VerifyResultCMDResponse, error)
+	IsSignedFunc               func(ctx context.Context, keyID string, sbom []byte) (bool, error)
+	TimeoutFunc                func() time.Duration
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ securesbom.ClientInterface = (*FakeClient)(nil)
+
+func (f *FakeClient) record(method, keyID string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, KeyID: keyID, Args: args})
+}
+
+func (f *FakeClient) HealthCheck(ctx context.Context) error {
+	f.record("HealthCheck", "")
+	if f.HealthCheckFunc != nil {
+		return f.HealthCheckFunc(ctx)
+	}
+	return nil
+}
+
+func (f *FakeClient) HealthCheckStatus(ctx context.Context) (*securesbom.HealthStatus, error) {
+	f.record("HealthCheckStatus", "")
+	if f.HealthCheckStatusFunc != nil {
+		return f.HealthCheckStatusFunc(ctx)
+	}
+	return &securesbom.HealthStatus{Status: "healthy"}, nil
+}
+
+func (f *FakeClient) ServerInfo(ctx context.Context) (*securesbom.ServerInfo, error) {
+	f.record("ServerInfo", "")
+	if f.ServerInfoFunc != nil {
+		return f.ServerInfoFunc(ctx)
+	}
+	return &securesbom.ServerInfo{Version: "fake"}, nil
+}
+
+func (f *FakeClient) Capabilities(ctx context.Context) (*securesbom.Capabilities, error) {
+	f.record("Capabilities", "")
+	if f.CapabilitiesFunc != nil {
+		return f.CapabilitiesFunc(ctx)
+	}
+	return &securesbom.Capabilities{
+		SBOMFormats: []string{"cyclonedx", "spdx"},
+		Algorithms: []string{
+			securesbom.AlgorithmEd25519,
+			securesbom.AlgorithmECDSAP256,
+			securesbom.AlgorithmECDSAP384,
+			securesbom.AlgorithmRSA2048,
+			securesbom.AlgorithmRSA4096,
+		},
+	}, nil
+}
+
+func (f *FakeClient) ListKeys(ctx context.Context) (*securesbom.KeyListResponse, error) {
+	f.record("ListKeys", "")
+	if f.ListKeysFunc != nil {
+		return f.ListKeysFunc(ctx)
+	}
+	return &securesbom.KeyListResponse{Keys: []securesbom.GenerateKeyCMDResponse{}}, nil
+}
+
+func (f *FakeClient) ListKeysPaged(ctx context.Context, opts securesbom.ListKeysOptions) (*securesbom.KeyListResponse, error) {
+	f.record("ListKeysPaged", "", opts)
+	if f.ListKeysPagedFunc != nil {
+		return f.ListKeysPagedFunc(ctx, opts)
+	}
+	return &securesbom.KeyListResponse{Keys: []securesbom.GenerateKeyCMDResponse{}}, nil
+}
+
+func (f *FakeClient) GenerateKey(ctx context.Context) (*securesbom.GenerateKeyCMDResponse, error) {
+	f.record("GenerateKey", "")
+	if f.GenerateKeyFunc != nil {
+		return f.GenerateKeyFunc(ctx)
+	}
+	return f.generatedKey(securesbom.GenerateKeyOptions{}), nil
+}
+
+func (f *FakeClient) GenerateKeyWithBackend(ctx context.Context, backend string) (*securesbom.GenerateKeyCMDResponse, error) {
+	f.record("GenerateKeyWithBackend", "", backend)
+	if f.GenerateKeyWithBackendFunc != nil {
+		return f.GenerateKeyWithBackendFunc(ctx, backend)
+	}
+	return f.generatedKey(securesbom.GenerateKeyOptions{Backend: backend}), nil
+}
+
+func (f *FakeClient) GenerateKeyWithOptions(ctx context.Context, opts securesbom.GenerateKeyOptions) (*securesbom.GenerateKeyCMDResponse, error) {
+	f.record("GenerateKeyWithOptions", "", opts)
+	if f.GenerateKeyWithOptionsFunc != nil {
+		return f.GenerateKeyWithOptionsFunc(ctx, opts)
+	}
+	return f.generatedKey(opts), nil
+}
+
+// generatedKey numbers keys by how many have been generated so far
+func (f *FakeClient) generatedKey(opts securesbom.GenerateKeyOptions) *securesbom.GenerateKeyCMDResponse {
+	n := len(f.CallsTo("GenerateKey")) + len(f.CallsTo("GenerateKeyWithBackend")) + len(f.CallsTo("GenerateKeyWithOptions"))
+	return &securesbom.GenerateKeyCMDResponse{
+		ID:        fmt.Sprintf("fake-key-%d", n),
+		Algorithm: opts.Algorithm,
+		Backend:   opts.Backend,
+		Labels:    opts.Labels,
+	}
+}
+
+func (f *FakeClient) GetKey(ctx context.Context, keyID string) (*securesbom.GenerateKeyCMDResponse, error) {
+	f.record("GetKey", keyID)
+	if f.GetKeyFunc != nil {
+		return f.GetKeyFunc(ctx, keyID)
+	}
+	return &securesbom.GenerateKeyCMDResponse{ID: keyID}, nil
+}
+
+func (f *FakeClient) GetPublicKey(ctx context.Context, keyID string) (string, error) {
+	f.record("GetPublicKey", keyID)
+	if f.GetPublicKeyFunc != nil {
+		return f.GetPublicKeyFunc(ctx, keyID)
+	}
+	return "", nil
+}
+
+func (f *FakeClient) DeleteKey(ctx context.Context, keyID string) error {
+	f.record("DeleteKey", keyID)
+	if f.DeleteKeyFunc != nil {
+		return f.DeleteKeyFunc(ctx, keyID)
+	}
+	return nil
+}
+
+func (f *FakeClient) ImportPublicKey(ctx context.Context, keyID, publicKeyPEM, algorithm string) (*securesbom.GenerateKeyCMDResponse, error) {
+	f.record("ImportPublicKey", keyID, publicKeyPEM, algorithm)
+	if f.ImportPublicKeyFunc != nil {
+		return f.ImportPublicKeyFunc(ctx, keyID, publicKeyPEM, algorithm)
+	}
+	return &securesbom.GenerateKeyCMDResponse{
+		ID:        keyID,
+		Algorithm: algorithm,
+		PublicKey: publicKeyPEM,
+		Status:    securesbom.KeyStatusActive,
+	}, nil
+}
+
+func (f *FakeClient) SignSBOM(ctx context.Context, keyID string, sbom interface{}, callOpts ...securesbom.CallOption) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignSBOM", keyID, sbom)
+	if f.SignSBOMFunc != nil {
+		return f.SignSBOMFunc(ctx, keyID, sbom, callOpts...)
+	}
+	return fakeSignResult(keyID, sbom, securesbom.SignOptions{})
+}
+
+func (f *FakeClient) SignSBOMWithOptions(ctx context.Context, keyID string, sbom interface{}, opts securesbom.SignOptions, callOpts ...securesbom.CallOption) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignSBOMWithOptions", keyID, sbom, opts)
+	if f.SignSBOMWithOptionsFunc != nil {
+		return f.SignSBOMWithOptionsFunc(ctx, keyID, sbom, opts, callOpts...)
+	}
+	return fakeSignResult(keyID, sbom, opts)
+}
+
+func (f *FakeClient) SignSBOMFromReader(ctx context.Context, keyID string, r io.Reader, size int64) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignSBOMFromReader", keyID, r, size)
+	if f.SignSBOMFromReaderFunc != nil {
+		return f.SignSBOMFromReaderFunc(ctx, keyID, r, size)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+	return fakeSignResult(keyID, json.RawMessage(data), securesbom.SignOptions{})
+}
+
+// SignSBOMToWriter writes sbom to w unchanged by default
+func (f *FakeClient) SignSBOMToWriter(ctx context.Context, keyID string, sbom []byte, w io.Writer) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignSBOMToWriter", keyID, sbom, w)
+	if f.SignSBOMToWriterFunc != nil {
+		return f.SignSBOMToWriterFunc(ctx, keyID, sbom, w)
+	}
+	if _, err := w.Write(sbom); err != nil {
+		return nil, fmt.Errorf("failed to write signed SBOM: %w", err)
+	}
+	return &securesbom.SignResultAPIResponseV2{KeyID: keyID}, nil
+}
+
+func (f *FakeClient) SignBytes(ctx context.Context, keyID string, data []byte, contentType string) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignBytes", keyID, data, contentType)
+	if f.SignBytesFunc != nil {
+		return f.SignBytesFunc(ctx, keyID, data, contentType)
+	}
+	return &securesbom.SignResultAPIResponseV2{Detached: true, SignatureB64: FakeSignature, KeyID: keyID}, nil
+}
+
+func (f *FakeClient) SignDigest(ctx context.Context, req securesbom.SignDigestRequest, callOpts ...securesbom.CallOption) (*securesbom.SignDigestResponse, error) {
+	f.record("SignDigest", req.KeyID, req)
+	if f.SignDigestFunc != nil {
+		return f.SignDigestFunc(ctx, req, callOpts...)
+	}
+	return &securesbom.SignDigestResponse{
+		HashAlgorithm: req.HashAlgorithm,
+		KeyID:         req.KeyID,
+		Signature:     FakeSignature,
+	}, nil
+}
+
+func (f *FakeClient) VerifySBOM(ctx context.Context, req securesbom.VerifyCMDRequest, callOpts ...securesbom.CallOption) (*securesbom.VerifyResultCMDResponse, error) {
+	f.record("VerifySBOM", req.KeyID, req)
+	if f.VerifySBOMFunc != nil {
+		return f.VerifySBOMFunc(ctx, req, callOpts...)
+	}
+	return &securesbom.VerifyResultCMDResponse{Valid: true, Code: "VALID", KeyID: req.KeyID}, nil
+}
+
+func (f *FakeClient) VerifyWithPublicKey(ctx context.Context, publicKeyPEM string, sbom []byte) (*securesbom.VerifyResultCMDResponse, error) {
+	f.record("VerifyWithPublicKey", "", publicKeyPEM, sbom)
+	if f.VerifyWithPublicKeyFunc != nil {
+		return f.VerifyWithPublicKeyFunc(ctx, publicKeyPEM, sbom)
+	}
+	return &securesbom.VerifyResultCMDResponse{Valid: true, Code: "VALID"}, nil
+}
+
+func (f *FakeClient) VerifyKeyless(ctx context.Context, sbom []byte, rekorBundle []byte, identity securesbom.KeylessIdentity) (*securesbom.VerifyResultCMDResponse, error) {
+	f.record("VerifyKeyless", "", sbom, rekorBundle, identity)
+	if f.VerifyKeylessFunc != nil {
+		return f.VerifyKeylessFunc(ctx, sbom, rekorBundle, identity)
+	}
+	return &securesbom.VerifyResultCMDResponse{Valid: true, Code: "VALID"}, nil
+}
+
+// IsSigned reports false by default, so code under test signs every SBOM
+func (f *FakeClient) IsSigned(ctx context.Context, keyID string, sbom []byte) (bool, error) {
+	f.record("IsSigned", keyID, sbom)
+	if f.IsSignedFunc != nil {
+		return f.IsSignedFunc(ctx, keyID, sbom)
+	}
+	return false, nil
+}
+
+// SignSBOMFromFile loads the SBOM at path by default and echoes it back like
+// SignSBOM, signing SPDX documents detached
+func (f *FakeClient) SignSBOMFromFile(ctx context.Context, keyID, path string) (*securesbom.SignResultAPIResponseV2, error) {
+	f.record("SignSBOMFromFile", keyID, path)
+	if f.SignSBOMFromFileFunc != nil {
+		return f.SignSBOMFromFileFunc(ctx, keyID, path)
+	}
+	sbom, err := loadSBOM(path)
+	if err != nil {
+		return nil, err
+	}
+	return fakeSignResult(keyID, sbom, securesbom.SignOptions{Detached: sbom.Format() == "spdx"})
+}
+
+// VerifySBOMFromFile loads the SBOM at path by default and reports it valid
+func (f *FakeClient) VerifySBOMFromFile(ctx context.Context, keyID, path string) (*securesbom.VerifyResultCMDResponse, error) {
+	f.record("VerifySBOMFromFile", keyID, path)
+	if f.VerifySBOMFromFileFunc != nil {
+		return f.VerifySBOMFromFileFunc(ctx, keyID, path)
+	}
+	if _, err := loadSBOM(path); err != nil {
+		return nil, err
+	}
+	return &securesbom.VerifyResultCMDResponse{Valid: true, Code: "VALID", KeyID: keyID}, nil
+}
+
+// loadSBOM loads the SBOM at path, or stdin for securesbom.StdinPath
+func loadSBOM(path string) (*securesbom.SBOM, error) {
+	if path == securesbom.StdinPath {
+		return securesbom.LoadSBOMFromReader(os.Stdin)
+	}
+	return securesbom.LoadSBOMFromFile(path)
+}
+
+// fakeSignResult echoes the SBOM back as the signed document, or returns
+// FakeSignature for detached signing
+func fakeSignResult(keyID string, sbom interface{}, opts securesbom.SignOptions) (*securesbom.SignResultAPIResponseV2, error) {
+	if opts.Detached {
+		return &securesbom.SignResultAPIResponseV2{Detached: true, Algorithm: opts.Algorithm, SignatureB64: FakeSignature, KeyID: keyID}, nil
+	}
+
+	var signed json.RawMessage
+	switch v := sbom.(type) {
+	case []byte:
+		signed = v
+	case json.RawMessage:
+		signed = v
+	case *securesbom.SBOM:
+		return fakeSignResult(keyID, v.Data(), opts)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal SBOM: %w", err)
+		}
+		signed = encoded
+	}
+	return &securesbom.SignResultAPIResponseV2{SignedSBOM: signed, Algorithm: opts.Algorithm, KeyID: keyID}, nil
+}
+
+// Timeout delegates to TimeoutFunc when set and otherwise returns
+// securesbom.DefaultTimeout. It is not recorded as a call.
+func (f *FakeClient) Timeout() time.Duration {
+	if f.TimeoutFunc != nil {
+		return f.TimeoutFunc()
+	}
+	return securesbom.DefaultTimeout
+}
+
+// Calls returns every recorded call in order
+func (f *FakeClient) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the recorded calls to method in order
+func (f *FakeClient) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []Call
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets all recorded calls. Programmed Func fields are kept.
+func (f *FakeClient) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// AssertCalled fails the test unless method was called at least once
+func (f *FakeClient) AssertCalled(t testing.TB, method string) {
+	t.Helper()
+	if len(f.CallsTo(method)) == 0 {
+		t.Errorf("expected %s to be called, calls were %s", method, f.describeCalls())
+	}
+}
+
+// AssertNotCalled fails the test if method was called
+func (f *FakeClient) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+	if n := len(f.CallsTo(method)); n > 0 {
+		t.Errorf("expected %s not to be called, it was called %d times", method, n)
+	}
+}
+
+// AssertCalledWithKey fails the test unless method was called with keyID
+func (f *FakeClient) AssertCalledWithKey(t testing.TB, method, keyID string) {
+	t.Helper()
+	for _, call := range f.CallsTo(method) {
+		if call.KeyID == keyID {
+			return
+		}
+	}
+	t.Errorf("expected %s to be called with key %q, calls were %s", method, keyID, f.describeCalls())
+}
+
+// AssertCallCount fails the test unless method was called exactly n times
+func (f *FakeClient) AssertCallCount(t testing.TB, method string, n int) {
+	t.Helper()
+	if got := len(f.CallsTo(method)); got != n {
+		t.Errorf("expected %s to be called %d times, got %d", method, n, got)
+	}
+}
+
+func (f *FakeClient) describeCalls() string {
+	calls := f.Calls()
+	if len(calls) == 0 {
+		return "none"
+	}
+	description := ""
+	for i, call := range calls {
+		if i > 0 {
+			description += ", "
+		}
+		description += call.Method
+		if call.KeyID != "" {
+			description += fmt.Sprintf("(%s)", call.KeyID)
+		}
+	}
+	return description
+}
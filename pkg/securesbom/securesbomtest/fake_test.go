@@ -0,0 +1,125 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbomtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+)
+
+// recordingTB captures assertion failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestFakeClient_Defaults(t *testing.T) {
+	ctx := context.Background()
+	fake := &FakeClient{}
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	signed, err := fake.SignSBOM(ctx, "key-1", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	if string(signed.SignedSBOM) != `{"bomFormat":"CycloneDX","specVersion":"1.5"}` {
+		t.Errorf("SignSBOM() SignedSBOM = %s, want the SBOM echoed back", signed.SignedSBOM)
+	}
+
+	detached, err := securesbom.SignSBOMDetached(ctx, fake, "key-1", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOMDetached() error = %v", err)
+	}
+	if detached.Base64() != FakeSignature {
+		t.Errorf("SignSBOMDetached() signature = %q, want %q", detached.Base64(), FakeSignature)
+	}
+
+	result, err := fake.VerifySBOM(ctx, securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: signed.SignedSBOM})
+	if err != nil {
+		t.Fatalf("VerifySBOM() error = %v", err)
+	}
+	if !result.Valid || result.KeyID != "key-1" {
+		t.Errorf("VerifySBOM() = %+v, want a valid result for key-1", result)
+	}
+
+	first, _ := fake.GenerateKey(ctx)
+	second, _ := fake.GenerateKeyWithOptions(ctx, securesbom.GenerateKeyOptions{Algorithm: securesbom.AlgorithmEd25519})
+	if first.ID == second.ID || second.Algorithm != securesbom.AlgorithmEd25519 {
+		t.Errorf("GenerateKey() = %+v then %+v, want distinct keys honoring options", first, second)
+	}
+}
+
+func TestFakeClient_Programmed(t *testing.T) {
+	fake := &FakeClient{
+		SignDigestFunc: func(ctx context.Context, req securesbom.SignDigestRequest, callOpts ...securesbom.CallOption) (*securesbom.SignDigestResponse, error) {
+			return nil, securesbom.ErrKeyNotFound
+		},
+	}
+
+	_, err := fake.SignDigest(context.Background(), securesbom.SignDigestRequest{KeyID: "missing", Digest: "ZGlnZXN0"})
+	if !errors.Is(err, securesbom.ErrKeyNotFound) {
+		t.Errorf("SignDigest() error = %v, want ErrKeyNotFound", err)
+	}
+	fake.AssertCalledWithKey(t, "SignDigest", "missing")
+}
+
+func TestFakeClient_Assertions(t *testing.T) {
+	fake := &FakeClient{}
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX"}
+	_, _ = fake.SignSBOM(context.Background(), "key-1", sbom)
+	_, _ = fake.SignSBOM(context.Background(), "key-2", sbom)
+
+	tests := []struct {
+		name   string
+		assert func(tb testing.TB)
+		fails  bool
+	}{
+		{name: "called", assert: func(tb testing.TB) { fake.AssertCalled(tb, "SignSBOM") }},
+		{name: "not called", assert: func(tb testing.TB) { fake.AssertCalled(tb, "VerifySBOM") }, fails: true},
+		{name: "called with key", assert: func(tb testing.TB) { fake.AssertCalledWithKey(tb, "SignSBOM", "key-2") }},
+		{name: "called with other key", assert: func(tb testing.TB) { fake.AssertCalledWithKey(tb, "SignSBOM", "key-3") }, fails: true},
+		{name: "never called", assert: func(tb testing.TB) { fake.AssertNotCalled(tb, "DeleteKey") }},
+		{name: "unexpectedly called", assert: func(tb testing.TB) { fake.AssertNotCalled(tb, "SignSBOM") }, fails: true},
+		{name: "call count", assert: func(tb testing.TB) { fake.AssertCallCount(tb, "SignSBOM", 2) }},
+		{name: "wrong call count", assert: func(tb testing.TB) { fake.AssertCallCount(tb, "SignSBOM", 1) }, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			tt.assert(tb)
+			if failed := len(tb.failures) > 0; failed != tt.fails {
+				t.Errorf("assertion failed = %v, want %v (%v)", failed, tt.fails, tb.failures)
+			}
+		})
+	}
+
+	fake.Reset()
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("Calls() after Reset() = %v, want none", calls)
+	}
+}
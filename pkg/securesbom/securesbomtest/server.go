@@ -0,0 +1,572 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbomtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+)
+
+// ServerOptions configures the fake API served by NewServer. The zero value
+// accepts any credentials and answers every request immediately.
+type ServerOptions struct {
+	// APIKey, when set, must be sent as the x-api-key header or as a bearer
+	// token; other requests get a 401. The health check is always open.
+	APIKey string
+	// Keys are created at startup with these IDs
+	Keys []string
+
+	// Latency delays every response, or until the client gives up on the request
+	Latency time.Duration
+	// Failures are the status codes returned, in order, for the first requests
+	// the server receives, e.g. []int{503, 429} fails the first two requests
+	// before answering normally
+	Failures []int
+	// RetryAfter is sent with injected 429 and 503 failures
+	RetryAfter time.Duration
+
+	// OnRequest, when set, is called with every request as it arrives, e.g. to
+	// count attempts
+	OnRequest func(r *http.Request)
+}
+
+// NewServer starts a fake SecureSBOM API for integration tests. Point a client
+// at it with Config.BaseURL = server.URL and close it when done.
+//
+// Keys are Ed25519 keys held in memory, and signatures are real Ed25519
+// signatures over the JSON encoding of the SBOM without its "signature" member,
+// so tampered documents fail verification. The server implements:
+//
+//	GET    /infra/healthcheck          health status
+//	GET    /infra/info                 server info
+//	GET    /api/v1/keys                list keys (page_size, page_token)
+//	POST   /api/v1/keys                generate a key (201)
+//	GET    /api/v1/keys/public?key_id= PEM public key
+//	GET    /api/v1/keys/{id}           key details
+//	DELETE /api/v1/keys/{id}           delete a key (204)
+//	POST   /api/v1/digest/sign         sign a base64 digest
+//	POST   /api/v2/blob/sign?key_id=   sign a raw request body (detached)
+//	POST   /api/v2/sbom/sign           sign an SBOM, embedded or detached; only the raw
+//	                                   Accept-Signature-Format is accepted (406 otherwise)
+//	POST   /api/v2/sbom/verify         verify an SBOM by key_id or public_key (400 INVALID_SIGNATURE on mismatch)
+//	HEAD   /api/v2/sbom/signatures/{digest}?key_id=
+//	                                   200 if the server signed the SBOM with the key, 404 if not
+//
+// Unknown keys get a 404. Gzip-encoded request bodies are accepted, and every
+// response echoes the request's X-Request-ID.
+func NewServer(opts ServerOptions) *httptest.Server {
+	s := &fakeServer{opts: opts, keys: map[string]*fakeKey{}, signed: map[string]bool{}}
+	for _, id := range opts.Keys {
+		s.addKey(id)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+securesbom.API_ENDPOINT_HEALTHCHECK, s.healthCheck)
+	mux.HandleFunc("GET "+securesbom.API_ENDPOINT_INFO, s.serverInfo)
+	keys := securesbom.API_VERSION + securesbom.API_ENDPOINT_KEYS
+	mux.HandleFunc("GET "+keys, s.listKeys)
+	mux.HandleFunc("POST "+keys, s.generateKey)
+	mux.HandleFunc("GET "+keys+"/public", s.publicKey)
+	mux.HandleFunc("GET "+keys+"/{id}", s.getKey)
+	mux.HandleFunc("DELETE "+keys+"/{id}", s.deleteKey)
+	mux.HandleFunc("POST "+securesbom.API_VERSION+securesbom.API_ENDPOING_DIGEST+"/sign", s.signDigest)
+	mux.HandleFunc("POST "+securesbom.API_VERSION_V2+securesbom.API_ENDPOINT_BLOB+"/sign", s.signBlob)
+	mux.HandleFunc("POST "+securesbom.API_VERSION_V2+securesbom.API_ENDPOINT_SBOM+"/sign", s.signSBOM)
+	mux.HandleFunc("POST "+securesbom.API_VERSION_V2+securesbom.API_ENDPOINT_SBOM+"/verify", s.verifySBOM)
+	mux.HandleFunc("HEAD "+securesbom.API_VERSION_V2+securesbom.API_ENDPOINT_SBOM+"/signatures/{digest}", s.signatureStatus)
+
+	return httptest.NewServer(s.middleware(mux))
+}
+
+type fakeKey struct {
+	id         string
+	createdAt  time.Time
+	privateKey ed25519.PrivateKey
+	labels     map[string]string
+}
+
+func (k *fakeKey) publicKeyPEM() string {
+	der, _ := x509.MarshalPKIXPublicKey(k.privateKey.Public())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func (k *fakeKey) fingerprint() string {
+	return publicKeyFingerprint(k.privateKey.Public().(ed25519.PublicKey))
+}
+
+func publicKeyFingerprint(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *fakeKey) apiResponse() securesbom.GenerateKeyAPIReponse {
+	return securesbom.GenerateKeyAPIReponse{
+		KeyID:     k.id,
+		CreatedAt: k.createdAt,
+		Algorithm: securesbom.AlgorithmEd25519,
+		PublicKey: k.publicKeyPEM(),
+		Backend:   securesbom.KeyBackendFile,
+		Status:    securesbom.KeyStatusActive,
+		Labels:    k.labels,
+	}
+}
+
+type fakeServer struct {
+	opts ServerOptions
+
+	mu       sync.Mutex
+	requests int
+	keys     map[string]*fakeKey
+	order    []string
+	nextID   int
+	// signed holds "<key id>/<digest>" for every SBOM signed, for IsSigned
+	signed map[string]bool
+}
+
+func (s *fakeServer) addKey(id string) *fakeKey {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("securesbomtest: failed to generate key: %v", err))
+	}
+	key := &fakeKey{id: id, createdAt: time.Now().UTC(), privateKey: privateKey}
+	s.keys[id] = key
+	s.order = append(s.order, id)
+	return key
+}
+
+func (s *fakeServer) key(id string) (*fakeKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	return key, ok
+}
+
+// middleware applies latency, injected failures and authentication before routing
+func (s *fakeServer) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.opts.OnRequest != nil {
+			s.opts.OnRequest(r)
+		}
+		if id := r.Header.Get(securesbom.RequestIDHeader); id != "" {
+			w.Header().Set(securesbom.RequestIDHeader, id)
+		}
+
+		if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+			body, err := gzip.NewReader(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "request body is not valid gzip")
+				return
+			}
+			r.Body = body
+		}
+
+		if s.opts.Latency > 0 {
+			// The server only notices a client hanging up once the body is read
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			timer := time.NewTimer(s.opts.Latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		s.mu.Lock()
+		n := s.requests
+		s.requests++
+		s.mu.Unlock()
+		if n < len(s.opts.Failures) {
+			status := s.opts.Failures[n]
+			if s.opts.RetryAfter > 0 && (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(s.opts.RetryAfter.Seconds()))))
+			}
+			writeError(w, status, "INJECTED_FAILURE", http.StatusText(status))
+			return
+		}
+
+		if s.opts.APIKey != "" && r.URL.Path != securesbom.API_ENDPOINT_HEALTHCHECK &&
+			r.Header.Get("x-api-key") != s.opts.APIKey &&
+			r.Header.Get("Authorization") != "Bearer "+s.opts.APIKey {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *fakeServer) healthCheck(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, securesbom.HealthStatus{Status: "healthy", Version: "securesbomtest"})
+}
+
+func (s *fakeServer) serverInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, securesbom.ServerInfo{
+		Version:          "securesbomtest",
+		SupportedFormats: []string{"cyclonedx", "spdx"},
+	})
+}
+
+func (s *fakeServer) listKeys(w http.ResponseWriter, r *http.Request) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pageSize <= 0 {
+		pageSize = len(s.order)
+	}
+	start = min(max(start, 0), len(s.order))
+	end := min(start+pageSize, len(s.order))
+
+	page := struct {
+		Keys          []securesbom.ListKeysAPIResponse `json:"keys"`
+		NextPageToken string                           `json:"next_page_token,omitempty"`
+	}{Keys: []securesbom.ListKeysAPIResponse{}}
+	for _, id := range s.order[start:end] {
+		key := s.keys[id].apiResponse()
+		page.Keys = append(page.Keys, securesbom.ListKeysAPIResponse{
+			ID:        key.KeyID,
+			CreatedAt: key.CreatedAt,
+			Algorithm: key.Algorithm,
+			Backend:   key.Backend,
+			Status:    key.Status,
+			Labels:    key.Labels,
+		})
+	}
+	if end < len(s.order) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *fakeServer) generateKey(w http.ResponseWriter, r *http.Request) {
+	var req securesbom.GenerateKeyOptions
+	if r.ContentLength != 0 {
+		var body struct {
+			Algorithm string            `json:"algorithm"`
+			Labels    map[string]string `json:"labels"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		req.Algorithm = body.Algorithm
+		req.Labels = body.Labels
+	}
+	if req.Algorithm != "" && req.Algorithm != securesbom.AlgorithmEd25519 {
+		writeError(w, http.StatusBadRequest, "UNSUPPORTED_ALGORITHM", "the test server only generates ed25519 keys")
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	id := fmt.Sprintf("test-key-%d", s.nextID)
+	for s.keys[id] != nil {
+		s.nextID++
+		id = fmt.Sprintf("test-key-%d", s.nextID)
+	}
+	key := s.addKey(id)
+	key.labels = req.Labels
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, key.apiResponse())
+}
+
+func (s *fakeServer) publicKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := s.key(r.URL.Query().Get("key_id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	_, _ = w.Write([]byte(key.publicKeyPEM()))
+}
+
+func (s *fakeServer) getKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := s.key(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+	apiKey := key.apiResponse()
+	writeJSON(w, http.StatusOK, securesbom.ListKeysAPIResponse{
+		ID:        apiKey.KeyID,
+		CreatedAt: apiKey.CreatedAt,
+		Algorithm: apiKey.Algorithm,
+		Backend:   apiKey.Backend,
+		Status:    apiKey.Status,
+		Labels:    apiKey.Labels,
+	})
+}
+
+func (s *fakeServer) deleteKey(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[id]; !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+	delete(s.keys, id)
+	for i, existing := range s.order {
+		if existing == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *fakeServer) signDigest(w http.ResponseWriter, r *http.Request) {
+	var req securesbom.SignDigestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	digest, err := base64.StdEncoding.DecodeString(req.Digest)
+	if err != nil || len(digest) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "digest must be base64")
+		return
+	}
+	key, ok := s.key(req.KeyID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, securesbom.SignDigestResponse{
+		HashAlgorithm:      req.HashAlgorithm,
+		KeyID:              req.KeyID,
+		Signature:          base64.StdEncoding.EncodeToString(ed25519.Sign(key.privateKey, digest)),
+		SignatureAlgorithm: securesbom.AlgorithmEd25519,
+	})
+}
+
+func (s *fakeServer) signBlob(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Content-Type is required")
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil || len(data) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "request body is required")
+		return
+	}
+	key, ok := s.key(r.URL.Query().Get("key_id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, securesbom.SignResultAPIResponseV2{
+		Algorithm:    securesbom.AlgorithmEd25519,
+		Detached:     true,
+		SignatureB64: base64.StdEncoding.EncodeToString(ed25519.Sign(key.privateKey, data)),
+	})
+}
+
+func (s *fakeServer) signSBOM(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		KeyID     string          `json:"key_id"`
+		SBOM      json.RawMessage `json:"sbom"`
+		Detached  bool            `json:"detached"`
+		Algorithm string          `json:"algorithm"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.Algorithm != "" && req.Algorithm != securesbom.AlgorithmEd25519 {
+		writeError(w, http.StatusBadRequest, "UNSUPPORTED_ALGORITHM", "the test server only signs with ed25519")
+		return
+	}
+	format := securesbom.SignatureFormat(r.Header.Get(securesbom.SignatureFormatHeader))
+	if format != "" && format != securesbom.SignatureFormatRaw {
+		writeError(w, http.StatusNotAcceptable, "UNSUPPORTED_SIGNATURE_FORMAT", "the test server only produces raw signatures")
+		return
+	}
+	key, ok := s.key(req.KeyID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+		return
+	}
+	doc, payload, ok := signingPayload(w, req.SBOM)
+	if !ok {
+		return
+	}
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key.privateKey, payload))
+	if digest, err := securesbom.NewSBOM(doc).Digest(0); err == nil {
+		s.mu.Lock()
+		s.signed[key.id+"/"+digest] = true
+		s.mu.Unlock()
+	}
+	if req.Detached || format == securesbom.SignatureFormatRaw {
+		writeJSON(w, http.StatusOK, securesbom.SignResultAPIResponseV2{
+			Algorithm:       securesbom.AlgorithmEd25519,
+			Detached:        true,
+			SignatureB64:    signature,
+			SignatureFormat: format,
+		})
+		return
+	}
+
+	doc["signature"] = map[string]interface{}{
+		"algorithm": "Ed25519",
+		"keyId":     key.id,
+		"value":     signature,
+	}
+	signed, _ := json.Marshal(doc)
+	writeJSON(w, http.StatusOK, securesbom.SignResultAPIResponseV2{
+		SignedSBOM: signed,
+		Algorithm:  securesbom.AlgorithmEd25519,
+	})
+}
+
+func (s *fakeServer) verifySBOM(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		KeyID        string          `json:"key_id"`
+		SBOM         json.RawMessage `json:"sbom"`
+		SignatureB64 string          `json:"signature_b64"`
+		PublicKey    string          `json:"public_key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	var publicKey ed25519.PublicKey
+	if req.PublicKey != "" {
+		block, _ := pem.Decode([]byte(req.PublicKey))
+		if block == nil {
+			writeError(w, http.StatusBadRequest, "INVALID_PUBLIC_KEY", "public_key is not PEM")
+			return
+		}
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		edKey, ok := parsed.(ed25519.PublicKey)
+		if err != nil || !ok {
+			writeError(w, http.StatusBadRequest, "INVALID_PUBLIC_KEY", "public_key must be an Ed25519 key")
+			return
+		}
+		publicKey = edKey
+	} else {
+		key, ok := s.key(req.KeyID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "KEY_NOT_FOUND", "key not found")
+			return
+		}
+		publicKey = key.privateKey.Public().(ed25519.PublicKey)
+	}
+	doc, payload, ok := signingPayload(w, req.SBOM)
+	if !ok {
+		return
+	}
+
+	encoded := req.SignatureB64
+	if encoded == "" {
+		if embedded, ok := doc["signature"].(map[string]interface{}); ok {
+			encoded, _ = embedded["value"].(string)
+		}
+	}
+	if encoded == "" {
+		writeError(w, http.StatusBadRequest, "SIGNATURE_MISSING", "the SBOM is not signed")
+		return
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !ed25519.Verify(publicKey, payload, signature) {
+		writeError(w, http.StatusBadRequest, "INVALID_SIGNATURE", "signature verification failed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, securesbom.VerifyResultAPIResponseV2{
+		Code:                 "VALID",
+		Message:              "signature is valid",
+		Algorithm:            securesbom.AlgorithmEd25519,
+		PublicKeyFingerprint: publicKeyFingerprint(publicKey),
+	})
+}
+
+func (s *fakeServer) signatureStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	signed := s.signed[r.URL.Query().Get("key_id")+"/"+r.PathValue("digest")]
+	s.mu.Unlock()
+	if !signed {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// signingPayload parses an SBOM object and returns the bytes that are signed:
+// its JSON encoding without the embedded signature
+func signingPayload(w http.ResponseWriter, raw json.RawMessage) (map[string]interface{}, []byte, bool) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil || doc == nil {
+		writeError(w, http.StatusBadRequest, "INVALID_SBOM", "sbom must be a JSON object")
+		return nil, nil, false
+	}
+
+	unsigned := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		if k != "signature" {
+			unsigned[k] = v
+		}
+	}
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_SBOM", err.Error())
+		return nil, nil, false
+	}
+	return doc, payload, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
@@ -0,0 +1,620 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbomtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+)
+
+func newServerClient(t *testing.T, opts ServerOptions) *securesbom.Client {
+	t.Helper()
+	server := NewServer(opts)
+	t.Cleanup(server.Close)
+
+	client, err := securesbom.NewClient(&securesbom.Config{BaseURL: server.URL, APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestServer_SignAndVerify(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1", "key-2"}})
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}
+
+	signed, err := client.SignSBOM(ctx, "key-1", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	var signedDoc map[string]interface{}
+	if err := json.Unmarshal(signed.SignedSBOM, &signedDoc); err != nil {
+		t.Fatalf("signed SBOM is not JSON: %v", err)
+	}
+
+	detached, err := securesbom.SignSBOMDetached(ctx, client, "key-2", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOMDetached() error = %v", err)
+	}
+
+	tampered := map[string]interface{}{}
+	for k, v := range signedDoc {
+		tampered[k] = v
+	}
+	tampered["version"] = 2
+
+	tests := []struct {
+		name       string
+		req        securesbom.VerifyCMDRequest
+		wantCode   string
+		wantReason securesbom.VerifyReason
+	}{
+		{name: "embedded", req: securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: signedDoc}},
+		{name: "detached", req: securesbom.VerifyCMDRequest{KeyID: "key-2", SBOM: sbom, SignatureB64: detached.Base64()}},
+		{
+			name:       "tampered",
+			req:        securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: tampered},
+			wantCode:   "INVALID_SIGNATURE",
+			wantReason: securesbom.VerifyReasonSignatureMismatch,
+		},
+		{
+			name:       "wrong key",
+			req:        securesbom.VerifyCMDRequest{KeyID: "key-2", SBOM: signedDoc},
+			wantCode:   "INVALID_SIGNATURE",
+			wantReason: securesbom.VerifyReasonSignatureMismatch,
+		},
+		{
+			name:       "unsigned",
+			req:        securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: sbom},
+			wantCode:   "SIGNATURE_MISSING",
+			wantReason: securesbom.VerifyReasonSignatureMissing,
+		},
+		{
+			name:       "unknown key",
+			req:        securesbom.VerifyCMDRequest{KeyID: "missing", SBOM: signedDoc},
+			wantCode:   "KEY_NOT_FOUND",
+			wantReason: securesbom.VerifyReasonKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.VerifySBOM(ctx, tt.req)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("VerifySBOM() error = %v", err)
+				}
+				if !result.Valid || result.Algorithm != securesbom.AlgorithmEd25519 || result.PublicKeyFingerprint == "" {
+					t.Errorf("VerifySBOM() = %+v, want a valid Ed25519 result", result)
+				}
+				return
+			}
+
+			apiErr, ok := securesbom.AsAPIError(err)
+			if !ok {
+				t.Fatalf("VerifySBOM() error = %v, want an API error", err)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("VerifySBOM() code = %q, want %q", apiErr.Code, tt.wantCode)
+			}
+			if result == nil || result.Valid || result.Reason != tt.wantReason {
+				t.Errorf("VerifySBOM() result = %+v, want an invalid result with reason %q", result, tt.wantReason)
+			}
+		})
+	}
+}
+
+// embeddedSignature signs sbom with keyID and returns the signature object
+// the server embedded
+func embeddedSignature(t *testing.T, client *securesbom.Client, keyID string, sbom map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	signed, err := client.SignSBOM(context.Background(), keyID, sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(signed.SignedSBOM, &doc); err != nil {
+		t.Fatalf("failed to decode signed SBOM: %v", err)
+	}
+	return doc["signature"].(map[string]interface{})
+}
+
+func TestServer_VerifyAllSignatures(t *testing.T) {
+	client := newServerClient(t, ServerOptions{Keys: []string{"a", "b"}})
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}
+	good := embeddedSignature(t, client, "b", sbom)
+	bad := map[string]interface{}{"algorithm": "Ed25519", "keyId": "a", "value": good["value"]}
+	unknown := map[string]interface{}{"algorithm": "Ed25519", "keyId": "missing", "value": good["value"]}
+
+	tests := []struct {
+		name        string
+		signers     []interface{}
+		wantErr     error
+		wantValid   []bool
+		wantReasons []securesbom.VerifyReason
+	}{
+		{
+			name:        "bad then good is trusted",
+			signers:     []interface{}{bad, good},
+			wantValid:   []bool{false, true},
+			wantReasons: []securesbom.VerifyReason{securesbom.VerifyReasonSignatureMismatch, ""},
+		},
+		{
+			name:        "unknown key then good is trusted",
+			signers:     []interface{}{unknown, good},
+			wantValid:   []bool{false, true},
+			wantReasons: []securesbom.VerifyReason{securesbom.VerifyReasonKeyNotFound, ""},
+		},
+		{
+			name:        "no valid signature",
+			signers:     []interface{}{bad, unknown},
+			wantErr:     securesbom.ErrSignatureInvalid,
+			wantValid:   []bool{false, false},
+			wantReasons: []securesbom.VerifyReason{securesbom.VerifyReasonSignatureMismatch, securesbom.VerifyReasonKeyNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1,
+				"signature": map[string]interface{}{"signers": tt.signers}}
+			data, _ := json.Marshal(doc)
+
+			results, err := securesbom.VerifyAllSignatures(context.Background(), client, data)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("VerifyAllSignatures() error = %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyAllSignatures() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(results) != len(tt.wantValid) {
+				t.Fatalf("VerifyAllSignatures() returned %d results, want %d", len(results), len(tt.wantValid))
+			}
+			for i, result := range results {
+				if result.Valid != tt.wantValid[i] || result.Reason != tt.wantReasons[i] {
+					t.Errorf("result %d = valid %v reason %q, want valid %v reason %q",
+						i, result.Valid, result.Reason, tt.wantValid[i], tt.wantReasons[i])
+				}
+			}
+		})
+	}
+
+	t.Run("server error stops verification", func(t *testing.T) {
+		failing := newServerClient(t, ServerOptions{Keys: []string{"b"}, Failures: []int{http.StatusServiceUnavailable}})
+		doc := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1,
+			"signature": map[string]interface{}{"signers": []interface{}{good, good}}}
+		data, _ := json.Marshal(doc)
+
+		results, err := securesbom.VerifyAllSignatures(context.Background(), failing, data)
+		if !securesbom.IsTemporary(err) || len(results) != 0 {
+			t.Errorf("VerifyAllSignatures() = %d results, %v, want none and the 503", len(results), err)
+		}
+	})
+}
+
+func TestServer_ResignSBOM(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"a", "b", "c"}})
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}
+	signed, err := client.SignSBOM(ctx, "a", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	unsigned, _ := json.Marshal(sbom)
+
+	tests := []struct {
+		name       string
+		oldKeyID   string
+		sbom       []byte
+		wantReason securesbom.VerifyReason
+	}{
+		{name: "matching key", oldKeyID: "a", sbom: signed.SignedSBOM},
+		{name: "wrong key", oldKeyID: "b", sbom: signed.SignedSBOM, wantReason: securesbom.VerifyReasonSignatureMismatch},
+		{name: "unsigned", oldKeyID: "a", sbom: unsigned, wantReason: securesbom.VerifyReasonSignatureMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := securesbom.ResignSBOM(ctx, client, tt.oldKeyID, "c", tt.sbom)
+			if tt.wantReason != "" {
+				if !errors.Is(err, securesbom.ErrSignatureInvalid) {
+					t.Fatalf("ResignSBOM() error = %v, want ErrSignatureInvalid", err)
+				}
+				if result == nil || result.Verification == nil || result.Verification.Valid {
+					t.Fatalf("ResignSBOM() result = %+v, want an invalid verification", result)
+				}
+				if result.Verification.Reason != tt.wantReason || result.Signed != nil {
+					t.Errorf("ResignSBOM() = reason %q signed %v, want reason %q and nothing signed",
+						result.Verification.Reason, result.Signed != nil, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResignSBOM() error = %v", err)
+			}
+
+			verified, err := client.VerifySBOM(ctx, securesbom.VerifyCMDRequest{KeyID: "c", SBOM: result.Signed.SignedSBOM})
+			if err != nil || !verified.Valid {
+				t.Errorf("VerifySBOM() with the new key = %+v, %v, want valid", verified, err)
+			}
+		})
+	}
+}
+
+func TestServer_VerifySBOMAnyKey(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"a", "b"}})
+	signed, err := client.SignSBOM(ctx, "b", map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1})
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		keyIDs      []string
+		wantKey     string
+		wantInvalid bool
+	}{
+		{name: "second key matches", keyIDs: []string{"a", "b"}, wantKey: "b"},
+		{name: "wrong key", keyIDs: []string{"a"}, wantInvalid: true},
+		{name: "unknown key", keyIDs: []string{"missing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := securesbom.VerifySBOMAnyKey(ctx, client, tt.keyIDs, signed.SignedSBOM)
+			if tt.wantKey != "" {
+				if err != nil || !result.Valid || result.KeyID != tt.wantKey {
+					t.Fatalf("VerifySBOMAnyKey() = %+v, %v, want valid for %q", result, err, tt.wantKey)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("VerifySBOMAnyKey() error = nil, want an error")
+			}
+			if errors.Is(err, securesbom.ErrSignatureInvalid) != tt.wantInvalid {
+				t.Errorf("errors.Is(err, ErrSignatureInvalid) = %v, want %v: %v", !tt.wantInvalid, tt.wantInvalid, err)
+			}
+		})
+	}
+}
+
+func TestServer_SignSBOMToWriter(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+
+	var out bytes.Buffer
+	result, err := client.SignSBOMToWriter(ctx, "key-1", []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`), &out)
+	if err != nil {
+		t.Fatalf("SignSBOMToWriter() error = %v", err)
+	}
+	if result.Algorithm != securesbom.AlgorithmEd25519 {
+		t.Errorf("Algorithm = %q, want %q", result.Algorithm, securesbom.AlgorithmEd25519)
+	}
+
+	var signedDoc map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &signedDoc); err != nil {
+		t.Fatalf("streamed SBOM is not JSON: %v", err)
+	}
+	verified, err := client.VerifySBOM(ctx, securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: signedDoc})
+	if err != nil || !verified.Valid {
+		t.Errorf("VerifySBOM() = %+v, %v, want the streamed SBOM to verify", verified, err)
+	}
+}
+
+func TestServer_VerifyWithPublicKey(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1", "key-2"}})
+
+	signed, err := client.SignSBOM(ctx, "key-1", map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"})
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	signingKey, err := client.GetPublicKey(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	otherKey, err := client.GetPublicKey(ctx, "key-2")
+	if err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+
+	result, err := client.VerifyWithPublicKey(ctx, signingKey, signed.SignedSBOM)
+	if err != nil || !result.Valid {
+		t.Fatalf("VerifyWithPublicKey() = %+v, %v, want a valid result", result, err)
+	}
+
+	_, err = client.VerifyWithPublicKey(ctx, otherKey, signed.SignedSBOM)
+	if apiErr, ok := securesbom.AsAPIError(err); !ok || apiErr.Code != "INVALID_SIGNATURE" {
+		t.Errorf("VerifyWithPublicKey() with another key error = %v, want INVALID_SIGNATURE", err)
+	}
+}
+
+func TestServer_SignBytes(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+	manifest := []byte("apiVersion: apps/v1\nkind: Deployment\n")
+
+	result, err := client.SignBytes(ctx, "key-1", manifest, "application/yaml")
+	if err != nil {
+		t.Fatalf("SignBytes() error = %v", err)
+	}
+
+	publicKeyPEM, err := client.GetPublicKey(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	signature, _ := base64.StdEncoding.DecodeString(result.SignatureB64)
+	if !ed25519.Verify(publicKey.(ed25519.PublicKey), manifest, signature) {
+		t.Error("signature does not verify over the raw bytes")
+	}
+}
+
+func TestServer_CompressedRequests(t *testing.T) {
+	server := NewServer(ServerOptions{Keys: []string{"key-1"}})
+	defer server.Close()
+
+	client, err := securesbom.NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL(server.URL).
+		WithCompression(true, 1).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("BuildClient() error = %v", err)
+	}
+
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+	signed, err := client.SignSBOM(context.Background(), "key-1", sbom)
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	if _, err := client.VerifySBOM(context.Background(), securesbom.VerifyCMDRequest{KeyID: "key-1", SBOM: signed.SignedSBOM}); err != nil {
+		t.Errorf("VerifySBOM() error = %v", err)
+	}
+}
+
+func TestServer_Keys(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+
+	generated, err := client.GenerateKey(ctx)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if _, err := client.GenerateKeyWithOptions(ctx, securesbom.GenerateKeyOptions{Algorithm: securesbom.AlgorithmRSA2048}); err == nil {
+		t.Error("GenerateKeyWithOptions(rsa-2048) expected an error")
+	}
+
+	var ids []string
+	for key, err := range securesbom.IterateKeys(ctx, client, securesbom.ListKeysOptions{PageSize: 1}) {
+		if err != nil {
+			t.Fatalf("IterateKeys() error = %v", err)
+		}
+		ids = append(ids, key.ID)
+	}
+	if strings.Join(ids, ",") != "key-1,"+generated.ID {
+		t.Errorf("IterateKeys() = %v, want key-1 and %s", ids, generated.ID)
+	}
+
+	publicKey, err := client.GetPublicKey(ctx, generated.ID)
+	if err != nil {
+		t.Fatalf("GetPublicKey() error = %v", err)
+	}
+	if publicKey != generated.PublicKey || !strings.HasPrefix(publicKey, "-----BEGIN PUBLIC KEY-----") {
+		t.Errorf("GetPublicKey() = %q, want the generated PEM key", publicKey)
+	}
+
+	if err := client.DeleteKey(ctx, generated.ID); err != nil {
+		t.Fatalf("DeleteKey() error = %v", err)
+	}
+	if _, err := client.GetKey(ctx, generated.ID); !errors.Is(err, securesbom.ErrKeyNotFound) {
+		t.Errorf("GetKey() after delete error = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestServer_KeyLabels(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+
+	labelled, err := client.GenerateKeyWithOptions(ctx, securesbom.GenerateKeyOptions{Labels: map[string]string{"team": "payments"}})
+	if err != nil {
+		t.Fatalf("GenerateKeyWithOptions() error = %v", err)
+	}
+	if labelled.Labels["team"] != "payments" {
+		t.Errorf("GenerateKeyWithOptions() labels = %v, want team=payments", labelled.Labels)
+	}
+
+	page, err := client.ListKeysPaged(ctx, securesbom.ListKeysOptions{LabelSelector: "team=payments"})
+	if err != nil {
+		t.Fatalf("ListKeysPaged() error = %v", err)
+	}
+	if len(page.Keys) != 1 || page.Keys[0].ID != labelled.ID {
+		t.Errorf("ListKeysPaged() = %v, want only %s", page.Keys, labelled.ID)
+	}
+
+	key, err := client.GetKey(ctx, labelled.ID)
+	if err != nil {
+		t.Fatalf("GetKey() error = %v", err)
+	}
+	if key.Labels["team"] != "payments" {
+		t.Errorf("GetKey() labels = %v, want team=payments", key.Labels)
+	}
+}
+
+func TestServer_IsSigned(t *testing.T) {
+	ctx := context.Background()
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1", "key-2"}})
+	sbom := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}`)
+
+	if signed, err := client.IsSigned(ctx, "key-1", sbom); err != nil || signed {
+		t.Fatalf("IsSigned() before signing = %v, %v; want false", signed, err)
+	}
+	if _, err := client.SignSBOM(ctx, "key-1", sbom); err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		keyID    string
+		sbom     string
+		expected bool
+	}{
+		{name: "same document", keyID: "key-1", sbom: string(sbom), expected: true},
+		{name: "reformatted document", keyID: "key-1", sbom: `{"version":1,"specVersion":"1.5","bomFormat":"CycloneDX"}`, expected: true},
+		{name: "changed document", keyID: "key-1", sbom: `{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 2}`},
+		{name: "other key", keyID: "key-2", sbom: string(sbom)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := client.IsSigned(ctx, tt.keyID, []byte(tt.sbom))
+			if err != nil {
+				t.Fatalf("IsSigned() error = %v", err)
+			}
+			if signed != tt.expected {
+				t.Errorf("IsSigned() = %v, want %v", signed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServer_SignatureFormat(t *testing.T) {
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+	sbom := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}`)
+
+	result, err := client.SignSBOM(context.Background(), "key-1", sbom,
+		securesbom.WithSignatureFormat(securesbom.SignatureFormatRaw))
+	if err != nil {
+		t.Fatalf("SignSBOM() error = %v", err)
+	}
+	if result.SignatureFormat != securesbom.SignatureFormatRaw || result.SignatureB64 == "" {
+		t.Errorf("SignSBOM() = format %q signature %q, want a raw signature", result.SignatureFormat, result.SignatureB64)
+	}
+
+	_, err = client.SignSBOM(context.Background(), "key-1", sbom,
+		securesbom.WithSignatureFormat(securesbom.SignatureFormatJWS))
+	if !errors.Is(err, securesbom.ErrSignatureFormatUnsupported) {
+		t.Errorf("SignSBOM() error = %v, want ErrSignatureFormatUnsupported", err)
+	}
+}
+
+func TestServer_SignDigest(t *testing.T) {
+	client := newServerClient(t, ServerOptions{Keys: []string{"key-1"}})
+
+	digest := sha256.Sum256([]byte("artifact"))
+	result, err := client.SignDigest(context.Background(), securesbom.SignDigestRequest{
+		Digest:        base64.StdEncoding.EncodeToString(digest[:]),
+		HashAlgorithm: "sha256",
+		KeyID:         "key-1",
+	})
+	if err != nil {
+		t.Fatalf("SignDigest() error = %v", err)
+	}
+	if result.Signature == "" || result.SignatureAlgorithm != securesbom.AlgorithmEd25519 {
+		t.Errorf("SignDigest() = %+v, want an Ed25519 signature", result)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	retryConfig := securesbom.RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: 10 * time.Millisecond, Multiplier: 1}
+
+	tests := []struct {
+		name         string
+		opts         ServerOptions
+		callOpts     []securesbom.CallOption
+		wantAttempts int32
+		check        func(t *testing.T, err error)
+	}{
+		{
+			name:         "retried until success",
+			opts:         ServerOptions{Failures: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, RetryAfter: time.Millisecond},
+			wantAttempts: 3,
+			check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("error = %v, want success after retries", err)
+				}
+			},
+		},
+		{
+			name:         "retries exhausted",
+			opts:         ServerOptions{Failures: []int{500, 500, 500}},
+			wantAttempts: 3,
+			check: func(t *testing.T, err error) {
+				if !securesbom.IsTemporary(err) {
+					t.Errorf("error = %v, want a temporary API error", err)
+				}
+			},
+		},
+		{
+			name:         "latency exceeds timeout",
+			opts:         ServerOptions{Latency: time.Second},
+			callOpts:     []securesbom.CallOption{securesbom.WithTimeout(20 * time.Millisecond), securesbom.WithRetries(1)},
+			wantAttempts: 1,
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("error = %v, want context.DeadlineExceeded", err)
+				}
+			},
+		},
+		{
+			name:         "wrong API key",
+			opts:         ServerOptions{APIKey: "other-key"},
+			wantAttempts: 1,
+			check: func(t *testing.T, err error) {
+				if !securesbom.IsUnauthorized(err) {
+					t.Errorf("error = %v, want unauthorized", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			tt.opts.Keys = []string{"key-1"}
+			tt.opts.OnRequest = func(r *http.Request) { attempts.Add(1) }
+			client := securesbom.WithRetryingClient(newServerClient(t, tt.opts), retryConfig)
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			_, err := client.SignSBOM(context.Background(), "key-1", sbom, tt.callOpts...)
+			tt.check(t, err)
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
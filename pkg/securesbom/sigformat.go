@@ -0,0 +1,100 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SignatureFormat is the envelope a signature is returned in
+type SignatureFormat string
+
+const (
+	// SignatureFormatJWS is a JSON Web Signature
+	SignatureFormatJWS SignatureFormat = "jws"
+	// SignatureFormatPKCS7 is a PKCS#7 / CMS SignedData structure
+	SignatureFormatPKCS7 SignatureFormat = "pkcs7"
+	// SignatureFormatRaw is the bare signature bytes
+	SignatureFormatRaw SignatureFormat = "raw"
+)
+
+// SignatureFormatHeader asks the server for a signature format. The server
+// reports the format it produced in SignatureFormatResponseHeader or in the
+// signature_format member of the sign response.
+const (
+	SignatureFormatHeader         = "Accept-Signature-Format"
+	SignatureFormatResponseHeader = "Signature-Format"
+)
+
+func validateSignatureFormat(format SignatureFormat) error {
+	switch format {
+	case "", SignatureFormatJWS, SignatureFormatPKCS7, SignatureFormatRaw:
+		return nil
+	}
+	return fmt.Errorf("%w: %q is not one of %q, %q or %q", ErrSignatureFormatUnsupported,
+		format, SignatureFormatJWS, SignatureFormatPKCS7, SignatureFormatRaw)
+}
+
+// signatureFormat resolves the format a sign call asks for, from its call
+// options or else Config.SignatureFormat, and carries it in the returned
+// context so the request is sent with SignatureFormatHeader
+func (c *Client) signatureFormat(ctx context.Context) (context.Context, SignatureFormat, error) {
+	format := callOptionsFromContext(ctx).signatureFormat
+	if format == "" {
+		format = c.config.SignatureFormat
+	}
+	if format == "" {
+		return ctx, "", nil
+	}
+	if err := validateSignatureFormat(format); err != nil {
+		return nil, "", err
+	}
+	if capabilities := c.cachedCapabilities(); capabilities != nil && len(capabilities.SignatureFormats) > 0 &&
+		!capabilities.SupportsSignatureFormat(string(format)) {
+		return nil, "", fmt.Errorf("%w: the server does not advertise %q", ErrSignatureFormatUnsupported, format)
+	}
+	return withCallOptions(ctx, []CallOption{WithSignatureFormat(format)}), format, nil
+}
+
+// signatureFormatError marks a 406 Not Acceptable answer to a sign request
+// that asked for a signature format
+func signatureFormatError(err error, format SignatureFormat) error {
+	var apiErr *APIError
+	if format != "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotAcceptable {
+		return fmt.Errorf("%w: %w", ErrSignatureFormatUnsupported, err)
+	}
+	return err
+}
+
+// checkSignatureFormat records the format the server produced and fails the
+// call when it is not the one that was asked for, which is how a server that
+// ignores SignatureFormatHeader shows up
+func checkSignatureFormat(resp *http.Response, result *SignResultAPIResponseV2, format SignatureFormat) error {
+	if result.SignatureFormat == "" {
+		result.SignatureFormat = SignatureFormat(resp.Header.Get(SignatureFormatResponseHeader))
+	}
+	if format == "" || result.SignatureFormat == format {
+		return nil
+	}
+	if result.SignatureFormat == "" {
+		return fmt.Errorf("%w: requested %q but the server did not report a signature format", ErrSignatureFormatUnsupported, format)
+	}
+	return fmt.Errorf("%w: requested %q but the server produced %q", ErrSignatureFormatUnsupported, format, result.SignatureFormat)
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_SignatureFormat(t *testing.T) {
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	tests := []struct {
+		name         string
+		configFormat SignatureFormat
+		callOpts     []CallOption
+		capabilities *Capabilities
+		status       int
+		body         string
+		header       string
+		wantSent     string
+		wantFormat   SignatureFormat
+		wantErr      error
+		wantRequest  bool
+	}{
+		{
+			name:        "server default",
+			body:        `{"algorithm":"ed25519"}`,
+			wantRequest: true,
+		},
+		{
+			name:        "server default reported in header",
+			body:        `{"algorithm":"ed25519"}`,
+			header:      "jws",
+			wantFormat:  SignatureFormatJWS,
+			wantRequest: true,
+		},
+		{
+			name:         "config format",
+			configFormat: SignatureFormatJWS,
+			body:         `{"algorithm":"ed25519","signature_format":"jws"}`,
+			wantSent:     "jws",
+			wantFormat:   SignatureFormatJWS,
+			wantRequest:  true,
+		},
+		{
+			name:         "call option overrides config",
+			configFormat: SignatureFormatJWS,
+			callOpts:     []CallOption{WithSignatureFormat(SignatureFormatPKCS7)},
+			body:         `{"algorithm":"ed25519","signature_format":"pkcs7"}`,
+			wantSent:     "pkcs7",
+			wantFormat:   SignatureFormatPKCS7,
+			wantRequest:  true,
+		},
+		{
+			name:        "format reported in header",
+			callOpts:    []CallOption{WithSignatureFormat(SignatureFormatRaw)},
+			body:        `{"algorithm":"ed25519"}`,
+			header:      "raw",
+			wantSent:    "raw",
+			wantFormat:  SignatureFormatRaw,
+			wantRequest: true,
+		},
+		{
+			name:        "not acceptable",
+			callOpts:    []CallOption{WithSignatureFormat(SignatureFormatPKCS7)},
+			status:      http.StatusNotAcceptable,
+			body:        `{"error":"UNSUPPORTED_SIGNATURE_FORMAT","message":"pkcs7 is not supported"}`,
+			wantSent:    "pkcs7",
+			wantErr:     ErrSignatureFormatUnsupported,
+			wantRequest: true,
+		},
+		{
+			name:        "server produced another format",
+			callOpts:    []CallOption{WithSignatureFormat(SignatureFormatPKCS7)},
+			body:        `{"algorithm":"ed25519","signature_format":"jws"}`,
+			wantSent:    "pkcs7",
+			wantErr:     ErrSignatureFormatUnsupported,
+			wantRequest: true,
+		},
+		{
+			name:        "server ignored the request",
+			callOpts:    []CallOption{WithSignatureFormat(SignatureFormatJWS)},
+			body:        `{"algorithm":"ed25519"}`,
+			wantSent:    "jws",
+			wantErr:     ErrSignatureFormatUnsupported,
+			wantRequest: true,
+		},
+		{
+			name:     "unknown format",
+			callOpts: []CallOption{WithSignatureFormat("xml-dsig")},
+			wantErr:  ErrSignatureFormatUnsupported,
+		},
+		{
+			name:         "not advertised by server",
+			callOpts:     []CallOption{WithSignatureFormat(SignatureFormatPKCS7)},
+			capabilities: &Capabilities{SignatureFormats: []string{"jws", "raw"}},
+			wantErr:      ErrSignatureFormatUnsupported,
+		},
+		{
+			name:         "advertised by server",
+			callOpts:     []CallOption{WithSignatureFormat(SignatureFormatJWS)},
+			capabilities: &Capabilities{SignatureFormats: []string{"JWS"}},
+			body:         `{"algorithm":"ed25519","signature_format":"jws"}`,
+			wantSent:     "jws",
+			wantFormat:   SignatureFormatJWS,
+			wantRequest:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := false
+			var sent string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requested = true
+				sent = req.Header.Get(SignatureFormatHeader)
+				status := tt.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				resp := createMockResponse(status, tt.body)
+				if tt.header != "" {
+					resp.Header.Set(SignatureFormatResponseHeader, tt.header)
+				}
+				return resp, nil
+			}, func(config *Config) { config.SignatureFormat = tt.configFormat })
+			client.capabilities = tt.capabilities
+
+			result, err := client.SignSBOM(context.Background(), "key-1", sbom, tt.callOpts...)
+			if requested != tt.wantRequest {
+				t.Errorf("request sent = %v, want %v", requested, tt.wantRequest)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("%s = %q, want %q", SignatureFormatHeader, sent, tt.wantSent)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SignSBOM() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignSBOM() error = %v", err)
+			}
+			if result.SignatureFormat != tt.wantFormat {
+				t.Errorf("SignatureFormat = %q, want %q", result.SignatureFormat, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_WithSignatureFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  SignatureFormat
+		wantErr bool
+	}{
+		{name: "jws", format: SignatureFormatJWS},
+		{name: "default", format: ""},
+		{name: "unknown", format: "xml-dsig", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithSignatureFormat(tt.format).
+				BuildClient()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && client.config.SignatureFormat != tt.format {
+				t.Errorf("SignatureFormat = %q, want %q", client.config.SignatureFormat, tt.format)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// VerifyAllSignatures verifies every signature embedded in a CycloneDX SBOM,
+// each against the key named by its keyId, and returns one result per
+// signature in document order. Both a single JSF signature and a
+// multi-signature {"signers": [...]} object are supported. A signature without
+// a keyId yields an invalid result without calling the API.
+//
+// The service rejects a signature that does not verify with an error that
+// VerifyReasonFromError explains, such as a signature mismatch or an unknown
+// key; that signature gets an invalid result carrying the Reason and the
+// others are still checked. The document is trusted if at least one signature
+// is valid; otherwise the results are returned with an error wrapping
+// ErrSignatureInvalid. A document without signatures returns an empty slice
+// and ErrNoSignatures. Any other error, such as a network failure, a 5xx
+// response or a cancelled context, stops verification, returning the results
+// gathered so far.
+func VerifyAllSignatures(ctx context.Context, client ClientInterface, sbom []byte) ([]*VerifyResultCMDResponse, error) {
+	_, doc, err := marshalSBOM(sbom)
+	if err != nil {
+		return nil, err
+	}
+	if detectSBOMFormat(doc) != "cyclonedx" {
+		return nil, fmt.Errorf("embedded signatures require a CycloneDX SBOM: %w", ErrUnsupportedFormat)
+	}
+	document := doc.(map[string]interface{})
+
+	signers := embeddedSignatures(document[cycloneDXSignatureField])
+	results := make([]*VerifyResultCMDResponse, 0, len(signers))
+	if len(signers) == 0 {
+		return results, ErrNoSignatures
+	}
+
+	anyValid := false
+	for i, signer := range signers {
+		keyID, _ := signer["keyId"].(string)
+		if keyID == "" {
+			results = append(results, &VerifyResultCMDResponse{
+				Valid:   false,
+				Message: fmt.Sprintf("signature %d does not name a key", i),
+			})
+			continue
+		}
+
+		// Verify each signature as if it were the document's only one
+		single := maps.Clone(document)
+		single[cycloneDXSignatureField] = signer
+
+		result, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: keyID, SBOM: single})
+		if err != nil {
+			reason := VerifyReasonFromError(err)
+			if reason == "" {
+				return results, fmt.Errorf("failed to verify signature %d (key %s): %w", i, keyID, err)
+			}
+			if result == nil {
+				result = &VerifyResultCMDResponse{Reason: reason, KeyID: keyID, Message: err.Error()}
+			}
+		}
+		results = append(results, result)
+		anyValid = anyValid || result.Valid
+	}
+
+	if !anyValid {
+		return results, fmt.Errorf("none of the %d signatures is valid: %w", len(results), ErrSignatureInvalid)
+	}
+	return results, nil
+}
+
+// embeddedSignatures returns the individual JSF signer objects in a CycloneDX
+// signature member
+func embeddedSignatures(signature interface{}) []map[string]interface{} {
+	object, ok := signature.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	list, ok := object["signers"].([]interface{})
+	if !ok {
+		if _, hasValue := object["value"]; hasValue {
+			return []map[string]interface{}{object}
+		}
+		return nil
+	}
+
+	signers := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if signer, ok := item.(map[string]interface{}); ok {
+			signers = append(signers, signer)
+		}
+	}
+	return signers
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// Verification against the API is tested with securesbomtest.NewServer in
+// TestServer_VerifyAllSignatures
+func TestVerifyAllSignatures(t *testing.T) {
+	tests := []struct {
+		name        string
+		sbom        []byte
+		expectErr   error
+		expectValid []bool
+	}{
+		{
+			name:        "signature without a key",
+			sbom:        []byte(`{"bomFormat": "CycloneDX", "signature": {"signers": [{"algorithm": "ES256", "value": "YW5vbg=="}]}}`),
+			expectErr:   ErrSignatureInvalid,
+			expectValid: []bool{false},
+		},
+		{
+			name:        "unsigned",
+			sbom:        []byte(`{"bomFormat": "CycloneDX"}`),
+			expectErr:   ErrNoSignatures,
+			expectValid: []bool{},
+		},
+		{
+			name:      "SPDX",
+			sbom:      []byte(`{"spdxVersion": "SPDX-2.3"}`),
+			expectErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				t.Errorf("unexpected request to %s", req.URL.Path)
+				return createMockResponse(http.StatusInternalServerError, nil), nil
+			}, nil)
+
+			results, err := VerifyAllSignatures(context.Background(), client, tt.sbom)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected %v, got %v", tt.expectErr, err)
+			}
+
+			if tt.expectValid == nil {
+				if results != nil {
+					t.Errorf("expected no results, got %v", results)
+				}
+				return
+			}
+			if len(results) != len(tt.expectValid) {
+				t.Fatalf("expected %d results, got %d", len(tt.expectValid), len(results))
+			}
+			for i, result := range results {
+				if result.Valid != tt.expectValid[i] {
+					t.Errorf("result %d: expected valid %v, got %v", i, tt.expectValid[i], result.Valid)
+				}
+			}
+		})
+	}
+}
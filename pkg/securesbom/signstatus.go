@@ -0,0 +1,60 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// IsSigned reports whether the API has already signed sbom with keyID, so
+// pipelines that re-run can skip SBOMs they have signed before. The lookup is
+// a HEAD request by the SBOM's digest (see SBOM.Digest), so neither the SBOM
+// nor a signature is sent. Formatting differences don't matter, but any change
+// to the content does, and a key the API doesn't know reports false.
+func (c *Client) IsSigned(ctx context.Context, keyID string, sbom []byte) (_ bool, err error) {
+	ctx, span := c.startSpan(ctx, "IsSigned", attribute.String("sbom.key_id", keyID))
+	defer func() { span.end(err) }()
+
+	if keyID == "" {
+		return false, fmt.Errorf("keyID is required")
+	}
+	if len(sbom) == 0 {
+		return false, fmt.Errorf("sbom is required")
+	}
+	digest, err := sbomDigest(sbom, crypto.SHA256)
+	if err != nil {
+		return false, err
+	}
+
+	endpoint := API_VERSION_V2 + API_ENDPOINT_SBOM + "/signatures/" + digest + "?key_id=" + url.QueryEscape(keyID)
+	resp, err := c.doRequest(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check signing status: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return true, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_IsSigned(t *testing.T) {
+	sbom := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5"}`)
+	digest, err := sbomDigest(sbom, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		keyID        string
+		sbom         []byte
+		status       int
+		expectSigned bool
+		expectError  bool
+		expectCalled bool
+	}{
+		{name: "signed", keyID: "key 1", sbom: sbom, status: http.StatusOK, expectSigned: true, expectCalled: true},
+		{name: "not signed", keyID: "key 1", sbom: sbom, status: http.StatusNotFound, expectCalled: true},
+		{name: "server error", keyID: "key 1", sbom: sbom, status: http.StatusForbidden, expectError: true, expectCalled: true},
+		{name: "empty key ID", sbom: sbom, expectError: true},
+		{name: "empty SBOM", keyID: "key 1", expectError: true},
+		{name: "malformed SBOM", keyID: "key 1", sbom: []byte(`{"bomFormat": `), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				called = true
+				if req.Method != http.MethodHead {
+					t.Errorf("method = %s, want HEAD", req.Method)
+				}
+				if want := "https://api.example.com/api/v2/sbom/signatures/" + digest + "?key_id=key+1"; req.URL.String() != want {
+					t.Errorf("URL = %s, want %s", req.URL, want)
+				}
+				return &http.Response{StatusCode: tt.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}, nil)
+
+			signed, err := client.IsSigned(context.Background(), tt.keyID, tt.sbom)
+			if called != tt.expectCalled {
+				t.Errorf("request sent = %v, want %v", called, tt.expectCalled)
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if signed != tt.expectSigned {
+				t.Errorf("IsSigned() = %v, want %v", signed, tt.expectSigned)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// streamSignResponse reads a sign response from r, copying the signed_sbom
+// value to w as it arrives and decoding the remaining fields into result. Only
+// the small metadata fields are held in memory.
+func streamSignResponse(r io.Reader, w io.Writer, result *SignResultAPIResponseV2) error {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+
+	if err := expectJSONByte(br, '{'); err != nil {
+		return err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	wroteSBOM := false
+	for {
+		c, err := peekJSONByte(br)
+		if err != nil {
+			return err
+		}
+		if c == '}' {
+			break
+		}
+		if len(fields) > 0 || wroteSBOM {
+			if err := expectJSONByte(br, ','); err != nil {
+				return err
+			}
+		}
+
+		var rawKey bytes.Buffer
+		if err := skipJSONSpace(br); err != nil {
+			return err
+		}
+		if err := copyJSONValue(br, &rawKey); err != nil {
+			return err
+		}
+		var key string
+		if err := json.Unmarshal(rawKey.Bytes(), &key); err != nil {
+			return fmt.Errorf("invalid sign response: %w", err)
+		}
+		if err := expectJSONByte(br, ':'); err != nil {
+			return err
+		}
+		if err := skipJSONSpace(br); err != nil {
+			return err
+		}
+
+		if key == "signed_sbom" {
+			if err := copyJSONValue(br, bw); err != nil {
+				return err
+			}
+			wroteSBOM = true
+			continue
+		}
+		var value bytes.Buffer
+		if err := copyJSONValue(br, &value); err != nil {
+			return err
+		}
+		fields[key] = value.Bytes()
+	}
+
+	if !wroteSBOM {
+		return fmt.Errorf("sign response did not include a signed SBOM")
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write signed SBOM: %w", err)
+	}
+
+	metadata, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("invalid sign response: %w", err)
+	}
+	if err := json.Unmarshal(metadata, result); err != nil {
+		return fmt.Errorf("invalid sign response: %w", err)
+	}
+	return nil
+}
+
+// copyJSONValue copies one JSON value from br to out byte by byte. It tracks
+// only nesting and string state, leaving full validation to whoever reads out.
+func copyJSONValue(br *bufio.Reader, out io.ByteWriter) error {
+	depth := 0
+	inString, escaped := false, false
+	for n := 0; ; n++ {
+		c, err := br.ReadByte()
+		if err != nil {
+			return unexpectedJSONEnd(err)
+		}
+
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']' || c == ',' || isJSONSpace(c):
+			if depth == 0 {
+				// The end of a number or literal, which belongs to the parent
+				if n == 0 {
+					return fmt.Errorf("invalid sign response: unexpected %q", c)
+				}
+				return br.UnreadByte()
+			}
+			if c == '}' || c == ']' {
+				depth--
+			}
+		}
+
+		if err := out.WriteByte(c); err != nil {
+			return fmt.Errorf("failed to write signed SBOM: %w", err)
+		}
+		if depth == 0 && !inString && (c == '"' || c == '}' || c == ']') {
+			return nil
+		}
+	}
+}
+
+// expectJSONByte skips whitespace and consumes want
+func expectJSONByte(br *bufio.Reader, want byte) error {
+	c, err := peekJSONByte(br)
+	if err != nil {
+		return err
+	}
+	if c != want {
+		return fmt.Errorf("invalid sign response: expected %q, got %q", want, c)
+	}
+	_, err = br.ReadByte()
+	return err
+}
+
+// peekJSONByte skips whitespace and returns the next byte without consuming it
+func peekJSONByte(br *bufio.Reader) (byte, error) {
+	if err := skipJSONSpace(br); err != nil {
+		return 0, err
+	}
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, unexpectedJSONEnd(err)
+	}
+	return c, br.UnreadByte()
+}
+
+func skipJSONSpace(br *bufio.Reader) error {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return unexpectedJSONEnd(err)
+		}
+		if !isJSONSpace(c) {
+			return br.UnreadByte()
+		}
+	}
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// unexpectedJSONEnd reports a body that ends mid-document as incomplete
+func unexpectedJSONEnd(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, ErrIncompleteResponse) {
+		return fmt.Errorf("%w: %w", ErrIncompleteResponse, err)
+	}
+	return err
+}
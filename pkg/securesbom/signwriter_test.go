@@ -0,0 +1,240 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStreamSignResponse(t *testing.T) {
+	const signed = `{"bomFormat":"CycloneDX","metadata":{"component":{"name":"a \"quoted\" }{ name","version":1.5e3}},"components":[],"signature":{"algorithm":"ES256","value":"c2ln"}}`
+
+	tests := []struct {
+		name          string
+		body          string
+		expectOutput  string
+		expectAlg     string
+		expectSig     string
+		expectError   string
+		expectPartial bool
+	}{
+		{
+			name:         "metadata after signed SBOM",
+			body:         `{"signed_sbom":` + signed + `,"algorithm":"ES256","signature_b64":"c2ln"}`,
+			expectOutput: signed,
+			expectAlg:    "ES256",
+			expectSig:    "c2ln",
+		},
+		{
+			name:         "metadata before signed SBOM with whitespace",
+			body:         "{\n  \"algorithm\": \"ed25519\",\n  \"detached\": false,\n  \"signed_sbom\": " + signed + "\n}\n",
+			expectOutput: signed,
+			expectAlg:    "ed25519",
+		},
+		{
+			name:        "no signed SBOM",
+			body:        `{"algorithm":"ES256","detached":true,"signature_b64":"c2ln"}`,
+			expectError: "did not include a signed SBOM",
+		},
+		{
+			name:        "not an object",
+			body:        `["signed_sbom"]`,
+			expectError: "invalid sign response",
+		},
+		{
+			name:          "cut short in signed SBOM",
+			body:          `{"algorithm":"ES256","signed_sbom":` + signed[:40],
+			expectPartial: true,
+		},
+		{
+			name:          "cut short after signed SBOM",
+			body:          `{"signed_sbom":` + signed + `,"algorithm":"ES`,
+			expectPartial: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var result SignResultAPIResponseV2
+			err := streamSignResponse(strings.NewReader(tt.body), &out, &result)
+
+			if tt.expectPartial {
+				if !errors.Is(err, ErrIncompleteResponse) {
+					t.Fatalf("error = %v, want ErrIncompleteResponse", err)
+				}
+				return
+			}
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != tt.expectOutput {
+				t.Errorf("output = %s, want %s", out.String(), tt.expectOutput)
+			}
+			if result.Algorithm != tt.expectAlg {
+				t.Errorf("Algorithm = %q, want %q", result.Algorithm, tt.expectAlg)
+			}
+			if result.SignatureB64 != tt.expectSig {
+				t.Errorf("SignatureB64 = %q, want %q", result.SignatureB64, tt.expectSig)
+			}
+			if result.SignedSBOM != nil {
+				t.Errorf("SignedSBOM = %s, want it left empty", result.SignedSBOM)
+			}
+		})
+	}
+}
+
+func TestClient_SignSBOMToWriter(t *testing.T) {
+	sbomJSON := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`)
+
+	tests := []struct {
+		name        string
+		keyID       string
+		sbom        []byte
+		writer      io.Writer
+		expectError string
+	}{
+		{name: "streams signed SBOM", keyID: "key-123", sbom: sbomJSON, writer: &bytes.Buffer{}},
+		{name: "empty key ID", sbom: sbomJSON, writer: &bytes.Buffer{}, expectError: "keyID is required"},
+		{name: "empty SBOM", keyID: "key-123", writer: &bytes.Buffer{}, expectError: "sbom is required"},
+		{name: "nil writer", keyID: "key-123", sbom: sbomJSON, expectError: "writer is required"},
+		{name: "invalid JSON", keyID: "key-123", sbom: []byte(`{"bomFormat":`), writer: &bytes.Buffer{}, expectError: "not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				if req.ContentLength != int64(len(`{"key_id":"key-123","sbom":}`)+len(sbomJSON)) {
+					t.Errorf("unexpected content length %d", req.ContentLength)
+				}
+				var body struct {
+					KeyID string          `json:"key_id"`
+					SBOM  json.RawMessage `json:"sbom"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatalf("request body is not valid JSON: %v", err)
+				}
+				if string(body.SBOM) != string(sbomJSON) {
+					t.Errorf("expected SBOM %s, got %s", sbomJSON, body.SBOM)
+				}
+				signed := `{"bomFormat":"CycloneDX","specVersion":"1.5","signature":{"value":"c2ln"}}`
+				resp := createMockResponse(http.StatusOK, `{"signed_sbom":`+signed+`,"algorithm":"ES256"}`)
+				resp.Header.Set(RequestIDHeader, "req-1")
+				return resp, nil
+			}, nil)
+
+			result, err := client.SignSBOMToWriter(context.Background(), tt.keyID, tt.sbom, tt.writer)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := tt.writer.(*bytes.Buffer).String(); !strings.Contains(got, `"signature":{"value":"c2ln"}`) {
+				t.Errorf("writer got %s, want the signed SBOM", got)
+			}
+			if result.Algorithm != "ES256" || result.RequestID != "req-1" {
+				t.Errorf("result = %+v, want algorithm ES256 and request ID req-1", result)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_SignSBOMToWriter(t *testing.T) {
+	// Large enough that part of it reaches the writer before the body ends
+	signed := `{"bomFormat":"CycloneDX","description":"` + strings.Repeat("x", 16*1024) + `","signature":{"value":"c2ln"}}`
+
+	tests := []struct {
+		name           string
+		responses      []func() (*http.Response, error)
+		expectError    bool
+		expectAttempts int
+		expectOutput   string
+	}{
+		{
+			name: "retries before anything is written",
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) {
+					return createMockResponse(http.StatusServiceUnavailable, `{"error":"unavailable"}`), nil
+				},
+				func() (*http.Response, error) {
+					return createMockResponse(http.StatusOK, `{"signed_sbom":`+signed+`}`), nil
+				},
+			},
+			expectAttempts: 2,
+			expectOutput:   signed,
+		},
+		{
+			name: "does not retry once output was written",
+			responses: []func() (*http.Response, error){
+				func() (*http.Response, error) {
+					return createMockResponse(http.StatusOK, `{"signed_sbom":`+signed), nil
+				},
+				func() (*http.Response, error) {
+					return createMockResponse(http.StatusOK, `{"signed_sbom":`+signed+`}`), nil
+				},
+			},
+			expectError:    true,
+			expectAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return tt.responses[attempts-1]()
+			}, func(config *Config) { config.clock = newFakeClock() })
+			retrying := WithRetryingClient(client, RetryConfig{MaxAttempts: 3, Multiplier: 1})
+
+			var out bytes.Buffer
+			_, err := retrying.SignSBOMToWriter(context.Background(), "key-123", []byte(`{"bomFormat":"CycloneDX"}`), &out)
+			if tt.expectError {
+				if !errors.Is(err, ErrIncompleteResponse) {
+					t.Errorf("error = %v, want ErrIncompleteResponse", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if attempts != tt.expectAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.expectAttempts)
+			}
+			if tt.expectOutput != "" && out.String() != tt.expectOutput {
+				t.Errorf("output is %d bytes, want the %d byte signed SBOM", out.Len(), len(tt.expectOutput))
+			}
+		})
+	}
+}
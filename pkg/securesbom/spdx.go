@@ -0,0 +1,269 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SPDX signatures are carried in a document-level annotation whose comment
+// starts with spdxSignaturePrefix, since SPDX has no signature field
+const (
+	spdxSignaturePrefix  = "secure-sbom-signature: "
+	spdxAnnotator        = "Tool: secure-sbom-sdk-go-" + Version
+	spdxDocumentRef      = "SPDXRef-DOCUMENT"
+	spdxAnnotationsField = "annotations"
+)
+
+// ContentTypeSPDXTagValue is the media type SPDX tag-value documents are sent
+// to the API with
+const ContentTypeSPDXTagValue = "text/spdx"
+
+// ExtractSPDXSignature returns the base64 signature embedded in an SPDX
+// document by EmbedSPDXSignature. Both JSON and tag-value documents are
+// accepted; found is false if the document carries no signature. The signature
+// covers the document without its annotation, so verify the output of
+// StripSPDXSignature, or use VerifySBOMFromFile which does both.
+func ExtractSPDXSignature(sbom []byte) (signature string, found bool, err error) {
+	if isSPDXTagValue(sbom) {
+		_, _, signature, found = findTagValueSignature(sbom)
+		return signature, found, nil
+	}
+
+	doc, err := parseSPDXJSON(sbom)
+	if err != nil {
+		return "", false, err
+	}
+	signature, found = spdxSignature(doc)
+	return signature, found, nil
+}
+
+// EmbedSPDXSignature returns sbom with sig, a base64 signature such as a
+// detached signature from SignSBOMFromFile, stored in a document-level
+// annotation. Any signature embedded earlier is replaced. JSON documents are
+// re-encoded with two-space indentation; tag-value documents have the
+// annotation appended.
+func EmbedSPDXSignature(sbom []byte, sig string) ([]byte, error) {
+	sig = strings.TrimSpace(sig)
+	if sig == "" {
+		return nil, fmt.Errorf("signature is required")
+	}
+
+	annotationDate := time.Now().UTC().Format(time.RFC3339)
+	if isSPDXTagValue(sbom) {
+		unsigned := stripTagValueSignature(sbom)
+		if len(unsigned) > 0 && !bytes.HasSuffix(unsigned, []byte("\n")) {
+			unsigned = append(unsigned, '\n')
+		}
+		annotation := fmt.Sprintf("\nAnnotator: %s\nAnnotationDate: %s\nAnnotationType: OTHER\nSPDXREF: %s\nAnnotationComment: <text>%s%s</text>\n",
+			spdxAnnotator, annotationDate, spdxDocumentRef, spdxSignaturePrefix, sig)
+		return append(unsigned, annotation...), nil
+	}
+
+	doc, err := parseSPDXJSON(sbom)
+	if err != nil {
+		return nil, err
+	}
+	doc = withoutSPDXSignature(doc)
+	annotations, _ := doc[spdxAnnotationsField].([]interface{})
+	doc[spdxAnnotationsField] = append(slices.Clone(annotations), map[string]interface{}{
+		"annotator":      spdxAnnotator,
+		"annotationDate": annotationDate,
+		"annotationType": "OTHER",
+		"comment":        spdxSignaturePrefix + sig,
+	})
+	return encodeSPDXJSON(doc)
+}
+
+// StripSPDXSignature returns sbom without the annotation added by
+// EmbedSPDXSignature, which is the document the signature covers. Documents
+// without an embedded signature are returned unchanged.
+func StripSPDXSignature(sbom []byte) ([]byte, error) {
+	if isSPDXTagValue(sbom) {
+		return stripTagValueSignature(sbom), nil
+	}
+
+	doc, err := parseSPDXJSON(sbom)
+	if err != nil {
+		return nil, err
+	}
+	if _, found := spdxSignature(doc); !found {
+		return sbom, nil
+	}
+	return encodeSPDXJSON(withoutSPDXSignature(doc))
+}
+
+// parseSPDXJSON decodes a JSON SPDX document
+func parseSPDXJSON(sbom []byte) (map[string]interface{}, error) {
+	if err := checkNotXML(sbom); err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(sbom, &doc); err != nil {
+		return nil, fmt.Errorf("sbom is neither tag-value nor valid JSON: %w", err)
+	}
+	if detectSBOMFormat(doc) != "spdx" {
+		return nil, fmt.Errorf("not an SPDX document: %w", ErrUnsupportedFormat)
+	}
+	return doc.(map[string]interface{}), nil
+}
+
+func encodeSPDXJSON(doc map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal SPDX document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// spdxSignature finds the signature annotation in a parsed JSON SPDX document
+func spdxSignature(doc map[string]interface{}) (string, bool) {
+	annotations, _ := doc[spdxAnnotationsField].([]interface{})
+	for _, a := range annotations {
+		if signature, ok := signatureAnnotation(a); ok {
+			return signature, true
+		}
+	}
+	return "", false
+}
+
+// withoutSPDXSignature returns a shallow copy of doc without the signature
+// annotation, dropping the annotations member if nothing else is left in it
+func withoutSPDXSignature(doc map[string]interface{}) map[string]interface{} {
+	unsigned := maps.Clone(doc)
+	annotations, _ := doc[spdxAnnotationsField].([]interface{})
+	kept := slices.DeleteFunc(slices.Clone(annotations), func(a interface{}) bool {
+		_, ok := signatureAnnotation(a)
+		return ok
+	})
+	if len(kept) == len(annotations) {
+		return unsigned
+	}
+	if len(kept) == 0 {
+		delete(unsigned, spdxAnnotationsField)
+	} else {
+		unsigned[spdxAnnotationsField] = kept
+	}
+	return unsigned
+}
+
+func signatureAnnotation(a interface{}) (string, bool) {
+	annotation, _ := a.(map[string]interface{})
+	comment, _ := annotation["comment"].(string)
+	if !strings.HasPrefix(comment, spdxSignaturePrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(comment, spdxSignaturePrefix)), true
+}
+
+// isSPDXTagValue reports whether sbom looks like an SPDX tag-value document
+func isSPDXTagValue(sbom []byte) bool {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(sbom, []byte("\xef\xbb\xbf")), " \t\r\n")
+	return bytes.HasPrefix(trimmed, []byte("SPDXVersion:")) || bytes.HasPrefix(trimmed, []byte("#"))
+}
+
+// tagValuePayload returns the text of sbom if it is an SPDX tag-value
+// document, given as an *SBOM or as bytes, which is sent as text/spdx rather
+// than in a JSON request
+func tagValuePayload(sbom interface{}) ([]byte, bool) {
+	switch v := sbom.(type) {
+	case *SBOM:
+		if v != nil && v.tagValue {
+			return v.raw, true
+		}
+	case []byte:
+		if isSPDXTagValue(v) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// parseTagValueMetadata reads the document creation section of a tag-value
+// document, whose tags mirror the JSON fields parseSBOMMetadata reads
+func parseTagValueMetadata(sbom []byte) *SBOMMetadata {
+	metadata := &SBOMMetadata{Format: "spdx"}
+	for _, line := range strings.Split(string(sbom), "\n") {
+		tag, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch tag {
+		case "SPDXVersion":
+			metadata.SpecVersion = value
+		case "DocumentName":
+			metadata.Name = value
+		case "DocumentNamespace":
+			metadata.SerialNumber = value
+		case "Created":
+			metadata.Created = parseMetadataTime(value)
+		case "PackageName":
+			metadata.ComponentCount++
+		}
+	}
+	return metadata
+}
+
+// findTagValueSignature locates the signature annotation in a tag-value
+// document. start and end are the byte offsets of the annotation block,
+// including the blank line EmbedSPDXSignature puts before it.
+func findTagValueSignature(sbom []byte) (start, end int, signature string, found bool) {
+	annotator := -1
+	for offset := 0; offset < len(sbom); {
+		lineEnd := bytes.IndexByte(sbom[offset:], '\n')
+		next := len(sbom)
+		if lineEnd >= 0 {
+			next = offset + lineEnd + 1
+		}
+		line := strings.TrimSpace(string(sbom[offset:next]))
+
+		switch {
+		case strings.HasPrefix(line, "Annotator:"):
+			annotator = offset
+		case strings.HasPrefix(line, "AnnotationComment:"):
+			comment := strings.TrimSpace(strings.TrimPrefix(line, "AnnotationComment:"))
+			comment = strings.TrimSuffix(strings.TrimPrefix(comment, "<text>"), "</text>")
+			if strings.HasPrefix(comment, spdxSignaturePrefix) && annotator >= 0 {
+				start = annotator
+				if start >= 2 && string(sbom[start-2:start]) == "\n\n" {
+					start--
+				}
+				return start, next, strings.TrimSpace(strings.TrimPrefix(comment, spdxSignaturePrefix)), true
+			}
+		}
+		offset = next
+	}
+	return 0, 0, "", false
+}
+
+func stripTagValueSignature(sbom []byte) []byte {
+	start, end, _, found := findTagValueSignature(sbom)
+	if !found {
+		return sbom
+	}
+	return append(slices.Clone(sbom[:start]), sbom[end:]...)
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSPDXTagValue = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: app
+`
+
+func TestSPDXSignature_RoundTrip(t *testing.T) {
+	annotated := `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","annotations":[{"annotator":"Person: Jane","annotationType":"REVIEW","comment":"looks good"}]}`
+
+	tests := []struct {
+		name string
+		sbom string
+	}{
+		{name: "JSON", sbom: testSPDX},
+		{name: "JSON with existing annotations", sbom: annotated},
+		{name: "tag-value", sbom: testSPDXTagValue},
+		{name: "tag-value without trailing newline", sbom: strings.TrimSuffix(testSPDXTagValue, "\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, found, err := ExtractSPDXSignature([]byte(tt.sbom)); err != nil || found {
+				t.Fatalf("ExtractSPDXSignature() on unsigned document found = %v, err = %v", found, err)
+			}
+
+			signed, err := EmbedSPDXSignature([]byte(tt.sbom), "c2lnLTE=")
+			if err != nil {
+				t.Fatalf("EmbedSPDXSignature() error = %v", err)
+			}
+			// Embedding again replaces the signature rather than adding another
+			signed, err = EmbedSPDXSignature(signed, "c2lnLTI=")
+			if err != nil {
+				t.Fatalf("EmbedSPDXSignature() error = %v", err)
+			}
+			if n := bytes.Count(signed, []byte(spdxSignaturePrefix)); n != 1 {
+				t.Errorf("expected 1 embedded signature, got %d in:\n%s", n, signed)
+			}
+
+			signature, found, err := ExtractSPDXSignature(signed)
+			if err != nil || !found || signature != "c2lnLTI=" {
+				t.Fatalf("ExtractSPDXSignature() = %q, %v, %v; want c2lnLTI=", signature, found, err)
+			}
+
+			unsigned, err := StripSPDXSignature(signed)
+			if err != nil {
+				t.Fatalf("StripSPDXSignature() error = %v", err)
+			}
+			if isSPDXTagValue([]byte(tt.sbom)) {
+				if strings.TrimSpace(string(unsigned)) != strings.TrimSpace(tt.sbom) {
+					t.Errorf("StripSPDXSignature() = %q, want %q", unsigned, tt.sbom)
+				}
+				return
+			}
+			want, _ := canonicalJSON([]byte(tt.sbom))
+			got, err := canonicalJSON(unsigned)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Errorf("StripSPDXSignature() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestEmbedSPDXSignature_JSONAnnotation(t *testing.T) {
+	signed, err := EmbedSPDXSignature([]byte(testSPDX), "c2ln")
+	if err != nil {
+		t.Fatalf("EmbedSPDXSignature() error = %v", err)
+	}
+
+	var doc struct {
+		Annotations []map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(signed, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Annotations) != 1 {
+		t.Fatalf("expected 1 annotation, got %d", len(doc.Annotations))
+	}
+	annotation := doc.Annotations[0]
+	if annotation["annotationType"] != "OTHER" || annotation["comment"] != "secure-sbom-signature: c2ln" ||
+		!strings.HasPrefix(annotation["annotator"], "Tool: ") || annotation["annotationDate"] == "" {
+		t.Errorf("unexpected annotation %v", annotation)
+	}
+}
+
+func TestSPDXSignature_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		sbom        string
+		signature   string
+		expectError error
+	}{
+		{name: "CycloneDX", sbom: testCycloneDX, signature: "c2ln", expectError: ErrUnsupportedFormat},
+		{name: "XML", sbom: `<?xml version="1.0"?><bom/>`, signature: "c2ln", expectError: ErrUnsupportedFormat},
+		{name: "not JSON", sbom: `{"spdxVersion":`, signature: "c2ln"},
+		{name: "empty signature", sbom: testSPDX},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EmbedSPDXSignature([]byte(tt.sbom), tt.signature)
+			if err == nil {
+				t.Fatal("EmbedSPDXSignature() expected an error")
+			}
+			if tt.expectError != nil && !errors.Is(err, tt.expectError) {
+				t.Errorf("EmbedSPDXSignature() error = %v, want %v", err, tt.expectError)
+			}
+			if tt.signature == "" {
+				return
+			}
+			if _, _, err := ExtractSPDXSignature([]byte(tt.sbom)); err == nil {
+				t.Error("ExtractSPDXSignature() expected an error")
+			}
+		})
+	}
+}
+
+func TestSBOM_SPDXTagValue(t *testing.T) {
+	sbom, err := LoadSBOMFromReader(strings.NewReader(testSPDXTagValue))
+	if err != nil {
+		t.Fatalf("LoadSBOMFromReader() error = %v", err)
+	}
+
+	if got := sbom.Format(); got != "spdx" {
+		t.Errorf("Format() = %q, want spdx", got)
+	}
+	if got := sbom.ContentType(); got != ContentTypeSPDXTagValue {
+		t.Errorf("ContentType() = %q, want %q", got, ContentTypeSPDXTagValue)
+	}
+	if got, _ := sbom.Data().(string); got != testSPDXTagValue {
+		t.Errorf("Data() = %v, want the tag-value text", sbom.Data())
+	}
+	if got := sbom.String(); got != testSPDXTagValue {
+		t.Errorf("String() = %q, want the tag-value text", got)
+	}
+	var buf bytes.Buffer
+	if err := sbom.WriteToWriter(&buf); err != nil || buf.String() != testSPDXTagValue {
+		t.Errorf("WriteToWriter() = %q, %v; want the tag-value text", buf.String(), err)
+	}
+	if _, err := sbom.Canonical(); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Canonical() error = %v, want ErrUnsupportedFormat", err)
+	}
+	sum := sha256.Sum256([]byte(testSPDXTagValue))
+	if digest, err := sbom.Digest(0); err != nil || digest != hex.EncodeToString(sum[:]) {
+		t.Errorf("Digest() = %q, %v; want the SHA-256 of the document", digest, err)
+	}
+
+	jsonSBOM, err := LoadSBOMFromReader(strings.NewReader(testSPDX))
+	if err != nil {
+		t.Fatalf("LoadSBOMFromReader() error = %v", err)
+	}
+	if got := jsonSBOM.ContentType(); got != "application/json" {
+		t.Errorf("ContentType() of JSON = %q, want application/json", got)
+	}
+}
+
+func TestClient_SPDXSerializations(t *testing.T) {
+	tagValueSBOM, err := LoadSBOMFromReader(strings.NewReader(testSPDXTagValue))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonSBOM, err := LoadSBOMFromReader(strings.NewReader(testSPDX))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		sbom            interface{}
+		wantContentType string
+		// wantBody is the exact request body of a tag-value request; JSON
+		// requests carry the document in their "sbom" member
+		wantBody string
+	}{
+		{name: "JSON", sbom: jsonSBOM, wantContentType: "application/json"},
+		{name: "tag-value", sbom: tagValueSBOM, wantContentType: ContentTypeSPDXTagValue, wantBody: testSPDXTagValue},
+		{name: "tag-value bytes", sbom: []byte(testSPDXTagValue), wantContentType: ContentTypeSPDXTagValue, wantBody: testSPDXTagValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*http.Request
+			var bodies []string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				requests = append(requests, req)
+				bodies = append(bodies, string(body))
+				if strings.HasSuffix(req.URL.Path, "/sign") {
+					return createMockResponse(http.StatusOK, `{"algorithm":"ed25519","detached":true,"signature_b64":"c2ln"}`), nil
+				}
+				return createMockResponse(http.StatusOK, `{"code":"VALID","message":"signature is valid"}`), nil
+			}, nil)
+
+			signed, err := client.SignSBOMWithOptions(context.Background(), "key-1", tt.sbom, SignOptions{Detached: true})
+			if err != nil {
+				t.Fatalf("SignSBOMWithOptions() error = %v", err)
+			}
+			if signed.SBOMDigest == "" {
+				t.Error("expected SBOMDigest to be set")
+			}
+			verified, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-1", SBOM: tt.sbom, SignatureB64: "c2ln"})
+			if err != nil {
+				t.Fatalf("VerifySBOM() error = %v", err)
+			}
+			if verified.SBOMDigest != signed.SBOMDigest {
+				t.Errorf("verify SBOMDigest = %q, sign SBOMDigest = %q", verified.SBOMDigest, signed.SBOMDigest)
+			}
+			if verified.SBOMMetadata == nil || verified.SBOMMetadata.Format != "spdx" || verified.SBOMMetadata.Name != "app" {
+				t.Errorf("Metadata = %+v, want SPDX document app", verified.SBOMMetadata)
+			}
+
+			if len(requests) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(requests))
+			}
+			for i, req := range requests {
+				if got := req.Header.Get("Content-Type"); got != tt.wantContentType {
+					t.Errorf("%s Content-Type = %q, want %q", req.URL.Path, got, tt.wantContentType)
+				}
+				if tt.wantBody == "" {
+					var envelope map[string]interface{}
+					if err := json.Unmarshal([]byte(bodies[i]), &envelope); err != nil || envelope["sbom"] == nil {
+						t.Errorf("%s body = %s, want a JSON request with the SBOM", req.URL.Path, bodies[i])
+					}
+					continue
+				}
+				if bodies[i] != tt.wantBody {
+					t.Errorf("%s body = %q, want the tag-value document", req.URL.Path, bodies[i])
+				}
+				if got := req.URL.Query().Get("key_id"); got != "key-1" {
+					t.Errorf("%s key_id = %q, want key-1", req.URL.Path, got)
+				}
+			}
+			if tt.wantBody != "" {
+				if got := requests[0].URL.Query().Get("detached"); got != "true" {
+					t.Errorf("sign detached = %q, want true", got)
+				}
+				if got := requests[1].URL.Query().Get("signature_b64"); got != "c2ln" {
+					t.Errorf("verify signature_b64 = %q, want c2ln", got)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_VerifySBOMFromFile_TagValue(t *testing.T) {
+	embedded, err := EmbedSPDXSignature([]byte(testSPDXTagValue), "ZW1iZWRkZWQ=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := writeTestFiles(t, map[string]string{"bom.spdx": string(embedded)})
+
+	var req *http.Request
+	var body []byte
+	client := newTestClient(t, func(r *http.Request) (*http.Response, error) {
+		req = r
+		body, _ = io.ReadAll(r.Body)
+		return createMockResponse(http.StatusOK, `{"code":"VALID","message":"signature is valid"}`), nil
+	}, nil)
+
+	result, err := client.VerifySBOMFromFile(context.Background(), "key-1", filepath.Join(dir, "bom.spdx"))
+	if err != nil {
+		t.Fatalf("VerifySBOMFromFile() error = %v", err)
+	}
+	if !result.Valid {
+		t.Error("expected a valid result")
+	}
+	if got := req.Header.Get("Content-Type"); got != ContentTypeSPDXTagValue {
+		t.Errorf("Content-Type = %q, want %q", got, ContentTypeSPDXTagValue)
+	}
+	if got := req.URL.Query().Get("signature_b64"); got != "ZW1iZWRkZWQ=" {
+		t.Errorf("signature_b64 = %q, want the embedded signature", got)
+	}
+	// The signature covers the document without its annotation
+	if string(body) != testSPDXTagValue {
+		t.Errorf("body = %q, want the document without its signature annotation", body)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// subsetDroppedFields describe the whole SBOM rather than the components in
+// it, so they are left out of a subset
+var subsetDroppedFields = []string{cycloneDXSignatureField, "services", "compositions", "vulnerabilities", "annotations", "formulation"}
+
+// SubsetSignResult is the outcome of SignSBOMSubset
+type SubsetSignResult struct {
+	// Signed is the signed sub-SBOM
+	Signed *SignResultAPIResponseV2
+	// IncludedRefs lists the bom-ref of every component in the sub-SBOM: the
+	// requested ones, their nested components and their transitive
+	// dependencies, in document order
+	IncludedRefs []string
+}
+
+// SignSBOMSubset signs only part of a large CycloneDX SBOM, such as the
+// subtree of one service in a monorepo SBOM. It extracts the components whose
+// bom-ref is in componentRefs, together with their nested components and
+// transitive dependencies, into a sub-SBOM and signs that.
+//
+// The sub-SBOM keeps the original metadata and nesting; a component nested in
+// one that is left out moves up to the nearest included level. Dependencies
+// are limited to the included components. The embedded signature and
+// document-wide sections (services, compositions, vulnerabilities,
+// annotations and formulation) are dropped, and the serial number is replaced
+// with one derived from the original and the requested refs, since the
+// sub-SBOM is a different BOM. A ref that no component has fails with
+// ErrComponentNotFound before anything is signed.
+func SignSBOMSubset(ctx context.Context, client ClientInterface, keyID string, sbom []byte, componentRefs []string) (*SubsetSignResult, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("keyID is required")
+	}
+	subset, included, err := extractSBOMSubset(sbom, componentRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := client.SignSBOM(ctx, keyID, subset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SBOM subset: %w", err)
+	}
+	return &SubsetSignResult{Signed: signed, IncludedRefs: included}, nil
+}
+
+// extractSBOMSubset returns the sub-SBOM described by SignSBOMSubset and the
+// bom-refs it includes
+func extractSBOMSubset(sbom []byte, componentRefs []string) (map[string]interface{}, []string, error) {
+	if len(componentRefs) == 0 {
+		return nil, nil, fmt.Errorf("at least one component ref is required")
+	}
+	_, parsed, err := marshalSBOM(sbom)
+	if err != nil {
+		return nil, nil, err
+	}
+	if detectSBOMFormat(parsed) != "cyclonedx" {
+		return nil, nil, fmt.Errorf("signing a subset requires a CycloneDX SBOM: %w", ErrUnsupportedFormat)
+	}
+	doc := parsed.(map[string]interface{})
+
+	// Index every component by bom-ref, nested ones and the metadata
+	// component included
+	components := make(map[string]map[string]interface{})
+	var index func(list interface{})
+	index = func(list interface{}) {
+		for _, component := range objects(list) {
+			if ref, _ := component["bom-ref"].(string); ref != "" {
+				components[ref] = component
+			}
+			index(component["components"])
+		}
+	}
+	if meta, ok := doc["metadata"].(map[string]interface{}); ok {
+		if root, ok := meta["component"].(map[string]interface{}); ok {
+			index([]interface{}{root})
+		}
+	}
+	index(doc["components"])
+
+	dependsOn := make(map[string][]string)
+	for _, dependency := range objects(doc["dependencies"]) {
+		ref, _ := dependency["ref"].(string)
+		deps, _ := dependency["dependsOn"].([]interface{})
+		for _, d := range deps {
+			if dep, ok := d.(string); ok {
+				dependsOn[ref] = append(dependsOn[ref], dep)
+			}
+		}
+	}
+
+	// The closure of the requested refs over dependencies and nesting
+	closure := make(map[string]bool)
+	var queue []string
+	for _, ref := range componentRefs {
+		if _, ok := components[ref]; !ok {
+			return nil, nil, fmt.Errorf("%w: %q", ErrComponentNotFound, ref)
+		}
+		queue = append(queue, ref)
+	}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if closure[ref] {
+			continue
+		}
+		closure[ref] = true
+		for _, dep := range dependsOn[ref] {
+			if _, ok := components[dep]; ok {
+				queue = append(queue, dep)
+			}
+		}
+		var nested func(list interface{})
+		nested = func(list interface{}) {
+			for _, child := range objects(list) {
+				if childRef, _ := child["bom-ref"].(string); childRef != "" {
+					queue = append(queue, childRef)
+				}
+				nested(child["components"])
+			}
+		}
+		nested(components[ref]["components"])
+	}
+
+	// Included components keep their place; the included descendants of an
+	// excluded one move up to its level
+	var included []string
+	var filter func(list interface{}, parentIncluded bool) []interface{}
+	filter = func(list interface{}, parentIncluded bool) []interface{} {
+		var out []interface{}
+		for _, component := range objects(list) {
+			ref, _ := component["bom-ref"].(string)
+			if !parentIncluded && !closure[ref] {
+				out = append(out, filter(component["components"], false)...)
+				continue
+			}
+			if ref != "" {
+				included = append(included, ref)
+			}
+			clone := maps.Clone(component)
+			if children := filter(component["components"], true); len(children) > 0 {
+				clone["components"] = children
+			} else {
+				delete(clone, "components")
+			}
+			out = append(out, clone)
+		}
+		return out
+	}
+
+	subset := maps.Clone(doc)
+	for _, field := range subsetDroppedFields {
+		delete(subset, field)
+	}
+	if meta, ok := doc["metadata"].(map[string]interface{}); ok {
+		if root, ok := meta["component"].(map[string]interface{}); ok {
+			if ref, _ := root["bom-ref"].(string); closure[ref] {
+				included = append(included, ref)
+			}
+		}
+	}
+	subset["components"] = filter(doc["components"], false)
+
+	dependencies := []interface{}{}
+	for _, dependency := range objects(doc["dependencies"]) {
+		ref, _ := dependency["ref"].(string)
+		if !closure[ref] {
+			continue
+		}
+		deps := []interface{}{}
+		for _, dep := range dependsOn[ref] {
+			if closure[dep] {
+				deps = append(deps, dep)
+			}
+		}
+		clone := maps.Clone(dependency)
+		clone["dependsOn"] = deps
+		dependencies = append(dependencies, clone)
+	}
+	if _, ok := doc["dependencies"]; ok {
+		subset["dependencies"] = dependencies
+	}
+
+	if serial, ok := doc["serialNumber"].(string); ok {
+		requested := slices.Clone(componentRefs)
+		slices.Sort(requested)
+		seed, _ := json.Marshal([]string{serial, strings.Join(requested, "\n")})
+		subset["serialNumber"] = "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, seed).String()
+	}
+	return subset, included, nil
+}
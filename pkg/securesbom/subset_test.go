@@ -0,0 +1,169 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// subsetSignStubClient records the SBOM passed to SignSBOM
+type subsetSignStubClient struct {
+	ClientInterface
+	signed map[string]interface{}
+}
+
+func (c *subsetSignStubClient) SignSBOM(ctx context.Context, keyID string, sbom interface{}, callOpts ...CallOption) (*SignResultAPIResponseV2, error) {
+	c.signed = sbom.(map[string]interface{})
+	return &SignResultAPIResponseV2{Signature: "c2ln"}, nil
+}
+
+const monorepoSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "metadata": {"component": {"bom-ref": "monorepo", "name": "monorepo"}},
+  "components": [
+    {"bom-ref": "svc-a", "name": "svc-a", "components": [{"bom-ref": "svc-a-core", "name": "svc-a-core"}]},
+    {"bom-ref": "svc-b", "name": "svc-b"},
+    {"bom-ref": "platform", "name": "platform", "components": [{"bom-ref": "lib-log", "name": "lib-log"}]},
+    {"bom-ref": "lib-http", "name": "lib-http"},
+    {"bom-ref": "lib-json", "name": "lib-json"},
+    {"bom-ref": "lib-unused", "name": "lib-unused"}
+  ],
+  "dependencies": [
+    {"ref": "monorepo", "dependsOn": ["svc-a", "svc-b"]},
+    {"ref": "svc-a", "dependsOn": ["lib-http"]},
+    {"ref": "svc-a-core", "dependsOn": ["lib-log"]},
+    {"ref": "svc-b", "dependsOn": ["lib-json"]},
+    {"ref": "lib-http", "dependsOn": ["lib-json", "svc-a"]}
+  ],
+  "services": [{"bom-ref": "api", "name": "api"}],
+  "signature": {"algorithm": "ES256", "value": "c2ln"}
+}`
+
+func TestSignSBOMSubset(t *testing.T) {
+	tests := []struct {
+		name           string
+		refs           []string
+		expectIncluded []string
+		expectTree     []string
+		expectDeps     map[string][]string
+		expectError    error
+	}{
+		{
+			name:           "service with nested and transitive dependencies",
+			refs:           []string{"svc-a"},
+			expectIncluded: []string{"svc-a", "svc-a-core", "lib-log", "lib-http", "lib-json"},
+			expectTree:     []string{"svc-a", "svc-a/svc-a-core", "lib-log", "lib-http", "lib-json"},
+			expectDeps: map[string][]string{
+				"svc-a":      {"lib-http"},
+				"svc-a-core": {"lib-log"},
+				"lib-http":   {"lib-json", "svc-a"},
+			},
+		},
+		{
+			name:           "leaf component",
+			refs:           []string{"lib-json"},
+			expectIncluded: []string{"lib-json"},
+			expectTree:     []string{"lib-json"},
+			expectDeps:     map[string][]string{},
+		},
+		{
+			name:           "several components",
+			refs:           []string{"svc-b", "lib-log"},
+			expectIncluded: []string{"svc-b", "lib-log", "lib-json"},
+			expectTree:     []string{"svc-b", "lib-log", "lib-json"},
+			expectDeps:     map[string][]string{"svc-b": {"lib-json"}},
+		},
+		{
+			name:        "unknown ref",
+			refs:        []string{"svc-a", "svc-z"},
+			expectError: ErrComponentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &subsetSignStubClient{}
+			result, err := SignSBOMSubset(context.Background(), client, "key-123", []byte(monorepoSBOM), tt.refs)
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("expected %v, got %v", tt.expectError, err)
+				}
+				if client.signed != nil {
+					t.Error("expected nothing to be signed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(result.IncludedRefs, tt.expectIncluded) {
+				t.Errorf("IncludedRefs = %v, want %v", result.IncludedRefs, tt.expectIncluded)
+			}
+
+			var tree []string
+			var walk func(list interface{}, prefix string)
+			walk = func(list interface{}, prefix string) {
+				for _, c := range objects(list) {
+					ref := prefix + c["bom-ref"].(string)
+					tree = append(tree, ref)
+					walk(c["components"], ref+"/")
+				}
+			}
+			walk(client.signed["components"], "")
+			if !slices.Equal(tree, tt.expectTree) {
+				t.Errorf("components = %v, want %v", tree, tt.expectTree)
+			}
+
+			deps := make(map[string][]string)
+			for _, d := range objects(client.signed["dependencies"]) {
+				var dependsOn []string
+				for _, dep := range d["dependsOn"].([]interface{}) {
+					dependsOn = append(dependsOn, dep.(string))
+				}
+				deps[d["ref"].(string)] = dependsOn
+			}
+			encodedDeps, _ := json.Marshal(deps)
+			expectedDeps, _ := json.Marshal(tt.expectDeps)
+			if string(encodedDeps) != string(expectedDeps) {
+				t.Errorf("dependencies = %s, want %s", encodedDeps, expectedDeps)
+			}
+
+			for _, field := range []string{"signature", "services"} {
+				if _, ok := client.signed[field]; ok {
+					t.Errorf("expected %s to be dropped", field)
+				}
+			}
+			if client.signed["serialNumber"] == "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" {
+				t.Error("expected a new serial number")
+			}
+		})
+	}
+
+	t.Run("SPDX", func(t *testing.T) {
+		_, err := SignSBOMSubset(context.Background(), &subsetSignStubClient{}, "key-123", []byte(testSPDX), []string{"SPDXRef-Package"})
+		if !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+}
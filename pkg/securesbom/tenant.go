@@ -0,0 +1,41 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TenantIDHeader carries Config.TenantID on every request. Multi-tenant
+// deployments scope keys and operations to this tenant and reject requests
+// without it with 403 Forbidden.
+const TenantIDHeader = "X-Tenant-ID"
+
+// validateTenantID rejects IDs that cannot be sent as a header value
+func validateTenantID(tenantID string) error {
+	if strings.TrimSpace(tenantID) == "" {
+		return fmt.Errorf("tenant ID cannot be empty")
+	}
+	if strings.IndexFunc(tenantID, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r) || r > unicode.MaxASCII
+	}) >= 0 {
+		return fmt.Errorf("invalid tenant ID %q: must be printable ASCII without spaces", tenantID)
+	}
+	return nil
+}
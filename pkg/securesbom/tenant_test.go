@@ -0,0 +1,127 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_WithTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		build      func(b *ConfigBuilder) *ConfigBuilder
+		env        string
+		wantTenant string
+		wantErr    string
+	}{
+		{
+			name:       "tenant set",
+			build:      func(b *ConfigBuilder) *ConfigBuilder { return b.WithTenant("acme") },
+			wantTenant: "acme",
+		},
+		{
+			name:    "empty tenant",
+			build:   func(b *ConfigBuilder) *ConfigBuilder { return b.WithTenant(" ") },
+			wantErr: "tenant ID cannot be empty",
+		},
+		{
+			name:    "tenant with spaces",
+			build:   func(b *ConfigBuilder) *ConfigBuilder { return b.WithTenant("acme corp") },
+			wantErr: "invalid tenant ID",
+		},
+		{
+			name:    "multi-tenant without tenant",
+			build:   func(b *ConfigBuilder) *ConfigBuilder { return b.WithMultiTenant(true) },
+			wantErr: "TenantID is required",
+		},
+		{
+			name:       "multi-tenant with tenant",
+			build:      func(b *ConfigBuilder) *ConfigBuilder { return b.WithMultiTenant(true).WithTenant("acme") },
+			wantTenant: "acme",
+		},
+		{
+			name:       "multi-tenant with tenant from env",
+			build:      func(b *ConfigBuilder) *ConfigBuilder { return b.WithMultiTenant(true).FromEnv() },
+			env:        "env-tenant",
+			wantTenant: "env-tenant",
+		},
+		{
+			name:       "explicit tenant overrides env",
+			build:      func(b *ConfigBuilder) *ConfigBuilder { return b.FromEnv().WithTenant("acme") },
+			env:        "env-tenant",
+			wantTenant: "acme",
+		},
+		{
+			name:  "single-tenant without tenant",
+			build: func(b *ConfigBuilder) *ConfigBuilder { return b },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SECURE_SBOM_TENANT_ID", tt.env)
+			b := NewConfigBuilder().WithAPIKey("test-key").WithBaseURL("https://api.example.com")
+			client, err := tt.build(b).BuildClient()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildClient() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildClient() error = %v", err)
+			}
+			if client.config.TenantID != tt.wantTenant {
+				t.Errorf("TenantID = %q, want %q", client.config.TenantID, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestClient_TenantIDHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+	}{
+		{name: "tenant set", tenantID: "acme"},
+		{name: "no tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				sent = append(sent, req.Header.Get(TenantIDHeader))
+				if _, ok := req.Header[http.CanonicalHeaderKey(TenantIDHeader)]; ok && tt.tenantID == "" {
+					t.Errorf("%s should not be sent without a tenant", TenantIDHeader)
+				}
+				return createMockResponse(http.StatusOK, `{"keys":[]}`), nil
+			}, nil)
+			client.config.TenantID = tt.tenantID
+
+			if _, err := client.ListKeys(context.Background()); err != nil {
+				t.Fatalf("ListKeys() error = %v", err)
+			}
+			if len(sent) != 1 || sent[0] != tt.tenantID {
+				t.Errorf("%s = %q, want %q", TenantIDHeader, sent, tt.tenantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	// Register the digests a timestamp token may use
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// TimestampInfo is the content of an RFC 3161 timestamp token that passed
+// VerifyTimestamp
+type TimestampInfo struct {
+	// Time is when the timestamp authority saw the signature
+	Time time.Time
+	// HashAlgorithm is the digest of the signature the token covers
+	HashAlgorithm crypto.Hash
+	// SerialNumber is unique among the tokens issued by the authority
+	SerialNumber *big.Int
+	// Policy is the OID of the authority's timestamping policy
+	Policy asn1.ObjectIdentifier
+	// Certificate is the authority's certificate that signed the token
+	Certificate *x509.Certificate
+	// Certificates holds every certificate carried in the token, for building
+	// a chain from Certificate to a trusted root
+	Certificates []*x509.Certificate
+}
+
+var (
+	oidSignedData        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidAttrContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+)
+
+// timestampHashes maps the digest OIDs a token may use to their hash. SHA-1
+// is deliberately absent.
+var timestampHashes = map[string]crypto.Hash{
+	"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+	"2.16.840.1.101.3.4.2.2": crypto.SHA384,
+	"2.16.840.1.101.3.4.2.3": crypto.SHA512,
+}
+
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	// Content is the [0] EXPLICIT wrapper; its Bytes hold the inner content
+	Content asn1.RawValue
+}
+
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	EncapContentInfo cmsEncapContentInfo
+	Certificates     asn1.RawValue   `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue   `asn1:"optional,tag:1"`
+	SignerInfos      []cmsSignerInfo `asn1:"set"`
+}
+
+type cmsEncapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type cmsSignerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+type cmsIssuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint struct {
+		HashAlgorithm pkix.AlgorithmIdentifier
+		HashedMessage []byte
+	}
+	SerialNumber *big.Int
+	GenTime      time.Time `asn1:"generalized"`
+}
+
+// checkTimestamping fails a sign call before it is sent when timestamping is
+// required and Capabilities has already reported the server can't provide it
+func (c *Client) checkTimestamping() error {
+	if !c.config.Timestamping {
+		return nil
+	}
+	if capabilities := c.cachedCapabilities(); capabilities != nil && !capabilities.Timestamping {
+		return fmt.Errorf("%w: timestamping is enabled but the server does not advertise it", ErrTimestampingUnsupported)
+	}
+	return nil
+}
+
+// checkTimestampToken fails a sign call whose response lacks the timestamp
+// token that was asked for
+func (c *Client) checkTimestampToken(result *SignResultAPIResponseV2) error {
+	if c.config.Timestamping && len(result.TimestampToken) == 0 {
+		return fmt.Errorf("%w: timestamping is enabled but the sign response has no timestamp token", ErrTimestampingUnsupported)
+	}
+	return nil
+}
+
+// VerifyTimestamp checks that token, the TimestampToken of a sign result, is
+// an RFC 3161 timestamp over signature, the raw signature bytes (the decoded
+// signature value, not its base64 form). It verifies the token's message
+// imprint against signature, and the authority's signature over the token
+// with the certificate embedded in it, which must be valid for timestamping at
+// the stamped time. Failures wrap ErrTimestampInvalid.
+//
+// Whether the authority itself is trusted is left to the caller: verify
+// Certificate against the expected roots, e.g.
+//
+//	info.Certificate.Verify(x509.VerifyOptions{
+//		Roots:       tsaRoots,
+//		CurrentTime: info.Time,
+//		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+//	})
+func VerifyTimestamp(token, signature []byte) (*TimestampInfo, error) {
+	if len(token) == 0 {
+		return nil, fmt.Errorf("%w: token is empty", ErrTimestampInvalid)
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("signature is required")
+	}
+
+	var contentInfo cmsContentInfo
+	if err := unmarshalDER(token, &contentInfo); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+	if !contentInfo.ContentType.Equal(oidSignedData) || contentInfo.Content.Class != asn1.ClassContextSpecific || contentInfo.Content.Tag != 0 {
+		return nil, fmt.Errorf("%w: content type %s is not signed data", ErrTimestampInvalid, contentInfo.ContentType)
+	}
+	var signedData cmsSignedData
+	if err := unmarshalDER(contentInfo.Content.Bytes, &signedData); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+	if !signedData.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("%w: content type %s is not a timestamp", ErrTimestampInvalid, signedData.EncapContentInfo.EContentType)
+	}
+	content := signedData.EncapContentInfo.EContent
+	var info tstInfo
+	if err := unmarshalDER(content, &info); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+
+	hash, err := timestampHash(info.MessageImprint.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	h := hash.New()
+	h.Write(signature)
+	if !bytes.Equal(h.Sum(nil), info.MessageImprint.HashedMessage) {
+		return nil, fmt.Errorf("%w: token does not cover this signature", ErrTimestampInvalid)
+	}
+
+	certs, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse certificates: %v", ErrTimestampInvalid, err)
+	}
+	if len(signedData.SignerInfos) != 1 {
+		return nil, fmt.Errorf("%w: expected one signer, found %d", ErrTimestampInvalid, len(signedData.SignerInfos))
+	}
+	signer := signedData.SignerInfos[0]
+	cert, err := verifyTimestampSigner(signer, certs, content)
+	if err != nil {
+		return nil, err
+	}
+	if info.GenTime.Before(cert.NotBefore) || info.GenTime.After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: stamped at %s, outside the validity of the authority's certificate", ErrTimestampInvalid, info.GenTime.Format(time.RFC3339))
+	}
+
+	return &TimestampInfo{
+		Time:          info.GenTime,
+		HashAlgorithm: hash,
+		SerialNumber:  info.SerialNumber,
+		Policy:        info.Policy,
+		Certificate:   cert,
+		Certificates:  certs,
+	}, nil
+}
+
+// verifyTimestampSigner checks the authority's signature over the signed
+// attributes, and that those attributes bind it to content, returning the
+// certificate that made it
+func verifyTimestampSigner(signer cmsSignerInfo, certs []*x509.Certificate, content []byte) (*x509.Certificate, error) {
+	cert := findSignerCertificate(signer.SID, certs)
+	if cert == nil {
+		return nil, fmt.Errorf("%w: token does not carry the signer's certificate", ErrTimestampInvalid)
+	}
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageTimeStamping) {
+		return nil, fmt.Errorf("%w: signer's certificate is not valid for timestamping", ErrTimestampInvalid)
+	}
+
+	// RFC 3161 requires signed attributes, so the signature never covers the
+	// content directly
+	if len(signer.SignedAttrs.FullBytes) == 0 {
+		return nil, fmt.Errorf("%w: token has no signed attributes", ErrTimestampInvalid)
+	}
+	hash, err := timestampHash(signer.DigestAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var attrs []cmsAttribute
+	if _, err := asn1.UnmarshalWithParams(signer.SignedAttrs.FullBytes, &attrs, "set,tag:0"); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse signed attributes: %v", ErrTimestampInvalid, err)
+	}
+	var contentType asn1.ObjectIdentifier
+	var messageDigest []byte
+	for _, attr := range attrs {
+		if len(attr.Values) != 1 {
+			continue
+		}
+		switch {
+		case attr.Type.Equal(oidAttrContentType):
+			_ = unmarshalDER(attr.Values[0].FullBytes, &contentType)
+		case attr.Type.Equal(oidAttrMessageDigest):
+			_ = unmarshalDER(attr.Values[0].FullBytes, &messageDigest)
+		}
+	}
+	if !contentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("%w: signed attributes do not name a timestamp", ErrTimestampInvalid)
+	}
+	h := hash.New()
+	h.Write(content)
+	if !bytes.Equal(h.Sum(nil), messageDigest) {
+		return nil, fmt.Errorf("%w: message digest does not match the timestamp", ErrTimestampInvalid)
+	}
+
+	// The signature is over the attributes encoded as an explicit SET
+	// rather than the implicitly tagged form they are carried in
+	signed := append([]byte{0x31}, signer.SignedAttrs.FullBytes[1:]...)
+	if err := verifyCMSSignature(cert.PublicKey, hash, signed, signer.Signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+	return cert, nil
+}
+
+// findSignerCertificate returns the certificate named by a signer identifier,
+// either an issuer and serial number or a [0] subject key identifier
+func findSignerCertificate(sid asn1.RawValue, certs []*x509.Certificate) *x509.Certificate {
+	if sid.Class == asn1.ClassContextSpecific && sid.Tag == 0 {
+		for _, cert := range certs {
+			if bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert
+			}
+		}
+		return nil
+	}
+	var ias cmsIssuerAndSerial
+	if err := unmarshalDER(sid.FullBytes, &ias); err != nil {
+		return nil
+	}
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) && cert.SerialNumber.Cmp(ias.SerialNumber) == 0 {
+			return cert
+		}
+	}
+	return nil
+}
+
+func verifyCMSSignature(publicKey interface{}, hash crypto.Hash, signed, signature []byte) error {
+	if key, ok := publicKey.(ed25519.PublicKey); ok {
+		if !ed25519.Verify(key, signed, signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+func timestampHash(algorithm pkix.AlgorithmIdentifier) (crypto.Hash, error) {
+	hash, ok := timestampHashes[algorithm.Algorithm.String()]
+	if !ok {
+		return 0, fmt.Errorf("%w: unsupported hash algorithm %s", ErrTimestampInvalid, algorithm.Algorithm)
+	}
+	return hash, nil
+}
+
+// unmarshalDER is asn1.Unmarshal that rejects trailing data
+func unmarshalDER(der []byte, v interface{}) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("trailing data after ASN.1 value")
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTSA is a throwaway timestamp authority for building RFC 3161 tokens
+type testTSA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestTSA(t *testing.T, usage x509.ExtKeyUsage) *testTSA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		Subject:      pkix.Name{CommonName: "securesbom-test-tsa"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return &testTSA{key: key, cert: cert}
+}
+
+// token returns a DER timestamp token over signature stamped at genTime
+func (tsa *testTSA) token(t *testing.T, signature []byte, genTime time.Time) []byte {
+	t.Helper()
+
+	mustMarshal := func(v interface{}, params string) []byte {
+		der, err := asn1.MarshalWithParams(v, params)
+		if err != nil {
+			t.Fatalf("failed to marshal %T: %v", v, err)
+		}
+		return der
+	}
+	sha256ID := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}}
+
+	info := tstInfo{
+		Version:      1,
+		Policy:       asn1.ObjectIdentifier{1, 2, 3, 4},
+		SerialNumber: big.NewInt(42),
+		GenTime:      genTime.UTC().Truncate(time.Second),
+	}
+	imprint := sha256.Sum256(signature)
+	info.MessageImprint.HashAlgorithm = sha256ID
+	info.MessageImprint.HashedMessage = imprint[:]
+	content := mustMarshal(info, "")
+
+	contentDigest := sha256.Sum256(content)
+	attrs := mustMarshal([]cmsAttribute{
+		{Type: oidAttrContentType, Values: []asn1.RawValue{{FullBytes: mustMarshal(oidTSTInfo, "")}}},
+		{Type: oidAttrMessageDigest, Values: []asn1.RawValue{{FullBytes: mustMarshal(contentDigest[:], "")}}},
+	}, "set")
+	attrsDigest := sha256.Sum256(attrs)
+	signature, err := ecdsa.SignASN1(rand.Reader, tsa.key, attrsDigest[:])
+	if err != nil {
+		t.Fatalf("failed to sign attributes: %v", err)
+	}
+
+	signer := cmsSignerInfo{
+		Version: 1,
+		SID: asn1.RawValue{FullBytes: mustMarshal(cmsIssuerAndSerial{
+			Issuer:       asn1.RawValue{FullBytes: tsa.cert.RawIssuer},
+			SerialNumber: tsa.cert.SerialNumber,
+		}, "")},
+		DigestAlgorithm:    sha256ID,
+		SignedAttrs:        asn1.RawValue{FullBytes: append([]byte{0xa0}, attrs[1:]...)},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+		Signature:          signature,
+	}
+	signedData := cmsSignedData{
+		Version:          3,
+		DigestAlgorithms: asn1.RawValue{FullBytes: mustMarshal([]pkix.AlgorithmIdentifier{sha256ID}, "set")},
+		EncapContentInfo: cmsEncapContentInfo{EContentType: oidTSTInfo, EContent: content},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: tsa.cert.Raw},
+		SignerInfos:      []cmsSignerInfo{signer},
+	}
+	return mustMarshal(cmsContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: mustMarshal(signedData, "")},
+	}, "")
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	signature := []byte("signature-bytes")
+	tsa := newTestTSA(t, x509.ExtKeyUsageTimeStamping)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		token     func(t *testing.T) []byte
+		signature []byte
+		errIs     error
+	}{
+		{
+			name:      "valid token",
+			token:     func(t *testing.T) []byte { return tsa.token(t, signature, now) },
+			signature: signature,
+		},
+		{
+			name:      "token for another signature",
+			token:     func(t *testing.T) []byte { return tsa.token(t, []byte("other"), now) },
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name: "tampered token",
+			token: func(t *testing.T) []byte {
+				token := tsa.token(t, signature, now)
+				// Flip a bit of the authority's signature, the last field
+				token[len(token)-1] ^= 0x01
+				return token
+			},
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name: "authority not valid for timestamping",
+			token: func(t *testing.T) []byte {
+				return newTestTSA(t, x509.ExtKeyUsageCodeSigning).token(t, signature, now)
+			},
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name:      "stamped outside the certificate's validity",
+			token:     func(t *testing.T) []byte { return tsa.token(t, signature, now.Add(2*time.Hour)) },
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name:      "not DER",
+			token:     func(t *testing.T) []byte { return []byte("not a token") },
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name:      "empty token",
+			token:     func(t *testing.T) []byte { return nil },
+			signature: signature,
+			errIs:     ErrTimestampInvalid,
+		},
+		{
+			name:  "missing signature",
+			token: func(t *testing.T) []byte { return tsa.token(t, signature, now) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := VerifyTimestamp(tt.token(t), tt.signature)
+			if tt.errIs != nil || tt.signature == nil {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error wrapping %v, got %v", tt.errIs, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !info.Time.Equal(now.UTC().Truncate(time.Second)) {
+				t.Errorf("Time = %v, want %v", info.Time, now.UTC().Truncate(time.Second))
+			}
+			if info.SerialNumber.Int64() != 42 {
+				t.Errorf("SerialNumber = %v, want 42", info.SerialNumber)
+			}
+			if !info.Certificate.Equal(tsa.cert) {
+				t.Error("Certificate is not the authority's certificate")
+			}
+		})
+	}
+}
+
+func TestClient_SignWithTimestamping(t *testing.T) {
+	token := []byte{0x30, 0x03, 0x02, 0x01, 0x01}
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	tests := []struct {
+		name           string
+		timestamping   bool
+		capabilities   *Capabilities
+		response       map[string]interface{}
+		expectRequests int
+		expectToken    bool
+		errIs          error
+	}{
+		{
+			name:           "token returned",
+			timestamping:   true,
+			response:       map[string]interface{}{"signed_sbom": sbom, "timestamp_token": base64.StdEncoding.EncodeToString(token)},
+			expectRequests: 1,
+			expectToken:    true,
+		},
+		{
+			name:           "server ignores timestamping",
+			timestamping:   true,
+			response:       map[string]interface{}{"signed_sbom": sbom},
+			expectRequests: 1,
+			errIs:          ErrTimestampingUnsupported,
+		},
+		{
+			name:         "capabilities rule it out",
+			timestamping: true,
+			capabilities: &Capabilities{SBOMFormats: []string{"cyclonedx"}},
+			errIs:        ErrTimestampingUnsupported,
+		},
+		{
+			name:           "timestamping disabled",
+			response:       map[string]interface{}{"signed_sbom": sbom},
+			expectRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				body, _ := io.ReadAll(req.Body)
+				if got := strings.Contains(string(body), `"timestamp":true`); got != tt.timestamping {
+					t.Errorf("request asks for a timestamp = %t, want %t: %s", got, tt.timestamping, body)
+				}
+				return createMockResponse(http.StatusOK, tt.response), nil
+			}, func(config *Config) { config.Timestamping = tt.timestamping })
+			client.capabilities = tt.capabilities
+
+			result, err := client.SignSBOM(context.Background(), "key-123", sbom)
+			if requests != tt.expectRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.expectRequests)
+			}
+			if tt.errIs != nil {
+				if !errors.Is(err, tt.errIs) {
+					t.Fatalf("expected error wrapping %v, got %v", tt.errIs, err)
+				}
+				if IsTemporary(err) {
+					t.Error("expected a permanent error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectToken && string(result.TimestampToken) != string(token) {
+				t.Errorf("TimestampToken = %x, want %x", result.TimestampToken, token)
+			}
+		})
+	}
+}
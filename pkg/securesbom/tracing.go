@@ -0,0 +1,78 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/shiftleftcyber/securesbom-sdk-golang/v2/pkg/securesbom"
+
+// operationSpan wraps the span for a single SDK operation. A nil
+// *operationSpan is valid and does nothing, which keeps tracing free when no
+// TracerProvider is configured.
+type operationSpan struct {
+	span trace.Span
+}
+
+// startSpan records the SDK operation on ctx for metrics and starts a span
+// named after it if tracing is enabled
+func (c *Client) startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, *operationSpan) {
+	ctx = withOperation(ctx, operation)
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	ctx, span := c.tracer.Start(ctx, "securesbom."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return ctx, &operationSpan{span: span}
+}
+
+// end records err, if any, and ends the span
+func (s *operationSpan) end(err error) {
+	if s == nil {
+		return
+	}
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// traceRequest propagates the span context to the outgoing request headers
+func (c *Client) traceRequest(ctx context.Context, req *http.Request) {
+	if c.tracer == nil {
+		return
+	}
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// traceResponse records the HTTP status code on the current span
+func (c *Client) traceResponse(ctx context.Context, statusCode int) {
+	if c.tracer == nil {
+		return
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.status_code", statusCode))
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestClient_Tracing(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		expectError  bool
+		expectStatus codes.Code
+	}{
+		{name: "successful sign", statusCode: 200, expectStatus: codes.Unset},
+		{name: "failed sign", statusCode: 401, expectError: true, expectStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			var traceparent string
+			mockClient := &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					traceparent = req.Header.Get("traceparent")
+					return createMockResponse(tt.statusCode, map[string]string{"algorithm": "ES256"}), nil
+				},
+			}
+
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				WithHTTPClient(mockClient).
+				WithTracerProvider(provider).
+				BuildClient()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			_, err = client.SignSBOM(context.Background(), "key-123", sbom)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			span := spans[0]
+
+			if span.Name() != "securesbom.SignSBOM" {
+				t.Errorf("expected span name %q, got %q", "securesbom.SignSBOM", span.Name())
+			}
+			if v, _ := spanAttribute(span, "sbom.key_id"); v.AsString() != "key-123" {
+				t.Errorf("expected sbom.key_id attribute, got %q", v.AsString())
+			}
+			if v, _ := spanAttribute(span, "sbom.format"); v.AsString() != "cyclonedx" {
+				t.Errorf("expected sbom.format attribute, got %q", v.AsString())
+			}
+			if v, _ := spanAttribute(span, "http.status_code"); v.AsInt64() != int64(tt.statusCode) {
+				t.Errorf("expected http.status_code %d, got %d", tt.statusCode, v.AsInt64())
+			}
+			if span.Status().Code != tt.expectStatus {
+				t.Errorf("expected span status %v, got %v", tt.expectStatus, span.Status().Code)
+			}
+
+			traceID := span.SpanContext().TraceID().String()
+			if !strings.Contains(traceparent, traceID) {
+				t.Errorf("expected traceparent header %q to carry trace ID %s", traceparent, traceID)
+			}
+		})
+	}
+}
+
+func TestClient_TracingDisabled(t *testing.T) {
+	mockClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("traceparent") != "" {
+				t.Error("expected no traceparent header without a tracer provider")
+			}
+			return createMockResponse(200, "OK"), nil
+		},
+	}
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL("https://api.example.com").
+		WithHTTPClient(mockClient).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.tracer != nil {
+		t.Error("expected tracer to be nil without a tracer provider")
+	}
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
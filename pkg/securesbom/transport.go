@@ -0,0 +1,229 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// newHTTPClient builds the HTTP client used when Config.HTTPClient is not set,
+// using the transport from newTransport
+func newHTTPClient(cfg *Config) (*http.Client, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	}, nil
+}
+
+// Connection pool defaults for the SDK's own transport. The client talks to a
+// single host, so the idle limit applies per host as well as overall.
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// newTransport applies the TLS, proxy and connection pool settings from cfg
+// on top of Config.Transport. A caller-supplied transport without such
+// settings is returned unchanged; otherwise the default is a clone of
+// http.DefaultTransport that attempts HTTP/2 and keeps DefaultMaxIdleConns
+// idle connections to the API.
+func newTransport(cfg *Config) (http.RoundTripper, error) {
+	if cfg.Transport != nil && !hasTLSSettings(cfg) && !hasPoolSettings(cfg) && cfg.ProxyURL == nil {
+		return cfg.Transport, nil
+	}
+
+	var transport *http.Transport
+	switch t := cfg.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		// Custom TLS settings would otherwise turn HTTP/2 off
+		transport.ForceAttemptHTTP2 = true
+		transport.MaxIdleConns = DefaultMaxIdleConns
+		transport.MaxIdleConnsPerHost = DefaultMaxIdleConns
+		transport.IdleConnTimeout = DefaultIdleConnTimeout
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		if hasTLSSettings(cfg) {
+			return nil, fmt.Errorf("TLS options require an *http.Transport, got %T", t)
+		}
+		if cfg.ProxyURL != nil {
+			return nil, fmt.Errorf("proxy options require an *http.Transport, got %T", t)
+		}
+		return nil, fmt.Errorf("connection pool options require an *http.Transport, got %T", t)
+	}
+
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConns
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	if cfg.ProxyURL != nil {
+		rules, err := parseNoProxy(cfg.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxyFunc(cfg.ProxyURL, rules)
+	}
+
+	if !hasTLSSettings(cfg) {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	tlsConfig.Certificates = append(tlsConfig.Certificates, cfg.ClientCertificates...)
+	if cfg.RootCAs != nil {
+		tlsConfig.RootCAs = cfg.RootCAs
+	}
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true // #nosec G402 -- explicit opt-in for local testing
+	}
+	if len(cfg.PinnedSPKI) > 0 {
+		pins, err := parseSPKIPins(cfg.PinnedSPKI)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.VerifyPeerCertificate = verifyPinnedSPKI(pins, tlsConfig.VerifyPeerCertificate)
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func hasPoolSettings(cfg *Config) bool {
+	return cfg.MaxIdleConns > 0 || cfg.MaxConnsPerHost > 0 || cfg.IdleConnTimeout > 0
+}
+
+func hasTLSSettings(cfg *Config) bool {
+	return len(cfg.ClientCertificates) > 0 || cfg.RootCAs != nil || cfg.InsecureSkipVerify || len(cfg.PinnedSPKI) > 0
+}
+
+// cancelOnCloseBody releases a per-request context once the caller is done
+// reading the response body. stop unregisters the function that closes the
+// body when the context ends.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	stop   func() bool
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	b.stop()
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// incompleteBody reports a response body that ends early, e.g. because the
+// connection dropped mid-stream, as ErrIncompleteResponse, and one cut off
+// because the request context ended as that context's error
+type incompleteBody struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+func (b *incompleteBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == nil || err == io.EOF {
+		return n, err
+	}
+	if ctxErr := b.ctx.Err(); ctxErr != nil {
+		if !errors.Is(err, ctxErr) {
+			err = fmt.Errorf("%w: %w", ctxErr, err)
+		}
+		return n, err
+	}
+	if !corruptGzip(err) {
+		err = fmt.Errorf("%w: %w", ErrIncompleteResponse, err)
+	}
+	return n, err
+}
+
+// limitedBody fails reads with ErrResponseTooLarge once more than limit bytes
+// have been read, so a runaway response can't exhaust memory
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.read > b.limit {
+		return 0, b.tooLarge()
+	}
+	// Read at most one byte past the limit to tell a body of exactly limit
+	// bytes from a larger one
+	if remaining := b.limit - b.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), b.tooLarge()
+	}
+	return n, err
+}
+
+func (b *limitedBody) tooLarge() error {
+	return fmt.Errorf("%w: body exceeds the %d byte limit", ErrResponseTooLarge, b.limit)
+}
+
+// corruptGzip reports a gzip body that is malformed rather than cut short
+func corruptGzip(err error) bool {
+	return errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum)
+}
+
+// decodeJSON decodes a JSON response body, reporting a body cut short as
+// ErrIncompleteResponse rather than a parse error
+func decodeJSON(r io.Reader, v interface{}) error {
+	err := json.NewDecoder(r).Decode(v)
+	if errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, ErrIncompleteResponse) {
+		return fmt.Errorf("%w: %w", ErrIncompleteResponse, err)
+	}
+	return err
+}
+
+// unmarshalJSON is decodeJSON for a body that was already read
+func unmarshalJSON(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(data)) {
+		return fmt.Errorf("%w: %w", ErrIncompleteResponse, err)
+	}
+	return err
+}
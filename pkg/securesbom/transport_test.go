@@ -0,0 +1,818 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// generateTestCertificate returns a self-signed PEM certificate and key
+func generateTestCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "securesbom-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
+
+func TestConfigBuilder_WithClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.crt")
+	keyPath := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certPath, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		configure   func(b *ConfigBuilder) *ConfigBuilder
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid PEM",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithClientCertificate(certPEM, keyPEM)
+			},
+		},
+		{
+			name: "valid files",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithClientCertificateFile(certPath, keyPath)
+			},
+		},
+		{
+			name: "invalid PEM",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithClientCertificate([]byte("not a cert"), keyPEM)
+			},
+			expectError: true,
+			errorMsg:    "failed to load client certificate",
+		},
+		{
+			name: "missing file",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithClientCertificateFile(filepath.Join(dir, "missing.crt"), keyPath)
+			},
+			expectError: true,
+			errorMsg:    "missing.crt",
+		},
+		{
+			name: "non http.Transport",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithTransport(roundTripperFunc(nil)).WithClientCertificate(certPEM, keyPEM)
+			},
+			expectError: true,
+			errorMsg:    "TLS options require an *http.Transport",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com")
+
+			client, err := tt.configure(builder).BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("expected error to contain %q, got %q", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			transport := client.httpClient.(*http.Client).Transport.(*http.Transport)
+			if len(transport.TLSClientConfig.Certificates) != 1 {
+				t.Errorf("expected 1 client certificate, got %d", len(transport.TLSClientConfig.Certificates))
+			}
+		})
+	}
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// The test server's transport trusts its certificate; the client
+	// certificate is layered on top of it
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL(server.URL).
+		WithTransport(server.Client().Transport).
+		WithClientCertificate(certPEM, keyPEM).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Errorf("expected mTLS health check to succeed, got %v", err)
+	}
+}
+
+func TestConfigBuilder_WithRootCAs(t *testing.T) {
+	clientCertPEM, clientKeyPEM := generateTestCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, serverCAPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		configure        func(b *ConfigBuilder) *ConfigBuilder
+		expectBuildError bool
+		expectCallError  bool
+	}{
+		{
+			name:            "system roots reject private CA",
+			configure:       func(b *ConfigBuilder) *ConfigBuilder { return b },
+			expectCallError: true,
+		},
+		{
+			name: "custom CA from PEM",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs(serverCAPEM)
+			},
+		},
+		{
+			name: "custom CA from file with client certificate",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAsFile(caPath).WithClientCertificate(clientCertPEM, clientKeyPEM)
+			},
+		},
+		{
+			name: "insecure skip verify",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithInsecureSkipVerify(true)
+			},
+		},
+		{
+			name: "invalid CA PEM",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAs([]byte("garbage"))
+			},
+			expectBuildError: true,
+		},
+		{
+			name: "missing CA file",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithRootCAsFile(filepath.Join(t.TempDir(), "missing.pem"))
+			},
+			expectBuildError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL(server.URL)
+
+			client, err := tt.configure(builder).BuildClient()
+			if tt.expectBuildError {
+				if err == nil {
+					t.Error("expected build error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected build error: %v", err)
+			}
+
+			err = client.HealthCheck(context.Background())
+			if tt.expectCallError && err == nil {
+				t.Error("expected call error but got none")
+			}
+			if !tt.expectCallError && err != nil {
+				t.Errorf("unexpected call error: %v", err)
+			}
+		})
+	}
+}
+
+// droppedReader yields data and then fails as if the connection dropped
+type droppedReader struct {
+	data io.Reader
+}
+
+func (r *droppedReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("read tcp: connection reset by peer")
+	}
+	return n, err
+}
+
+func TestClient_IncompleteResponse(t *testing.T) {
+	const verifyBody = `{"code":"VALID","message":"signature is valid","certificate_chain":["leaf","root"]}`
+	const signBody = `{"signed_sbom":{"bomFormat":"CycloneDX","signature":{"value":"c2ln"}},"algorithm":"ed25519"}`
+
+	tests := []struct {
+		name string
+		sign bool
+		body func() io.Reader
+	}{
+		{name: "verify connection dropped", body: func() io.Reader { return &droppedReader{data: strings.NewReader(verifyBody[:30])} }},
+		{name: "verify body cut short", body: func() io.Reader { return strings.NewReader(verifyBody[:30]) }},
+		{name: "sign connection dropped", sign: true, body: func() io.Reader { return &droppedReader{data: strings.NewReader(signBody[:40])} }},
+		{name: "sign body cut short", sign: true, body: func() io.Reader { return strings.NewReader(signBody[:40]) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				resp := createMockResponse(http.StatusOK, nil)
+				resp.Body = io.NopCloser(tt.body())
+				return resp, nil
+			}, nil)
+
+			sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+			var err error
+			if tt.sign {
+				_, err = client.SignSBOM(context.Background(), "key-1", sbom)
+			} else {
+				_, err = client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"})
+			}
+			if !errors.Is(err, ErrIncompleteResponse) {
+				t.Fatalf("error = %v, want ErrIncompleteResponse", err)
+			}
+			if !IsTemporary(err) {
+				t.Errorf("IsTemporary(%v) = false, want true", err)
+			}
+		})
+	}
+}
+
+func TestRetryingClient_RecoversFromIncompleteResponse(t *testing.T) {
+	const body = `{"code":"VALID","message":"signature is valid"}`
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if attempts.Add(1) == 1 {
+			// Promise the whole body, send half of it, then drop the connection
+			w.Header().Set("Content-Length", "1000")
+			_, _ = w.Write([]byte(body[:20]))
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	base, err := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client := WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond, Multiplier: 1})
+
+	sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+	result, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"})
+	if err != nil {
+		t.Fatalf("VerifySBOM() error = %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("VerifySBOM() = %+v, want a valid result", result)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+// cancelCalls are the operations exercised by the cancellation tests: a
+// buffered verify and the two streaming sign paths
+var cancelCalls = []struct {
+	name string
+	call func(ctx context.Context, client ClientInterface) error
+}{
+	{name: "verify", call: func(ctx context.Context, client ClientInterface) error {
+		sbom := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+		_, err := client.VerifySBOM(ctx, VerifyCMDRequest{KeyID: "key-1", SBOM: sbom, SignatureB64: "c2ln"})
+		return err
+	}},
+	{name: "sign from reader", call: func(ctx context.Context, client ClientInterface) error {
+		sbom := `{"bomFormat":"CycloneDX","specVersion":"1.5"}`
+		_, err := client.SignSBOMFromReader(ctx, "key-1", strings.NewReader(sbom), int64(len(sbom)))
+		return err
+	}},
+	{name: "sign to writer", call: func(ctx context.Context, client ClientInterface) error {
+		_, err := client.SignSBOMToWriter(ctx, "key-1", []byte(`{"bomFormat":"CycloneDX","specVersion":"1.5"}`), io.Discard)
+		return err
+	}},
+}
+
+// assertCancelled cancels ctx once the request is in flight and checks that
+// call returns a context error promptly
+func assertCancelled(t *testing.T, inFlight <-chan struct{}, call func(ctx context.Context) error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- call(ctx) }()
+
+	select {
+	case <-inFlight:
+	case err := <-done:
+		t.Fatalf("call returned before the request was in flight: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request never reached the server")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("call did not return within 1s of cancellation")
+	}
+}
+
+func TestClient_CancelInFlight(t *testing.T) {
+	stalls := []struct {
+		name        string
+		sendHeaders bool
+	}{
+		{name: "before headers"},
+		{name: "mid body", sendHeaders: true},
+	}
+
+	for _, stall := range stalls {
+		for _, tc := range cancelCalls {
+			t.Run(stall.name+"/"+tc.name, func(t *testing.T) {
+				inFlight := make(chan struct{}, 1)
+				release := make(chan struct{})
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if stall.sendHeaders {
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`{"code":`))
+						w.(http.Flusher).Flush()
+					}
+					inFlight <- struct{}{}
+					<-release
+				}))
+				defer server.Close()
+				defer close(release)
+
+				client, err := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL})
+				if err != nil {
+					t.Fatalf("NewClient() error = %v", err)
+				}
+				assertCancelled(t, inFlight, func(ctx context.Context) error { return tc.call(ctx, client) })
+			})
+		}
+	}
+}
+
+func TestClient_CancelStalledBody(t *testing.T) {
+	// A custom HTTPClient whose body knows nothing about the request context
+	for _, tc := range cancelCalls {
+		t.Run(tc.name, func(t *testing.T) {
+			inFlight := make(chan struct{}, 1)
+			pr, pw := io.Pipe()
+			defer pw.Close()
+
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				resp := createMockResponse(http.StatusOK, nil)
+				resp.Body = pr
+				inFlight <- struct{}{}
+				return resp, nil
+			}, nil)
+			assertCancelled(t, inFlight, func(ctx context.Context) error { return tc.call(ctx, client) })
+		})
+	}
+}
+
+func TestRetryingClient_CancelInFlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		stall bool
+	}{
+		// The first attempt fails and the cancel lands during the backoff
+		{name: "during backoff"},
+		// The second attempt stalls and the cancel lands mid-request
+		{name: "during retry", stall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inFlight := make(chan struct{}, 1)
+			release := make(chan struct{})
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					if !tt.stall {
+						inFlight <- struct{}{}
+					}
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				inFlight <- struct{}{}
+				<-release
+			}))
+			defer server.Close()
+			defer close(release)
+
+			base, err := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			wait := time.Hour
+			if tt.stall {
+				wait = time.Millisecond
+			}
+			client := WithRetryingClient(base, RetryConfig{MaxAttempts: 3, InitialWait: wait, MaxWait: wait, Multiplier: 1})
+
+			assertCancelled(t, inFlight, func(ctx context.Context) error { return cancelCalls[0].call(ctx, client) })
+			if got := attempts.Load(); got > 2 {
+				t.Errorf("attempts = %d, want at most 2", got)
+			}
+		})
+	}
+}
+
+func TestClient_MaxResponseSize(t *testing.T) {
+	const limit = 1024
+
+	tests := []struct {
+		name        string
+		size        int
+		chunked     bool
+		gzip        bool
+		expectError bool
+	}{
+		{name: "under the limit", size: limit - 1},
+		{name: "exactly the limit", size: limit},
+		{name: "over the limit", size: limit + 1, expectError: true},
+		{name: "chunked over the limit", size: 4 * limit, chunked: true, expectError: true},
+		{name: "gzip expands over the limit", size: 64 * limit, gzip: true, expectError: true},
+		{name: "gzip within the limit", size: limit, gzip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(strings.Repeat("a", tt.size))
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+					_, _ = w.Write(gzipBytes(t, body))
+					return
+				}
+				if tt.chunked {
+					for chunk := range slices.Chunk(body, 100) {
+						_, _ = w.Write(chunk)
+						w.(http.Flusher).Flush()
+					}
+					return
+				}
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			client, err := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL(server.URL).
+				WithMaxResponseSize(limit).
+				BuildClient()
+			if err != nil {
+				t.Fatalf("BuildClient() error = %v", err)
+			}
+
+			publicKey, err := client.GetPublicKey(context.Background(), "key-1")
+			if tt.expectError {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Fatalf("error = %v, want ErrResponseTooLarge", err)
+				}
+				if IsTemporary(err) {
+					t.Errorf("IsTemporary(%v) = true, want false", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPublicKey() error = %v", err)
+			}
+			if len(publicKey) != tt.size {
+				t.Errorf("read %d bytes, want %d", len(publicKey), tt.size)
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_WithMaxResponseSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     *ConfigBuilder
+		expectError bool
+		expectSize  int64
+	}{
+		{name: "default", builder: NewConfigBuilder(), expectSize: DefaultMaxResponseSize},
+		{name: "custom", builder: NewConfigBuilder().WithMaxResponseSize(1 << 20), expectSize: 1 << 20},
+		{name: "zero", builder: NewConfigBuilder().WithMaxResponseSize(0), expectError: true},
+		{name: "negative", builder: NewConfigBuilder().WithMaxResponseSize(-1), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.builder.
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com").
+				BuildClient()
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.config.MaxResponseSize != tt.expectSize {
+				t.Errorf("MaxResponseSize = %d, want %d", client.config.MaxResponseSize, tt.expectSize)
+			}
+		})
+	}
+}
+
+func TestConfigBuilder_ConnectionPool(t *testing.T) {
+	tests := []struct {
+		name                string
+		configure           func(b *ConfigBuilder) *ConfigBuilder
+		wantMaxIdleConns    int
+		wantMaxConnsPerHost int
+		wantIdleConnTimeout time.Duration
+		errorMsg            string
+	}{
+		{
+			name:                "defaults",
+			configure:           func(b *ConfigBuilder) *ConfigBuilder { return b },
+			wantMaxIdleConns:    DefaultMaxIdleConns,
+			wantIdleConnTimeout: DefaultIdleConnTimeout,
+		},
+		{
+			name: "tuned",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithMaxIdleConns(10).WithMaxConnsPerHost(4).WithIdleConnTimeout(time.Minute)
+			},
+			wantMaxIdleConns:    10,
+			wantMaxConnsPerHost: 4,
+			wantIdleConnTimeout: time.Minute,
+		},
+		{
+			name: "applied to a custom transport",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithTransport(&http.Transport{MaxIdleConns: 1, MaxIdleConnsPerHost: 1, IdleConnTimeout: time.Second}).WithMaxConnsPerHost(2)
+			},
+			wantMaxIdleConns:    1,
+			wantMaxConnsPerHost: 2,
+			wantIdleConnTimeout: time.Second,
+		},
+		{
+			name: "negative idle connections",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithMaxIdleConns(-1)
+			},
+			errorMsg: "max idle connections cannot be negative",
+		},
+		{
+			name: "negative connections per host",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithMaxConnsPerHost(-1)
+			},
+			errorMsg: "max connections per host cannot be negative",
+		},
+		{
+			name: "negative idle timeout",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithIdleConnTimeout(-time.Second)
+			},
+			errorMsg: "idle connection timeout cannot be negative",
+		},
+		{
+			name: "non http.Transport",
+			configure: func(b *ConfigBuilder) *ConfigBuilder {
+				return b.WithTransport(roundTripperFunc(nil)).WithMaxIdleConns(10)
+			},
+			errorMsg: "connection pool options require an *http.Transport",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL("https://api.example.com")
+
+			client, err := tt.configure(builder).BuildClient()
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transport := client.httpClient.(*http.Client).Transport.(*http.Transport)
+			if transport.MaxIdleConns != tt.wantMaxIdleConns || transport.MaxIdleConnsPerHost != tt.wantMaxIdleConns {
+				t.Errorf("idle connections = %d (%d per host), want %d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, tt.wantMaxIdleConns)
+			}
+			if transport.MaxConnsPerHost != tt.wantMaxConnsPerHost {
+				t.Errorf("MaxConnsPerHost = %d, want %d", transport.MaxConnsPerHost, tt.wantMaxConnsPerHost)
+			}
+			if transport.IdleConnTimeout != tt.wantIdleConnTimeout {
+				t.Errorf("IdleConnTimeout = %v, want %v", transport.IdleConnTimeout, tt.wantIdleConnTimeout)
+			}
+		})
+	}
+}
+
+// newHTTP2Server starts a TLS test server that speaks HTTP/2 and returns it
+// with its certificate in PEM form
+func newHTTP2Server(t testing.TB, handler http.Handler) (*httptest.Server, []byte) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func TestClient_HTTP2(t *testing.T) {
+	var conns sync.Map
+	server, caPEM := newHTTP2Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			http.Error(w, "expected HTTP/2, got "+r.Proto, http.StatusHTTPVersionNotSupported)
+			return
+		}
+		conns.Store(r.RemoteAddr, true)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	client, err := NewConfigBuilder().
+		WithAPIKey("test-key").
+		WithBaseURL(server.URL).
+		WithRootCAs(caPEM).
+		BuildClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.HealthCheck(context.Background())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("HealthCheck() error = %v", err)
+		}
+	}
+
+	count := 0
+	conns.Range(func(_, _ any) bool { count++; return true })
+	if count != 1 {
+		t.Errorf("expected requests to share 1 HTTP/2 connection, got %d", count)
+	}
+}
+
+// BenchmarkClient_ConnectionReuse compares pooled connections with a fresh
+// connection, and TLS handshake, for every call
+func BenchmarkClient_ConnectionReuse(b *testing.B) {
+	server, caPEM := newHTTP2Server(b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	benchmarks := []struct {
+		name      string
+		transport *http.Transport
+	}{
+		{name: "reuse"},
+		{name: "new connection per call", transport: &http.Transport{DisableKeepAlives: true, ForceAttemptHTTP2: true}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			builder := NewConfigBuilder().
+				WithAPIKey("test-key").
+				WithBaseURL(server.URL).
+				WithRootCAs(caPEM)
+			if bm.transport != nil {
+				builder = builder.WithTransport(bm.transport)
+			}
+			client, err := builder.BuildClient()
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := client.HealthCheck(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -17,18 +17,168 @@
 package securesbom
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Config holds configuration for the Secure SBOM API client
 type Config struct {
-	BaseURL    string
-	APIKey     string
-	HTTPClient HTTPClient
-	Timeout    time.Duration
-	UserAgent  string
+	BaseURL string
+	APIKey  string
+	// TokenSource supplies OAuth bearer tokens instead of a static APIKey.
+	// Tokens are refreshed shortly before they expire, and once on a 401.
+	TokenSource TokenSource
+	// TenantID is sent in the X-Tenant-ID header of every request. On a
+	// multi-tenant deployment it scopes keys and operations to the tenant.
+	TenantID string
+	// MultiTenant marks BaseURL as a multi-tenant enterprise deployment, which
+	// answers requests without a TenantID with 403 Forbidden. NewClient
+	// rejects a multi-tenant config without one.
+	MultiTenant bool
+	// SignatureFormat asks the server for signatures in this envelope. Empty
+	// keeps the server's default.
+	SignatureFormat SignatureFormat
+	// DefaultHeaders are set on every request, replacing SDK headers of the
+	// same name. Credentials and body framing headers such as Authorization
+	// and Content-Length can't be set.
+	DefaultHeaders map[string]string
+	HTTPClient     HTTPClient
+	// Transport is used by the default HTTP client when HTTPClient is not set
+	Transport http.RoundTripper
+	// MaxIdleConns, MaxConnsPerHost and IdleConnTimeout tune the connection
+	// pool of the default transport, or a clone of Transport. Zero values use
+	// DefaultMaxIdleConns, no connection limit and DefaultIdleConnTimeout.
+	MaxIdleConns    int
+	MaxConnsPerHost int
+	IdleConnTimeout time.Duration
+	// MaxResponseSize is the largest response body, after decompression, that
+	// the client reads; larger bodies fail with ErrResponseTooLarge. Zero uses
+	// DefaultMaxResponseSize.
+	MaxResponseSize int64
+	// Timeout bounds each request, including reading the response body. It is
+	// applied as a context deadline so it also holds for injected HTTP clients.
+	Timeout time.Duration
+	// Retries is the maximum number of attempts made by clients built with
+	// ConfigBuilder.BuildRetryingClient. Zero uses DefaultRetryConfig.
+	Retries int
+	// MaxRetryElapsedTime bounds the total time clients built with
+	// ConfigBuilder.BuildRetryingClient spend retrying a call. Zero means no
+	// bound.
+	MaxRetryElapsedTime time.Duration
+	// Backoff decides the wait between the attempts of clients built with
+	// ConfigBuilder.BuildRetryingClient. Nil uses the exponential backoff of
+	// DefaultRetryConfig.
+	Backoff BackoffStrategy
+	// UserAgent identifies the calling application. The SDK's own product token
+	// is always appended, e.g. "myapp/1.2.3 secure-sbom-sdk-go/3.0.0".
+	UserAgent string
+
+	// ClientCertificates are presented to the server for mutual TLS
+	ClientCertificates []tls.Certificate
+	// RootCAs replaces the system roots used to verify the server certificate
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables server certificate verification. Local testing only.
+	InsecureSkipVerify bool
+	// PinnedSPKI lists the base64 SHA-256 digests of the public keys the
+	// server's leaf certificate may carry; see SPKIPin. Connections presenting
+	// any other key fail with ErrCertificatePinMismatch. Empty disables
+	// pinning. It requires the SDK's HTTP client, not a custom HTTPClient.
+	PinnedSPKI []string
+
+	// ProxyURL sends this client's requests through an HTTP, HTTPS or SOCKS5
+	// proxy instead of the one from HTTP_PROXY and HTTPS_PROXY. Credentials in
+	// the URL authenticate to the proxy.
+	ProxyURL *url.URL
+	// NoProxy lists hosts that bypass ProxyURL, in NO_PROXY syntax
+	NoProxy []string
+
+	// Logger receives debug output about requests and retries. Defaults to a no-op logger.
+	Logger Logger
+
+	// TracerProvider enables OpenTelemetry spans around API calls when set
+	TracerProvider trace.TracerProvider
+
+	// PublicKeyCacheTTL caches GetPublicKey results in memory for this long.
+	// Zero disables the cache.
+	PublicKeyCacheTTL time.Duration
+
+	// VerifyCacheTTL caches successful VerifySBOM results in memory for this
+	// long, keyed by key ID, SBOM digest and detached signature, so verifying
+	// an unchanged SBOM again doesn't call the API. Zero disables the cache.
+	VerifyCacheTTL time.Duration
+
+	// RateLimit caps outgoing requests per second across all client methods,
+	// allowing bursts of up to RateLimitBurst. Zero disables rate limiting.
+	RateLimit      float64
+	RateLimitBurst int
+
+	// Metrics receives request, retry and circuit breaker metrics when set
+	Metrics Collector
+
+	// Compression gzips request bodies of at least CompressionMinSize bytes
+	// (DefaultCompressionMinSize when zero) and accepts gzip responses
+	Compression        bool
+	CompressionMinSize int
+
+	// AllowedAlgorithms rejects valid signatures made with any other algorithm,
+	// as reported by the server, with ErrDisallowedAlgorithm. Empty allows all.
+	AllowedAlgorithms []string
+
+	// RedactErrors scrubs the API key, bearer tokens and other credential-like
+	// values from error messages, including text echoed back by the API
+	RedactErrors bool
+
+	// DebugHTTP logs every request and response, headers and up to 64 KiB of
+	// body, at debug level through Logger, with API keys, bearer tokens and
+	// signature values masked. Without a Logger the dumps go to stderr. It is
+	// also turned on by setting SECURE_SBOM_DEBUG=1, for capturing a trace to
+	// attach to a support ticket.
+	DebugHTTP bool
+
+	// Timestamping asks the server for an RFC 3161 timestamp token with every
+	// SBOM signature. Signing fails with ErrTimestampingUnsupported if none is
+	// returned.
+	Timestamping bool
+
+	// KeylessTrustRoot holds the Fulcio and Rekor trust material
+	// VerifyKeyless checks keyless signatures against
+	KeylessTrustRoot *KeylessTrustRoot
+
+	// RequestInterceptors and ResponseInterceptors run, in order, around
+	// every HTTP exchange, including each retry attempt
+	RequestInterceptors  []RequestInterceptor
+	ResponseInterceptors []ResponseInterceptor
+
+	// CheckKeyUsage makes signing calls check first that the key's Usage
+	// permits signing, see WithKeyUsageCheck
+	CheckKeyUsage bool
+
+	// clock replaces the wall clock in tests; nil means the real time
+	clock clock
+}
+
+// ServerInfo describes the SecureSBOM API deployment the client is talking to
+type ServerInfo struct {
+	Version          string   `json:"version"`
+	Commit           string   `json:"commit,omitempty"`
+	Region           string   `json:"region,omitempty"`
+	SupportedFormats []string `json:"supported_formats,omitempty"`
+}
+
+// HealthStatus is the detailed result of a health check. Fields the API does
+// not report are left empty.
+type HealthStatus struct {
+	Status  string `json:"status,omitempty"`
+	Version string `json:"version,omitempty"`
+	Region  string `json:"region,omitempty"`
+	// Latency is the round-trip time of the health check request
+	Latency time.Duration `json:"-"`
 }
 
 type HTTPClient interface {
@@ -38,39 +188,113 @@ type HTTPClient interface {
 // SecureSBOM Keys
 
 type GenerateKeyCMDResponse struct {
-	ID              string    `json:"id"`
-	CreatedAt       time.Time `json:"created_at"`
-	Algorithm       string    `json:"algorithm"`
-	PublicKey       string    `json:"public_key,omitempty"`
-	Backend         string    `json:"backend,omitempty"`
-	KMSPath         string    `json:"kms_path,omitempty"`
-	ProtectionLevel string    `json:"protection_level,omitempty"`
-	Purpose         string    `json:"purpose,omitempty"`
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Algorithm is usually one of the Algorithm constants, but algorithms
+	// introduced by newer servers are passed through as reported; see
+	// IsKnownAlgorithm
+	Algorithm       string `json:"algorithm"`
+	PublicKey       string `json:"public_key,omitempty"`
+	Backend         string `json:"backend,omitempty"`
+	KMSPath         string `json:"kms_path,omitempty"`
+	ProtectionLevel string `json:"protection_level,omitempty"`
+	Purpose         string `json:"purpose,omitempty"`
+	// Usage lists what the key may be used for, as KeyUsage constants. Empty
+	// means the server reports no restriction.
+	Usage []string `json:"usage,omitempty"`
+	// Status is one of the KeyStatus constants. Keys whose ExpiresAt has
+	// passed are reported as KeyStatusExpired whatever the server says.
+	Status string `json:"status,omitempty"`
+	// ExpiresAt is when the key stops being usable for signing; zero if it
+	// never expires
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Labels are the free-form key=value tags set when the key was created,
+	// e.g. team=payments
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type KeyListResponse struct {
 	Keys []GenerateKeyCMDResponse `json:"keys"`
+	// NextPageToken is set when more keys are available; pass it as
+	// ListKeysOptions.PageToken to fetch the next page
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// ListKeysOptions selects a single page of keys for ListKeysPaged
+type ListKeysOptions struct {
+	// PageSize is the maximum number of keys to return; zero uses the server default
+	PageSize int
+	// PageToken continues a listing from a previous NextPageToken
+	PageToken string
+	// Status lists only keys with this status, e.g. KeyStatusActive; empty
+	// lists keys of every status
+	Status string
+	// Algorithm lists only keys using this algorithm, e.g. AlgorithmRSA2048,
+	// or this family of algorithms, e.g. "rsa" or "ecdsa"; matching is case
+	// insensitive and empty lists keys of every algorithm
+	Algorithm string
+	// CreatedBefore lists only keys created strictly before this time; zero
+	// applies no upper bound
+	CreatedBefore time.Time
+	// CreatedAfter lists only keys created strictly after this time; zero
+	// applies no lower bound
+	CreatedAfter time.Time
+	// LabelSelector lists only keys whose labels match every comma separated
+	// requirement: "k=v", "k!=v", "k" (label set) or "!k" (label not set),
+	// e.g. "team=payments,env=prod"; empty lists keys with any labels
+	LabelSelector string
+}
+
+// listKeysPageAPIResponse is the paginated form of the list keys response
+type listKeysPageAPIResponse struct {
+	Keys          []ListKeysAPIResponse `json:"keys"`
+	NextPageToken string                `json:"next_page_token"`
 }
 
 type ListKeysAPIResponse struct {
-	ID              string    `json:"id"`
-	CreatedAt       time.Time `json:"created_at"`
-	Algorithm       string    `json:"algorithm"`
-	Backend         string    `json:"backend"`
-	KMSPath         string    `json:"kms_path,omitempty"`
-	ProtectionLevel string    `json:"protection_level,omitempty"`
-	Purpose         string    `json:"purpose,omitempty"`
+	ID              string            `json:"id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Algorithm       string            `json:"algorithm"`
+	Backend         string            `json:"backend"`
+	KMSPath         string            `json:"kms_path,omitempty"`
+	ProtectionLevel string            `json:"protection_level,omitempty"`
+	Purpose         string            `json:"purpose,omitempty"`
+	Usage           []string          `json:"usage,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	ExpiresAt       time.Time         `json:"expires_at,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// toKeyInfo converts the API representation of a key to the SDK type
+func (k ListKeysAPIResponse) toKeyInfo() GenerateKeyCMDResponse {
+	return GenerateKeyCMDResponse{
+		ID:              k.ID,
+		CreatedAt:       k.CreatedAt,
+		Algorithm:       k.Algorithm,
+		Backend:         k.Backend,
+		KMSPath:         k.KMSPath,
+		ProtectionLevel: k.ProtectionLevel,
+		Purpose:         k.Purpose,
+		Usage:           k.Usage,
+		Status:          keyStatus(k.Status, k.ExpiresAt),
+		ExpiresAt:       k.ExpiresAt,
+		Labels:          k.Labels,
+	}
 }
 
 type GenerateKeyAPIReponse struct {
-	KeyID           string    `json:"id"`
-	CreatedAt       time.Time `json:"created_at"`
-	Algorithm       string    `json:"algorithm"`
-	PublicKey       string    `json:"public_key"`
-	Backend         string    `json:"backend"`
-	KMSPath         string    `json:"kms_path,omitempty"`
-	ProtectionLevel string    `json:"protection_level,omitempty"`
-	Purpose         string    `json:"purpose,omitempty"`
+	KeyID           string            `json:"id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Algorithm       string            `json:"algorithm"`
+	PublicKey       string            `json:"public_key"`
+	Backend         string            `json:"backend"`
+	KMSPath         string            `json:"kms_path,omitempty"`
+	ProtectionLevel string            `json:"protection_level,omitempty"`
+	Purpose         string            `json:"purpose,omitempty"`
+	Usage           []string          `json:"usage,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	ExpiresAt       time.Time         `json:"expires_at,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
 }
 
 // Signing
@@ -82,6 +306,28 @@ type SignResultAPIResponseV2 struct {
 	SBOMType     string          `json:"sbom_type,omitempty"`
 	Signature    string          `json:"signature,omitempty"`
 	SignatureB64 string          `json:"signature_b64,omitempty"`
+
+	// KeyID is the key the content was signed with, as requested by the caller
+	// unless the server reports it
+	KeyID string `json:"key_id,omitempty"`
+
+	// SBOMDigest is the hex SHA-256 digest of the canonical form of the SBOM that
+	// was submitted, computed locally (see SBOM.Digest). It is empty for
+	// SignSBOMFromReader and for SBOMs loaded with LoadSBOMFromReaderRaw, which
+	// are not parsed.
+	SBOMDigest string `json:"sbom_digest,omitempty"`
+
+	// TimestampToken is the DER-encoded RFC 3161 timestamp token over the
+	// signature, present when Config.Timestamping is set. Check it with
+	// VerifyTimestamp.
+	TimestampToken []byte `json:"timestamp_token,omitempty"`
+
+	// SignatureFormat is the envelope the server produced the signature in.
+	// It is empty when the server does not report one.
+	SignatureFormat SignatureFormat `json:"signature_format,omitempty"`
+
+	// RequestID is the X-Request-ID the server returned for the sign request
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type SignDigestRequest struct {
@@ -96,6 +342,8 @@ type SignDigestResponse struct {
 	Signature          string `json:"signature"`
 	SignatureAlgorithm string `json:"signature_algorithm"`
 	PublicKey          any    `json:"publicKey,omitempty"`
+	// RequestID is the X-Request-ID the server returned for the sign request
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // verification
@@ -107,17 +355,49 @@ type VerifyResultCMDResponse struct {
 	KeyID     string    `json:"key_id,omitempty"`
 	Algorithm string    `json:"algorithm,omitempty"`
 	Timestamp time.Time `json:"timestamp,omitempty"`
+
+	// Reason says why an invalid result failed: a signature that does not
+	// match rather than a key the service does not know, for example. Empty
+	// for valid results and failures the server did not explain.
+	Reason VerifyReason `json:"reason,omitempty"`
+
+	// PublicKeyFingerprint identifies the key that produced the signature
+	PublicKeyFingerprint string `json:"public_key_fingerprint,omitempty"`
+	// CertificateChain holds the PEM-encoded signing certificate chain, leaf first,
+	// when the service returns one
+	CertificateChain []string `json:"certificate_chain,omitempty"`
+
+	// SBOMDigest is the hex SHA-256 digest of the canonical form of the SBOM that
+	// was verified, computed locally (see SBOM.Digest)
+	SBOMDigest string `json:"sbom_digest,omitempty"`
+	// SBOMMetadata identifies the verified document, parsed locally alongside
+	// SBOMDigest; nil for documents that are neither CycloneDX nor SPDX
+	SBOMMetadata *SBOMMetadata `json:"sbom_metadata,omitempty"`
+
+	// RequestID is the X-Request-ID the server returned for the verify request
+	RequestID string `json:"request_id,omitempty"`
+
+	// Warnings lists non-fatal issues the server noted, such as a key nearing
+	// expiry or a deprecated algorithm. A result can be valid and still carry
+	// warnings.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type VerifyAPIRequestV2 struct {
-	KeyID        string      `json:"key_id"`
+	KeyID        string      `json:"key_id,omitempty"`
 	SBOM         interface{} `json:"sbom"`
 	SignatureB64 string      `json:"signature_b64"`
+	// PublicKey is a PEM public key to verify against instead of looking up KeyID
+	PublicKey string `json:"public_key,omitempty"`
 }
 
 type VerifyResultAPIResponseV2 struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code                 string   `json:"code"`
+	Message              string   `json:"message"`
+	Algorithm            string   `json:"algorithm,omitempty"`
+	PublicKeyFingerprint string   `json:"public_key_fingerprint,omitempty"`
+	CertificateChain     []string `json:"certificate_chain,omitempty"`
+	Warnings             []string `json:"warnings,omitempty"`
 }
 
 type VerifyCMDRequest struct {
@@ -127,10 +407,34 @@ type VerifyCMDRequest struct {
 }
 
 type generateKeyRequest struct {
-	Backend string `json:"backend,omitempty"`
+	Backend   string            `json:"backend,omitempty"`
+	Algorithm string            `json:"algorithm,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type importKeyRequest struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"public_key"`
+	Algorithm string `json:"algorithm"`
+}
+
+// GenerateKeyOptions selects how a new key is created. Zero values use the
+// server defaults.
+type GenerateKeyOptions struct {
+	// Backend is KeyBackendKMS or KeyBackendFile
+	Backend string
+	// Algorithm is one of the Algorithm constants, e.g. AlgorithmEd25519
+	Algorithm string
+	// Labels tag the key for later filtering with ListKeysOptions.LabelSelector.
+	// Keys must be non-empty and must not contain '!', '=' or ','; values must
+	// not contain '=' or ','.
+	Labels map[string]string
 }
 
 type SignOptions struct {
 	Detached bool
 	Pretty   bool
+	// Algorithm selects the signature algorithm for keys that support several.
+	// Empty uses the key's default.
+	Algorithm string
 }
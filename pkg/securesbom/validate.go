@@ -0,0 +1,71 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ValidateSignRequest checks a sign request without performing the signature:
+// the SBOM must be a CycloneDX or SPDX JSON document and the key must exist.
+// All problems found are returned together via errors.Join, so callers can use
+// errors.Is with ErrUnsupportedFormat or ErrKeyNotFound.
+//
+// The sbom may be a parsed document, an *SBOM, or raw JSON as []byte or
+// json.RawMessage.
+func ValidateSignRequest(ctx context.Context, client ClientInterface, keyID string, sbom interface{}) error {
+	var errs []error
+
+	if err := validateSBOMFormat(sbom); err != nil {
+		errs = append(errs, err)
+	}
+
+	if keyID == "" {
+		errs = append(errs, fmt.Errorf("keyID is required"))
+	} else if _, err := client.GetKey(ctx, keyID); err != nil {
+		errs = append(errs, fmt.Errorf("key %s is not usable: %w", keyID, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateSBOMFormat reports whether sbom is a supported SBOM document
+func validateSBOMFormat(sbom interface{}) error {
+	switch raw := sbom.(type) {
+	case nil:
+		return fmt.Errorf("sbom is required")
+	case []byte:
+		return validateSBOMFormat(json.RawMessage(raw))
+	case json.RawMessage:
+		if err := checkNotXML(raw); err != nil {
+			return err
+		}
+		var doc interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("sbom is not valid JSON: %w", err)
+		}
+		sbom = doc
+	}
+
+	if detectSBOMFormat(sbom) == "" {
+		return fmt.Errorf("sbom is neither CycloneDX nor SPDX: %w", ErrUnsupportedFormat)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateSignRequest(t *testing.T) {
+	cycloneDX := map[string]interface{}{"bomFormat": "CycloneDX", "specVersion": "1.5"}
+
+	tests := []struct {
+		name              string
+		keyID             string
+		sbom              interface{}
+		expectError       bool
+		expectUnsupported bool
+		expectNotFound    bool
+		expectedRequests  int
+	}{
+		{
+			name:             "valid CycloneDX document",
+			keyID:            "key-123",
+			sbom:             cycloneDX,
+			expectedRequests: 1,
+		},
+		{
+			name:             "valid SPDX JSON bytes",
+			keyID:            "key-123",
+			sbom:             []byte(`{"spdxVersion": "SPDX-2.3"}`),
+			expectedRequests: 1,
+		},
+		{
+			name:             "SBOM wrapper",
+			keyID:            "key-123",
+			sbom:             NewSBOM(cycloneDX),
+			expectedRequests: 1,
+		},
+		{
+			name:              "CycloneDX XML bytes",
+			keyID:             "key-123",
+			sbom:              []byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1"/>`),
+			expectError:       true,
+			expectUnsupported: true,
+			expectedRequests:  1,
+		},
+		{
+			name:              "unknown format",
+			keyID:             "key-123",
+			sbom:              map[string]interface{}{"name": "not an sbom"},
+			expectError:       true,
+			expectUnsupported: true,
+			expectedRequests:  1,
+		},
+		{
+			name:             "invalid JSON",
+			keyID:            "key-123",
+			sbom:             []byte(`{not json`),
+			expectError:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "missing key",
+			keyID:            "missing",
+			sbom:             cycloneDX,
+			expectError:      true,
+			expectNotFound:   true,
+			expectedRequests: 1,
+		},
+		{
+			name:              "aggregates every problem",
+			keyID:             "missing",
+			sbom:              map[string]interface{}{},
+			expectError:       true,
+			expectUnsupported: true,
+			expectNotFound:    true,
+			expectedRequests:  1,
+		},
+		{
+			name:        "empty key ID and nil SBOM",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				requests++
+				if strings.HasSuffix(req.URL.Path, "/missing") {
+					return createMockResponse(404, map[string]string{"error": "key not found"}), nil
+				}
+				return createMockResponse(200, ListKeysAPIResponse{ID: "key-123"}), nil
+			}, nil)
+
+			err := ValidateSignRequest(context.Background(), client, tt.keyID, tt.sbom)
+
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if errors.Is(err, ErrUnsupportedFormat) != tt.expectUnsupported {
+				t.Errorf("expected errors.Is(err, ErrUnsupportedFormat) to be %v, got %v", tt.expectUnsupported, err)
+			}
+			if errors.Is(err, ErrKeyNotFound) != tt.expectNotFound {
+				t.Errorf("expected errors.Is(err, ErrKeyNotFound) to be %v, got %v", tt.expectNotFound, err)
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests)
+			}
+		})
+	}
+}
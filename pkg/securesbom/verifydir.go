@@ -0,0 +1,212 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// VerifyDirectoryOptions configures VerifyDirectory
+type VerifyDirectoryOptions struct {
+	// Pattern selects the files to verify with path.Match syntax, e.g.
+	// "*.cdx.json". A pattern containing a slash is matched against the path
+	// relative to the directory, otherwise against the file name. Empty
+	// selects every file.
+	Pattern string
+	// KeyIDs maps paths relative to the directory, with forward slashes, to
+	// the key to verify them with. It takes precedence over the keyId in an
+	// embedded CycloneDX signature.
+	KeyIDs map[string]string
+	// KeyID verifies files that are not in KeyIDs and whose signature names no
+	// key, such as SPDX documents
+	KeyID string
+	// Batch sets the concurrency and progress callback of the underlying
+	// VerifyBatchStream
+	Batch BatchOptions
+	// OnResult, if set, is called with each file's result as soon as it is
+	// known, in completion order; index is the file's position in the results
+	// VerifyDirectory returns. Calls are made from the calling goroutine, one
+	// at a time.
+	OnResult func(index int, result FileVerifyResult)
+}
+
+// FileVerifyResult is the outcome of verifying one file with VerifyDirectory
+type FileVerifyResult struct {
+	// Path is relative to the directory, with forward slashes
+	Path string
+	// Format is "cyclonedx" or "spdx", or empty if the file could not be loaded
+	Format string
+	// KeyID is the key the file was verified with
+	KeyID string
+	// Result is the verification result; nil when Err is set
+	Result *VerifyResultCMDResponse
+	// Err reports why the file could not be verified, e.g. it is not an SBOM,
+	// no key is known for it, or the API call failed
+	Err error
+}
+
+// Valid reports whether the file's signature verified
+func (r FileVerifyResult) Valid() bool {
+	return r.Err == nil && r.Result != nil && r.Result.Valid
+}
+
+// VerifyDirectory verifies every SBOM under dir, recursively, that matches
+// opts.Pattern, e.g. the signed SBOMs of a release bundle. Each file's format
+// is detected and its signature found as by VerifySBOMFromFile: embedded, or
+// detached beside it, so .sig files themselves are skipped. The key comes
+// from opts.KeyIDs, then the keyId of an embedded CycloneDX signature, then
+// opts.KeyID. The files are verified concurrently with VerifyBatchStream.
+//
+// The results hold one entry per file in lexical order. If any file does not
+// verify, the error joins the failures, each prefixed by its path, and wraps
+// ErrSignatureInvalid if any signature is invalid, so ExitCode(nil, err)
+// classifies the whole run. A directory without matching files is an error.
+func VerifyDirectory(ctx context.Context, client ClientInterface, dir string, opts VerifyDirectoryOptions) ([]FileVerifyResult, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("directory is required")
+	}
+	if _, err := path.Match(opts.Pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", opts.Pattern, err)
+	}
+
+	var results []FileVerifyResult
+	var files []string
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasSuffix(file, DetachedSignatureExt) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !matchesPattern(opts.Pattern, rel) {
+			return nil
+		}
+		results = append(results, FileVerifyResult{Path: rel})
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no files matching %q in %s", opts.Pattern, dir)
+	}
+
+	// Files that can't be loaded fail here without a request
+	var requests []VerifyCMDRequest
+	var pending []int
+	for i := range results {
+		req, err := directoryVerifyRequest(&results[i], files[i], opts)
+		if err != nil {
+			results[i].Err = err
+			if opts.OnResult != nil {
+				opts.OnResult(i, results[i])
+			}
+			continue
+		}
+		requests = append(requests, req)
+		pending = append(pending, i)
+	}
+
+	for verified := range VerifyBatchStream(ctx, client, requests, opts.Batch) {
+		i := pending[verified.Index]
+		results[i].Result = verified.Result
+		results[i].Err = verified.Err
+		if verified.Err != nil {
+			results[i].Result = nil
+		}
+		if opts.OnResult != nil {
+			opts.OnResult(i, results[i])
+		}
+	}
+
+	var errs []error
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", r.Path, r.Err))
+		case !r.Result.Valid && r.Result.Message != "":
+			errs = append(errs, fmt.Errorf("%s: %s: %w", r.Path, r.Result.Message, ErrSignatureInvalid))
+		case !r.Result.Valid:
+			errs = append(errs, fmt.Errorf("%s: %w", r.Path, ErrSignatureInvalid))
+		}
+	}
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d of %d SBOMs failed verification: %w", len(errs), len(results), errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// matchesPattern reports whether the relative path rel is selected by a
+// VerifyDirectoryOptions pattern
+func matchesPattern(pattern, rel string) bool {
+	if pattern == "" {
+		return true
+	}
+	name := rel
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(rel)
+	}
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// directoryVerifyRequest loads file and builds its verify request, recording
+// its format and key in result
+func directoryVerifyRequest(result *FileVerifyResult, file string, opts VerifyDirectoryOptions) (VerifyCMDRequest, error) {
+	sbom, err := LoadSBOMFromFile(file)
+	if err != nil {
+		return VerifyCMDRequest{}, err
+	}
+	result.Format = sbom.Format()
+
+	result.KeyID = opts.KeyIDs[result.Path]
+	if result.KeyID == "" {
+		result.KeyID = embeddedKeyID(sbom)
+	}
+	if result.KeyID == "" {
+		result.KeyID = opts.KeyID
+	}
+	if result.KeyID == "" {
+		return VerifyCMDRequest{}, fmt.Errorf("no key ID known for %s; set one in KeyIDs or KeyID", result.Path)
+	}
+
+	return fileVerifyRequest(sbom, result.KeyID, file)
+}
+
+// embeddedKeyID returns the keyId of the first embedded CycloneDX signature
+// that names one
+func embeddedKeyID(sbom *SBOM) string {
+	doc, _ := sbom.Data().(map[string]interface{})
+	for _, signer := range embeddedSignatures(doc[cycloneDXSignatureField]) {
+		if keyID, _ := signer["keyId"].(string); keyID != "" {
+			return keyID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// dirVerifyStubClient answers VerifySBOM by key ID and is safe for the
+// concurrent calls VerifyBatch makes
+type dirVerifyStubClient struct {
+	ClientInterface
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *dirVerifyStubClient) VerifySBOM(ctx context.Context, req VerifyCMDRequest, callOpts ...CallOption) (*VerifyResultCMDResponse, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+
+	switch req.KeyID {
+	case "valid-key":
+		return &VerifyResultCMDResponse{Valid: true, KeyID: req.KeyID}, nil
+	case "revoked-key":
+		return &VerifyResultCMDResponse{Valid: false, KeyID: req.KeyID, Message: "key revoked"}, nil
+	default:
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "key not found"}
+	}
+}
+
+func TestVerifyDirectory(t *testing.T) {
+	signedBy := func(keyID string) string {
+		return `{"bomFormat":"CycloneDX","signature":{"algorithm":"ES256","keyId":"` + keyID + `","value":"c2ln"}}`
+	}
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"good/app.cdx.json":        signedBy("valid-key"),
+		"good/db.spdx.json":        testSPDX,
+		"good/db.spdx.json.sig":    "c2ln",
+		"good/nested/svc.cdx.json": `{"bomFormat":"CycloneDX","signature":{"algorithm":"ES256","value":"c2ln"}}`,
+		"bad/lib.cdx.json":         signedBy("revoked-key"),
+		"bad/gone.cdx.json":        signedBy("deleted-key"),
+		"bad/notes.txt":            "release notes",
+	} {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		dir         string
+		opts        VerifyDirectoryOptions
+		expectPaths []string
+		expectValid []string
+		expectCalls int
+		expectError bool
+		expectExit  int
+	}{
+		{
+			name: "all valid with mapped and default keys",
+			dir:  filepath.Join(root, "good"),
+			opts: VerifyDirectoryOptions{
+				KeyIDs: map[string]string{"nested/svc.cdx.json": "valid-key"},
+				KeyID:  "valid-key",
+			},
+			expectPaths: []string{"app.cdx.json", "db.spdx.json", "nested/svc.cdx.json"},
+			expectValid: []string{"app.cdx.json", "db.spdx.json", "nested/svc.cdx.json"},
+			expectCalls: 3,
+		},
+		{
+			name:        "files without a known key",
+			dir:         filepath.Join(root, "good"),
+			expectPaths: []string{"app.cdx.json", "db.spdx.json", "nested/svc.cdx.json"},
+			expectValid: []string{"app.cdx.json"},
+			expectCalls: 1,
+			expectError: true,
+			expectExit:  ExitBadInput,
+		},
+		{
+			name:        "invalid signature and unknown key",
+			dir:         filepath.Join(root, "bad"),
+			opts:        VerifyDirectoryOptions{Pattern: "*.json", Batch: BatchOptions{Concurrency: 2}},
+			expectPaths: []string{"gone.cdx.json", "lib.cdx.json"},
+			expectCalls: 2,
+			expectError: true,
+			expectExit:  ExitInvalidSignature,
+		},
+		{
+			name:        "non-SBOM file without a pattern",
+			dir:         filepath.Join(root, "bad"),
+			opts:        VerifyDirectoryOptions{KeyIDs: map[string]string{"gone.cdx.json": "valid-key", "lib.cdx.json": "valid-key"}},
+			expectPaths: []string{"gone.cdx.json", "lib.cdx.json", "notes.txt"},
+			expectValid: []string{"gone.cdx.json", "lib.cdx.json"},
+			expectCalls: 2,
+			expectError: true,
+			expectExit:  ExitBadInput,
+		},
+		{
+			name:        "pattern with a directory",
+			dir:         root,
+			opts:        VerifyDirectoryOptions{Pattern: "good/*.cdx.json"},
+			expectPaths: []string{"good/app.cdx.json"},
+			expectValid: []string{"good/app.cdx.json"},
+			expectCalls: 1,
+		},
+		{
+			name:        "no matching files",
+			dir:         root,
+			opts:        VerifyDirectoryOptions{Pattern: "*.xml"},
+			expectError: true,
+			expectExit:  ExitBadInput,
+		},
+		{
+			name:        "invalid pattern",
+			dir:         root,
+			opts:        VerifyDirectoryOptions{Pattern: "["},
+			expectError: true,
+			expectExit:  ExitBadInput,
+		},
+		{
+			name:        "missing directory",
+			dir:         filepath.Join(root, "missing"),
+			expectError: true,
+			expectExit:  ExitBadInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &dirVerifyStubClient{}
+			streamed := make(map[int]FileVerifyResult)
+			tt.opts.OnResult = func(index int, result FileVerifyResult) {
+				if _, ok := streamed[index]; ok {
+					t.Errorf("OnResult called twice for index %d", index)
+				}
+				streamed[index] = result
+			}
+			results, err := VerifyDirectory(context.Background(), client, tt.dir, tt.opts)
+
+			if tt.expectError != (err != nil) {
+				t.Fatalf("error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil {
+				if code := ExitCode(nil, err); code != tt.expectExit {
+					t.Errorf("ExitCode = %d, want %d for %v", code, tt.expectExit, err)
+				}
+			}
+			if client.calls != tt.expectCalls {
+				t.Errorf("expected %d verify calls, got %d", tt.expectCalls, client.calls)
+			}
+			if tt.expectPaths == nil {
+				if results != nil {
+					t.Errorf("expected no results, got %v", results)
+				}
+				return
+			}
+
+			var paths, valid []string
+			for _, r := range results {
+				paths = append(paths, r.Path)
+				if r.Valid() {
+					valid = append(valid, r.Path)
+				}
+				if r.Err == nil && r.Result == nil {
+					t.Errorf("%s has neither a result nor an error", r.Path)
+				}
+			}
+			if len(streamed) != len(results) {
+				t.Errorf("OnResult called for %d files, want %d", len(streamed), len(results))
+			}
+			for i, r := range results {
+				if got := streamed[i]; got.Path != r.Path || got.Valid() != r.Valid() {
+					t.Errorf("OnResult index %d = %s, want %s", i, got.Path, r.Path)
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.expectPaths) {
+				t.Errorf("paths = %v, want %v", paths, tt.expectPaths)
+			}
+			if !reflect.DeepEqual(valid, tt.expectValid) {
+				t.Errorf("valid = %v, want %v", valid, tt.expectValid)
+			}
+		})
+	}
+
+	t.Run("failures wrap their causes", func(t *testing.T) {
+		_, err := VerifyDirectory(context.Background(), &dirVerifyStubClient{}, filepath.Join(root, "bad"), VerifyDirectoryOptions{Pattern: "*.json"})
+		if !errors.Is(err, ErrSignatureInvalid) || !IsNotFound(err) {
+			t.Errorf("expected the error to wrap ErrSignatureInvalid and the 404, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"net/http"
+	"time"
+)
+
+// VerifyReason says why a verification failed, so callers can tell an SBOM
+// whose signature does not match from one that could not be checked against
+// the key it names
+type VerifyReason string
+
+const (
+	// VerifyReasonSignatureMismatch means the signature does not match the SBOM
+	VerifyReasonSignatureMismatch VerifyReason = "signature_mismatch"
+	// VerifyReasonSignatureMissing means the SBOM carries no signature
+	VerifyReasonSignatureMissing VerifyReason = "signature_missing"
+	// VerifyReasonKeyNotFound means the key ID is not known to the service
+	VerifyReasonKeyNotFound VerifyReason = "key_not_found"
+	// VerifyReasonKeyRevoked means the key exists but was revoked or disabled
+	VerifyReasonKeyRevoked VerifyReason = "key_revoked"
+	// VerifyReasonDocumentMalformed means the service could not parse the SBOM
+	VerifyReasonDocumentMalformed VerifyReason = "document_malformed"
+)
+
+// verifyReason maps a verify response's error code, or failing that its
+// status, to a VerifyReason; "" when neither says why. A bare 404 names no
+// reason, since a wrong base URL returns one too.
+func verifyReason(status int, code string) VerifyReason {
+	switch code {
+	case "INVALID_SIGNATURE", "SIGNATURE_MISMATCH", VerifyCodeSignatureInvalid:
+		return VerifyReasonSignatureMismatch
+	case "SIGNATURE_MISSING":
+		return VerifyReasonSignatureMissing
+	case "KEY_NOT_FOUND":
+		return VerifyReasonKeyNotFound
+	case "KEY_REVOKED", "KEY_DISABLED":
+		return VerifyReasonKeyRevoked
+	case "INVALID_SBOM", "MALFORMED_SBOM", "INVALID_DOCUMENT":
+		return VerifyReasonDocumentMalformed
+	}
+
+	switch status {
+	case http.StatusGone:
+		return VerifyReasonKeyRevoked
+	case http.StatusUnprocessableEntity:
+		return VerifyReasonDocumentMalformed
+	}
+	return ""
+}
+
+// VerifyReasonFromError returns the VerifyReason for an error returned by a
+// verify call, for callers that only kept the error, such as
+// FileVerifyResult.Err; "" when err is not a verify failure the server
+// explained
+func VerifyReasonFromError(err error) VerifyReason {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return ""
+	}
+	return verifyReason(apiErr.StatusCode, apiErr.Code)
+}
+
+// verifyFailure builds the invalid result returned alongside err when the
+// service rejected a verify request for a reason VerifyReason names, so
+// callers holding only the result still see why; nil for other errors
+func verifyFailure(err error, keyID string, now time.Time, digest string, metadata *SBOMMetadata) *VerifyResultCMDResponse {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return nil
+	}
+	reason := verifyReason(apiErr.StatusCode, apiErr.Code)
+	if reason == "" {
+		return nil
+	}
+	return &VerifyResultCMDResponse{
+		Code:         apiErr.Code,
+		Message:      apiErr.Message,
+		Reason:       reason,
+		KeyID:        keyID,
+		Timestamp:    now,
+		SBOMDigest:   digest,
+		SBOMMetadata: metadata,
+		RequestID:    apiErr.RequestID,
+	}
+}
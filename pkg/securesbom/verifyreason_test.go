@@ -0,0 +1,168 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_VerifySBOM_Reason(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        interface{}
+		wantReason  VerifyReason
+		wantResult  bool
+		expectError bool
+	}{
+		{
+			name:        "signature mismatch",
+			status:      http.StatusBadRequest,
+			body:        map[string]string{"code": "INVALID_SIGNATURE", "message": "signature verification failed"},
+			wantReason:  VerifyReasonSignatureMismatch,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "unsigned SBOM",
+			status:      http.StatusBadRequest,
+			body:        map[string]string{"code": "SIGNATURE_MISSING", "message": "the SBOM is not signed"},
+			wantReason:  VerifyReasonSignatureMissing,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "unknown key",
+			status:      http.StatusNotFound,
+			body:        map[string]string{"code": "KEY_NOT_FOUND", "message": "key not found"},
+			wantReason:  VerifyReasonKeyNotFound,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "revoked key",
+			status:      http.StatusForbidden,
+			body:        map[string]string{"code": "KEY_REVOKED", "message": "key was revoked"},
+			wantReason:  VerifyReasonKeyRevoked,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "gone without a code",
+			status:      http.StatusGone,
+			body:        map[string]string{"message": "key deleted"},
+			wantReason:  VerifyReasonKeyRevoked,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "malformed document",
+			status:      http.StatusBadRequest,
+			body:        map[string]string{"code": "INVALID_SBOM", "message": "sbom must be a JSON object"},
+			wantReason:  VerifyReasonDocumentMalformed,
+			wantResult:  true,
+			expectError: true,
+		},
+		{
+			name:        "not found without a code",
+			status:      http.StatusNotFound,
+			body:        map[string]string{"message": "404 page not found"},
+			expectError: true,
+		},
+		{
+			name:        "unexplained server error",
+			status:      http.StatusInternalServerError,
+			body:        map[string]string{"message": "internal error"},
+			expectError: true,
+		},
+		{
+			name:       "invalid result in a 2xx response",
+			status:     http.StatusAccepted,
+			body:       map[string]string{"code": "INVALID_SIGNATURE", "message": "signature verification failed"},
+			wantReason: VerifyReasonSignatureMismatch,
+			wantResult: true,
+		},
+		{
+			name:       "valid",
+			status:     http.StatusOK,
+			body:       map[string]string{"code": "VALID"},
+			wantResult: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(req *http.Request) (*http.Response, error) {
+				return createMockResponse(tt.status, tt.body), nil
+			}, nil)
+
+			result, err := client.VerifySBOM(context.Background(), VerifyCMDRequest{
+				KeyID: "key-123",
+				SBOM:  map[string]interface{}{"bomFormat": "CycloneDX"},
+			})
+			if tt.expectError != (err != nil) {
+				t.Fatalf("VerifySBOM() error = %v, expectError %v", err, tt.expectError)
+			}
+			if got := VerifyReasonFromError(err); err != nil && got != tt.wantReason {
+				t.Errorf("VerifyReasonFromError() = %q, want %q", got, tt.wantReason)
+			}
+			if !tt.wantResult {
+				if result != nil {
+					t.Errorf("VerifySBOM() result = %+v, want nil", result)
+				}
+				return
+			}
+			if result == nil {
+				t.Fatal("VerifySBOM() result = nil, want a result")
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", result.Reason, tt.wantReason)
+			}
+			if tt.wantReason != "" && (result.Valid || result.KeyID != "key-123" || result.SBOMDigest == "") {
+				t.Errorf("result = %+v, want an invalid result for key-123 with a digest", result)
+			}
+		})
+	}
+}
+
+func TestVerifyReasonFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want VerifyReason
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "not an API error", err: errors.New("connection refused"), want: ""},
+		{name: "code wins over status", err: fmt.Errorf("failed to verify SBOM: %w", &APIError{StatusCode: http.StatusNotFound, Code: "INVALID_SBOM"}), want: VerifyReasonDocumentMalformed},
+		{name: "disabled key", err: &APIError{StatusCode: http.StatusForbidden, Code: "KEY_DISABLED"}, want: VerifyReasonKeyRevoked},
+		{name: "unprocessable", err: &APIError{StatusCode: http.StatusUnprocessableEntity}, want: VerifyReasonDocumentMalformed},
+		{name: "bare not found", err: &APIError{StatusCode: http.StatusNotFound, Message: "404 page not found"}, want: ""},
+		{name: "unauthorized", err: &APIError{StatusCode: http.StatusUnauthorized, Code: "UNAUTHORIZED"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyReasonFromError(tt.err); got != tt.want {
+				t.Errorf("VerifyReasonFromError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MarshalYAML encodes v as YAML for human review, using the same field names
+// as its JSON encoding. Timestamps in verification results and key metadata
+// render in RFC 3339 and are left out when unset, rather than appearing as
+// year 1.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(yamlView(v))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	out, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return out, nil
+}
+
+// rfc3339Time encodes a time in RFC 3339 at second precision
+type rfc3339Time time.Time
+
+func (t rfc3339Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).Format(time.RFC3339))
+}
+
+// yamlTime returns nil for the zero time so omitempty drops it
+func yamlTime(t time.Time) *rfc3339Time {
+	if t.IsZero() {
+		return nil
+	}
+	rt := rfc3339Time(t)
+	return &rt
+}
+
+type verifyResultYAML struct {
+	*VerifyResultCMDResponse
+	Timestamp *rfc3339Time `json:"timestamp,omitempty"`
+}
+
+type keyYAML struct {
+	*GenerateKeyCMDResponse
+	CreatedAt *rfc3339Time `json:"created_at,omitempty"`
+	ExpiresAt *rfc3339Time `json:"expires_at,omitempty"`
+}
+
+type keyListYAML struct {
+	Keys          []keyYAML `json:"keys"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+}
+
+// yamlView wraps the result types whose timestamps need reformatting; other
+// values are encoded as they are
+func yamlView(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *VerifyResultCMDResponse:
+		if v == nil {
+			return nil
+		}
+		return verifyResultYAML{VerifyResultCMDResponse: v, Timestamp: yamlTime(v.Timestamp)}
+	case VerifyResultCMDResponse:
+		return yamlView(&v)
+	case *GenerateKeyCMDResponse:
+		if v == nil {
+			return nil
+		}
+		return keyYAML{GenerateKeyCMDResponse: v, CreatedAt: yamlTime(v.CreatedAt), ExpiresAt: yamlTime(v.ExpiresAt)}
+	case GenerateKeyCMDResponse:
+		return yamlView(&v)
+	case *KeyListResponse:
+		if v == nil {
+			return nil
+		}
+		keys := make([]keyYAML, len(v.Keys))
+		for i := range v.Keys {
+			keys[i] = yamlView(&v.Keys[i]).(keyYAML)
+		}
+		return keyListYAML{Keys: keys, NextPageToken: v.NextPageToken}
+	case KeyListResponse:
+		return yamlView(&v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 ShiftLeftCyber Inc and Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securesbom
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 30, 15, 123456789, time.UTC)
+	expires := time.Date(2027, 3, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	key := GenerateKeyCMDResponse{ID: "key-123", CreatedAt: created, Algorithm: AlgorithmECDSAP256, ExpiresAt: expires}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name: "verification result",
+			value: &VerifyResultCMDResponse{
+				Valid:     true,
+				Code:      "OK",
+				KeyID:     "key-123",
+				Timestamp: created,
+				Warnings:  []string{"key expires soon"},
+			},
+			expected: "code: OK\nkey_id: key-123\ntimestamp: \"2026-03-01T09:30:15Z\"\nvalid: true\nwarnings:\n- key expires soon\n",
+		},
+		{
+			name:     "verification result without timestamp",
+			value:    VerifyResultCMDResponse{Valid: false, Code: "INVALID", Message: "signature mismatch"},
+			expected: "code: INVALID\nmessage: signature mismatch\nvalid: false\n",
+		},
+		{
+			name:     "generated key",
+			value:    &key,
+			expected: "algorithm: ecdsa-p256\ncreated_at: \"2026-03-01T09:30:15Z\"\nexpires_at: \"2027-03-01T00:00:00+01:00\"\nid: key-123\n",
+		},
+		{
+			name:     "key list",
+			value:    KeyListResponse{Keys: []GenerateKeyCMDResponse{{ID: "key-1", CreatedAt: created, Algorithm: AlgorithmEd25519}}, NextPageToken: "next"},
+			expected: "keys:\n- algorithm: ed25519\n  created_at: \"2026-03-01T09:30:15Z\"\n  id: key-1\nnext_page_token: next\n",
+		},
+		{
+			name:     "other values",
+			value:    map[string]int{"count": 2},
+			expected: "count: 2\n",
+		},
+		{
+			name:     "nil result",
+			value:    (*VerifyResultCMDResponse)(nil),
+			expected: "null\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalYAML(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("MarshalYAML() =\n%s\nwant:\n%s", data, tt.expected)
+			}
+		})
+	}
+}